@@ -5,14 +5,23 @@ import (
 	entityHttpV1Package "anti-fraud/account-service/entity/http/v1"
 	mapperV1Package "anti-fraud/account-service/mapper/v1"
 	repoV1Package "anti-fraud/account-service/repository/v1"
+	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
 	utilV1 "anti-fraud/utils-server/middleware/v1"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 
+	"encoding/csv"
 	"encoding/json"
+	"io"
 	"net/http"
 )
 
@@ -24,29 +33,47 @@ type IAccountController interface {
 
 	// GetAccountDetails retrieves the details of an existing account by its ID.
 	GetAccountDetails(w http.ResponseWriter, r *http.Request)
+
+	// BlockAccount moves an active account to BLOCKED.
+	BlockAccount(w http.ResponseWriter, r *http.Request)
+
+	// UnblockAccount moves a blocked account back to ACTIVE.
+	UnblockAccount(w http.ResponseWriter, r *http.Request)
+
+	// CloseAccount moves an active or blocked account to CLOSED.
+	CloseAccount(w http.ResponseWriter, r *http.Request)
+
+	// GetAccountStatement returns the account statement of a period, as JSON or CSV.
+	GetAccountStatement(w http.ResponseWriter, r *http.Request)
+
+	// UpdateAvailableCreditLimit applies a signed delta to the available credit limit, for remote mediator clients.
+	UpdateAvailableCreditLimit(w http.ResponseWriter, r *http.Request)
 }
 
 // AccountController implements IAccountController interface and
 // provides HTTP handlers for account-related operations.
 type AccountController struct {
-	repoV1 repoV1Package.IAccountRepository // Repository for lower-level DB operations
-	coreV1 coreV1Package.IAccountCore       // Core layer providing business logic.
-	db     *gorm.DB
-	logger *logrus.Logger
+	repoV1           repoV1Package.IAccountRepository    // Repository for lower-level DB operations
+	coreV1           coreV1Package.IAccountCore          // Core layer providing business logic.
+	idempotencyStore utilIdempotencyV1.IIdempotencyStore // Store for Idempotency-Key records.
+	db               *gorm.DB
+	logger           *logrus.Logger
 }
 
 // NewAccountController creates and returns a new AccountController initialized.
 func NewAccountController(
 	repoV1 repoV1Package.IAccountRepository,
 	coreV1 coreV1Package.IAccountCore,
+	idempotencyStore utilIdempotencyV1.IIdempotencyStore,
 	db *gorm.DB,
 	logger *logrus.Logger,
 ) *AccountController {
 	return &AccountController{
-		repoV1: repoV1,
-		coreV1: coreV1,
-		db:     db,
-		logger: logger,
+		repoV1:           repoV1,
+		coreV1:           coreV1,
+		idempotencyStore: idempotencyStore,
+		db:               db,
+		logger:           logger,
 	}
 }
 
@@ -56,20 +83,25 @@ func NewAccountController(
 //  1. Decode the incoming JSON payload into CreateAccountRequest.
 //  2. Validate the request payload.
 //  3. Begin db txn.
+//     If an Idempotency-Key header is sent, claim it; a retry of a completed request replays the stored response.
 //  4. Invoke the core layer to create the account (business logic).
-//  5. Commit the txn on success (or rollback on error).
+//  5. Store the response for the idempotency key and commit the txn on success (or rollback on error).
 //  6. Return a JSON response with the newly created account details.
 func (controller *AccountController) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	requestID := utilV1.GetRequestID(ctx)
 	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
 
 	// 1. Decode JSON request body.
 	var accountReq entityHttpV1Package.CreateAccountRequest
-	err := json.NewDecoder(r.Body).Decode(&accountReq)
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &accountReq)
+	}
 	if err != nil {
 		logger.Errorf("Error decoding request body: %v", err)
-		http.Error(w, "Error decoding request body: "+err.Error(), http.StatusBadRequest)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
 		return
 	}
 
@@ -79,39 +111,74 @@ func (controller *AccountController) CreateAccount(w http.ResponseWriter, r *htt
 	err = accountReq.Validate()
 	if err != nil {
 		logger.Errorf("Validation failed: %v", err)
-		http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	idempotencyKey := r.Header.Get(idempotencyConstantPackage.HEADER)
+	if err := utilIdempotencyV1.ValidateKey(idempotencyKey); err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
 	// 3. Begin new db txn.
-	tx := controller.db.Begin()
-	defer tx.Rollback() // Rollback if we exit prematurely.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	var idempotencyRecord *utilIdempotencyV1.IdempotencyRecord
+	if idempotencyKey != "" {
+		record, replay, err := controller.idempotencyStore.Claim(ctx, idempotencyConstantPackage.SCOPE_CREATE_ACCOUNT, idempotencyKey, utilIdempotencyV1.HashRequest(body), tx)
+		if err != nil {
+			logger.Errorf("Error claiming idempotency key: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+		if replay {
+			logger.Infof("Replaying stored response for idempotency key: %s", idempotencyKey)
+			utilIdempotencyV1.WriteReplay(w, record)
+			return
+		}
+		idempotencyRecord = record
+	}
 
 	// 4. Create account using the core layer’s business logic.
 	accountPayload := mapperV1Package.CreateAccountPayloadMapper(&accountReq)
-	account, err := controller.coreV1.CreateAccount(logger, accountPayload, tx)
+	account, err := controller.coreV1.CreateAccount(ctx, accountPayload, tx)
 	if err != nil {
 		logger.Errorf("Error creating account: %v", err)
-		http.Error(w, "An internal error occurred: "+err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
-	// 5. Commit txn.
-	if err := tx.Commit().Error; err != nil {
+	// 5. Build the response, store it for the idempotency key and commit txn.
+	response, err := json.Marshal(map[string]interface{}{
+		"success": true,
+		"account": mapperV1Package.AccountDetailsResponseMapper(account),
+	})
+	if err != nil {
+		logger.Errorf("Error encoding response: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if idempotencyRecord != nil {
+		if err := controller.idempotencyStore.SaveResponse(ctx, idempotencyRecord, http.StatusOK, response, tx); err != nil {
+			logger.Errorf("Error saving idempotent response: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
 		logger.Errorf("Error committing transaction: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
 	logger.Infof("Account created successfully: %v", account)
 
-	// 6. Build and send JSON response.
-	response := map[string]interface{}{
-		"success": true,
-		"account": mapperV1Package.AccountDetailsResponseMapper(account),
-	}
+	// 6. Send JSON response.
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	w.Write(response)
 }
 
 // GetAccountDetails is an HTTP handler that retrieves account details by its ID.
@@ -127,6 +194,7 @@ func (controller *AccountController) GetAccountDetails(w http.ResponseWriter, r
 	ctx := r.Context()
 	requestID := utilV1.GetRequestID(ctx)
 	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
 	// 1. Extract the "accountId" from URL params.
 	params := mux.Vars(r)
 	accountIdStr := params["accountId"]
@@ -136,26 +204,26 @@ func (controller *AccountController) GetAccountDetails(w http.ResponseWriter, r
 	accountId, err := strconv.Atoi(accountIdStr)
 	if err != nil {
 		logger.Errorf("Error converting string to int: %v", err)
-		http.Error(w, "Error converting string to int: "+err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
 		return
 	}
 
 	// 3. Begin a db txn.
-	tx := controller.db.Begin()
-	defer tx.Rollback() // Rollback if we exit prematurely.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
 
 	// 4. Fetch the account via core layer.
-	account, err := controller.coreV1.GetAccount(logger, accountId, tx)
+	account, err := controller.coreV1.GetAccount(ctx, accountId, tx)
 	if err != nil {
 		logger.Errorf("Error fetching account details: %v", err)
-		http.Error(w, "An internal error occurred: "+err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
 	// 5. Commit txn.
-	if err := tx.Commit().Error; err != nil {
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
 		logger.Errorf("Error committing transaction: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
@@ -167,3 +235,238 @@ func (controller *AccountController) GetAccountDetails(w http.ResponseWriter, r
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// BlockAccount is an HTTP handler that blocks an account.
+func (controller *AccountController) BlockAccount(w http.ResponseWriter, r *http.Request) {
+	controller.changeAccountStatus(w, r, constantPackage.STATUS_BLOCKED)
+}
+
+// UnblockAccount is an HTTP handler that unblocks an account.
+func (controller *AccountController) UnblockAccount(w http.ResponseWriter, r *http.Request) {
+	controller.changeAccountStatus(w, r, constantPackage.STATUS_ACTIVE)
+}
+
+// CloseAccount is an HTTP handler that closes an account.
+func (controller *AccountController) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	controller.changeAccountStatus(w, r, constantPackage.STATUS_CLOSED)
+}
+
+// changeAccountStatus moves the account in the URL to the given status.
+//
+// Workflow:
+//  1. Extract the "accountId" from the URL path and convert it to an int.
+//  2. Begin db txn.
+//  3. Invoke the core layer to validate and apply the status transition.
+//  4. Commit txn on success (or rollback on error).
+//  5. Return a JSON response with the updated account details.
+func (controller *AccountController) changeAccountStatus(w http.ResponseWriter, r *http.Request, status string) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "accountId" from URL params.
+	accountIdStr := mux.Vars(r)["accountId"]
+	logger.Infof("Change account status endpoint called for accountId: %v, status: %s", accountIdStr, status)
+	accountId, err := strconv.Atoi(accountIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Begin a db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	// 3. Apply the status transition via core layer.
+	account, err := controller.coreV1.ChangeAccountStatus(ctx, accountId, status, tx)
+	if err != nil {
+		logger.Errorf("Error changing account status: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Commit txn.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success": true,
+		"account": mapperV1Package.AccountDetailsResponseMapper(account),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetAccountStatement is an HTTP handler that returns the statement of an account.
+//
+// Workflow:
+//  1. Extract the "accountId" from the URL path and convert it to an int.
+//  2. Parse and validate the from, to and format query parameters.
+//  3. Begin db txn.
+//  4. Build the statement via the core layer.
+//  5. Commit txn on success (or rollback on error).
+//  6. Return the statement as JSON, or as CSV when requested.
+func (controller *AccountController) GetAccountStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "accountId" from URL params.
+	accountIdStr := mux.Vars(r)["accountId"]
+	logger.Infof("GetAccountStatement endpoint called for accountId: %v", accountIdStr)
+	accountId, err := strconv.Atoi(accountIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Parse and validate the query parameters.
+	statementReq, err := entityHttpV1Package.ParseStatementRequest(r.URL.Query(), r.Header.Get("Accept"), time.Now().UTC())
+	if err == nil {
+		err = statementReq.Validate()
+	}
+	if err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	// 3. Begin a db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	// 4. Build the statement via core layer.
+	statement, err := controller.coreV1.GetAccountStatement(ctx, accountId, statementReq.From, statementReq.To, tx)
+	if err != nil {
+		logger.Errorf("Error building account statement: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Commit txn.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 6. Send the statement in the requested format.
+	if statementReq.Format == constantPackage.STATEMENT_FORMAT_CSV {
+		w.Header().Set("Content-Type", constantPackage.STATEMENT_CSV_MEDIA)
+		w.Header().Set("Content-Disposition", "attachment; filename=\"statement-"+accountIdStr+".csv\"")
+		csv.NewWriter(w).WriteAll(mapperV1Package.AccountStatementCSVMapper(statement))
+		return
+	}
+	response := map[string]interface{}{
+		"success":   true,
+		"statement": mapperV1Package.AccountStatementResponseMapper(statement),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// UpdateAvailableCreditLimit is the internal HTTP handler behind the remote mediator account client.
+// The update is committed in its own db txn, not in the caller's one.
+//
+// Workflow:
+//  1. Extract the "accountId" from the URL path and convert it to an int.
+//  2. Decode and validate the JSON payload into UpdateAvailableCreditLimitRequest.
+//  3. Begin db txn and claim the Idempotency-Key the client keeps across its retries,
+//     so a retried update is replayed instead of applied twice.
+//  4. Apply the delta via the core layer.
+//  5. Store the response for the idempotency key and commit txn on success (or rollback on error).
+//  6. Return a JSON success response.
+func (controller *AccountController) UpdateAvailableCreditLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "accountId" from URL params.
+	accountIdStr := mux.Vars(r)["accountId"]
+	logger.Infof("UpdateAvailableCreditLimit endpoint called for accountId: %v", accountIdStr)
+	accountId, err := strconv.Atoi(accountIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Decode and validate JSON request body.
+	var updateReq entityHttpV1Package.UpdateAvailableCreditLimitRequest
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &updateReq)
+	}
+	if err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+	if err := updateReq.Validate(); err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	idempotencyKey := r.Header.Get(idempotencyConstantPackage.HEADER)
+	if err := utilIdempotencyV1.ValidateKey(idempotencyKey); err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Begin new db txn and claim the idempotency key.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	var idempotencyRecord *utilIdempotencyV1.IdempotencyRecord
+	if idempotencyKey != "" {
+		record, replay, err := controller.idempotencyStore.Claim(ctx, idempotencyConstantPackage.SCOPE_UPDATE_CREDIT_LIMIT, idempotencyKey, utilIdempotencyV1.HashRequest([]byte(accountIdStr+":"+string(body))), tx)
+		if err != nil {
+			logger.Errorf("Error claiming idempotency key: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+		if replay {
+			logger.Infof("Replaying stored response for idempotency key: %s", idempotencyKey)
+			utilIdempotencyV1.WriteReplay(w, record)
+			return
+		}
+		idempotencyRecord = record
+	}
+
+	// 4. Apply the delta via core layer.
+	if err := controller.coreV1.UpdateAvailableCreditLimit(ctx, accountId, *updateReq.Delta, tx); err != nil {
+		logger.Errorf("Error updating available credit limit: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Store the response for the idempotency key and commit txn.
+	response := []byte(`{"success":true}`)
+	if idempotencyRecord != nil {
+		if err := controller.idempotencyStore.SaveResponse(ctx, idempotencyRecord, http.StatusOK, response, tx); err != nil {
+			logger.Errorf("Error saving idempotent response: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 6. Send JSON response.
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
@@ -3,12 +3,20 @@ package account_controller_v1
 import (
 	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
-
+	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -26,18 +34,35 @@ type MockAccountCore struct {
 	mock.Mock
 }
 
-func (m *MockAccountCore) CreateAccount(logger *logrus.Entry, payload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountCore) CreateAccount(ctx context.Context, payload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(payload, tx)
 	account, _ := args.Get(0).(*entityDbV1Package.Account)
 	return account, args.Error(1)
 }
 
-func (m *MockAccountCore) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountCore) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(accountId, tx)
 	account, _ := args.Get(0).(*entityDbV1Package.Account)
 	return account, args.Error(1)
 }
 
+func (m *MockAccountCore) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	args := m.Called(accountId, delta, tx)
+	return args.Error(0)
+}
+
+func (m *MockAccountCore) ChangeAccountStatus(ctx context.Context, accountId int, status string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	args := m.Called(accountId, status, tx)
+	account, _ := args.Get(0).(*entityDbV1Package.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockAccountCore) GetAccountStatement(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountStatement, error) {
+	args := m.Called(accountId, from, to, tx)
+	statement, _ := args.Get(0).(*entityCoreV1Package.AccountStatement)
+	return statement, args.Error(1)
+}
+
 //--------------------------------//
 //  2. Helper: Create Test DB
 //--------------------------------//
@@ -47,6 +72,12 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("failed to open test db: %v", err)
 	}
+	// A single connection keeps every txn on the same in-memory database.
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&utilIdempotencyV1.IdempotencyRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
 	return db
 }
 
@@ -59,7 +90,7 @@ func TestCreateAccount_Success(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	mockCore.On("CreateAccount", mock.Anything, mock.Anything, mock.Anything).
 		Return(&entityDbV1Package.Account{
@@ -83,7 +114,7 @@ func TestCreateAccount_InvalidPayload(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	requestBody := `{"document_number": 123}`
 	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(requestBody))
@@ -102,7 +133,7 @@ func TestCreateAccount_MissingDocumentNumber(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	requestBody := `{"document_number": ""}`
 	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(requestBody))
@@ -116,12 +147,92 @@ func TestCreateAccount_MissingDocumentNumber(t *testing.T) {
 	mockCore.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything)
 }
 
+func TestCreateAccount_Currency(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("CreateAccount", mock.MatchedBy(func(payload *entityCoreV1Package.CreateAccountPayload) bool {
+		return payload.Currency == "USD"
+	}), mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, DocumentNumber: "1", Currency: "USD"}, nil).Once()
+	mockCore.On("CreateAccount", mock.MatchedBy(func(payload *entityCoreV1Package.CreateAccountPayload) bool {
+		return payload.Currency == "JPY"
+	}), mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 2}, DocumentNumber: "2", Currency: "JPY"}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"1"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"currency":"USD"`)
+
+	rr = httptest.NewRecorder()
+	controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"2","currency":"JPY","available_credit_limit":100000}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"currency":"JPY"`)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateAccount_InvalidCurrency(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	for _, body := range []string{`{"document_number":"1","currency":"XXX"}`, `{"document_number":"1","currency":"JPY","available_credit_limit":10.5}`} {
+		rr := httptest.NewRecorder()
+		controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything)
+}
+
+func TestCreateAccount_Tier(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("CreateAccount", mock.MatchedBy(func(payload *entityCoreV1Package.CreateAccountPayload) bool {
+		return payload.Tier == "STANDARD"
+	}), mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, DocumentNumber: "1", Tier: "STANDARD"}, nil).Once()
+	mockCore.On("CreateAccount", mock.MatchedBy(func(payload *entityCoreV1Package.CreateAccountPayload) bool {
+		return payload.Tier == "GOLD"
+	}), mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 2}, DocumentNumber: "2", Tier: "GOLD"}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"1"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"tier":"STANDARD"`)
+
+	rr = httptest.NewRecorder()
+	controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"2","tier":"GOLD"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"tier":"GOLD"`)
+
+	rr = httptest.NewRecorder()
+	controller.CreateAccount(rr, httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"3","tier":"DIAMOND"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED)
+
+	mockCore.AssertExpectations(t)
+}
+
 func TestCreateAccount_CommitError(t *testing.T) {
 	db := setupTestDB(t)
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	requestBody := `{"document_number":"123456789"}`
 	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(requestBody))
@@ -143,7 +254,7 @@ func TestCreateAccount_CommitError(t *testing.T) {
 	controller.CreateAccount(rr, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Contains(t, rr.Body.String(), "commit failed")
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INTERNAL_ERROR)
 
 	mockCore.AssertExpectations(t)
 }
@@ -152,7 +263,7 @@ func TestCreateAccount_CoreError(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	requestBody := `{"document_number":"123456789"}`
 	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(requestBody))
@@ -170,6 +281,72 @@ func TestCreateAccount_CoreError(t *testing.T) {
 	mockCore.AssertExpectations(t)
 }
 
+func TestCreateAccount_DuplicateDocumentNumber(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	requestBody := `{"document_number":"123456789"}`
+	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	mockCore.On("CreateAccount", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_DOCUMENT_NUMBER, "duplicate account found with document_number: 123456789"))
+
+	controller.CreateAccount(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.DUPLICATE_DOCUMENT_NUMBER)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateAccount_IdempotentReplay(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("CreateAccount", mock.Anything, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, DocumentNumber: "123456789"}, nil).
+		Once()
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"123456789"}`))
+		req.Header.Set(idempotencyConstantPackage.HEADER, "account-key")
+		rr := httptest.NewRecorder()
+
+		controller.CreateAccount(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"document_number":"123456789"`)
+	}
+
+	mockCore.AssertNumberOfCalls(t, "CreateAccount", 1)
+}
+
+func TestCreateAccount_IdempotencyKeyTooLong(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	req := httptest.NewRequest("POST", "/accounts/v1", strings.NewReader(`{"document_number":"123456789"}`))
+	req.Header.Set(idempotencyConstantPackage.HEADER, strings.Repeat("k", idempotencyConstantPackage.MAX_KEY_LENGTH+1))
+	rr := httptest.NewRecorder()
+
+	controller.CreateAccount(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_IDEMPOTENCY_KEY)
+	mockCore.AssertNotCalled(t, "CreateAccount", mock.Anything, mock.Anything)
+}
+
 //-------------------------------------//
 //  4. Tests for GetAccountDetails
 //-------------------------------------//
@@ -179,7 +356,7 @@ func TestGetAccountDetails_Success(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	accountID := 1
 	mockCore.On("GetAccount", accountID, mock.Anything).
@@ -205,7 +382,7 @@ func TestGetAccountDetails_InvalidPathParam(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	req := httptest.NewRequest("GET", "/accounts/v1/abc", nil)
 	rr := httptest.NewRecorder()
@@ -214,7 +391,8 @@ func TestGetAccountDetails_InvalidPathParam(t *testing.T) {
 	}
 	req = mux.SetURLVars(req, vars)
 	controller.GetAccountDetails(rr, req)
-	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "INVALID_PATH_PARAMETER")
 	assert.Contains(t, rr.Body.String(), "Error converting string to int")
 	mockCore.AssertNotCalled(t, "GetAccount", mock.Anything, mock.Anything)
 }
@@ -224,7 +402,7 @@ func TestGetAccountDetails_CoreError(t *testing.T) {
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	accountID := 5
 	mockCore.On("GetAccount", accountID, mock.Anything).
@@ -244,12 +422,35 @@ func TestGetAccountDetails_CoreError(t *testing.T) {
 
 	mockCore.AssertExpectations(t)
 }
+func TestGetAccountDetails_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("GetAccount", 77, mock.Anything).
+		Return(&entityDbV1Package.Account{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account_id: 77 not found in database"))
+
+	req := httptest.NewRequest("GET", "/accounts/v1/77", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"accountId": "77"})
+
+	controller.GetAccountDetails(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.ACCOUNT_NOT_FOUND)
+	assert.Contains(t, rr.Body.String(), `"success":false`)
+
+	mockCore.AssertExpectations(t)
+}
+
 func TestGetAccountDetails_CommitError(t *testing.T) {
 	db := setupTestDB(t)
 	logger := logrus.New()
 
 	mockCore := new(MockAccountCore)
-	controller := NewAccountController(nil, mockCore, db, logger)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
 
 	accountID := 5
 	mockCore.On("GetAccount", accountID, mock.Anything).
@@ -270,7 +471,240 @@ func TestGetAccountDetails_CommitError(t *testing.T) {
 	controller.GetAccountDetails(rr, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Contains(t, rr.Body.String(), "commit failed")
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INTERNAL_ERROR)
+
+	mockCore.AssertExpectations(t)
+}
+
+//-------------------------------------//
+//  5. Tests for account status changes
+//-------------------------------------//
+
+func TestBlockAccount_Success(t *testing.T) {
+	db := setupTestDB(t)
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logrus.New()), db, logrus.New())
+
+	mockCore.On("ChangeAccountStatus", 7, constantPackage.STATUS_BLOCKED, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 7}, Status: constantPackage.STATUS_BLOCKED}, nil)
+
+	req := httptest.NewRequest("POST", "/accounts/v1/7/block", nil)
+	req = mux.SetURLVars(req, map[string]string{"accountId": "7"})
+	rr := httptest.NewRecorder()
+	controller.BlockAccount(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"BLOCKED"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestUnblockAndCloseAccount_TargetStatus(t *testing.T) {
+	db := setupTestDB(t)
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logrus.New()), db, logrus.New())
+
+	mockCore.On("ChangeAccountStatus", 7, constantPackage.STATUS_ACTIVE, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 7}, Status: constantPackage.STATUS_ACTIVE}, nil)
+	mockCore.On("ChangeAccountStatus", 7, constantPackage.STATUS_CLOSED, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 7}, Status: constantPackage.STATUS_CLOSED}, nil)
+
+	req := mux.SetURLVars(httptest.NewRequest("POST", "/accounts/v1/7/unblock", nil), map[string]string{"accountId": "7"})
+	rr := httptest.NewRecorder()
+	controller.UnblockAccount(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"ACTIVE"`)
+
+	req = mux.SetURLVars(httptest.NewRequest("POST", "/accounts/v1/7/close", nil), map[string]string{"accountId": "7"})
+	rr = httptest.NewRecorder()
+	controller.CloseAccount(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"CLOSED"`)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestBlockAccount_CoreError(t *testing.T) {
+	db := setupTestDB(t)
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logrus.New()), db, logrus.New())
 
+	mockCore.On("ChangeAccountStatus", 7, constantPackage.STATUS_BLOCKED, mock.Anything).
+		Return(nil, utilErrorsV1.NewConflictError(errorConstantPackage.INVALID_STATUS_TRANSITION, "account_id: 7 cannot move from status CLOSED to BLOCKED"))
+
+	req := mux.SetURLVars(httptest.NewRequest("POST", "/accounts/v1/7/block", nil), map[string]string{"accountId": "7"})
+	rr := httptest.NewRecorder()
+	controller.BlockAccount(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_STATUS_TRANSITION)
+	assert.Contains(t, rr.Body.String(), "cannot move from status CLOSED to BLOCKED")
 	mockCore.AssertExpectations(t)
 }
+
+//--------------------------------------//
+//  Tests for GetAccountStatement
+//--------------------------------------//
+
+func sampleStatement(from time.Time, to time.Time) *entityCoreV1Package.AccountStatement {
+	return &entityCoreV1Package.AccountStatement{
+		AccountId:      3,
+		From:           from,
+		To:             to,
+		OpeningBalance: utilMoneyV1.MustParse("-10"),
+		ClosingBalance: utilMoneyV1.MustParse("-60.5"),
+		Lines: []*entityCoreV1Package.StatementLine{
+			{TransactionId: 8, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50.5"), RunningBalance: utilMoneyV1.MustParse("-60.5"), EventDate: from.Add(time.Hour)},
+		},
+		Totals: []*entityCoreV1Package.StatementTotal{
+			{OperationTypeId: 1, Count: 1, Total: utilMoneyV1.MustParse("-50.5")},
+		},
+	}
+}
+
+func TestGetAccountStatement_JSON(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// A date-only "to" covers the whole day.
+	to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockCore.On("GetAccountStatement", 3, from, to, mock.Anything).Return(sampleStatement(from, to), nil)
+
+	req := httptest.NewRequest("GET", "/accounts/v1/3/statement?from=2026-03-01&to=2026-03-31", nil)
+	req = mux.SetURLVars(req, map[string]string{"accountId": "3"})
+	rr := httptest.NewRecorder()
+	controller.GetAccountStatement(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"opening_balance":-10`)
+	assert.Contains(t, rr.Body.String(), `"running_balance":-60.5`)
+	assert.Contains(t, rr.Body.String(), `"totals":[{"operation_type_id":1,"count":1,"total":-50.5}]`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestGetAccountStatement_CSV(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	mockCore.On("GetAccountStatement", 3, from, to, mock.Anything).Return(sampleStatement(from, to), nil)
+
+	req := httptest.NewRequest("GET", "/accounts/v1/3/statement?from=2026-03-01T00:00:00Z&to=2026-03-15T12:00:00Z", nil)
+	req.Header.Set("Accept", "text/csv")
+	req = mux.SetURLVars(req, map[string]string{"accountId": "3"})
+	rr := httptest.NewRecorder()
+	controller.GetAccountStatement(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	assert.Equal(t, strings.Join([]string{
+		"record_type,transaction_id,operation_type_id,event_date,count,amount,balance",
+		"OPENING_BALANCE,,,2026-03-01T00:00:00Z,,,-10",
+		"TRANSACTION,8,1,2026-03-01T01:00:00Z,,-50.5,-60.5",
+		"TOTAL,,1,,1,-50.5,",
+		"CLOSING_BALANCE,,,2026-03-15T12:00:00Z,,,-60.5",
+		"",
+	}, "\n"), rr.Body.String())
+}
+
+func TestGetAccountStatement_InvalidQuery(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	for _, query := range []string{"from=yesterday", "from=2026-03-10&to=2026-03-01", "from=2020-01-01&to=2026-01-01", "format=xml"} {
+		req := httptest.NewRequest("GET", "/accounts/v1/3/statement?"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"accountId": "3"})
+		rr := httptest.NewRecorder()
+		controller.GetAccountStatement(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER, query)
+	}
+	mockCore.AssertNotCalled(t, "GetAccountStatement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetAccountStatement_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("GetAccountStatement", 9, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account not found"))
+
+	req := httptest.NewRequest("GET", "/accounts/v1/9/statement", nil)
+	req = mux.SetURLVars(req, map[string]string{"accountId": "9"})
+	rr := httptest.NewRecorder()
+	controller.GetAccountStatement(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.ACCOUNT_NOT_FOUND)
+}
+
+//-------------------------------------------//
+//  6. Tests for UpdateAvailableCreditLimit
+//-------------------------------------------//
+
+func TestUpdateAvailableCreditLimit_IdempotentRetry(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("UpdateAvailableCreditLimit", 4, utilMoneyV1.MustParse("-50"), mock.Anything).Return(nil).Once()
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("POST", "/internal/accounts/v1/4/available-credit-limit", strings.NewReader(`{"delta":"-50.00"}`))
+		req = mux.SetURLVars(req, map[string]string{"accountId": "4"})
+		req.Header.Set(idempotencyConstantPackage.HEADER, "limit-key")
+		rr := httptest.NewRecorder()
+		controller.UpdateAvailableCreditLimit(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"success":true`)
+	}
+
+	mockCore.AssertNumberOfCalls(t, "UpdateAvailableCreditLimit", 1)
+}
+
+func TestUpdateAvailableCreditLimit_MissingDelta(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	req := httptest.NewRequest("POST", "/internal/accounts/v1/4/available-credit-limit", strings.NewReader(`{}`))
+	req = mux.SetURLVars(req, map[string]string{"accountId": "4"})
+	rr := httptest.NewRecorder()
+	controller.UpdateAvailableCreditLimit(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED)
+	mockCore.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateAvailableCreditLimit_InsufficientLimit(t *testing.T) {
+	db := setupTestDB(t)
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	controller := NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+
+	mockCore.On("UpdateAvailableCreditLimit", 4, utilMoneyV1.MustParse("-5000"), mock.Anything).
+		Return(utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, "insufficient available credit limit for account_id: 4"))
+
+	req := httptest.NewRequest("POST", "/internal/accounts/v1/4/available-credit-limit", strings.NewReader(`{"delta":"-5000"}`))
+	req = mux.SetURLVars(req, map[string]string{"accountId": "4"})
+	rr := httptest.NewRecorder()
+	controller.UpdateAvailableCreditLimit(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT)
+}
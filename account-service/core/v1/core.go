@@ -5,7 +5,17 @@ import (
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
 	mapperV1Package "anti-fraud/account-service/mapper/v1"
 	repoV1Package "anti-fraud/account-service/repository/v1"
+	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -15,21 +25,39 @@ import (
 type IAccountCore interface {
 
 	// CreateAccount creates a new account if the given document number is not already registered.
-	CreateAccount(logger *logrus.Entry, accountPayload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error)
+	CreateAccount(ctx context.Context, accountPayload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error)
 
 	// GetAccount retrieves an account by its unique ID.
-	GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error)
+	GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error)
+
+	// UpdateAvailableCreditLimit draws down (negative delta) or restores (positive delta) the account's available credit limit.
+	UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error
+
+	// ChangeAccountStatus moves an account to a new lifecycle status if the transition is allowed.
+	ChangeAccountStatus(ctx context.Context, accountId int, status string, tx *gorm.DB) (*entityDbV1Package.Account, error)
+
+	// GetAccountStatement builds the statement of an account over [from, to).
+	GetAccountStatement(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountStatement, error)
+}
+
+// allowedStatusTransitions lists, for each account status, the statuses it may move to.
+// CLOSED is terminal.
+var allowedStatusTransitions = map[string][]string{
+	constantPackage.STATUS_ACTIVE:  {constantPackage.STATUS_BLOCKED, constantPackage.STATUS_CLOSED},
+	constantPackage.STATUS_BLOCKED: {constantPackage.STATUS_ACTIVE, constantPackage.STATUS_CLOSED},
 }
 
 // AccountCore implements the IAccountCore interface, containing business logic for account operations.
 type AccountCore struct {
-	repoV1 repoV1Package.IAccountRepository
-	logger *logrus.Logger
+	repoV1            repoV1Package.IAccountRepository
+	logger            *logrus.Logger
+	transactionClient transactionClientV1Package.ITransactionClient
+	eventBus          eventBusV1Package.IEventBus
 }
 
 // NewAccountCore cretae new AccountCore instance.
-func NewAccountCore(repoV1 repoV1Package.IAccountRepository, logger *logrus.Logger) *AccountCore {
-	return &AccountCore{repoV1: repoV1, logger: logger}
+func NewAccountCore(repoV1 repoV1Package.IAccountRepository, logger *logrus.Logger, transactionClient transactionClientV1Package.ITransactionClient, eventBus eventBusV1Package.IEventBus) *AccountCore {
+	return &AccountCore{repoV1: repoV1, logger: logger, transactionClient: transactionClient, eventBus: eventBus}
 }
 
 // CreateAccount handles the creation of a new account.
@@ -38,7 +66,8 @@ func NewAccountCore(repoV1 repoV1Package.IAccountRepository, logger *logrus.Logg
 //   1. Checks if an account with the same document number already exists via the repository.
 //   2. If a duplicate is found, it returns that existing account and an error indicating a duplicate.
 //   3. Otherwise, maps the request payload to a DB entity and creates a new account record.
-//   4. Returns the created account and Error if occured.
+//   4. Publishes AccountCreated.
+//   5. Returns the created account and Error if occured.
 //
 // Parameters:
 //   - accountPayload: Holds the new account details.
@@ -48,11 +77,12 @@ func NewAccountCore(repoV1 repoV1Package.IAccountRepository, logger *logrus.Logg
 //   - A pointer to the newly created Account entity (or the duplicate if found).
 //   - An encountered Error.
 
-func (core *AccountCore) CreateAccount(logger *logrus.Entry, accountPayload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (core *AccountCore) CreateAccount(ctx context.Context, accountPayload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("CreateAccount method called in account core layer.")
 
 	// 1. Check for an existing account with the same document number
-	accountFound, err := core.repoV1.CheckDuplicateAccount(logger, accountPayload.DocumentNumber, tx)
+	accountFound, err := core.repoV1.CheckDuplicateAccount(ctx, accountPayload.DocumentNumber, tx)
 	if err != nil {
 		logger.Errorf("Error occured while checking for duplicate account : %s", err.Error())
 		return &entityDbV1Package.Account{}, err
@@ -61,14 +91,27 @@ func (core *AccountCore) CreateAccount(logger *logrus.Entry, accountPayload *ent
 	// 2. If a duplicate exists, return it along with an error
 	if accountFound.ID > 0 {
 		logger.Error("Error: Duplicate account found")
-		return accountFound, fmt.Errorf("duplicate account found with document_number: %s", accountPayload.DocumentNumber)
+		return accountFound, utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_DOCUMENT_NUMBER, fmt.Sprintf("duplicate account found with document_number: %s", accountPayload.DocumentNumber))
 	}
 
 	// 3. Map the incoming payload to a DB entity
 	account := mapperV1Package.AccountMapper(accountPayload)
 
 	// 4. Create the new account record in the DB
-	err = core.repoV1.CreateAccount(logger, account, tx)
+	err = core.repoV1.CreateAccount(ctx, account, tx)
+	if err != nil {
+		return account, err
+	}
+
+	// 5. Let the other services know about the account.
+	err = core.eventBus.Publish(ctx, &eventBusV1Package.AccountCreated{
+		AccountId:            int(account.ID),
+		DocumentNumber:       account.DocumentNumber,
+		AvailableCreditLimit: account.AvailableCreditLimit,
+		Currency:             account.Currency,
+		Tier:                 account.Tier,
+		OccurredAt:           time.Now(),
+	}, tx)
 	return account, err
 
 }
@@ -77,7 +120,7 @@ func (core *AccountCore) CreateAccount(logger *logrus.Entry, accountPayload *ent
 //
 // Steps:
 //  1. Delegates to the repository to fetch the account.
-//  2. Returns the account if found, or an error if not found or if any issue occurs.
+//  2. Returns the account if found, or a not found Error if missing, or any other encountered Error.
 //
 // Parameters:
 //   - accountId: ID of the account to retrieve.
@@ -86,8 +129,183 @@ func (core *AccountCore) CreateAccount(logger *logrus.Entry, accountPayload *ent
 // Returns:
 //   - db entity Account.
 //   - An encountered Error.
-func (core *AccountCore) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (core *AccountCore) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetAccount method called in account core layer.")
-	account, err := core.repoV1.GetAccount(logger, accountId, tx)
+	account, err := core.repoV1.GetAccount(ctx, accountId, tx)
 	return account, err
 }
+
+// UpdateAvailableCreditLimit applies delta to the available credit limit of an account.
+//
+// Steps:
+//  1. Delegates the atomic conditional update to the repository.
+//  2. If no row was updated, the account does not have enough available limit: return an Error.
+//
+// Parameters:
+//   - accountId: ID of the account to update.
+//   - delta:     signed amount added to the limit.
+//   - tx:        db txn, the same one the caller uses to persist the transaction.
+//
+// Returns:
+//   - An encountered Error.
+func (core *AccountCore) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAvailableCreditLimit method called in account core layer.")
+	updated, err := core.repoV1.UpdateAvailableCreditLimit(ctx, accountId, delta, tx)
+	if err != nil {
+		logger.Errorf("Error occured while updating available credit limit: %s", err.Error())
+		return err
+	}
+	if !updated {
+		logger.Error("Error: insufficient available credit limit")
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, fmt.Sprintf("insufficient available credit limit for account_id: %d", accountId))
+	}
+	return nil
+}
+
+// ChangeAccountStatus applies a lifecycle transition to an account.
+//
+// Steps:
+//  1. Fetch the account; return a not found Error if it does not exist.
+//  2. Validate the transition from the current status against allowedStatusTransitions.
+//  3. Persist the new status, guarded on the current status so concurrent transitions cannot both win.
+//  4. Publish AccountStatusChanged.
+//
+// Parameters:
+//   - accountId: ID of the account to update.
+//   - status:    target status.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The updated account.
+//   - An encountered Error.
+func (core *AccountCore) ChangeAccountStatus(ctx context.Context, accountId int, status string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ChangeAccountStatus method called in account core layer.")
+
+	// 1. Fetch the account.
+	account, err := core.repoV1.GetAccount(ctx, accountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching account: %s", err.Error())
+		return account, err
+	}
+
+	// 2. Validate the transition.
+	if !isStatusTransitionAllowed(account.Status, status) {
+		logger.Errorf("Error: status transition from %s to %s is not allowed", account.Status, status)
+		return account, utilErrorsV1.NewConflictError(errorConstantPackage.INVALID_STATUS_TRANSITION, fmt.Sprintf("account_id: %d cannot move from status %s to %s", accountId, account.Status, status))
+	}
+
+	// 3. Persist the new status.
+	updated, err := core.repoV1.UpdateAccountStatus(ctx, accountId, account.Status, status, tx)
+	if err != nil {
+		logger.Errorf("Error occured while updating account status: %s", err.Error())
+		return account, err
+	}
+	if !updated {
+		logger.Error("Error: account status changed concurrently")
+		return account, utilErrorsV1.NewConflictError(errorConstantPackage.CONCURRENT_MODIFICATION, fmt.Sprintf("account_id: %d status was changed concurrently", accountId))
+	}
+
+	// 4. Let the other services react to the transition.
+	err = core.eventBus.Publish(ctx, &eventBusV1Package.AccountStatusChanged{
+		AccountId:  accountId,
+		FromStatus: account.Status,
+		ToStatus:   status,
+		OccurredAt: time.Now(),
+	}, tx)
+	if err != nil {
+		return account, err
+	}
+	account.Status = status
+	return account, nil
+}
+
+// GetAccountStatement builds the statement of an account over [from, to).
+//
+// Steps:
+//  1. Fetch the account; return a not found Error if it does not exist.
+//  2. Fetch, via the transaction client, the opening balance and the posted transactions of the period.
+//  3. Walk the transactions oldest first, computing the running balance and the totals per operation type.
+//
+// Declined transactions never moved the account, so they are not part of the statement.
+//
+// Parameters:
+//   - accountId: ID of the account.
+//   - from:      start of the period, inclusive.
+//   - to:        end of the period, exclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The statement.
+//   - An encountered Error.
+func (core *AccountCore) GetAccountStatement(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountStatement, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAccountStatement method called in account core layer.")
+
+	// 1. Fetch the account.
+	account, err := core.repoV1.GetAccount(ctx, accountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching account: %s", err.Error())
+		return nil, err
+	}
+
+	// 2. Fetch the opening balance and the transactions of the period.
+	openingBalance, err := core.transactionClient.GetPostedBalance(ctx, accountId, from, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching opening balance: %s", err.Error())
+		return nil, err
+	}
+	transactions, err := core.transactionClient.GetPostedTransactions(ctx, accountId, from, to, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching statement transactions: %s", err.Error())
+		return nil, err
+	}
+
+	// 3. Compute the running balance and the totals.
+	statement := &entityCoreV1Package.AccountStatement{
+		AccountId:      accountId,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+		Lines:          make([]*entityCoreV1Package.StatementLine, 0, len(transactions)),
+		Totals:         []*entityCoreV1Package.StatementTotal{},
+	}
+	balance := openingBalance
+	totals := map[int]*entityCoreV1Package.StatementTotal{}
+	for _, transaction := range transactions {
+		balance = balance.Add(transaction.Amount)
+		statement.Lines = append(statement.Lines, &entityCoreV1Package.StatementLine{
+			TransactionId:   transaction.Id,
+			OperationTypeId: transaction.OperationTypeId,
+			Amount:          transaction.Amount,
+			RunningBalance:  balance,
+			EventDate:       transaction.EventDate,
+		})
+		total, ok := totals[transaction.OperationTypeId]
+		if !ok {
+			total = &entityCoreV1Package.StatementTotal{OperationTypeId: transaction.OperationTypeId}
+			totals[transaction.OperationTypeId] = total
+			statement.Totals = append(statement.Totals, total)
+		}
+		total.Count++
+		total.Total = total.Total.Add(transaction.Amount)
+	}
+	sort.Slice(statement.Totals, func(i, j int) bool {
+		return statement.Totals[i].OperationTypeId < statement.Totals[j].OperationTypeId
+	})
+	statement.ClosingBalance = balance
+	return statement, nil
+}
+
+// isStatusTransitionAllowed reports whether an account may move from one status to another.
+func isStatusTransitionAllowed(fromStatus string, toStatus string) bool {
+	for _, allowed := range allowedStatusTransitions[fromStatus] {
+		if allowed == toStatus {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,19 @@
 package account_core_v1
 
 import (
+	constantPackage "anti-fraud/constants/account"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
+	errorConstantPackage "anti-fraud/constants/errors"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -17,34 +25,95 @@ type MockAccountRepository struct {
 	mock.Mock
 }
 
-func (m *MockAccountRepository) CheckDuplicateAccount(logger *logrus.Entry, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountRepository) CheckDuplicateAccount(ctx context.Context, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(documentNumber, tx)
 	account, _ := args.Get(0).(*entityDbV1Package.Account)
 	return account, args.Error(1)
 }
 
-func (m *MockAccountRepository) CreateAccount(logger *logrus.Entry, account *entityDbV1Package.Account, tx *gorm.DB) error {
+func (m *MockAccountRepository) CreateAccount(ctx context.Context, account *entityDbV1Package.Account, tx *gorm.DB) error {
 	args := m.Called(account, tx)
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountRepository) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(accountId, tx)
 	account, _ := args.Get(0).(*entityDbV1Package.Account)
 	return account, args.Error(1)
 }
 
+func (m *MockAccountRepository) UpdateAccountStatus(ctx context.Context, accountId int, fromStatus string, toStatus string, tx *gorm.DB) (bool, error) {
+	args := m.Called(accountId, fromStatus, toStatus, tx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAccountRepository) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) (bool, error) {
+	args := m.Called(accountId, delta, tx)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockTransactionClient struct {
+	mock.Mock
+}
+
+func (m *MockTransactionClient) SetupCore(transactionCoreV1 transactionClientV1Package.ITransactionCore) {
+	m.Called(transactionCoreV1)
+}
+
+func (m *MockTransactionClient) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*transactionClientV1Package.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*transactionClientV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionClient) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionClient) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionClient) PostCapturedTransaction(ctx context.Context, capture *transactionClientV1Package.CapturedTransaction, tx *gorm.DB) (*transactionClientV1Package.Transaction, error) {
+	args := m.Called(capture, tx)
+	transaction, _ := args.Get(0).(*transactionClientV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Subscribe(eventName string, delivery string, subscriber string, handler eventBusV1Package.Handler) {
+	m.Called(eventName, delivery, subscriber)
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event eventBusV1Package.Event, tx *gorm.DB) error {
+	args := m.Called(event, tx)
+	return args.Error(0)
+}
+
 //---------------------//
 //   Unit Test Setup   //
 //---------------------//
 
 func setupTest() (*MockAccountRepository, *AccountCore) {
+	mockRepo, _, accountCore := setupStatementTest()
+	return mockRepo, accountCore
+}
+
+func setupStatementTest() (*MockAccountRepository, *MockTransactionClient, *AccountCore) {
 	mockRepo := new(MockAccountRepository)
+	mockClient := new(MockTransactionClient)
+	mockBus := new(MockEventBus)
+	mockBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
 	logger := logrus.New()
 
-	accountCore := NewAccountCore(mockRepo, logger)
+	accountCore := NewAccountCore(mockRepo, logger, mockClient, mockBus)
 
-	return mockRepo, accountCore
+	return mockRepo, mockClient, accountCore
 }
 
 //-------------------------------//
@@ -53,7 +122,7 @@ func setupTest() (*MockAccountRepository, *AccountCore) {
 
 func TestCreateAccount_Success(t *testing.T) {
 	mockRepo, accountCore := setupTest()
-	logger := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
 	payload := &entityCoreV1Package.CreateAccountPayload{
 		DocumentNumber: "123456789",
 	}
@@ -63,15 +132,34 @@ func TestCreateAccount_Success(t *testing.T) {
 	// 2. CreateAccount -> returns no error
 	mockRepo.On("CreateAccount", mock.Anything, mock.Anything).Return(nil)
 
-	account, err := accountCore.CreateAccount(logger, payload, &gorm.DB{})
+	account, err := accountCore.CreateAccount(ctx, payload, &gorm.DB{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, account)
 	assert.Equal(t, payload.DocumentNumber, account.DocumentNumber)
+	accountCore.eventBus.(*MockEventBus).AssertCalled(t, "Publish", mock.MatchedBy(func(event *eventBusV1Package.AccountCreated) bool {
+		return event.DocumentNumber == payload.DocumentNumber
+	}), mock.Anything)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreateAccount_PublishError(t *testing.T) {
+	mockRepo := new(MockAccountRepository)
+	mockBus := new(MockEventBus)
+	accountCore := NewAccountCore(mockRepo, logrus.New(), new(MockTransactionClient), mockBus)
+	payload := &entityCoreV1Package.CreateAccountPayload{DocumentNumber: "123456789"}
+
+	mockRepo.On("CheckDuplicateAccount", payload.DocumentNumber, mock.Anything).Return(&entityDbV1Package.Account{}, nil)
+	mockRepo.On("CreateAccount", mock.Anything, mock.Anything).Return(nil)
+	mockBus.On("Publish", mock.Anything, mock.Anything).Return(errors.New("outbox unavailable"))
+
+	_, err := accountCore.CreateAccount(context.Background(), payload, &gorm.DB{})
+
+	assert.EqualError(t, err, "outbox unavailable", "the account insert must roll back with the lost event")
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCreateAccount_DuplicateAccount(t *testing.T) {
 	mockRepo, accountCore := setupTest()
 
@@ -85,13 +173,14 @@ func TestCreateAccount_DuplicateAccount(t *testing.T) {
 	}
 
 	mockRepo.On("CheckDuplicateAccount", payload.DocumentNumber, mock.Anything).Return(existingAccount, nil)
-	logger := logrus.NewEntry(logrus.New())
-	account, err := accountCore.CreateAccount(logger, payload, &gorm.DB{})
+	ctx := context.Background()
+	account, err := accountCore.CreateAccount(ctx, payload, &gorm.DB{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "duplicate account found")
 	assert.NotNil(t, account)
 	assert.Equal(t, existingAccount, account)
+	accountCore.eventBus.(*MockEventBus).AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 
 	mockRepo.AssertExpectations(t)
 }
@@ -105,9 +194,9 @@ func TestCreateAccount_RepoError(t *testing.T) {
 
 	mockRepo.On("CheckDuplicateAccount", payload.DocumentNumber, mock.Anything).
 		Return(&entityDbV1Package.Account{}, errors.New("some repo error"))
-	logger := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
 
-	account, err := accountCore.CreateAccount(logger, payload, &gorm.DB{})
+	account, err := accountCore.CreateAccount(ctx, payload, &gorm.DB{})
 
 	assert.Error(t, err)
 	assert.Equal(t, 0, int(account.ID))
@@ -130,9 +219,9 @@ func TestGetAccount_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetAccount", accountID, mock.Anything).Return(accountDB, nil)
-	logger := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
 
-	account, err := accountCore.GetAccount(logger, accountID, &gorm.DB{})
+	account, err := accountCore.GetAccount(ctx, accountID, &gorm.DB{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, account)
@@ -147,9 +236,9 @@ func TestGetAccount_NotFound(t *testing.T) {
 
 	accountID := 1
 	mockRepo.On("GetAccount", accountID, mock.Anything).Return((*entityDbV1Package.Account)(nil), gorm.ErrRecordNotFound)
-	logger := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
 
-	account, err := accountCore.GetAccount(logger, accountID, &gorm.DB{})
+	account, err := accountCore.GetAccount(ctx, accountID, &gorm.DB{})
 
 	assert.Error(t, err)
 	assert.Nil(t, account)
@@ -163,9 +252,9 @@ func TestGetAccount_RepoError(t *testing.T) {
 
 	accountID := 2
 	mockRepo.On("GetAccount", accountID, mock.Anything).Return((*entityDbV1Package.Account)(nil), errors.New("db error"))
-	logger := logrus.NewEntry(logrus.New())
+	ctx := context.Background()
 
-	account, err := accountCore.GetAccount(logger, accountID, &gorm.DB{})
+	account, err := accountCore.GetAccount(ctx, accountID, &gorm.DB{})
 
 	assert.Error(t, err)
 	assert.Nil(t, account)
@@ -173,3 +262,200 @@ func TestGetAccount_RepoError(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+//--------------------------------------------//
+// Tests for UpdateAvailableCreditLimit Method //
+//--------------------------------------------//
+
+func TestUpdateAvailableCreditLimit_Success(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-100"), mock.Anything).Return(true, nil)
+
+	err := accountCore.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-100"), &gorm.DB{})
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateAvailableCreditLimit_Insufficient(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-100"), mock.Anything).Return(false, nil)
+
+	err := accountCore.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-100"), &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient available credit limit for account_id: 1")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateAvailableCreditLimit_RepoError(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("100"), mock.Anything).Return(false, errors.New("db error"))
+
+	err := accountCore.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("100"), &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+
+	mockRepo.AssertExpectations(t)
+}
+
+//-------------------------------------//
+// Tests for ChangeAccountStatus Method //
+//-------------------------------------//
+
+func TestChangeAccountStatus_Success(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("GetAccount", 1, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, Status: constantPackage.STATUS_ACTIVE}, nil)
+	mockRepo.On("UpdateAccountStatus", 1, constantPackage.STATUS_ACTIVE, constantPackage.STATUS_BLOCKED, mock.Anything).Return(true, nil)
+
+	account, err := accountCore.ChangeAccountStatus(context.Background(), 1, constantPackage.STATUS_BLOCKED, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_BLOCKED, account.Status)
+	accountCore.eventBus.(*MockEventBus).AssertCalled(t, "Publish", mock.MatchedBy(func(event *eventBusV1Package.AccountStatusChanged) bool {
+		return event.AccountId == 1 && event.FromStatus == constantPackage.STATUS_ACTIVE && event.ToStatus == constantPackage.STATUS_BLOCKED
+	}), mock.Anything)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestChangeAccountStatus_InvalidTransition(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("GetAccount", 1, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, Status: constantPackage.STATUS_CLOSED}, nil)
+
+	_, err := accountCore.ChangeAccountStatus(context.Background(), 1, constantPackage.STATUS_ACTIVE, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot move from status CLOSED to ACTIVE")
+
+	mockRepo.AssertNotCalled(t, "UpdateAccountStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeAccountStatus_SameStatusIsNotATransition(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("GetAccount", 1, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, Status: constantPackage.STATUS_BLOCKED}, nil)
+
+	_, err := accountCore.ChangeAccountStatus(context.Background(), 1, constantPackage.STATUS_BLOCKED, &gorm.DB{})
+	assert.Error(t, err)
+
+	mockRepo.AssertNotCalled(t, "UpdateAccountStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangeAccountStatus_NotFound(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("GetAccount", 9, mock.Anything).
+		Return(&entityDbV1Package.Account{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account_id: 9 not found in database"))
+
+	_, err := accountCore.ChangeAccountStatus(context.Background(), 9, constantPackage.STATUS_CLOSED, &gorm.DB{})
+	assert.Error(t, err)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	assert.Contains(t, err.Error(), "account_id: 9 not found")
+	mockRepo.AssertNotCalled(t, "UpdateAccountStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestChangeAccountStatus_ConcurrentChange(t *testing.T) {
+	mockRepo, accountCore := setupTest()
+
+	mockRepo.On("GetAccount", 1, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}, Status: constantPackage.STATUS_BLOCKED}, nil)
+	mockRepo.On("UpdateAccountStatus", 1, constantPackage.STATUS_BLOCKED, constantPackage.STATUS_CLOSED, mock.Anything).Return(false, nil)
+
+	_, err := accountCore.ChangeAccountStatus(context.Background(), 1, constantPackage.STATUS_CLOSED, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "changed concurrently")
+	accountCore.eventBus.(*MockEventBus).AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+
+	mockRepo.AssertExpectations(t)
+}
+
+//---------------------------------------//
+// Tests for GetAccountStatement Method  //
+//---------------------------------------//
+
+func TestGetAccountStatement_Success(t *testing.T) {
+	mockRepo, mockClient, accountCore := setupStatementTest()
+	ctx := context.Background()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	mockRepo.On("GetAccount", 1, mock.Anything).Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}}, nil)
+	mockClient.On("GetPostedBalance", 1, from, mock.Anything).Return(utilMoneyV1.MustParse("-100"), nil)
+	mockClient.On("GetPostedTransactions", 1, from, to, mock.Anything).Return([]*transactionClientV1Package.Transaction{
+		{Id: 10, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("200"), EventDate: from.Add(time.Hour)},
+		{Id: 11, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50.25"), EventDate: from.Add(2 * time.Hour)},
+		{Id: 12, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-10"), EventDate: from.Add(3 * time.Hour)},
+	}, nil)
+
+	statement, err := accountCore.GetAccountStatement(ctx, 1, from, to, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "-100", statement.OpeningBalance.String())
+	assert.Len(t, statement.Lines, 3)
+	assert.Equal(t, "100", statement.Lines[0].RunningBalance.String())
+	assert.Equal(t, "49.75", statement.Lines[1].RunningBalance.String())
+	assert.Equal(t, "39.75", statement.Lines[2].RunningBalance.String())
+	assert.Equal(t, "39.75", statement.ClosingBalance.String())
+	assert.Len(t, statement.Totals, 2)
+	assert.Equal(t, 1, statement.Totals[0].OperationTypeId)
+	assert.Equal(t, 2, statement.Totals[0].Count)
+	assert.Equal(t, "-60.25", statement.Totals[0].Total.String())
+	assert.Equal(t, 4, statement.Totals[1].OperationTypeId)
+	assert.Equal(t, "200", statement.Totals[1].Total.String())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetAccountStatement_EmptyPeriod(t *testing.T) {
+	mockRepo, mockClient, accountCore := setupStatementTest()
+	ctx := context.Background()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	mockRepo.On("GetAccount", 1, mock.Anything).Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}}, nil)
+	mockClient.On("GetPostedBalance", 1, from, mock.Anything).Return(utilMoneyV1.MustParse("-30"), nil)
+	mockClient.On("GetPostedTransactions", 1, from, to, mock.Anything).Return([]*transactionClientV1Package.Transaction{}, nil)
+
+	statement, err := accountCore.GetAccountStatement(ctx, 1, from, to, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, statement.Lines)
+	assert.Empty(t, statement.Totals)
+	assert.Equal(t, "-30", statement.ClosingBalance.String())
+}
+
+func TestGetAccountStatement_AccountNotFound(t *testing.T) {
+	mockRepo, mockClient, accountCore := setupStatementTest()
+	ctx := context.Background()
+
+	mockRepo.On("GetAccount", 99, mock.Anything).
+		Return(&entityDbV1Package.Account{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account not found"))
+
+	statement, err := accountCore.GetAccountStatement(ctx, 99, time.Now().Add(-time.Hour), time.Now(), &gorm.DB{})
+
+	assert.Nil(t, statement)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	mockClient.AssertNotCalled(t, "GetPostedTransactions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetAccountStatement_ClientError(t *testing.T) {
+	mockRepo, mockClient, accountCore := setupStatementTest()
+	ctx := context.Background()
+
+	mockRepo.On("GetAccount", 1, mock.Anything).Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}}, nil)
+	mockClient.On("GetPostedBalance", 1, mock.Anything, mock.Anything).Return(utilMoneyV1.Zero, nil)
+	mockClient.On("GetPostedTransactions", 1, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	statement, err := accountCore.GetAccountStatement(ctx, 1, time.Now().Add(-time.Hour), time.Now(), &gorm.DB{})
+
+	assert.Nil(t, statement)
+	assert.EqualError(t, err, "db error")
+}
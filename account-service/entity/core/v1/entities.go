@@ -1,5 +1,41 @@
 package account_entity_core_v1
 
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
 type CreateAccountPayload struct {
-	DocumentNumber string `json:"document_number"`
+	DocumentNumber       string             `json:"document_number"`
+	AvailableCreditLimit utilMoneyV1.Amount `json:"available_credit_limit"`
+	Currency             string             `json:"currency"`
+	Tier                 string             `json:"tier"`
+}
+
+// AccountStatement is the account activity over [From, To).
+type AccountStatement struct {
+	AccountId      int
+	Currency       string // currency of every amount of the statement
+	From           time.Time
+	To             time.Time
+	OpeningBalance utilMoneyV1.Amount // sum of the posted amounts before From
+	ClosingBalance utilMoneyV1.Amount
+	Lines          []*StatementLine  // oldest first
+	Totals         []*StatementTotal // ordered by operation type
+}
+
+// StatementLine is one posted transaction of a statement.
+type StatementLine struct {
+	TransactionId   int
+	OperationTypeId int
+	Amount          utilMoneyV1.Amount
+	RunningBalance  utilMoneyV1.Amount // balance right after this transaction
+	EventDate       time.Time
+}
+
+// StatementTotal aggregates the statement lines of one operation type.
+type StatementTotal struct {
+	OperationTypeId int
+	Count           int
+	Total           utilMoneyV1.Amount
 }
@@ -2,13 +2,18 @@ package account_entity_db_v1
 
 import (
 	constantPackage "anti-fraud/constants/account"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
 	"gorm.io/gorm"
 )
 
 type Account struct {
 	gorm.Model
-	DocumentNumber string `json:"document_number"`
+	DocumentNumber       string             `json:"document_number"`
+	AvailableCreditLimit utilMoneyV1.Amount `json:"available_credit_limit"`
+	Status               string             `json:"status"`
+	Currency             string             `json:"currency"` // ISO 4217 code every amount of the account is kept in
+	Tier                 string             `json:"tier"`
 }
 
 func (Account) TableName() string {
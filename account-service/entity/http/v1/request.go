@@ -1,14 +1,121 @@
 package account_entity_http_v1
 
-import "errors"
+import (
+	constantPackage "anti-fraud/constants/account"
+	moneyConstantPackage "anti-fraud/constants/money"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
 
 type CreateAccountRequest struct {
-	DocumentNumber string `json:"document_number"`
+	DocumentNumber       string              `json:"document_number"`
+	AvailableCreditLimit *utilMoneyV1.Amount `json:"available_credit_limit"` // optional, defaults to DEFAULT_AVAILABLE_CREDIT_LIMIT
+	Currency             string              `json:"currency"`               // optional, defaults to DEFAULT_CURRENCY
+	Tier                 string              `json:"tier"`                   // optional, defaults to TIER_STANDARD
 }
 
 func (createAccountRequest *CreateAccountRequest) Validate() error {
 	if createAccountRequest.DocumentNumber == "" {
 		return errors.New("document number should not be empty")
 	}
+	switch createAccountRequest.Tier {
+	case "", constantPackage.TIER_STANDARD, constantPackage.TIER_GOLD, constantPackage.TIER_PLATINUM:
+	default:
+		return fmt.Errorf("tier should be %s, %s or %s", constantPackage.TIER_STANDARD, constantPackage.TIER_GOLD, constantPackage.TIER_PLATINUM)
+	}
+	currency := moneyConstantPackage.DEFAULT_CURRENCY
+	if createAccountRequest.Currency != "" {
+		currency = createAccountRequest.Currency
+		if err := utilMoneyV1.ValidateCurrency(currency); err != nil {
+			return err
+		}
+	}
+	if createAccountRequest.AvailableCreditLimit != nil {
+		if createAccountRequest.AvailableCreditLimit.Sign() < 0 {
+			return errors.New("available credit limit should not be negative")
+		}
+		return utilMoneyV1.ValidateScale(*createAccountRequest.AvailableCreditLimit, currency)
+	}
+	return nil
+}
+
+// UpdateAvailableCreditLimitRequest is the body of the internal endpoint remote mediator clients
+// draw down or restore the available credit limit of an account with.
+type UpdateAvailableCreditLimitRequest struct {
+	Delta *utilMoneyV1.Amount `json:"delta"` // signed amount added to the limit
+}
+
+func (updateRequest *UpdateAvailableCreditLimitRequest) Validate() error {
+	if updateRequest.Delta == nil {
+		return errors.New("delta should not be empty")
+	}
+	return nil
+}
+
+// StatementRequest holds the query parameters of the account statement.
+type StatementRequest struct {
+	From   time.Time // inclusive
+	To     time.Time // exclusive
+	Format string
+}
+
+// ParseStatementRequest reads the statement query parameters.
+//
+// from and to accept an RFC 3339 timestamp or a YYYY-MM-DD date; a date-only to covers that whole day.
+// to defaults to now and from to STATEMENT_DEFAULT_PERIOD_DAYS before to.
+// The format comes from the format parameter, else from the Accept header, and defaults to json.
+func ParseStatementRequest(query url.Values, accept string, now time.Time) (*StatementRequest, error) {
+	statementRequest := &StatementRequest{To: now, Format: constantPackage.STATEMENT_FORMAT_JSON}
+	if raw := query.Get("to"); raw != "" {
+		to, dateOnly, err := parseStatementTime(raw, "to")
+		if err != nil {
+			return nil, err
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		statementRequest.To = to
+	}
+	statementRequest.From = statementRequest.To.AddDate(0, 0, -constantPackage.STATEMENT_DEFAULT_PERIOD_DAYS)
+	if raw := query.Get("from"); raw != "" {
+		from, _, err := parseStatementTime(raw, "from")
+		if err != nil {
+			return nil, err
+		}
+		statementRequest.From = from
+	}
+	if format := query.Get("format"); format != "" {
+		statementRequest.Format = strings.ToLower(format)
+	} else if strings.Contains(accept, constantPackage.STATEMENT_CSV_MEDIA) {
+		statementRequest.Format = constantPackage.STATEMENT_FORMAT_CSV
+	}
+	return statementRequest, nil
+}
+
+func (statementRequest *StatementRequest) Validate() error {
+	if !statementRequest.From.Before(statementRequest.To) {
+		return errors.New("from should be before to")
+	}
+	if statementRequest.To.Sub(statementRequest.From) > constantPackage.STATEMENT_MAX_PERIOD_DAYS*24*time.Hour {
+		return fmt.Errorf("statement period should not exceed %d days", constantPackage.STATEMENT_MAX_PERIOD_DAYS)
+	}
+	if statementRequest.Format != constantPackage.STATEMENT_FORMAT_JSON && statementRequest.Format != constantPackage.STATEMENT_FORMAT_CSV {
+		return fmt.Errorf("format should be %s or %s", constantPackage.STATEMENT_FORMAT_JSON, constantPackage.STATEMENT_FORMAT_CSV)
+	}
 	return nil
 }
+
+// parseStatementTime parses an RFC 3339 timestamp or a UTC date, reporting which one it was.
+func parseStatementTime(raw string, name string) (time.Time, bool, error) {
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return value, false, nil
+	}
+	if value, err := time.Parse(constantPackage.STATEMENT_DATE_LAYOUT, raw); err == nil {
+		return value, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%s should be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+}
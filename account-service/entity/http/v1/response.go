@@ -1,6 +1,40 @@
 package account_entity_http_v1
 
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
 type CreateAccountResponse struct {
-	AccountID      string `json:"account_id"`
-	DocumentNumber string `json:"document_number"`
+	AccountID            string             `json:"account_id"`
+	DocumentNumber       string             `json:"document_number"`
+	AvailableCreditLimit utilMoneyV1.Amount `json:"available_credit_limit"`
+	Status               string             `json:"status"`
+	Currency             string             `json:"currency"`
+	Tier                 string             `json:"tier"`
+}
+
+type AccountStatementResponse struct {
+	AccountID      string                   `json:"account_id"`
+	Currency       string                   `json:"currency"`
+	From           time.Time                `json:"from"`
+	To             time.Time                `json:"to"`
+	OpeningBalance utilMoneyV1.Amount       `json:"opening_balance"`
+	ClosingBalance utilMoneyV1.Amount       `json:"closing_balance"`
+	Lines          []StatementLineResponse  `json:"lines"`
+	Totals         []StatementTotalResponse `json:"totals"`
+}
+
+type StatementLineResponse struct {
+	TransactionID   string             `json:"transaction_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	RunningBalance  utilMoneyV1.Amount `json:"running_balance"`
+	EventDate       time.Time          `json:"event_date"`
+}
+
+type StatementTotalResponse struct {
+	OperationTypeId int                `json:"operation_type_id"`
+	Count           int                `json:"count"`
+	Total           utilMoneyV1.Amount `json:"total"`
 }
@@ -7,6 +7,9 @@ import (
 	routerV1Package "anti-fraud/account-service/routes/v1"
 
 	clientV1Package "anti-fraud/mediator-service/account-service-client"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
 	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
 
 	"github.com/gorilla/mux"
@@ -16,16 +19,18 @@ import (
 
 // AccountManager wires all components required to run account-service.
 type AccountManager struct {
-	db     *gorm.DB
-	router *mux.Router
-	logger *logrus.Logger
-	coreV1 coreV1Package.IAccountCore
+	db                *gorm.DB
+	router            *mux.Router
+	logger            *logrus.Logger
+	transactionClient transactionClientV1Package.ITransactionClient
+	eventBus          eventBusV1Package.IEventBus
+	coreV1            coreV1Package.IAccountCore
 }
 
 // NewAccountManager create and return new instance of AccountManager.
-func NewAccountManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger) *AccountManager {
+func NewAccountManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, transactionClient transactionClientV1Package.ITransactionClient, eventBus eventBusV1Package.IEventBus) *AccountManager {
 
-	return &AccountManager{db: db, router: router, logger: logger}
+	return &AccountManager{db: db, router: router, logger: logger, transactionClient: transactionClient, eventBus: eventBus}
 }
 
 // Init instantiate and wire all components, register routes for account-service.
@@ -33,8 +38,9 @@ func (mw *AccountManager) Init() {
 
 	managerHandler := middlewareHandlerPackageV1.NewMiddlewareHandler(mw.logger)
 	repoV1 := repoV1Package.NewAccountRepository(mw.logger)
-	mw.coreV1 = coreV1Package.NewAccountCore(repoV1, mw.logger)
-	controllerV1 := controllerV1Package.NewAccountController(repoV1, mw.coreV1, mw.db, mw.logger)
+	mw.coreV1 = coreV1Package.NewAccountCore(repoV1, mw.logger, mw.transactionClient, mw.eventBus)
+	idempotencyStore := utilIdempotencyV1.NewIdempotencyStore(mw.logger)
+	controllerV1 := controllerV1Package.NewAccountController(repoV1, mw.coreV1, idempotencyStore, mw.db, mw.logger)
 	router := routerV1Package.NewAccountRoutes(controllerV1, mw.router, managerHandler)
 	router.Init()
 }
@@ -3,10 +3,28 @@ package account_mapper_v1
 import (
 	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 	entityHttpV1Package "anti-fraud/account-service/entity/http/v1"
+	constantPackage "anti-fraud/constants/account"
+	moneyConstantPackage "anti-fraud/constants/money"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 )
 
 func CreateAccountPayloadMapper(accountCreationRequest *entityHttpV1Package.CreateAccountRequest) *entityCoreV1Package.CreateAccountPayload {
+	availableCreditLimit := utilMoneyV1.FromInt(constantPackage.DEFAULT_AVAILABLE_CREDIT_LIMIT)
+	if accountCreationRequest.AvailableCreditLimit != nil {
+		availableCreditLimit = *accountCreationRequest.AvailableCreditLimit
+	}
+	currency := moneyConstantPackage.DEFAULT_CURRENCY
+	if accountCreationRequest.Currency != "" {
+		currency = accountCreationRequest.Currency
+	}
+	tier := constantPackage.TIER_STANDARD
+	if accountCreationRequest.Tier != "" {
+		tier = accountCreationRequest.Tier
+	}
 	return &entityCoreV1Package.CreateAccountPayload{
-		DocumentNumber: accountCreationRequest.DocumentNumber,
+		DocumentNumber:       accountCreationRequest.DocumentNumber,
+		AvailableCreditLimit: availableCreditLimit,
+		Currency:             currency,
+		Tier:                 tier,
 	}
 }
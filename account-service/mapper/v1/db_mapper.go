@@ -3,10 +3,15 @@ package account_mapper_v1
 import (
 	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
+	constantPackage "anti-fraud/constants/account"
 )
 
 func AccountMapper(accountPayload *entityCoreV1Package.CreateAccountPayload) *entityDbV1Package.Account {
 	return &entityDbV1Package.Account{
-		DocumentNumber: accountPayload.DocumentNumber,
+		DocumentNumber:       accountPayload.DocumentNumber,
+		AvailableCreditLimit: accountPayload.AvailableCreditLimit,
+		Status:               constantPackage.STATUS_ACTIVE,
+		Currency:             accountPayload.Currency,
+		Tier:                 accountPayload.Tier,
 	}
 }
@@ -1,14 +1,83 @@
 package account_mapper_v1
 
 import (
+	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
 	entityHttpV1Package "anti-fraud/account-service/entity/http/v1"
+	constantPackage "anti-fraud/constants/account"
 	"strconv"
+	"time"
 )
 
 func AccountDetailsResponseMapper(account *entityDbV1Package.Account) *entityHttpV1Package.CreateAccountResponse {
 	return &entityHttpV1Package.CreateAccountResponse{
-		AccountID:      strconv.FormatUint(uint64(account.ID), 10),
-		DocumentNumber: account.DocumentNumber,
+		AccountID:            strconv.FormatUint(uint64(account.ID), 10),
+		DocumentNumber:       account.DocumentNumber,
+		AvailableCreditLimit: account.AvailableCreditLimit,
+		Status:               account.Status,
+		Currency:             account.Currency,
+		Tier:                 account.Tier,
 	}
 }
+
+func AccountStatementResponseMapper(statement *entityCoreV1Package.AccountStatement) *entityHttpV1Package.AccountStatementResponse {
+	response := &entityHttpV1Package.AccountStatementResponse{
+		AccountID:      strconv.Itoa(statement.AccountId),
+		Currency:       statement.Currency,
+		From:           statement.From,
+		To:             statement.To,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          make([]entityHttpV1Package.StatementLineResponse, 0, len(statement.Lines)),
+		Totals:         make([]entityHttpV1Package.StatementTotalResponse, 0, len(statement.Totals)),
+	}
+	for _, line := range statement.Lines {
+		response.Lines = append(response.Lines, entityHttpV1Package.StatementLineResponse{
+			TransactionID:   strconv.Itoa(line.TransactionId),
+			OperationTypeId: line.OperationTypeId,
+			Amount:          line.Amount,
+			RunningBalance:  line.RunningBalance,
+			EventDate:       line.EventDate,
+		})
+	}
+	for _, total := range statement.Totals {
+		response.Totals = append(response.Totals, entityHttpV1Package.StatementTotalResponse{
+			OperationTypeId: total.OperationTypeId,
+			Count:           total.Count,
+			Total:           total.Total,
+		})
+	}
+	return response
+}
+
+// AccountStatementCSVMapper flattens a statement into CSV records, header first.
+// The record_type column tells the opening balance, transaction, total and closing balance rows apart.
+func AccountStatementCSVMapper(statement *entityCoreV1Package.AccountStatement) [][]string {
+	records := [][]string{
+		{"record_type", "transaction_id", "operation_type_id", "event_date", "count", "amount", "balance"},
+		{constantPackage.STATEMENT_RECORD_OPENING_BALANCE, "", "", statement.From.Format(time.RFC3339), "", "", statement.OpeningBalance.String()},
+	}
+	for _, line := range statement.Lines {
+		records = append(records, []string{
+			constantPackage.STATEMENT_RECORD_TRANSACTION,
+			strconv.Itoa(line.TransactionId),
+			strconv.Itoa(line.OperationTypeId),
+			line.EventDate.Format(time.RFC3339),
+			"",
+			line.Amount.String(),
+			line.RunningBalance.String(),
+		})
+	}
+	for _, total := range statement.Totals {
+		records = append(records, []string{
+			constantPackage.STATEMENT_RECORD_TOTAL,
+			"",
+			strconv.Itoa(total.OperationTypeId),
+			"",
+			strconv.Itoa(total.Count),
+			total.Total.String(),
+			"",
+		})
+	}
+	return append(records, []string{constantPackage.STATEMENT_RECORD_CLOSING_BALANCE, "", "", statement.To.Format(time.RFC3339), "", "", statement.ClosingBalance.String()})
+}
@@ -4,6 +4,13 @@ import (
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
 
 	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -13,13 +20,20 @@ import (
 type IAccountRepository interface {
 
 	// CreateAccount persists a new account record to the db.
-	CreateAccount(logger *logrus.Entry, account *entityDbV1Package.Account, tx *gorm.DB) error
+	CreateAccount(ctx context.Context, account *entityDbV1Package.Account, tx *gorm.DB) error
 
 	// GetAccount retrieves an account by its unique ID.
-	GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error)
+	GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error)
 
 	// CheckDuplicateAccount checks if an account with the given document number already exists.
-	CheckDuplicateAccount(logger *logrus.Entry, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error)
+	CheckDuplicateAccount(ctx context.Context, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error)
+
+	// UpdateAvailableCreditLimit atomically adds delta to the account's available credit limit,
+	// unless the result would be negative.
+	UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) (bool, error)
+
+	// UpdateAccountStatus moves the account from one status to another, only if it is still in the expected status.
+	UpdateAccountStatus(ctx context.Context, accountId int, fromStatus string, toStatus string, tx *gorm.DB) (bool, error)
 }
 
 // AccountRepository implements IAccountRepository methods.
@@ -36,7 +50,9 @@ func NewAccountRepository(logger *logrus.Logger) *AccountRepository {
 //
 // Steps:
 //  1. Perform an INSERT operation on the account table.
-//  3. Returns any error encountered during the insertion.
+//  2. A unique violation means a concurrent request created an account with the same document number
+//     after the duplicate check: it is returned as a conflict Error.
+//  3. Returns any other error encountered during the insertion.
 //
 // Parameters:
 //   - account: account db entity.
@@ -44,10 +60,14 @@ func NewAccountRepository(logger *logrus.Logger) *AccountRepository {
 //
 // Returns:
 //   - An error if the insert fails, otherwise nil.
-func (repo *AccountRepository) CreateAccount(logger *logrus.Entry, account *entityDbV1Package.Account, tx *gorm.DB) error {
+func (repo *AccountRepository) CreateAccount(ctx context.Context, account *entityDbV1Package.Account, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("CreateAccount method called in account repo layer.")
-	result := tx.Table(constantPackage.TABLE_NAME).Create(account)
-	if result.Error != nil {
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Create(account)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		logger.Errorf("Failed to create account, duplicate document_number: %s", account.DocumentNumber)
+		return utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_DOCUMENT_NUMBER, fmt.Sprintf("duplicate account found with document_number: %s", account.DocumentNumber))
+	} else if result.Error != nil {
 		logger.Errorf("Failed to create account: %v", result.Error)
 	}
 	return result.Error
@@ -57,7 +77,7 @@ func (repo *AccountRepository) CreateAccount(logger *logrus.Entry, account *enti
 //
 // Steps:
 //  1. Executes a SELECT query using the given accountId as a primary key lookup.
-//  2. If the record is not found, it returns an empty account and nil error
+//  2. If the record is not found, it returns an empty account and a not found Error.
 //  3. Otherwise, returns the account data.
 //
 // Parameters:
@@ -67,13 +87,14 @@ func (repo *AccountRepository) CreateAccount(logger *logrus.Entry, account *enti
 // Returns:
 //   - db entity account.
 //   - Encountered Error.
-func (repo *AccountRepository) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (repo *AccountRepository) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetAccount method called in account repo layer.")
 	var account entityDbV1Package.Account
-	result := tx.Table(constantPackage.TABLE_NAME).First(&account, accountId)
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).First(&account, accountId)
 	if result.Error != nil && result.Error == gorm.ErrRecordNotFound {
 		logger.Errorf("Failed to find account with accountId: %d", accountId)
-		return &account, nil
+		return &account, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, fmt.Sprintf("account_id: %d not found in database", accountId))
 	} else if result.Error != nil {
 		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
 	}
@@ -95,10 +116,11 @@ func (repo *AccountRepository) GetAccount(logger *logrus.Entry, accountId int, t
 //   - db entity account.
 //   - Encountered Error.
 
-func (repo *AccountRepository) CheckDuplicateAccount(logger *logrus.Entry, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (repo *AccountRepository) CheckDuplicateAccount(ctx context.Context, documentNumber string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("CheckDuplicateAccount method called in account repo layer.")
 	var account entityDbV1Package.Account
-	result := tx.Table(constantPackage.TABLE_NAME).
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
 		Where("document_number = ?", documentNumber).First(&account)
 	if result.Error != nil && result.Error == gorm.ErrRecordNotFound { // account doesn't exist with `documentNumber`
 		logger.Error("Failed to find account with document_number.")
@@ -108,3 +130,61 @@ func (repo *AccountRepository) CheckDuplicateAccount(logger *logrus.Entry, docum
 	}
 	return &account, result.Error
 }
+
+// UpdateAvailableCreditLimit adds delta to the available credit limit of an account.
+//
+// Steps:
+//  1. Run a single conditional UPDATE that only matches when the new limit stays non-negative.
+//     The row lock taken by the UPDATE serializes concurrent requests on the same account, so
+//     two transactions can never both draw down the same remaining limit.
+//  2. Report whether a row was updated.
+//
+// Parameters:
+//   - accountId: account to update.
+//   - delta:     signed amount added to the limit (negative draws down, positive restores).
+//   - tx:        db txn.
+//
+// Returns:
+//   - bool: false if the account does not exist or has not enough available limit.
+//   - Encountered Error.
+func (repo *AccountRepository) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) (bool, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAvailableCreditLimit method called in account repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("id = ? AND deleted_at IS NULL AND available_credit_limit + ? >= 0", accountId, delta).
+		Update("available_credit_limit", gorm.Expr("available_credit_limit + ?", delta))
+	if result.Error != nil {
+		logger.Errorf("Error occured while updating available credit limit: %s", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateAccountStatus changes the status of an account.
+//
+// Steps:
+//  1. Run a conditional UPDATE that only matches while the account is still in fromStatus,
+//     so two concurrent transitions cannot both succeed from the same state.
+//  2. Report whether a row was updated.
+//
+// Parameters:
+//   - accountId:  account to update.
+//   - fromStatus: status the caller validated the transition from.
+//   - toStatus:   new status.
+//   - tx:         db txn.
+//
+// Returns:
+//   - bool: false if the account is no longer in fromStatus.
+//   - Encountered Error.
+func (repo *AccountRepository) UpdateAccountStatus(ctx context.Context, accountId int, fromStatus string, toStatus string, tx *gorm.DB) (bool, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAccountStatus method called in account repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("id = ? AND status = ? AND deleted_at IS NULL", accountId, fromStatus).
+		Update("status", toStatus)
+	if result.Error != nil {
+		logger.Errorf("Error occured while updating account status: %s", result.Error.Error())
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
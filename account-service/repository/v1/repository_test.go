@@ -1,10 +1,15 @@
 package account_repo_v1
 
 import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"net/http"
 	"testing"
 
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
 	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +42,7 @@ func TestCreateAccount_Success(t *testing.T) {
 		DocumentNumber: "123456789",
 	}
 
-	err := repo.CreateAccount(logrus.NewEntry(logrus.New()), acc, db)
+	err := repo.CreateAccount(context.Background(), acc, db)
 	assert.NoError(t, err, "expected no error on account creation")
 	assert.NotZero(t, acc.ID, "expected the newly created account to have a non-zero ID")
 
@@ -47,6 +52,25 @@ func TestCreateAccount_Success(t *testing.T) {
 	assert.Equal(t, acc.DocumentNumber, found.DocumentNumber)
 }
 
+func TestCreateAccount_DuplicateDocumentNumber(t *testing.T) {
+	// The duplicate check of a concurrent request saw no account: only the unique index catches it.
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to connect to in-memory database: %v", err)
+	}
+	assert.NoError(t, db.AutoMigrate(&entityDbV1Package.Account{}))
+	assert.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_account_document_number ON account (document_number)").Error)
+	repo := NewAccountRepository(logrus.New())
+
+	assert.NoError(t, repo.CreateAccount(context.Background(), &entityDbV1Package.Account{DocumentNumber: "123456789"}, db))
+	err = repo.CreateAccount(context.Background(), &entityDbV1Package.Account{DocumentNumber: "123456789"}, db)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusConflict, appError.Status)
+	assert.Equal(t, errorConstantPackage.DUPLICATE_DOCUMENT_NUMBER, appError.Code)
+}
+
 func TestGetAccount_Success(t *testing.T) {
 	db := setupTestDB(t)
 	logger := logrus.New()
@@ -57,7 +81,7 @@ func TestGetAccount_Success(t *testing.T) {
 	}
 	db.Create(acc)
 
-	got, err := repo.GetAccount(logrus.NewEntry(logrus.New()), int(acc.ID), db)
+	got, err := repo.GetAccount(context.Background(), int(acc.ID), db)
 	assert.NoError(t, err)
 	assert.NotNil(t, got)
 	assert.Equal(t, acc.ID, got.ID)
@@ -69,9 +93,10 @@ func TestGetAccount_NotFound(t *testing.T) {
 	logger := logrus.New()
 	repo := NewAccountRepository(logger)
 
-	got, err := repo.GetAccount(logrus.NewEntry(logrus.New()), 9999, db)
+	got, err := repo.GetAccount(context.Background(), 9999, db)
 
-	assert.NoError(t, err, "repository returns nil error for not found")
+	assert.Error(t, err)
+	assert.True(t, utilErrorsV1.IsNotFound(err), "repository returns a not found error")
 	assert.NotNil(t, got)
 	assert.Equal(t, uint(0), got.ID, "ID should be zero when not found")
 }
@@ -81,7 +106,7 @@ func TestCheckDuplicateAccount_NoDuplicate(t *testing.T) {
 	logger := logrus.New()
 	repo := NewAccountRepository(logger)
 
-	got, err := repo.CheckDuplicateAccount(logrus.NewEntry(logrus.New()), "unique", db)
+	got, err := repo.CheckDuplicateAccount(context.Background(), "unique", db)
 	assert.NoError(t, err, "no error should occur if record not found")
 	assert.Equal(t, uint(0), got.ID, "ID should be zero if not found")
 }
@@ -96,9 +121,82 @@ func TestCheckDuplicateAccount_Found(t *testing.T) {
 	}
 	db.Create(acc)
 
-	got, err := repo.CheckDuplicateAccount(logrus.NewEntry(logrus.New()), "duplicate", db)
+	got, err := repo.CheckDuplicateAccount(context.Background(), "duplicate", db)
 	assert.NoError(t, err)
 	assert.NotNil(t, got)
 	assert.Equal(t, acc.ID, got.ID)
 	assert.Equal(t, "duplicate", got.DocumentNumber)
 }
+
+func TestUpdateAvailableCreditLimit_DrawDownAndRestore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(logrus.New())
+
+	acc := &entityDbV1Package.Account{DocumentNumber: "limit", AvailableCreditLimit: utilMoneyV1.MustParse("100")}
+	db.Create(acc)
+
+	updated, err := repo.UpdateAvailableCreditLimit(context.Background(), int(acc.ID), utilMoneyV1.MustParse("-60"), db)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	updated, err = repo.UpdateAvailableCreditLimit(context.Background(), int(acc.ID), utilMoneyV1.MustParse("10"), db)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	var found entityDbV1Package.Account
+	db.Table(constantPackage.TABLE_NAME).First(&found, acc.ID)
+	assert.Equal(t, utilMoneyV1.MustParse("50"), found.AvailableCreditLimit)
+}
+
+func TestUpdateAvailableCreditLimit_Insufficient(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(logrus.New())
+
+	acc := &entityDbV1Package.Account{DocumentNumber: "limit", AvailableCreditLimit: utilMoneyV1.MustParse("100")}
+	db.Create(acc)
+
+	updated, err := repo.UpdateAvailableCreditLimit(context.Background(), int(acc.ID), utilMoneyV1.MustParse("-100.01"), db)
+	assert.NoError(t, err)
+	assert.False(t, updated, "limit must never become negative")
+
+	var found entityDbV1Package.Account
+	db.Table(constantPackage.TABLE_NAME).First(&found, acc.ID)
+	assert.Equal(t, utilMoneyV1.MustParse("100"), found.AvailableCreditLimit)
+}
+
+func TestUpdateAvailableCreditLimit_AccountNotFound(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(logrus.New())
+
+	updated, err := repo.UpdateAvailableCreditLimit(context.Background(), 9999, utilMoneyV1.MustParse("10"), db)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestUpdateAccountStatus_Success(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(logrus.New())
+
+	acc := &entityDbV1Package.Account{DocumentNumber: "status", Status: constantPackage.STATUS_ACTIVE}
+	db.Create(acc)
+
+	updated, err := repo.UpdateAccountStatus(context.Background(), int(acc.ID), constantPackage.STATUS_ACTIVE, constantPackage.STATUS_BLOCKED, db)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	var found entityDbV1Package.Account
+	db.Table(constantPackage.TABLE_NAME).First(&found, acc.ID)
+	assert.Equal(t, constantPackage.STATUS_BLOCKED, found.Status)
+}
+
+func TestUpdateAccountStatus_StaleFromStatus(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(logrus.New())
+
+	acc := &entityDbV1Package.Account{DocumentNumber: "status", Status: constantPackage.STATUS_CLOSED}
+	db.Create(acc)
+
+	updated, err := repo.UpdateAccountStatus(context.Background(), int(acc.ID), constantPackage.STATUS_ACTIVE, constantPackage.STATUS_BLOCKED, db)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+}
@@ -26,4 +26,12 @@ func (routes *AccountRoutes) Init() {
 
 	routes.muxRouter.HandleFunc("/accounts/v1", handlerFunc(routes.controller.CreateAccount)).Methods("POST")
 	routes.muxRouter.HandleFunc("/accounts/v1/{accountId}", handlerFunc(routes.controller.GetAccountDetails)).Methods("GET")
+	routes.muxRouter.HandleFunc("/accounts/v1/{accountId}/block", handlerFunc(routes.controller.BlockAccount)).Methods("POST")
+	routes.muxRouter.HandleFunc("/accounts/v1/{accountId}/unblock", handlerFunc(routes.controller.UnblockAccount)).Methods("POST")
+	routes.muxRouter.HandleFunc("/accounts/v1/{accountId}/close", handlerFunc(routes.controller.CloseAccount)).Methods("POST")
+	routes.muxRouter.HandleFunc("/accounts/v1/{accountId}/statement", handlerFunc(routes.controller.GetAccountStatement)).Methods("GET")
+
+	// Internal endpoints, called by the remote mediator account client of the other services.
+	routes.muxRouter.HandleFunc("/internal/accounts/v1/{accountId}", handlerFunc(routes.controller.GetAccountDetails)).Methods("GET")
+	routes.muxRouter.HandleFunc("/internal/accounts/v1/{accountId}/available-credit-limit", handlerFunc(routes.controller.UpdateAvailableCreditLimit)).Methods("POST")
 }
@@ -0,0 +1,296 @@
+package authorization_controller_v1
+
+import (
+	coreV1Package "anti-fraud/authorization-service/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	entityHttpV1Package "anti-fraud/authorization-service/entity/http/v1"
+	mapperV1Package "anti-fraud/authorization-service/mapper/v1"
+	repoV1Package "anti-fraud/authorization-service/repository/v1"
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	utilV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// IAuthorizationController defines methods interface for HTTP handler.
+type IAuthorizationController interface {
+
+	// CreateAuthorization handles an HTTP request to place a hold on an account's credit limit.
+	CreateAuthorization(w http.ResponseWriter, r *http.Request)
+
+	// GetAuthorizationDetails handles an HTTP request to fetch an authorization by its ID.
+	GetAuthorizationDetails(w http.ResponseWriter, r *http.Request)
+
+	// CaptureAuthorization handles an HTTP request to capture all or part of a hold.
+	CaptureAuthorization(w http.ResponseWriter, r *http.Request)
+
+	// VoidAuthorization handles an HTTP request to release a hold.
+	VoidAuthorization(w http.ResponseWriter, r *http.Request)
+}
+
+// AuthorizationController implements IAuthorizationController interface.
+type AuthorizationController struct {
+	repoV1           repoV1Package.IAuthorizationRepository
+	coreV1           coreV1Package.IAuthorizationCore
+	idempotencyStore utilIdempotencyV1.IIdempotencyStore
+	db               *gorm.DB
+	logger           *logrus.Logger
+}
+
+// NewAuthorizationController creates and returns new AuthorizationController instance.
+func NewAuthorizationController(repoV1 repoV1Package.IAuthorizationRepository, coreV1 coreV1Package.IAuthorizationCore, idempotencyStore utilIdempotencyV1.IIdempotencyStore, db *gorm.DB, logger *logrus.Logger) *AuthorizationController {
+	return &AuthorizationController{repoV1: repoV1, coreV1: coreV1, idempotencyStore: idempotencyStore, db: db, logger: logger}
+}
+
+// CreateAuthorization handles the HTTP request for placing a hold.
+//
+// Workflow:
+//  1. Parse the JSON request body into a CreateAuthorizationRequest struct.
+//  2. Validate the request data.
+//  3. Place the hold via the core layer inside a db txn, honouring an Idempotency-Key header.
+func (controller *AuthorizationController) CreateAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	var createReq entityHttpV1Package.CreateAuthorizationRequest
+
+	// 1. Decode HTTP input payload.
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &createReq)
+	}
+	if err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", createReq).Info("CreateAuthorization endpoint called.")
+
+	// 2. Validate payload.
+	err = createReq.Validate()
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	// 3. Place the hold.
+	controller.runAuthorizationCommand(ctx, w, r, idempotencyConstantPackage.SCOPE_CREATE_AUTHORIZATION, body, func(ctx context.Context, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+		return controller.coreV1.CreateAuthorization(ctx, mapperV1Package.CreateAuthorizationPayloadMapper(&createReq), tx)
+	})
+}
+
+// GetAuthorizationDetails handles the HTTP request for fetching an authorization by its ID.
+//
+// Workflow:
+//  1. Extract and convert the authorizationId path parameter.
+//  2. Fetch the authorization via the core layer inside a db txn.
+//  3. Return http response with the authorization.
+func (controller *AuthorizationController) GetAuthorizationDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the authorization id.
+	authorizationIdStr := mux.Vars(r)["authorizationId"]
+	logger.Infof("GetAuthorizationDetails endpoint called for authorizationId: %v", authorizationIdStr)
+	authorizationId, err := strconv.Atoi(authorizationIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Fetch the authorization via the core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	authorization, err := controller.coreV1.GetAuthorization(ctx, authorizationId, tx)
+	if err != nil {
+		logger.Errorf("Error fetching authorization: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success":       true,
+		"authorization": mapperV1Package.AuthorizationDetailsResponseMapper(authorization),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CaptureAuthorization handles the HTTP request for capturing a hold.
+//
+// Workflow:
+//  1. Extract the authorizationId path parameter and decode the optional JSON body.
+//  2. Validate the request data.
+//  3. Capture the hold via the core layer inside a db txn, honouring an Idempotency-Key header.
+func (controller *AuthorizationController) CaptureAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the authorization id and decode the payload.
+	authorizationIdStr := mux.Vars(r)["authorizationId"]
+	authorizationId, err := strconv.Atoi(authorizationIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+	var captureReq entityHttpV1Package.CaptureAuthorizationRequest
+	body, err := io.ReadAll(r.Body)
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, &captureReq)
+	}
+	if err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", captureReq).Infof("CaptureAuthorization endpoint called for authorizationId: %d", authorizationId)
+
+	// 2. Validate payload.
+	err = captureReq.Validate()
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	// 3. Capture the hold; the authorization id is part of the request hashed for the idempotency key.
+	requestBody := append([]byte(authorizationIdStr+"\n"), body...)
+	controller.runAuthorizationCommand(ctx, w, r, idempotencyConstantPackage.SCOPE_CAPTURE_AUTHORIZATION, requestBody, func(ctx context.Context, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+		return controller.coreV1.CaptureAuthorization(ctx, authorizationId, mapperV1Package.CaptureAuthorizationPayloadMapper(&captureReq), tx)
+	})
+}
+
+// VoidAuthorization handles the HTTP request for releasing a hold.
+//
+// Workflow:
+//  1. Extract and convert the authorizationId path parameter.
+//  2. Void the hold via the core layer inside a db txn, honouring an Idempotency-Key header.
+func (controller *AuthorizationController) VoidAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the authorization id.
+	authorizationIdStr := mux.Vars(r)["authorizationId"]
+	logger.Infof("VoidAuthorization endpoint called for authorizationId: %v", authorizationIdStr)
+	authorizationId, err := strconv.Atoi(authorizationIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Void the hold.
+	controller.runAuthorizationCommand(ctx, w, r, idempotencyConstantPackage.SCOPE_VOID_AUTHORIZATION, []byte(authorizationIdStr), func(ctx context.Context, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+		return controller.coreV1.VoidAuthorization(ctx, authorizationId, tx)
+	})
+}
+
+// runAuthorizationCommand runs a state-changing authorization command in its own db txn.
+//
+// Workflow:
+//  1. Validate the Idempotency-Key header and begin db txn.
+//  2. If a key is sent, claim it; a retry of a completed request replays the stored response.
+//  3. Run the command.
+//  4. Build the response, store it for the idempotency key and commit db txn.
+//  5. Send the http response with the authorization.
+func (controller *AuthorizationController) runAuthorizationCommand(ctx context.Context, w http.ResponseWriter, r *http.Request, scope string, requestBody []byte, command func(ctx context.Context, tx *gorm.DB) (*entityDbV1Package.Authorization, error)) {
+	logger := utilContextV1.Logger(ctx)
+
+	// 1. Validate the key and begin db txn.
+	idempotencyKey := r.Header.Get(idempotencyConstantPackage.HEADER)
+	if err := utilIdempotencyV1.ValidateKey(idempotencyKey); err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	// 2. Claim the idempotency key.
+	var idempotencyRecord *utilIdempotencyV1.IdempotencyRecord
+	if idempotencyKey != "" {
+		record, replay, err := controller.idempotencyStore.Claim(ctx, scope, idempotencyKey, utilIdempotencyV1.HashRequest(requestBody), tx)
+		if err != nil {
+			logger.Errorf("Error claiming idempotency key: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+		if replay {
+			logger.Infof("Replaying stored response for idempotency key: %s", idempotencyKey)
+			utilIdempotencyV1.WriteReplay(w, record)
+			return
+		}
+		idempotencyRecord = record
+	}
+
+	// 3. Run the command via the core layer.
+	authorization, err := command(ctx, tx)
+	if err != nil {
+		logger.Errorf("Error running authorization command: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Build the response, store it for the idempotency key and commit db txn.
+	response, err := json.Marshal(map[string]interface{}{
+		"success":       true,
+		"authorization": mapperV1Package.AuthorizationDetailsResponseMapper(authorization),
+	})
+	if err != nil {
+		logger.Errorf("Error encoding response: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if idempotencyRecord != nil {
+		if err := controller.idempotencyStore.SaveResponse(ctx, idempotencyRecord, http.StatusOK, response, tx); err != nil {
+			logger.Errorf("Error saving idempotent response: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Send http response.
+	logger.Infof("Authorization %d is %s", authorization.ID, authorization.Status)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
@@ -0,0 +1,254 @@
+package authorization_controller_v1
+
+import (
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+//--------------------------------//
+// Mock for IAuthorizationCore
+//--------------------------------//
+
+type MockAuthorizationCore struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizationCore) CreateAuthorization(ctx context.Context, createPayload *entityCoreV1Package.CreateAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(createPayload, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationCore) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(authorizationId, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationCore) CaptureAuthorization(ctx context.Context, authorizationId int, capturePayload *entityCoreV1Package.CaptureAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(authorizationId, capturePayload, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationCore) VoidAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(authorizationId, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationCore) ExpireAuthorizations(ctx context.Context, now time.Time, tx *gorm.DB) (int, error) {
+	args := m.Called(now, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuthorizationCore) ReleaseAccountAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) (int, error) {
+	args := m.Called(accountId, tx)
+	return args.Int(0), args.Error(1)
+}
+
+//----------------------------------------------//
+// Test Helpers
+//----------------------------------------------//
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	// A single connection keeps every txn on the same in-memory database.
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&utilIdempotencyV1.IdempotencyRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func setupTestController(t *testing.T) (*AuthorizationController, *MockAuthorizationCore) {
+	logger := logrus.New()
+	mockCore := new(MockAuthorizationCore)
+	controller := NewAuthorizationController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), setupTestDB(t), logger)
+	return controller, mockCore
+}
+
+func withAuthorizationId(req *http.Request, id string) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"authorizationId": id})
+}
+
+//------------------------------------------------//
+// CreateAuthorization
+//------------------------------------------------//
+
+func TestCreateAuthorization_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CreateAuthorization", &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("80")}, mock.Anything).
+		Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-80"), Status: "AUTHORIZED"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/authorizations/v1", strings.NewReader(`{"account_id": 1, "operation_type_id": 1, "amount": "80"}`))
+	rr := httptest.NewRecorder()
+	controller.CreateAuthorization(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"authorization_id":3`)
+	assert.Contains(t, rr.Body.String(), `"amount":-80`)
+	assert.Contains(t, rr.Body.String(), `"status":"AUTHORIZED"`)
+	assert.NotContains(t, rr.Body.String(), `"transaction_id"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateAuthorization_ValidationError(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	for _, body := range []string{`{"operation_type_id": 1, "amount": 5}`, `{"account_id": 1, "amount": 5}`, `{"account_id": 1, "operation_type_id": 1, "amount": 0}`} {
+		rr := httptest.NewRecorder()
+		controller.CreateAuthorization(rr, httptest.NewRequest(http.MethodPost, "/authorizations/v1", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "CreateAuthorization", mock.Anything, mock.Anything)
+}
+
+func TestCreateAuthorization_IdempotentReplay(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CreateAuthorization", mock.Anything, mock.Anything).
+		Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, Amount: utilMoneyV1.MustParse("-80"), Status: "AUTHORIZED"}, nil).
+		Once()
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/authorizations/v1", strings.NewReader(`{"account_id": 1, "operation_type_id": 1, "amount": 80}`))
+		req.Header.Set(idempotencyConstantPackage.HEADER, "hold-key")
+		rr := httptest.NewRecorder()
+		controller.CreateAuthorization(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		bodies = append(bodies, rr.Body.String())
+	}
+	assert.Equal(t, bodies[0], bodies[1])
+	mockCore.AssertNumberOfCalls(t, "CreateAuthorization", 1)
+}
+
+//------------------------------------------------//
+// GetAuthorizationDetails
+//------------------------------------------------//
+
+func TestGetAuthorizationDetails(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("GetAuthorization", 3, mock.Anything).Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, Status: "EXPIRED"}, nil)
+	mockCore.On("GetAuthorization", 4, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.AUTHORIZATION_NOT_FOUND, "authorization_id: 4 not found in database"))
+
+	rr := httptest.NewRecorder()
+	controller.GetAuthorizationDetails(rr, withAuthorizationId(httptest.NewRequest(http.MethodGet, "/authorizations/v1/3", nil), "3"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"EXPIRED"`)
+
+	rr = httptest.NewRecorder()
+	controller.GetAuthorizationDetails(rr, withAuthorizationId(httptest.NewRequest(http.MethodGet, "/authorizations/v1/4", nil), "4"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	controller.GetAuthorizationDetails(rr, withAuthorizationId(httptest.NewRequest(http.MethodGet, "/authorizations/v1/abc", nil), "abc"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_PATH_PARAMETER)
+}
+
+//------------------------------------------------//
+// CaptureAuthorization / VoidAuthorization
+//------------------------------------------------//
+
+func TestCaptureAuthorization_Partial(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	transactionId := uint(21)
+	mockCore.On("CaptureAuthorization", 3, mock.MatchedBy(func(payload *entityCoreV1Package.CaptureAuthorizationPayload) bool {
+		return payload.Amount != nil && payload.Amount.Cmp(utilMoneyV1.MustParse("60")) == 0
+	}), mock.Anything).Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, Amount: utilMoneyV1.MustParse("-100"), CapturedAmount: utilMoneyV1.MustParse("-60"), Status: "CAPTURED", TransactionId: &transactionId}, nil)
+
+	rr := httptest.NewRecorder()
+	controller.CaptureAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/capture", strings.NewReader(`{"amount": 60}`)), "3"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"captured_amount":-60`)
+	assert.Contains(t, rr.Body.String(), `"transaction_id":21`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCaptureAuthorization_EmptyBodyCapturesAll(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CaptureAuthorization", 3, &entityCoreV1Package.CaptureAuthorizationPayload{}, mock.Anything).
+		Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, Status: "CAPTURED"}, nil)
+
+	rr := httptest.NewRecorder()
+	controller.CaptureAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/capture", nil), "3"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCaptureAuthorization_InvalidAmount(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.CaptureAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/capture", strings.NewReader(`{"amount": -1}`)), "3"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED)
+	mockCore.AssertNotCalled(t, "CaptureAuthorization", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCaptureAuthorization_Expired(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CaptureAuthorization", 3, mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.AUTHORIZATION_EXPIRED, "authorization_id: 3 expired"))
+
+	rr := httptest.NewRecorder()
+	controller.CaptureAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/capture", nil), "3"))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.AUTHORIZATION_EXPIRED)
+}
+
+func TestVoidAuthorization(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("VoidAuthorization", 3, mock.Anything).Return(&entityDbV1Package.Authorization{Model: gorm.Model{ID: 3}, Status: "VOIDED"}, nil).Once()
+	mockCore.On("VoidAuthorization", 3, mock.Anything).
+		Return(nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.AUTHORIZATION_NOT_OPEN, "authorization_id: 3 is VOIDED"))
+
+	rr := httptest.NewRecorder()
+	controller.VoidAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/void", nil), "3"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"VOIDED"`)
+
+	rr = httptest.NewRecorder()
+	controller.VoidAuthorization(rr, withAuthorizationId(httptest.NewRequest(http.MethodPost, "/authorizations/v1/3/void", nil), "3"))
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.AUTHORIZATION_NOT_OPEN)
+}
@@ -0,0 +1,423 @@
+package authorization_core_v1
+
+import (
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	mapperV1Package "anti-fraud/authorization-service/mapper/v1"
+	repoV1Package "anti-fraud/authorization-service/repository/v1"
+	accountConstantPackage "anti-fraud/constants/account"
+	constantPackage "anti-fraud/constants/authorization"
+	errorConstantPackage "anti-fraud/constants/errors"
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	accountClientPackageV1 "anti-fraud/mediator-service/account-service-client"
+	fraudClientPackageV1 "anti-fraud/mediator-service/fraud-service-client"
+	operationClientPackageV1 "anti-fraud/mediator-service/operation-service-client"
+	transactionClientPackageV1 "anti-fraud/mediator-service/transaction-service-client"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IAuthorizationCore defines the methods interface for core business logic for authorization holds.
+type IAuthorizationCore interface {
+
+	// CreateAuthorization places a hold on the account's credit limit for a debit operation.
+	CreateAuthorization(ctx context.Context, createPayload *entityCoreV1Package.CreateAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// GetAuthorization fetches an authorization by its ID.
+	GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// CaptureAuthorization settles all or part of an open hold as a posted transaction and releases the rest.
+	CaptureAuthorization(ctx context.Context, authorizationId int, capturePayload *entityCoreV1Package.CaptureAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// VoidAuthorization releases an open hold without posting a transaction.
+	VoidAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// ExpireAuthorizations releases a batch of open holds whose TTL has elapsed and returns how many were expired.
+	ExpireAuthorizations(ctx context.Context, now time.Time, tx *gorm.DB) (int, error)
+
+	// ReleaseAccountAuthorizations voids every open hold of an account and returns how many were released.
+	ReleaseAccountAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) (int, error)
+}
+
+// AuthorizationCore implements IAuthorizationCore interface.
+//
+// inTxnAccountClient reaches account-service in-process, inside the caller's db txn, whatever the
+// mediator mode. It serves the account events delivered in the txn of account-service, which holds
+// locks on the account rows a remote call would wait on.
+type AuthorizationCore struct {
+	repoV1             repoV1Package.IAuthorizationRepository
+	logger             *logrus.Logger
+	holdTTL            time.Duration
+	operationClient    operationClientPackageV1.IOperationClient
+	accountClient      accountClientPackageV1.IAccountClient
+	inTxnAccountClient accountClientPackageV1.IAccountClient
+	fraudClient        fraudClientPackageV1.IFraudClient
+	transactionClient  transactionClientPackageV1.ITransactionClient
+}
+
+// NewAuthorizationCore creates and return new AuthorizationCore instance.
+func NewAuthorizationCore(repoV1 repoV1Package.IAuthorizationRepository, logger *logrus.Logger, holdTTL time.Duration, operationClient operationClientPackageV1.IOperationClient, accountClient accountClientPackageV1.IAccountClient, inTxnAccountClient accountClientPackageV1.IAccountClient, fraudClient fraudClientPackageV1.IFraudClient, transactionClient transactionClientPackageV1.ITransactionClient) *AuthorizationCore {
+	return &AuthorizationCore{repoV1: repoV1, logger: logger, holdTTL: holdTTL, operationClient: operationClient, accountClient: accountClient, inTxnAccountClient: inTxnAccountClient, fraudClient: fraudClient, transactionClient: transactionClient}
+}
+
+// CreateAuthorization places a hold on the account's credit limit.
+//
+// Steps:
+//  1. Ensure the account exists and is active. The hold is in the account currency, so the
+//     amount may not have more decimals than its minor unit.
+//  2. Compute the final signed amount with the handler of the operation type in effect now; the
+//     capture applies its coefficient as well. The handler rejects operation types that cannot be
+//     held, and only debits without installments are ever held.
+//  3. Evaluate fraud rules and record the decision; a declined authorization is stored as DECLINED without a hold.
+//  4. Otherwise, draw the available credit limit down by the held amount; the hold expires after the configured TTL.
+//  5. Persist the authorization.
+//
+// Parameters:
+//   - createPayload: account, operation type and amount to hold.
+//   - tx:            db txn, so the limit update commits or rolls back with the authorization insert.
+//
+// Returns:
+//   - A pointer to the newly created Authorization entity.
+//   - An encountered Error.
+func (core *AuthorizationCore) CreateAuthorization(ctx context.Context, createPayload *entityCoreV1Package.CreateAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CreateAuthorization method called in authorization core layer.")
+
+	// 1. Validate the account_id exist in db and is active.
+	account, err := core.checkAccountActive(ctx, createPayload.AccountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while doing validation on account id: %s", err.Error())
+		return nil, err
+	}
+	if err = utilMoneyV1.ValidateScale(createPayload.Amount, account.Currency); err != nil {
+		logger.Errorf("Error: %s", err.Error())
+		return nil, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error())
+	}
+
+	// 2. Map the payload to a DB entity and compute the final held amount.
+	now := time.Now()
+	authorization := mapperV1Package.AuthorizationMapper(createPayload, account.Currency, now.Add(core.holdTTL))
+	authorization.CreatedAt = now
+	result, err := core.operationClient.ComputeOperation(ctx, &operationClientPackageV1.OperationInput{
+		AccountId:       authorization.AccountId,
+		OperationTypeId: authorization.OperationTypeId,
+		Amount:          authorization.Amount,
+		Hold:            true,
+		At:              now,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error while computing operation with operation service: %s", err.Error())
+		if utilErrorsV1.IsNotFound(err) {
+			return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, err.Error())
+		}
+		return nil, err
+	}
+	if result.Amount.Sign() >= 0 || result.InstallmentCount > 0 {
+		logger.Errorf("Error: operation_type_id %d cannot be authorized", authorization.OperationTypeId)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_NOT_AUTHORIZABLE, fmt.Sprintf("operation_type_id: %d cannot be authorized", authorization.OperationTypeId))
+	}
+	authorization.Amount = result.Amount
+	authorization.Coefficient = result.Coefficient
+
+	// 3. Evaluate fraud rules; the decision is stored with the authorization whatever its outcome.
+	decision, err := core.fraudClient.EvaluateTransaction(ctx, &fraudClientPackageV1.TransactionCheck{
+		AccountId:       authorization.AccountId,
+		OperationTypeId: authorization.OperationTypeId,
+		Amount:          authorization.Amount,
+		Currency:        authorization.Currency,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error occured while evaluating fraud rules: %s", err.Error())
+		return nil, err
+	}
+	authorization.FraudDecision = decision.Decision
+	authorization.FraudRules = strings.Join(decision.FiredRules, ",")
+
+	// 4. Hold the amount against the credit limit, unless declined.
+	if authorization.FraudDecision == fraudConstantPackage.DECISION_DECLINE {
+		authorization.Status = constantPackage.STATUS_DECLINED
+	} else {
+		err = core.accountClient.UpdateAvailableCreditLimit(ctx, authorization.AccountId, authorization.Amount, tx)
+		if err != nil {
+			logger.Errorf("Error occured while holding credit limit: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	// 5. Persist the authorization in the DB.
+	err = core.repoV1.CreateAuthorization(ctx, authorization, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting authorization: %s", err.Error())
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// GetAuthorization fetches an authorization by its ID.
+//
+// Parameters:
+//   - authorizationId: ID of the authorization.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The authorization.
+//   - error: an encountered Error, not found included.
+func (core *AuthorizationCore) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAuthorization method called in authorization core layer.")
+	authorization, err := core.repoV1.GetAuthorization(ctx, authorizationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching authorization: %s", err.Error())
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// CaptureAuthorization captures all or part of an open hold.
+//
+// Steps:
+//  1. Fetch and lock the authorization; it must be AUTHORIZED and not past its expiry, and its
+//     account must still be ACTIVE, as a new transaction's would.
+//  2. Without an amount the whole hold is captured; a larger amount than held, or one with more
+//     decimals than the hold's currency allows, is rejected.
+//  3. Give back the credit limit held for the part not captured.
+//  4. Post the captured amount as a transaction via the transaction service. The hold already
+//     drew the limit down and the fraud engine ran at authorization, so neither is repeated.
+//  5. Mark the authorization CAPTURED with its captured amount and transaction.
+//
+// Parameters:
+//   - authorizationId: ID of the authorization.
+//   - capturePayload:  amount to capture, positive; nil for the whole hold.
+//   - tx:              db txn, so every effect commits or rolls back together.
+//
+// Returns:
+//   - The captured authorization.
+//   - An encountered Error.
+func (core *AuthorizationCore) CaptureAuthorization(ctx context.Context, authorizationId int, capturePayload *entityCoreV1Package.CaptureAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CaptureAuthorization method called in authorization core layer.")
+
+	// 1. Fetch, lock and validate the authorization.
+	authorization, err := core.getOpenAuthorization(ctx, authorizationId, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(authorization.ExpiresAt) {
+		logger.Errorf("Error: authorization %d expired at %s", authorizationId, authorization.ExpiresAt)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.AUTHORIZATION_EXPIRED, fmt.Sprintf("authorization_id: %d expired at %s", authorizationId, authorization.ExpiresAt.Format(time.RFC3339)))
+	}
+	_, err = core.checkAccountActive(ctx, authorization.AccountId, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Resolve the captured amount.
+	held := authorization.Amount.Abs()
+	amount := held
+	if capturePayload.Amount != nil {
+		amount = *capturePayload.Amount
+	}
+	if err = utilMoneyV1.ValidateScale(amount, authorization.Currency); err != nil {
+		logger.Errorf("Error: %s", err.Error())
+		return nil, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error())
+	}
+	if amount.Cmp(held) > 0 {
+		logger.Errorf("Error: capture of %s exceeds held amount %s", amount, held)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.CAPTURE_EXCEEDS_AMOUNT, fmt.Sprintf("amount: %s exceeds the held amount %s", amount, held))
+	}
+
+	// 3. Release the part of the hold that is not captured.
+	if released := held.Sub(amount); released.Sign() > 0 {
+		err = core.accountClient.UpdateAvailableCreditLimit(ctx, authorization.AccountId, released, tx)
+		if err != nil {
+			logger.Errorf("Error occured while releasing credit limit: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	// 4. Post the captured amount, signed like the hold.
+	transaction, err := core.transactionClient.PostCapturedTransaction(ctx, &transactionClientPackageV1.CapturedTransaction{
+		AccountId:       authorization.AccountId,
+		OperationTypeId: authorization.OperationTypeId,
+		Amount:          amount.Neg(),
+		Currency:        authorization.Currency,
+		FraudDecision:   authorization.FraudDecision,
+		FraudRules:      authorization.FraudRules,
+		InputAmount:     amount,
+		Coefficient:     authorization.Coefficient,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error occured while posting captured transaction: %s", err.Error())
+		return nil, err
+	}
+
+	// 5. Persist the capture.
+	transactionId := uint(transaction.Id)
+	authorization.CapturedAmount = amount.Neg()
+	authorization.TransactionId = &transactionId
+	authorization.Status = constantPackage.STATUS_CAPTURED
+	err = core.repoV1.UpdateAuthorization(ctx, authorization, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting capture: %s", err.Error())
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// VoidAuthorization voids an open hold.
+//
+// Steps:
+//  1. Fetch and lock the authorization; it must be AUTHORIZED. A hold past its expiry can still be
+//     voided, which releases it just as the sweeper would.
+//  2. Give back the whole held credit limit.
+//  3. Mark the authorization VOIDED.
+//
+// Parameters:
+//   - authorizationId: ID of the authorization.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The voided authorization.
+//   - An encountered Error.
+func (core *AuthorizationCore) VoidAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("VoidAuthorization method called in authorization core layer.")
+
+	authorization, err := core.getOpenAuthorization(ctx, authorizationId, tx)
+	if err != nil {
+		return nil, err
+	}
+	err = core.releaseHold(ctx, core.accountClient, authorization, constantPackage.STATUS_VOIDED, tx)
+	if err != nil {
+		return nil, err
+	}
+	return authorization, nil
+}
+
+// ExpireAuthorizations releases open holds whose TTL has elapsed.
+//
+// Steps:
+//  1. Fetch and lock up to SWEEP_BATCH_SIZE open authorizations expired at now, skipping rows locked by a capture or void.
+//  2. Give back the held credit limit of each and mark it EXPIRED.
+//
+// Parameters:
+//   - now: the sweep time.
+//   - tx:  db txn.
+//
+// Returns:
+//   - The number of authorizations expired.
+//   - An encountered Error.
+func (core *AuthorizationCore) ExpireAuthorizations(ctx context.Context, now time.Time, tx *gorm.DB) (int, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ExpireAuthorizations method called in authorization core layer.")
+
+	authorizations, err := core.repoV1.GetExpiredAuthorizations(ctx, now, constantPackage.SWEEP_BATCH_SIZE, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching expired authorizations: %s", err.Error())
+		return 0, err
+	}
+	for _, authorization := range authorizations {
+		err = core.releaseHold(ctx, core.accountClient, authorization, constantPackage.STATUS_EXPIRED, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(authorizations), nil
+}
+
+// ReleaseAccountAuthorizations voids the open holds of an account, e.g. once it is closed and can
+// no longer capture them. It runs in the txn closing the account, so the credit limit is given back
+// through the in-txn account client: a remote call would wait on the account row that txn has locked.
+//
+// Steps:
+//  1. Fetch and lock the open authorizations of the account.
+//  2. Give back the held credit limit of each and mark it VOIDED.
+//
+// Parameters:
+//   - accountId: ID of the account.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The number of authorizations voided.
+//   - An encountered Error.
+func (core *AuthorizationCore) ReleaseAccountAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) (int, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ReleaseAccountAuthorizations method called in authorization core layer.")
+
+	authorizations, err := core.repoV1.GetOpenAuthorizations(ctx, accountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching open authorizations: %s", err.Error())
+		return 0, err
+	}
+	for _, authorization := range authorizations {
+		err = core.releaseHold(ctx, core.inTxnAccountClient, authorization, constantPackage.STATUS_VOIDED, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(authorizations), nil
+}
+
+// getOpenAuthorization fetches and locks an authorization, rejecting it unless it is still AUTHORIZED.
+func (core *AuthorizationCore) getOpenAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	authorization, err := core.repoV1.GetAuthorizationForUpdate(ctx, authorizationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching authorization: %s", err.Error())
+		return nil, err
+	}
+	if authorization.Status != constantPackage.STATUS_AUTHORIZED {
+		logger.Errorf("Error: authorization %d is %s", authorizationId, authorization.Status)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.AUTHORIZATION_NOT_OPEN, fmt.Sprintf("authorization_id: %d is %s", authorizationId, authorization.Status))
+	}
+	return authorization, nil
+}
+
+// releaseHold gives the whole held amount back to the credit limit through accountClient and closes the
+// authorization with status.
+func (core *AuthorizationCore) releaseHold(ctx context.Context, accountClient accountClientPackageV1.IAccountClient, authorization *entityDbV1Package.Authorization, status string, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	err := accountClient.UpdateAvailableCreditLimit(ctx, authorization.AccountId, authorization.Amount.Neg(), tx)
+	if err != nil {
+		logger.Errorf("Error occured while releasing hold of authorization %d: %s", authorization.ID, err.Error())
+		return err
+	}
+	authorization.Status = status
+	err = core.repoV1.UpdateAuthorization(ctx, authorization, tx)
+	if err != nil {
+		logger.Errorf("Error occured while closing authorization %d: %s", authorization.ID, err.Error())
+	}
+	return err
+}
+
+// checkAccountActive verifies that the account exists and accepts new holds, and returns it.
+func (core *AuthorizationCore) checkAccountActive(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
+	logger := utilContextV1.Logger(ctx)
+	account, err := core.accountClient.GetAccount(ctx, accountId, tx)
+	if err != nil {
+		logger.Errorf("Error while fetching account data from account service: %s", err.Error())
+		if utilErrorsV1.IsNotFound(err) {
+			return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_FOUND, err.Error())
+		}
+		return nil, err
+	}
+	switch account.Status {
+	case accountConstantPackage.STATUS_ACTIVE:
+		return account, nil
+	case accountConstantPackage.STATUS_BLOCKED:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_BLOCKED, fmt.Sprintf("account_id: %d is blocked", accountId))
+	case accountConstantPackage.STATUS_CLOSED:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_CLOSED, fmt.Sprintf("account_id: %d is closed", accountId))
+	default:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_ACTIVE, fmt.Sprintf("account_id: %d is not active (status: %s)", accountId, account.Status))
+	}
+}
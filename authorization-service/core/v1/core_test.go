@@ -0,0 +1,529 @@
+package authorization_core_v1
+
+import (
+	accountCoreV1Package "anti-fraud/account-service/core/v1"
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	accountConstantPackage "anti-fraud/constants/account"
+	constantPackage "anti-fraud/constants/authorization"
+	errorConstantPackage "anti-fraud/constants/errors"
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	fraudCoreV1Package "anti-fraud/fraud-service/core/v1"
+	accountClientPackageV1 "anti-fraud/mediator-service/account-service-client"
+	fraudClientPackageV1 "anti-fraud/mediator-service/fraud-service-client"
+	operationClientPackageV1 "anti-fraud/mediator-service/operation-service-client"
+	transactionClientPackageV1 "anti-fraud/mediator-service/transaction-service-client"
+	opsCoreV1Package "anti-fraud/operation-service/core/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+//-------------------------------------------//
+// 1. Mocks
+//-------------------------------------------//
+
+type MockAuthorizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizationRepository) CreateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error {
+	args := m.Called(authorization, tx)
+	return args.Error(0)
+}
+
+func (m *MockAuthorizationRepository) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(authorizationId, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationRepository) GetAuthorizationForUpdate(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	args := m.Called(authorizationId, tx)
+	authorization, _ := args.Get(0).(*entityDbV1Package.Authorization)
+	return authorization, args.Error(1)
+}
+
+func (m *MockAuthorizationRepository) UpdateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error {
+	args := m.Called(authorization, tx)
+	return args.Error(0)
+}
+
+func (m *MockAuthorizationRepository) GetExpiredAuthorizations(ctx context.Context, now time.Time, limit int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error) {
+	args := m.Called(now, limit, tx)
+	authorizations, _ := args.Get(0).([]*entityDbV1Package.Authorization)
+	return authorizations, args.Error(1)
+}
+
+func (m *MockAuthorizationRepository) GetOpenAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error) {
+	args := m.Called(accountId, tx)
+	authorizations, _ := args.Get(0).([]*entityDbV1Package.Authorization)
+	return authorizations, args.Error(1)
+}
+
+type MockOperationClient struct {
+	mock.Mock
+}
+
+func (m *MockOperationClient) GetOperationCoefficient(ctx context.Context, operationTypeID int, at time.Time, tx *gorm.DB) (int, error) {
+	args := m.Called(operationTypeID, at, tx)
+	coef, _ := args.Get(0).(int)
+	return coef, args.Error(1)
+}
+
+func (m *MockOperationClient) ComputeOperation(ctx context.Context, input *operationClientPackageV1.OperationInput, tx *gorm.DB) (*operationClientPackageV1.OperationResult, error) {
+	args := m.Called(input, tx)
+	result, _ := args.Get(0).(*operationClientPackageV1.OperationResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOperationClient) ComputeFees(ctx context.Context, input *operationClientPackageV1.FeeInput, tx *gorm.DB) ([]*operationClientPackageV1.Fee, error) {
+	args := m.Called(input, tx)
+	fees, _ := args.Get(0).([]*operationClientPackageV1.Fee)
+	return fees, args.Error(1)
+}
+
+func (m *MockOperationClient) CacheStats() operationClientPackageV1.OperationCacheStats {
+	return operationClientPackageV1.OperationCacheStats{}
+}
+
+func (m *MockOperationClient) SetupCore(core opsCoreV1Package.IOperationCore) {
+	m.Called(core)
+}
+
+type MockAccountClient struct {
+	mock.Mock
+}
+
+func (m *MockAccountClient) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
+	args := m.Called(accountId, tx)
+	account, _ := args.Get(0).(*accountClientPackageV1.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockAccountClient) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	args := m.Called(accountId, delta, tx)
+	return args.Error(0)
+}
+
+func (m *MockAccountClient) SetupCore(core accountCoreV1Package.IAccountCore) {
+	m.Called(core)
+}
+
+type MockFraudClient struct {
+	mock.Mock
+}
+
+func (m *MockFraudClient) EvaluateTransaction(ctx context.Context, check *fraudClientPackageV1.TransactionCheck, tx *gorm.DB) (*fraudClientPackageV1.Decision, error) {
+	args := m.Called(check, tx)
+	decision, _ := args.Get(0).(*fraudClientPackageV1.Decision)
+	return decision, args.Error(1)
+}
+
+func (m *MockFraudClient) SetupCore(core fraudCoreV1Package.IFraudCore) {
+	m.Called(core)
+}
+
+type MockTransactionClient struct {
+	mock.Mock
+}
+
+func (m *MockTransactionClient) SetupCore(core transactionClientPackageV1.ITransactionCore) {
+	m.Called(core)
+}
+
+func (m *MockTransactionClient) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*transactionClientPackageV1.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*transactionClientPackageV1.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionClient) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionClient) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionClient) PostCapturedTransaction(ctx context.Context, capture *transactionClientPackageV1.CapturedTransaction, tx *gorm.DB) (*transactionClientPackageV1.Transaction, error) {
+	args := m.Called(capture, tx)
+	transaction, _ := args.Get(0).(*transactionClientPackageV1.Transaction)
+	return transaction, args.Error(1)
+}
+
+//-------------------------------------------//
+// 2. Setup Helpers
+//-------------------------------------------//
+
+type testCore struct {
+	core        *AuthorizationCore
+	repo        *MockAuthorizationRepository
+	operation   *MockOperationClient
+	account     *MockAccountClient
+	fraud       *MockFraudClient
+	transaction *MockTransactionClient
+}
+
+func setupTestCore() *testCore {
+	setup := &testCore{
+		repo:        new(MockAuthorizationRepository),
+		operation:   new(MockOperationClient),
+		account:     new(MockAccountClient),
+		fraud:       new(MockFraudClient),
+		transaction: new(MockTransactionClient),
+	}
+	setup.core = NewAuthorizationCore(setup.repo, logrus.New(), time.Hour, setup.operation, setup.account, setup.account, setup.fraud, setup.transaction)
+	return setup
+}
+
+func openAuthorization(amount string) *entityDbV1Package.Authorization {
+	return &entityDbV1Package.Authorization{
+		Model:           gorm.Model{ID: 3},
+		AccountId:       1,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse(amount),
+		Currency:        "USD",
+		Coefficient:     -1,
+		Status:          constantPackage.STATUS_AUTHORIZED,
+		FraudDecision:   fraudConstantPackage.DECISION_APPROVE,
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+}
+
+func assertAppError(t *testing.T, err error, status int, code string) {
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	if assert.True(t, ok, "expected an AppError, got %v", err) {
+		assert.Equal(t, status, appErr.Status)
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+//-------------------------------------------//
+// 3. Test: CreateAuthorization
+//-------------------------------------------//
+
+func TestCreateAuthorization_HoldsTheLimit(t *testing.T) {
+	setup := setupTestCore()
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	setup.operation.On("ComputeOperation", mock.MatchedBy(func(input *operationClientPackageV1.OperationInput) bool {
+		return input.OperationTypeId == 1 && input.Amount == utilMoneyV1.MustParse("80") && input.Hold
+	}), mock.Anything).Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-80"), Coefficient: -1}, nil)
+	setup.fraud.On("EvaluateTransaction", &fraudClientPackageV1.TransactionCheck{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-80"), Currency: "USD"}, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: fraudConstantPackage.DECISION_REVIEW, FiredRules: []string{"high_amount"}}, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-80"), mock.Anything).Return(nil)
+	setup.repo.On("CreateAuthorization", mock.Anything, mock.Anything).Return(nil)
+
+	before := time.Now()
+	authorization, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("80")}, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_AUTHORIZED, authorization.Status)
+	assert.Equal(t, "-80", authorization.Amount.String())
+	assert.Equal(t, -1, authorization.Coefficient)
+	assert.Equal(t, "high_amount", authorization.FraudRules)
+	assert.Equal(t, "USD", authorization.Currency)
+	assert.WithinDuration(t, before.Add(time.Hour), authorization.ExpiresAt, time.Minute)
+	setup.account.AssertExpectations(t)
+	setup.repo.AssertExpectations(t)
+}
+
+func TestCreateAuthorization_DeclinedHoldsNothing(t *testing.T) {
+	setup := setupTestCore()
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	setup.operation.On("ComputeOperation", mock.Anything, mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-10"), Coefficient: -1}, nil)
+	setup.fraud.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: fraudConstantPackage.DECISION_DECLINE, FiredRules: []string{"velocity"}}, nil)
+	setup.repo.On("CreateAuthorization", mock.Anything, mock.Anything).Return(nil)
+
+	authorization, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("10")}, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_DECLINED, authorization.Status)
+	setup.account.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateAuthorization_OperationNotAuthorizable(t *testing.T) {
+	// The handler of operation type 2 rejects holds; the one of operation type 4 computes a credit, which is never held.
+	handlerErr := utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_NOT_AUTHORIZABLE, "operation_type_id: 2 cannot be authorized")
+	credit := &operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("10"), Coefficient: 1}
+	for operationTypeId, result := range map[int]*operationClientPackageV1.OperationResult{2: nil, 4: credit} {
+		setup := setupTestCore()
+		setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+		if result == nil {
+			setup.operation.On("ComputeOperation", mock.Anything, mock.Anything).Return(nil, handlerErr)
+		} else {
+			setup.operation.On("ComputeOperation", mock.Anything, mock.Anything).Return(result, nil)
+		}
+
+		_, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: operationTypeId, Amount: utilMoneyV1.MustParse("10")}, &gorm.DB{})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_NOT_AUTHORIZABLE)
+		setup.fraud.AssertNotCalled(t, "EvaluateTransaction", mock.Anything, mock.Anything)
+	}
+}
+
+func TestCreateAuthorization_AmountScaleFollowsAccountCurrency(t *testing.T) {
+	setup := setupTestCore()
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "JPY"}, nil)
+
+	_, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("10.5")}, &gorm.DB{})
+
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	setup.operation.AssertNotCalled(t, "ComputeOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateAuthorization_InactiveAccount(t *testing.T) {
+	setup := setupTestCore()
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_BLOCKED}, nil)
+
+	_, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("10")}, &gorm.DB{})
+
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.ACCOUNT_BLOCKED)
+}
+
+func TestCreateAuthorization_InsufficientLimit(t *testing.T) {
+	setup := setupTestCore()
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	setup.operation.On("ComputeOperation", mock.Anything, mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-10"), Coefficient: -1}, nil)
+	setup.fraud.On("EvaluateTransaction", mock.Anything, mock.Anything).Return(&fraudClientPackageV1.Decision{Decision: fraudConstantPackage.DECISION_APPROVE}, nil)
+	limitErr := utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, "insufficient available credit limit")
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-10"), mock.Anything).Return(limitErr)
+
+	_, err := setup.core.CreateAuthorization(context.Background(), &entityCoreV1Package.CreateAuthorizationPayload{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("10")}, &gorm.DB{})
+
+	assert.Equal(t, limitErr, err)
+	setup.repo.AssertNotCalled(t, "CreateAuthorization", mock.Anything, mock.Anything)
+}
+
+//-------------------------------------------//
+// 4. Test: CaptureAuthorization
+//-------------------------------------------//
+
+func TestCaptureAuthorization_PartialReleasesTheRest(t *testing.T) {
+	setup := setupTestCore()
+	authorization := openAuthorization("-100")
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(authorization, nil)
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("40"), mock.Anything).Return(nil)
+	setup.transaction.On("PostCapturedTransaction", &transactionClientPackageV1.CapturedTransaction{
+		AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-60"), Currency: "USD", FraudDecision: fraudConstantPackage.DECISION_APPROVE,
+		InputAmount: utilMoneyV1.MustParse("60"), Coefficient: -1,
+	}, mock.Anything).Return(&transactionClientPackageV1.Transaction{Id: 21, Amount: utilMoneyV1.MustParse("-60")}, nil)
+	setup.repo.On("UpdateAuthorization", authorization, mock.Anything).Return(nil)
+
+	amount := utilMoneyV1.MustParse("60")
+	captured, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{Amount: &amount}, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_CAPTURED, captured.Status)
+	assert.Equal(t, "-60", captured.CapturedAmount.String())
+	assert.Equal(t, uint(21), *captured.TransactionId)
+	setup.account.AssertExpectations(t)
+	setup.transaction.AssertExpectations(t)
+}
+
+func TestCaptureAuthorization_FullKeepsTheLimitHeld(t *testing.T) {
+	setup := setupTestCore()
+	authorization := openAuthorization("-100")
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(authorization, nil)
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	setup.transaction.On("PostCapturedTransaction", mock.Anything, mock.Anything).Return(&transactionClientPackageV1.Transaction{Id: 21}, nil)
+	setup.repo.On("UpdateAuthorization", authorization, mock.Anything).Return(nil)
+
+	captured, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{}, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "-100", captured.CapturedAmount.String())
+	setup.account.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCaptureAuthorization_Rejected(t *testing.T) {
+	voided := openAuthorization("-100")
+	voided.Status = constantPackage.STATUS_VOIDED
+	expired := openAuthorization("-100")
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	tooMuch := utilMoneyV1.MustParse("100.01")
+
+	cases := []struct {
+		name          string
+		authorization *entityDbV1Package.Authorization
+		amount        *utilMoneyV1.Amount
+		code          string
+	}{
+		{"not open", voided, nil, errorConstantPackage.AUTHORIZATION_NOT_OPEN},
+		{"expired", expired, nil, errorConstantPackage.AUTHORIZATION_EXPIRED},
+		{"exceeds", openAuthorization("-100"), &tooMuch, errorConstantPackage.CAPTURE_EXCEEDS_AMOUNT},
+	}
+	for _, c := range cases {
+		setup := setupTestCore()
+		setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(c.authorization, nil)
+		setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+
+		_, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{Amount: c.amount}, &gorm.DB{})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, c.code)
+		setup.transaction.AssertNotCalled(t, "PostCapturedTransaction", mock.Anything, mock.Anything)
+	}
+}
+
+func TestCaptureAuthorization_AccountNotActive(t *testing.T) {
+	for status, code := range map[string]string{
+		accountConstantPackage.STATUS_BLOCKED: errorConstantPackage.ACCOUNT_BLOCKED,
+		accountConstantPackage.STATUS_CLOSED:  errorConstantPackage.ACCOUNT_CLOSED,
+	} {
+		setup := setupTestCore()
+		setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(openAuthorization("-100"), nil)
+		setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: status, Currency: "USD"}, nil)
+
+		_, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{}, &gorm.DB{})
+
+		assertAppError(t, err, http.StatusUnprocessableEntity, code)
+		setup.transaction.AssertNotCalled(t, "PostCapturedTransaction", mock.Anything, mock.Anything)
+		setup.repo.AssertNotCalled(t, "UpdateAuthorization", mock.Anything, mock.Anything)
+	}
+}
+
+func TestCaptureAuthorization_AmountScaleFollowsHoldCurrency(t *testing.T) {
+	setup := setupTestCore()
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(openAuthorization("-100"), nil)
+	setup.account.On("GetAccount", 1, mock.Anything).Return(&accountClientPackageV1.Account{Id: 1, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+
+	amount := utilMoneyV1.MustParse("10.001")
+	_, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{Amount: &amount}, &gorm.DB{})
+
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	setup.transaction.AssertNotCalled(t, "PostCapturedTransaction", mock.Anything, mock.Anything)
+}
+
+func TestCaptureAuthorization_NotFound(t *testing.T) {
+	setup := setupTestCore()
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.AUTHORIZATION_NOT_FOUND, "authorization_id: 3 not found in database"))
+
+	_, err := setup.core.CaptureAuthorization(context.Background(), 3, &entityCoreV1Package.CaptureAuthorizationPayload{}, &gorm.DB{})
+
+	assertAppError(t, err, http.StatusNotFound, errorConstantPackage.AUTHORIZATION_NOT_FOUND)
+}
+
+//-------------------------------------------//
+// 5. Test: VoidAuthorization / ExpireAuthorizations
+//-------------------------------------------//
+
+func TestVoidAuthorization_ReleasesTheHold(t *testing.T) {
+	setup := setupTestCore()
+	authorization := openAuthorization("-100")
+	authorization.ExpiresAt = time.Now().Add(-time.Minute) // not swept yet
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(authorization, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("100"), mock.Anything).Return(nil)
+	setup.repo.On("UpdateAuthorization", authorization, mock.Anything).Return(nil)
+
+	voided, err := setup.core.VoidAuthorization(context.Background(), 3, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_VOIDED, voided.Status)
+	setup.account.AssertExpectations(t)
+}
+
+func TestVoidAuthorization_AlreadyCaptured(t *testing.T) {
+	setup := setupTestCore()
+	authorization := openAuthorization("-100")
+	authorization.Status = constantPackage.STATUS_CAPTURED
+	setup.repo.On("GetAuthorizationForUpdate", 3, mock.Anything).Return(authorization, nil)
+
+	_, err := setup.core.VoidAuthorization(context.Background(), 3, &gorm.DB{})
+
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.AUTHORIZATION_NOT_OPEN)
+	setup.account.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExpireAuthorizations_ReleasesEachHold(t *testing.T) {
+	setup := setupTestCore()
+	now := time.Now()
+	first := openAuthorization("-10")
+	second := openAuthorization("-20.5")
+	second.AccountId = 2
+	setup.repo.On("GetExpiredAuthorizations", now, constantPackage.SWEEP_BATCH_SIZE, mock.Anything).Return([]*entityDbV1Package.Authorization{first, second}, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("10"), mock.Anything).Return(nil)
+	setup.account.On("UpdateAvailableCreditLimit", 2, utilMoneyV1.MustParse("20.5"), mock.Anything).Return(nil)
+	setup.repo.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+
+	expired, err := setup.core.ExpireAuthorizations(context.Background(), now, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired)
+	assert.Equal(t, constantPackage.STATUS_EXPIRED, first.Status)
+	assert.Equal(t, constantPackage.STATUS_EXPIRED, second.Status)
+	setup.account.AssertExpectations(t)
+}
+
+func TestExpireAuthorizations_DBError(t *testing.T) {
+	setup := setupTestCore()
+	setup.repo.On("GetExpiredAuthorizations", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	expired, err := setup.core.ExpireAuthorizations(context.Background(), time.Now(), &gorm.DB{})
+
+	assert.Zero(t, expired)
+	assert.EqualError(t, err, "db error")
+}
+
+func TestReleaseAccountAuthorizations_VoidsEachHold(t *testing.T) {
+	setup := setupTestCore()
+	first := openAuthorization("-10")
+	second := openAuthorization("-5")
+	setup.repo.On("GetOpenAuthorizations", 1, mock.Anything).Return([]*entityDbV1Package.Authorization{first, second}, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("10"), mock.Anything).Return(nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("5"), mock.Anything).Return(nil)
+	setup.repo.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+
+	released, err := setup.core.ReleaseAccountAuthorizations(context.Background(), 1, &gorm.DB{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, released)
+	assert.Equal(t, constantPackage.STATUS_VOIDED, first.Status)
+	assert.Equal(t, constantPackage.STATUS_VOIDED, second.Status)
+	setup.account.AssertExpectations(t)
+}
+
+func TestReleaseAccountAuthorizations_UsesInTxnAccountClient(t *testing.T) {
+	setup := setupTestCore()
+	inTxnAccount := new(MockAccountClient)
+	setup.core = NewAuthorizationCore(setup.repo, logrus.New(), time.Hour, setup.operation, setup.account, inTxnAccount, setup.fraud, setup.transaction)
+	tx := &gorm.DB{}
+	setup.repo.On("GetOpenAuthorizations", 1, tx).Return([]*entityDbV1Package.Authorization{openAuthorization("-10")}, nil)
+	inTxnAccount.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("10"), tx).Return(nil)
+	setup.repo.On("UpdateAuthorization", mock.Anything, tx).Return(nil)
+
+	released, err := setup.core.ReleaseAccountAuthorizations(context.Background(), 1, tx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, released)
+	inTxnAccount.AssertExpectations(t)
+	setup.account.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseAccountAuthorizations_LimitError(t *testing.T) {
+	setup := setupTestCore()
+	setup.repo.On("GetOpenAuthorizations", 1, mock.Anything).Return([]*entityDbV1Package.Authorization{openAuthorization("-10")}, nil)
+	setup.account.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("10"), mock.Anything).Return(errors.New("db error"))
+
+	released, err := setup.core.ReleaseAccountAuthorizations(context.Background(), 1, &gorm.DB{})
+
+	assert.Zero(t, released)
+	assert.EqualError(t, err, "db error")
+	setup.repo.AssertNotCalled(t, "UpdateAuthorization", mock.Anything, mock.Anything)
+}
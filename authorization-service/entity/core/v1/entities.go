@@ -0,0 +1,16 @@
+package authorization_entity_core_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+)
+
+type CreateAuthorizationPayload struct {
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+}
+
+// CaptureAuthorizationPayload holds the amount to capture, positive; nil captures the whole hold.
+type CaptureAuthorizationPayload struct {
+	Amount *utilMoneyV1.Amount `json:"amount"`
+}
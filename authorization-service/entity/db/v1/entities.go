@@ -0,0 +1,29 @@
+package authorization_entity_db_v1
+
+import (
+	constantPackage "anti-fraud/constants/authorization"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Authorization is a hold on an account's credit limit, settled by a capture or released by a void or expiry.
+type Authorization struct {
+	gorm.Model
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`          // final held amount, signed like a transaction
+	CapturedAmount  utilMoneyV1.Amount `json:"captured_amount"` // signed like Amount, zero until captured
+	Coefficient     int                `json:"coefficient"`     // operation type coefficient in effect when the hold was placed
+	Currency        string             `json:"currency"`        // currency of the account
+	Status          string             `json:"status"`          // AUTHORIZED, CAPTURED, VOIDED, EXPIRED or DECLINED
+	FraudDecision   string             `json:"fraud_decision"`
+	FraudRules      string             `json:"fraud_rules"` // comma separated names of the fraud rules that fired
+	ExpiresAt       time.Time          `json:"expires_at"`  // an uncaptured hold is released by the sweeper after this time
+	TransactionId   *uint              `json:"transaction_id"`
+}
+
+func (Authorization) TableName() string {
+	return constantPackage.TABLE_NAME
+}
@@ -0,0 +1,40 @@
+package authorization_entity_http_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"errors"
+)
+
+type CreateAuthorizationRequest struct {
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+}
+
+func (createRequest *CreateAuthorizationRequest) Validate() error {
+	if createRequest.AccountId == 0 {
+		return errors.New("account_id is mandatory")
+	}
+	if createRequest.OperationTypeId == 0 {
+		return errors.New("operation_type_id is mandatory")
+	}
+	if createRequest.Amount.IsZero() {
+		return errors.New("amount should be non-zero")
+	}
+	return nil
+}
+
+// CaptureAuthorizationRequest holds the amount to capture; without it the whole hold is captured.
+type CaptureAuthorizationRequest struct {
+	Amount *utilMoneyV1.Amount `json:"amount"`
+}
+
+func (captureRequest *CaptureAuthorizationRequest) Validate() error {
+	if captureRequest.Amount == nil {
+		return nil
+	}
+	if captureRequest.Amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package authorization_entity_http_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// AuthorizationResponse is the read model of an authorization, shared by all authorization endpoints.
+type AuthorizationResponse struct {
+	AuthorizationID int                `json:"authorization_id"`
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	CapturedAmount  utilMoneyV1.Amount `json:"captured_amount"`
+	Coefficient     int                `json:"coefficient"`
+	Currency        string             `json:"currency"`
+	Status          string             `json:"status"`
+	FraudDecision   string             `json:"fraud_decision"`
+	FraudRules      []string           `json:"fraud_rules"`
+	ExpiresAt       time.Time          `json:"expires_at"`
+	TransactionID   *int               `json:"transaction_id,omitempty"` // set once captured
+	EventDate       time.Time          `json:"event_date"`
+}
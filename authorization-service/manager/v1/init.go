@@ -0,0 +1,71 @@
+package authorization_manager_v1
+
+import (
+	controllerV1Package "anti-fraud/authorization-service/controllers/v1"
+	coreV1Package "anti-fraud/authorization-service/core/v1"
+	repoV1Package "anti-fraud/authorization-service/repository/v1"
+	routerV1Package "anti-fraud/authorization-service/routes/v1"
+	subscribersV1Package "anti-fraud/authorization-service/subscribers/v1"
+	sweeperV1Package "anti-fraud/authorization-service/sweeper/v1"
+	eventConstantPackage "anti-fraud/constants/event"
+
+	accountClientV1Package "anti-fraud/mediator-service/account-service-client"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	fraudClientV1Package "anti-fraud/mediator-service/fraud-service-client"
+	operationClientV1Package "anti-fraud/mediator-service/operation-service-client"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	configPackage "anti-fraud/utils-server/config"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// AuthorizationManager wires all components required to run authorization-service.
+type AuthorizationManager struct {
+	db                 *gorm.DB
+	router             *mux.Router
+	logger             *logrus.Logger
+	config             configPackage.AuthorizationConfig
+	operationClient    operationClientV1Package.IOperationClient
+	accountClient      accountClientV1Package.IAccountClient
+	inTxnAccountClient accountClientV1Package.IAccountClient
+	fraudClient        fraudClientV1Package.IFraudClient
+	transactionClient  transactionClientV1Package.ITransactionClient
+	eventBus           eventBusV1Package.IEventBus
+	sweeperV1          sweeperV1Package.IAuthorizationSweeper
+}
+
+// NewAuthorizationManager create and return new instance of AuthorizationManager. inTxnAccountClient
+// is an in-process account client, used by the subscribers delivered in the txn of account-service.
+func NewAuthorizationManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, config configPackage.AuthorizationConfig, operationClient operationClientV1Package.IOperationClient, accountClient accountClientV1Package.IAccountClient, inTxnAccountClient accountClientV1Package.IAccountClient, fraudClient fraudClientV1Package.IFraudClient, transactionClient transactionClientV1Package.ITransactionClient, eventBus eventBusV1Package.IEventBus) *AuthorizationManager {
+
+	return &AuthorizationManager{db: db, router: router, logger: logger, config: config, operationClient: operationClient, accountClient: accountClient, inTxnAccountClient: inTxnAccountClient, fraudClient: fraudClient, transactionClient: transactionClient, eventBus: eventBus}
+}
+
+// Init instantiate and wire all components, register routes and event subscribers for authorization-service.
+func (mw *AuthorizationManager) Init() {
+
+	middlewareHandler := middlewareHandlerPackageV1.NewMiddlewareHandler(mw.logger)
+	repoV1 := repoV1Package.NewAuthorizationRepository(mw.logger)
+	coreV1 := coreV1Package.NewAuthorizationCore(repoV1, mw.logger, mw.config.HoldTTL, mw.operationClient, mw.accountClient, mw.inTxnAccountClient, mw.fraudClient, mw.transactionClient)
+	idempotencyStore := utilIdempotencyV1.NewIdempotencyStore(mw.logger)
+	controllerV1 := controllerV1Package.NewAuthorizationController(repoV1, coreV1, idempotencyStore, mw.db, mw.logger)
+	router := routerV1Package.NewAuthorizationRoutes(controllerV1, mw.router, middlewareHandler)
+	router.Init()
+	accountSubscriber := subscribersV1Package.NewAccountSubscriber(coreV1)
+	mw.eventBus.Subscribe(eventConstantPackage.ACCOUNT_STATUS_CHANGED, eventConstantPackage.DELIVERY_SYNC_REQUIRED, "authorization-service.release-closed-account-holds", accountSubscriber.OnAccountStatusChanged)
+	mw.sweeperV1 = sweeperV1Package.NewAuthorizationSweeper(coreV1, mw.db, mw.logger, mw.config.SweepInterval)
+}
+
+// StartSweeper starts the background job releasing expired holds.
+func (mw *AuthorizationManager) StartSweeper() {
+	mw.sweeperV1.Start()
+}
+
+// StopSweeper stops the background job releasing expired holds, waiting for a sweep in progress.
+func (mw *AuthorizationManager) StopSweeper() {
+	mw.sweeperV1.Stop()
+}
@@ -0,0 +1,18 @@
+package authorization_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityHttpV1Package "anti-fraud/authorization-service/entity/http/v1"
+)
+
+func CreateAuthorizationPayloadMapper(createRequest *entityHttpV1Package.CreateAuthorizationRequest) *entityCoreV1Package.CreateAuthorizationPayload {
+	return &entityCoreV1Package.CreateAuthorizationPayload{
+		AccountId:       createRequest.AccountId,
+		OperationTypeId: createRequest.OperationTypeId,
+		Amount:          createRequest.Amount,
+	}
+}
+
+func CaptureAuthorizationPayloadMapper(captureRequest *entityHttpV1Package.CaptureAuthorizationRequest) *entityCoreV1Package.CaptureAuthorizationPayload {
+	return &entityCoreV1Package.CaptureAuthorizationPayload{Amount: captureRequest.Amount}
+}
@@ -0,0 +1,19 @@
+package authorization_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	constantPackage "anti-fraud/constants/authorization"
+	"time"
+)
+
+func AuthorizationMapper(createPayload *entityCoreV1Package.CreateAuthorizationPayload, currency string, expiresAt time.Time) *entityDbV1Package.Authorization {
+	return &entityDbV1Package.Authorization{
+		AccountId:       createPayload.AccountId,
+		OperationTypeId: createPayload.OperationTypeId,
+		Amount:          createPayload.Amount,
+		Currency:        currency,
+		Status:          constantPackage.STATUS_AUTHORIZED,
+		ExpiresAt:       expiresAt,
+	}
+}
@@ -0,0 +1,34 @@
+package authorization_mapper_v1
+
+import (
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	entityHttpV1Package "anti-fraud/authorization-service/entity/http/v1"
+	"strings"
+)
+
+func AuthorizationDetailsResponseMapper(authorization *entityDbV1Package.Authorization) *entityHttpV1Package.AuthorizationResponse {
+	fraudRules := []string{}
+	if authorization.FraudRules != "" {
+		fraudRules = strings.Split(authorization.FraudRules, ",")
+	}
+	var transactionId *int
+	if authorization.TransactionId != nil {
+		id := int(*authorization.TransactionId)
+		transactionId = &id
+	}
+	return &entityHttpV1Package.AuthorizationResponse{
+		AuthorizationID: int(authorization.ID),
+		AccountId:       authorization.AccountId,
+		OperationTypeId: authorization.OperationTypeId,
+		Amount:          authorization.Amount,
+		CapturedAmount:  authorization.CapturedAmount,
+		Coefficient:     authorization.Coefficient,
+		Currency:        authorization.Currency,
+		Status:          authorization.Status,
+		FraudDecision:   authorization.FraudDecision,
+		FraudRules:      fraudRules,
+		ExpiresAt:       authorization.ExpiresAt,
+		TransactionID:   transactionId,
+		EventDate:       authorization.CreatedAt,
+	}
+}
@@ -0,0 +1,194 @@
+package authorization_repo_v1
+
+import (
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	constantPackage "anti-fraud/constants/authorization"
+	errorConstantPackage "anti-fraud/constants/errors"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IAuthorizationRepository defines methods interface for authorization-related db operations.
+type IAuthorizationRepository interface {
+
+	// CreateAuthorization persists a new Authorization entity to the db.
+	CreateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error
+
+	// GetAuthorization fetches an Authorization entity by its ID.
+	GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// GetAuthorizationForUpdate fetches and locks an Authorization entity by its ID.
+	GetAuthorizationForUpdate(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error)
+
+	// UpdateAuthorization persists the status, captured amount and transaction of an Authorization entity.
+	UpdateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error
+
+	// GetExpiredAuthorizations locks and returns open authorizations whose hold expired at or before now.
+	GetExpiredAuthorizations(ctx context.Context, now time.Time, limit int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error)
+
+	// GetOpenAuthorizations locks and returns the open authorizations of an account.
+	GetOpenAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error)
+}
+
+// AuthorizationRepository implements IAuthorizationRepository methods.
+type AuthorizationRepository struct {
+	logger *logrus.Logger
+}
+
+// NewAuthorizationRepository returns a new AuthorizationRepository instance.
+func NewAuthorizationRepository(logger *logrus.Logger) *AuthorizationRepository {
+	return &AuthorizationRepository{logger: logger}
+}
+
+// CreateAuthorization inserts a new authorization record into the database.
+//
+// Parameters:
+//   - authorization: authorization db entity.
+//   - tx:            db txn.
+//
+// Returns:
+//   - An error if the insert fails, otherwise nil.
+func (repo *AuthorizationRepository) CreateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CreateAuthorization method called in authorization repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Create(authorization)
+	if result.Error != nil {
+		logger.Errorf("Failed to create authorization: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetAuthorization fetches an authorization by its ID.
+//
+// Steps:
+//  1. Query the table with the given authorization ID.
+//  2. If the record is not found, return a not found Error.
+//
+// Parameters:
+//   - authorizationId: ID of the authorization.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The authorization.
+//   - error: an encountered Error.
+func (repo *AuthorizationRepository) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAuthorization method called in authorization repo layer.")
+	authorization := &entityDbV1Package.Authorization{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Where("id = ?", authorizationId).First(authorization)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		logger.Errorf("Failed to find authorization with authorizationId: %d", authorizationId)
+		return authorization, utilErrorsV1.NewNotFoundError(errorConstantPackage.AUTHORIZATION_NOT_FOUND, fmt.Sprintf("authorization_id: %d not found in database", authorizationId))
+	}
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching authorization: %s", result.Error.Error())
+	}
+	return authorization, result.Error
+}
+
+// GetAuthorizationForUpdate fetches an authorization by its ID and locks its row (SELECT ... FOR UPDATE)
+// until the db txn ends, so a capture, a void and the sweeper never settle the same hold twice.
+//
+// Parameters:
+//   - authorizationId: ID of the authorization.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The authorization.
+//   - error: an encountered Error, not found included.
+func (repo *AuthorizationRepository) GetAuthorizationForUpdate(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAuthorizationForUpdate method called in authorization repo layer.")
+	return repo.GetAuthorization(ctx, authorizationId, tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
+// UpdateAuthorization updates the status, captured_amount and transaction_id columns of an authorization.
+//
+// Parameters:
+//   - authorization: authorization carrying the new values.
+//   - tx:            db txn.
+//
+// Returns:
+//   - error: an encountered Error.
+func (repo *AuthorizationRepository) UpdateAuthorization(ctx context.Context, authorization *entityDbV1Package.Authorization, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAuthorization method called in authorization repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("id = ?", authorization.ID).
+		Updates(map[string]interface{}{
+			"status":          authorization.Status,
+			"captured_amount": authorization.CapturedAmount,
+			"transaction_id":  authorization.TransactionId,
+		})
+	if result.Error != nil {
+		logger.Errorf("Failed to update authorization: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetExpiredAuthorizations fetches the open authorizations whose hold has expired.
+//
+// Steps:
+//  1. Filter by AUTHORIZED status and expires_at at or before now, oldest expiry first.
+//  2. Lock the rows, skipping those already locked by a capture or void in flight;
+//     the next sweep picks them up if they are still open.
+//  3. Fetch at most limit rows, so one sweep stays a short db txn.
+//
+// Parameters:
+//   - now:   the sweep time.
+//   - limit: maximum number of authorizations returned.
+//   - tx:    db txn.
+//
+// Returns:
+//   - Expired authorizations.
+//   - error: an encountered Error.
+func (repo *AuthorizationRepository) GetExpiredAuthorizations(ctx context.Context, now time.Time, limit int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetExpiredAuthorizations method called in authorization repo layer.")
+	authorizations := []*entityDbV1Package.Authorization{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND expires_at <= ?", constantPackage.STATUS_AUTHORIZED, now).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&authorizations)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching expired authorizations: %s", result.Error.Error())
+	}
+	return authorizations, result.Error
+}
+
+// GetOpenAuthorizations fetches and locks the open authorizations of an account.
+//
+// Rows locked by a capture, void or sweep in flight are waited for, not skipped, so no hold of the
+// account is left out.
+//
+// Parameters:
+//   - accountId: ID of the account.
+//   - tx:        db txn.
+//
+// Returns:
+//   - Open authorizations, oldest first.
+//   - error: an encountered Error.
+func (repo *AuthorizationRepository) GetOpenAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Authorization, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOpenAuthorizations method called in authorization repo layer.")
+	authorizations := []*entityDbV1Package.Authorization{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ? AND status = ?", accountId, constantPackage.STATUS_AUTHORIZED).
+		Order("id ASC").
+		Find(&authorizations)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching open authorizations: %s", result.Error.Error())
+	}
+	return authorizations, result.Error
+}
@@ -0,0 +1,119 @@
+package authorization_repo_v1
+
+import (
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	constantPackage "anti-fraud/constants/authorization"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&entityDbV1Package.Authorization{})
+	if err != nil {
+		t.Fatalf("failed to migrate schema: %v", err)
+	}
+
+	return db
+}
+
+func TestCreateAndGetAuthorization(t *testing.T) {
+	repo := NewAuthorizationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	authorization := &entityDbV1Package.Authorization{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-25.5"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: time.Now().Add(time.Hour)}
+	assert.NoError(t, repo.CreateAuthorization(ctx, authorization, db))
+	assert.NotZero(t, authorization.ID)
+
+	found, err := repo.GetAuthorizationForUpdate(ctx, int(authorization.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParse("-25.5"), found.Amount)
+	assert.Equal(t, constantPackage.STATUS_AUTHORIZED, found.Status)
+}
+
+func TestGetAuthorization_NotFound(t *testing.T) {
+	repo := NewAuthorizationRepository(logrus.New())
+	db := setupTestDB(t)
+
+	_, err := repo.GetAuthorization(context.Background(), 9999, db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+}
+
+func TestUpdateAuthorization(t *testing.T) {
+	repo := NewAuthorizationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	authorization := &entityDbV1Package.Authorization{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: time.Now().Add(time.Hour)}
+	db.Create(authorization)
+
+	transactionId := uint(12)
+	authorization.Status = constantPackage.STATUS_CAPTURED
+	authorization.CapturedAmount = utilMoneyV1.MustParse("-30")
+	authorization.TransactionId = &transactionId
+	assert.NoError(t, repo.UpdateAuthorization(ctx, authorization, db))
+
+	found, err := repo.GetAuthorization(ctx, int(authorization.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_CAPTURED, found.Status)
+	assert.Equal(t, utilMoneyV1.MustParse("-30"), found.CapturedAmount)
+	assert.Equal(t, uint(12), *found.TransactionId)
+}
+
+func TestGetExpiredAuthorizations_OpenAndDueOnly(t *testing.T) {
+	repo := NewAuthorizationRepository(logrus.New())
+	db := setupTestDB(t)
+	now := time.Now()
+
+	authorizations := []*entityDbV1Package.Authorization{
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-1"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(-time.Minute)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-2"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(-time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-3"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-4"), Status: constantPackage.STATUS_CAPTURED, ExpiresAt: now.Add(-time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-5"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(-2 * time.Hour)},
+	}
+	for _, authorization := range authorizations {
+		db.Create(authorization)
+	}
+
+	expired, err := repo.GetExpiredAuthorizations(context.Background(), now, 2, db)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 2)
+	assert.Equal(t, authorizations[4].ID, expired[0].ID)
+	assert.Equal(t, authorizations[1].ID, expired[1].ID)
+}
+
+func TestGetOpenAuthorizations_AccountAndOpenOnly(t *testing.T) {
+	repo := NewAuthorizationRepository(logrus.New())
+	db := setupTestDB(t)
+	now := time.Now()
+
+	authorizations := []*entityDbV1Package.Authorization{
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-1"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(time.Hour)},
+		{AccountId: 2, Amount: utilMoneyV1.MustParse("-2"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-3"), Status: constantPackage.STATUS_VOIDED, ExpiresAt: now.Add(time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-4"), Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: now.Add(-time.Hour)},
+	}
+	for _, authorization := range authorizations {
+		db.Create(authorization)
+	}
+
+	open, err := repo.GetOpenAuthorizations(context.Background(), 1, db)
+	assert.NoError(t, err)
+	assert.Len(t, open, 2)
+	assert.Equal(t, authorizations[0].ID, open[0].ID)
+	assert.Equal(t, authorizations[3].ID, open[1].ID)
+}
@@ -0,0 +1,31 @@
+package authorization_route_v1
+
+import (
+	controllerV1Package "anti-fraud/authorization-service/controllers/v1"
+
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/gorilla/mux"
+)
+
+type AuthorizationRoutes struct {
+	controller        controllerV1Package.IAuthorizationController
+	muxRouter         *mux.Router
+	middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler
+}
+
+// NewAuthorizationRoutes create and return an instance of AuthorizationRoutes.
+func NewAuthorizationRoutes(controller controllerV1Package.IAuthorizationController, router *mux.Router, middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler) *AuthorizationRoutes {
+	return &AuthorizationRoutes{controller: controller, muxRouter: router, middlewareHandler: middlewareHandler}
+
+}
+
+// Init register route for authorization-service.
+func (routes *AuthorizationRoutes) Init() {
+	handlerFunc := routes.middlewareHandler.MiddlewareHandlerFunc
+
+	routes.muxRouter.HandleFunc("/authorizations/v1", handlerFunc(routes.controller.CreateAuthorization)).Methods("POST")
+	routes.muxRouter.HandleFunc("/authorizations/v1/{authorizationId}", handlerFunc(routes.controller.GetAuthorizationDetails)).Methods("GET")
+	routes.muxRouter.HandleFunc("/authorizations/v1/{authorizationId}/capture", handlerFunc(routes.controller.CaptureAuthorization)).Methods("POST")
+	routes.muxRouter.HandleFunc("/authorizations/v1/{authorizationId}/void", handlerFunc(routes.controller.VoidAuthorization)).Methods("POST")
+}
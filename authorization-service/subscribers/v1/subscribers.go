@@ -0,0 +1,41 @@
+package authorization_subscribers_v1
+
+import (
+	coreV1Package "anti-fraud/authorization-service/core/v1"
+	accountConstantPackage "anti-fraud/constants/account"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AccountSubscriber reacts to account events on behalf of authorization-service.
+type AccountSubscriber struct {
+	coreV1 coreV1Package.IAuthorizationCore
+}
+
+// NewAccountSubscriber create new instance of AccountSubscriber.
+func NewAccountSubscriber(coreV1 coreV1Package.IAuthorizationCore) *AccountSubscriber {
+
+	return &AccountSubscriber{coreV1: coreV1}
+}
+
+// OnAccountStatusChanged voids the open holds of an account once it is closed, giving their credit
+// limit back. Delivered as DELIVERY_SYNC_REQUIRED in the txn closing the account: if the holds cannot
+// be released, the closure rolls back with them, so no hold of a closed account is left open.
+func (subscriber *AccountSubscriber) OnAccountStatusChanged(ctx context.Context, event eventBusV1Package.Event, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	statusChanged, ok := event.(*eventBusV1Package.AccountStatusChanged)
+	if !ok || statusChanged.ToStatus != accountConstantPackage.STATUS_CLOSED {
+		return nil
+	}
+
+	released, err := subscriber.coreV1.ReleaseAccountAuthorizations(ctx, statusChanged.AccountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while releasing holds of closed account %d: %s", statusChanged.AccountId, err.Error())
+		return err
+	}
+	logger.Infof("Released %d open authorization(s) of closed account %d.", released, statusChanged.AccountId)
+	return nil
+}
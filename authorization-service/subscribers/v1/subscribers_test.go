@@ -0,0 +1,195 @@
+package authorization_subscribers_v1
+
+import (
+	accountCoreV1Package "anti-fraud/account-service/core/v1"
+	accountEntityDbV1Package "anti-fraud/account-service/entity/db/v1"
+	accountRepoV1Package "anti-fraud/account-service/repository/v1"
+	coreV1Package "anti-fraud/authorization-service/core/v1"
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	repoV1Package "anti-fraud/authorization-service/repository/v1"
+	accountConstantPackage "anti-fraud/constants/account"
+	constantPackage "anti-fraud/constants/authorization"
+	eventConstantPackage "anti-fraud/constants/event"
+	accountClientV1Package "anti-fraud/mediator-service/account-service-client"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MockAuthorizationCore struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizationCore) CreateAuthorization(ctx context.Context, createPayload *entityCoreV1Package.CreateAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) CaptureAuthorization(ctx context.Context, authorizationId int, capturePayload *entityCoreV1Package.CaptureAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) VoidAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) ExpireAuthorizations(ctx context.Context, now time.Time, tx *gorm.DB) (int, error) {
+	return 0, nil
+}
+
+func (m *MockAuthorizationCore) ReleaseAccountAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) (int, error) {
+	args := m.Called(accountId, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestOnAccountStatusChanged_ClosedReleasesHolds(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	tx := &gorm.DB{}
+	mockCore.On("ReleaseAccountAuthorizations", 7, tx).Return(2, nil)
+	subscriber := NewAccountSubscriber(mockCore)
+
+	err := subscriber.OnAccountStatusChanged(context.Background(), &eventBusV1Package.AccountStatusChanged{
+		AccountId: 7, FromStatus: accountConstantPackage.STATUS_BLOCKED, ToStatus: accountConstantPackage.STATUS_CLOSED,
+	}, tx)
+
+	assert.NoError(t, err)
+	mockCore.AssertExpectations(t)
+}
+
+func TestOnAccountStatusChanged_OtherStatusIsIgnored(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	subscriber := NewAccountSubscriber(mockCore)
+
+	err := subscriber.OnAccountStatusChanged(context.Background(), &eventBusV1Package.AccountStatusChanged{
+		AccountId: 7, FromStatus: accountConstantPackage.STATUS_ACTIVE, ToStatus: accountConstantPackage.STATUS_BLOCKED,
+	}, &gorm.DB{})
+
+	assert.NoError(t, err)
+	mockCore.AssertNotCalled(t, "ReleaseAccountAuthorizations", mock.Anything, mock.Anything)
+}
+
+func TestOnAccountStatusChanged_ReleaseError(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	mockCore.On("ReleaseAccountAuthorizations", 7, mock.Anything).Return(0, errors.New("db error"))
+	subscriber := NewAccountSubscriber(mockCore)
+
+	err := subscriber.OnAccountStatusChanged(context.Background(), &eventBusV1Package.AccountStatusChanged{
+		AccountId: 7, ToStatus: accountConstantPackage.STATUS_CLOSED,
+	}, &gorm.DB{})
+
+	assert.EqualError(t, err, "db error")
+}
+
+// remoteModeSetup wires account-service and authorization-service as main does in remote mode: the
+// authorization core calls account-service over HTTP, except from the subscriber delivered in the txn
+// closing an account, which goes through the in-process account client.
+type remoteModeSetup struct {
+	db          *gorm.DB
+	accountCore *accountCoreV1Package.AccountCore
+	remoteCalls *atomic.Int64
+}
+
+func setupRemoteMode(t *testing.T) *remoteModeSetup {
+	logger := logrus.New()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "close.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&accountEntityDbV1Package.Account{}, &entityDbV1Package.Authorization{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	// account-service as reached in remote mode; every call is counted and times out.
+	remoteCalls := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	remoteAccountClient := accountClientV1Package.NewRemoteAccountClient(logger, utilConfig.RemoteServiceConfig{
+		BaseURL: server.URL, Timeout: time.Second, MaxRetries: -1, BreakerThreshold: 5, BreakerCooldown: time.Minute,
+	})
+
+	bus := eventBusV1Package.NewEventBus(db, logger, nil)
+	accountCore := accountCoreV1Package.NewAccountCore(accountRepoV1Package.NewAccountRepository(logger), logger, nil, bus)
+	inTxnAccountClient := accountClientV1Package.NewAccountClient(logger)
+	inTxnAccountClient.SetupCore(accountCore)
+	authorizationCore := coreV1Package.NewAuthorizationCore(repoV1Package.NewAuthorizationRepository(logger), logger, time.Hour, nil, remoteAccountClient, inTxnAccountClient, nil, nil)
+	bus.Subscribe(eventConstantPackage.ACCOUNT_STATUS_CHANGED, eventConstantPackage.DELIVERY_SYNC_REQUIRED, "authorization-service.release-closed-account-holds", NewAccountSubscriber(authorizationCore).OnAccountStatusChanged)
+
+	// An account with 30 of its 100 limit held by two open authorizations, and one captured.
+	db.Create(&accountEntityDbV1Package.Account{DocumentNumber: "12345678900", AvailableCreditLimit: utilMoneyV1.MustParse("70"), Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"})
+	for _, authorization := range []*entityDbV1Package.Authorization{
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-10"), Currency: "USD", Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: time.Now().Add(time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-20"), Currency: "USD", Status: constantPackage.STATUS_AUTHORIZED, ExpiresAt: time.Now().Add(time.Hour)},
+		{AccountId: 1, Amount: utilMoneyV1.MustParse("-5"), Currency: "USD", Status: constantPackage.STATUS_CAPTURED, ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		db.Create(authorization)
+	}
+	return &remoteModeSetup{db: db, accountCore: accountCore, remoteCalls: remoteCalls}
+}
+
+func (setup *remoteModeSetup) closeAccount() error {
+	ctx, tx := utilContextV1.Begin(context.Background(), setup.db)
+	_, err := setup.accountCore.ChangeAccountStatus(ctx, 1, accountConstantPackage.STATUS_CLOSED, tx)
+	if err != nil {
+		utilContextV1.Rollback(ctx, tx)
+		return err
+	}
+	return utilContextV1.Commit(ctx, tx)
+}
+
+func (setup *remoteModeSetup) statuses() []string {
+	var authorizations []*entityDbV1Package.Authorization
+	setup.db.Order("id").Find(&authorizations)
+	statuses := []string{}
+	for _, authorization := range authorizations {
+		statuses = append(statuses, authorization.Status)
+	}
+	return statuses
+}
+
+func TestCloseAccount_RemoteModeReleasesHoldsInTxn(t *testing.T) {
+	setup := setupRemoteMode(t)
+
+	assert.NoError(t, setup.closeAccount())
+
+	var account accountEntityDbV1Package.Account
+	setup.db.First(&account, 1)
+	assert.Equal(t, accountConstantPackage.STATUS_CLOSED, account.Status)
+	assert.Equal(t, "100", account.AvailableCreditLimit.String(), "the held limit is given back in the closing txn")
+	assert.Equal(t, []string{constantPackage.STATUS_VOIDED, constantPackage.STATUS_VOIDED, constantPackage.STATUS_CAPTURED}, setup.statuses())
+	assert.Zero(t, setup.remoteCalls.Load(), "no HTTP call back into the account the closing txn has locked")
+}
+
+func TestCloseAccount_RemoteModeReleaseFailureKeepsAccountOpen(t *testing.T) {
+	setup := setupRemoteMode(t)
+	// A limit still overdrawn once the first hold is given back rejects the release.
+	setup.db.Exec("UPDATE account SET available_credit_limit = ? WHERE id = 1", "-50")
+
+	assert.Error(t, setup.closeAccount())
+
+	var account accountEntityDbV1Package.Account
+	setup.db.First(&account, 1)
+	assert.Equal(t, accountConstantPackage.STATUS_ACTIVE, account.Status, "the closure rolls back with the holds it could not release")
+	assert.Equal(t, []string{constantPackage.STATUS_AUTHORIZED, constantPackage.STATUS_AUTHORIZED, constantPackage.STATUS_CAPTURED}, setup.statuses())
+}
@@ -0,0 +1,106 @@
+package authorization_sweeper_v1
+
+import (
+	coreV1Package "anti-fraud/authorization-service/core/v1"
+	constantPackage "anti-fraud/constants/authorization"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IAuthorizationSweeper defines methods interface for the background job releasing expired holds.
+type IAuthorizationSweeper interface {
+
+	// Start runs a sweep every interval in a background goroutine until Stop is called.
+	Start()
+
+	// Stop ends the background goroutine, waiting for a sweep in progress to finish.
+	Stop()
+
+	// Sweep expires one batch of holds in its own db txn and returns how many were expired.
+	Sweep() (int, error)
+}
+
+// AuthorizationSweeper implements IAuthorizationSweeper interface.
+type AuthorizationSweeper struct {
+	coreV1   coreV1Package.IAuthorizationCore
+	db       *gorm.DB
+	logger   *logrus.Logger
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewAuthorizationSweeper creates and returns new AuthorizationSweeper instance.
+func NewAuthorizationSweeper(coreV1 coreV1Package.IAuthorizationCore, db *gorm.DB, logger *logrus.Logger, interval time.Duration) *AuthorizationSweeper {
+	return &AuthorizationSweeper{coreV1: coreV1, db: db, logger: logger, interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+}
+
+// Start launches the sweep loop.
+//
+// Workflow:
+//  1. Wait for the next tick of the interval, or for Stop.
+//  2. Sweep batches until one comes back smaller than SWEEP_BATCH_SIZE, so a backlog
+//     is cleared within one tick. A failed sweep is logged and retried on the next tick.
+func (sweeper *AuthorizationSweeper) Start() {
+	sweeper.logger.Infof("Authorization sweeper started, interval: %s", sweeper.interval)
+	go func() {
+		defer close(sweeper.done)
+		ticker := time.NewTicker(sweeper.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sweeper.stop:
+				return
+			case <-ticker.C:
+				for {
+					expired, err := sweeper.Sweep()
+					if err != nil || expired < constantPackage.SWEEP_BATCH_SIZE {
+						break
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the sweep loop to end and waits for it. It must only be called after Start.
+func (sweeper *AuthorizationSweeper) Stop() {
+	sweeper.stopOnce.Do(func() { close(sweeper.stop) })
+	<-sweeper.done
+}
+
+// Sweep expires the holds due now.
+//
+// Workflow:
+//  1. Begin db txn.
+//  2. Expire one batch via the core layer, which gives the held credit limit back.
+//  3. Commit db txn.
+//
+// Returns:
+//   - The number of authorizations expired.
+//   - An encountered Error.
+func (sweeper *AuthorizationSweeper) Sweep() (int, error) {
+	logger := sweeper.logger.WithField("job", "authorization_sweeper")
+	ctx, tx := utilContextV1.Begin(utilContextV1.WithLogger(context.Background(), logger), sweeper.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	expired, err := sweeper.coreV1.ExpireAuthorizations(ctx, time.Now(), tx)
+	if err != nil {
+		logger.Errorf("Error expiring authorizations: %v", err)
+		return 0, err
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		return 0, err
+	}
+	if expired > 0 {
+		logger.Infof("Expired %d authorization holds", expired)
+	}
+	return expired, nil
+}
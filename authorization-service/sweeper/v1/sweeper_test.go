@@ -0,0 +1,92 @@
+package authorization_sweeper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/authorization-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/authorization-service/entity/db/v1"
+	constantPackage "anti-fraud/constants/authorization"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MockAuthorizationCore struct {
+	mock.Mock
+	sweeps atomic.Int32
+}
+
+func (m *MockAuthorizationCore) CreateAuthorization(ctx context.Context, createPayload *entityCoreV1Package.CreateAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) GetAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) CaptureAuthorization(ctx context.Context, authorizationId int, capturePayload *entityCoreV1Package.CaptureAuthorizationPayload, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) VoidAuthorization(ctx context.Context, authorizationId int, tx *gorm.DB) (*entityDbV1Package.Authorization, error) {
+	return nil, nil
+}
+
+func (m *MockAuthorizationCore) ExpireAuthorizations(ctx context.Context, now time.Time, tx *gorm.DB) (int, error) {
+	m.sweeps.Add(1)
+	args := m.Called(tx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuthorizationCore) ReleaseAccountAuthorizations(ctx context.Context, accountId int, tx *gorm.DB) (int, error) {
+	args := m.Called(accountId, tx)
+	return args.Int(0), args.Error(1)
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	return db
+}
+
+func TestSweep_ReturnsExpiredCount(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	mockCore.On("ExpireAuthorizations", mock.Anything).Return(3, nil)
+	sweeper := NewAuthorizationSweeper(mockCore, setupTestDB(t), logrus.New(), time.Minute)
+
+	expired, err := sweeper.Sweep()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+}
+
+func TestSweep_Error(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	mockCore.On("ExpireAuthorizations", mock.Anything).Return(0, errors.New("db error"))
+	sweeper := NewAuthorizationSweeper(mockCore, setupTestDB(t), logrus.New(), time.Minute)
+
+	_, err := sweeper.Sweep()
+	assert.EqualError(t, err, "db error")
+}
+
+func TestStart_SweepsFullBatchesUntilCaughtUpThenStops(t *testing.T) {
+	mockCore := new(MockAuthorizationCore)
+	mockCore.On("ExpireAuthorizations", mock.Anything).Return(constantPackage.SWEEP_BATCH_SIZE, nil).Twice()
+	mockCore.On("ExpireAuthorizations", mock.Anything).Return(0, nil)
+	sweeper := NewAuthorizationSweeper(mockCore, setupTestDB(t), logrus.New(), 10*time.Millisecond)
+
+	sweeper.Start()
+	assert.Eventually(t, func() bool { return mockCore.sweeps.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	sweeper.Stop()
+
+	sweeps := mockCore.sweeps.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, sweeps, mockCore.sweeps.Load())
+}
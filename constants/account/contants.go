@@ -2,4 +2,28 @@ package account_constants
 
 const (
 	TABLE_NAME = "account"
+
+	DEFAULT_AVAILABLE_CREDIT_LIMIT = 5000 // whole currency units
+
+	STATUS_ACTIVE  = "ACTIVE"
+	STATUS_BLOCKED = "BLOCKED"
+	STATUS_CLOSED  = "CLOSED"
+
+	// A tier selects the fees charged to the account, when an operation type has tier-specific fees.
+	TIER_STANDARD = "STANDARD"
+	TIER_GOLD     = "GOLD"
+	TIER_PLATINUM = "PLATINUM"
+
+	STATEMENT_DEFAULT_PERIOD_DAYS = 30
+	STATEMENT_MAX_PERIOD_DAYS     = 366
+	STATEMENT_DATE_LAYOUT         = "2006-01-02"
+
+	STATEMENT_FORMAT_JSON = "json"
+	STATEMENT_FORMAT_CSV  = "csv"
+	STATEMENT_CSV_MEDIA   = "text/csv"
+
+	STATEMENT_RECORD_OPENING_BALANCE = "OPENING_BALANCE"
+	STATEMENT_RECORD_TRANSACTION     = "TRANSACTION"
+	STATEMENT_RECORD_TOTAL           = "TOTAL"
+	STATEMENT_RECORD_CLOSING_BALANCE = "CLOSING_BALANCE"
 )
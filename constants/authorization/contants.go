@@ -0,0 +1,20 @@
+package authorization_constants
+
+import "time"
+
+const (
+	TABLE_NAME = "authorizations"
+
+	STATUS_AUTHORIZED = "AUTHORIZED"
+	STATUS_CAPTURED   = "CAPTURED"
+	STATUS_VOIDED     = "VOIDED"
+	STATUS_EXPIRED    = "EXPIRED"
+	STATUS_DECLINED   = "DECLINED"
+
+	// DEFAULT_HOLD_TTL applies when config.yml sets no authorization.hold_ttl.
+	DEFAULT_HOLD_TTL = 7 * 24 * time.Hour
+	// DEFAULT_SWEEP_INTERVAL applies when config.yml sets no authorization.sweep_interval.
+	DEFAULT_SWEEP_INTERVAL = time.Minute
+	// SWEEP_BATCH_SIZE bounds the number of holds expired in one sweeper db txn.
+	SWEEP_BATCH_SIZE = 100
+)
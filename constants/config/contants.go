@@ -0,0 +1,36 @@
+package config_constants
+
+import "time"
+
+const (
+	// DEFAULT_CONFIG_FILE is read, if it exists, when neither the -config flag nor CONFIG_FILE names a file.
+	DEFAULT_CONFIG_FILE = "config.yml"
+	// CONFIG_FILE_ENV names the env var holding the path of the YAML config file.
+	CONFIG_FILE_ENV = "CONFIG_FILE"
+
+	DEFAULT_DB_HOST     = "localhost"
+	DEFAULT_DB_USER     = "postgres"
+	DEFAULT_DB_NAME     = "anti_frauddb"
+	DEFAULT_DB_PORT     = 5432
+	DEFAULT_DB_SSLMODE  = "disable"
+	DEFAULT_DB_TIMEZONE = "UTC"
+
+	// DEFAULT_MAX_OPEN_CONNS bounds the connections of the db pool; 0 means unlimited.
+	DEFAULT_MAX_OPEN_CONNS = 25
+	// DEFAULT_MAX_IDLE_CONNS is the number of idle connections the db pool keeps.
+	DEFAULT_MAX_IDLE_CONNS = 5
+	// DEFAULT_CONN_MAX_LIFETIME closes a connection this long after it was opened; 0 keeps it forever.
+	DEFAULT_CONN_MAX_LIFETIME = 30 * time.Minute
+	// DEFAULT_CONN_MAX_IDLE_TIME closes a connection idle for this long; 0 keeps it forever.
+	DEFAULT_CONN_MAX_IDLE_TIME = 5 * time.Minute
+
+	DEFAULT_HTTP_ADDR        = ":8080"
+	DEFAULT_READ_TIMEOUT     = 15 * time.Second
+	DEFAULT_WRITE_TIMEOUT    = 30 * time.Second
+	DEFAULT_IDLE_TIMEOUT     = 60 * time.Second
+	DEFAULT_SHUTDOWN_TIMEOUT = 15 * time.Second
+
+	DEFAULT_LOG_LEVEL = "info"
+	LOG_FORMAT_JSON   = "json"
+	LOG_FORMAT_TEXT   = "text"
+)
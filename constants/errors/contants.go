@@ -0,0 +1,45 @@
+package error_constants
+
+// Machine-readable error codes returned in the JSON error body.
+const (
+	INVALID_REQUEST_BODY    = "INVALID_REQUEST_BODY"
+	VALIDATION_FAILED       = "VALIDATION_FAILED"
+	INVALID_PATH_PARAMETER  = "INVALID_PATH_PARAMETER"
+	INVALID_QUERY_PARAMETER = "INVALID_QUERY_PARAMETER"
+	INTERNAL_ERROR          = "INTERNAL_ERROR"
+	SERVICE_UNAVAILABLE     = "SERVICE_UNAVAILABLE"
+
+	ACCOUNT_NOT_FOUND         = "ACCOUNT_NOT_FOUND"
+	DUPLICATE_DOCUMENT_NUMBER = "DUPLICATE_DOCUMENT_NUMBER"
+	INSUFFICIENT_CREDIT_LIMIT = "INSUFFICIENT_CREDIT_LIMIT"
+	ACCOUNT_BLOCKED           = "ACCOUNT_BLOCKED"
+	ACCOUNT_CLOSED            = "ACCOUNT_CLOSED"
+	ACCOUNT_NOT_ACTIVE        = "ACCOUNT_NOT_ACTIVE"
+	INVALID_STATUS_TRANSITION = "INVALID_STATUS_TRANSITION"
+	CONCURRENT_MODIFICATION   = "CONCURRENT_MODIFICATION"
+
+	OPERATION_TYPE_NOT_FOUND   = "OPERATION_TYPE_NOT_FOUND"
+	TRANSACTION_NOT_FOUND      = "TRANSACTION_NOT_FOUND"
+	INVALID_INSTALLMENT_PLAN   = "INVALID_INSTALLMENT_PLAN"
+	TRANSACTION_NOT_REVERSIBLE = "TRANSACTION_NOT_REVERSIBLE"
+	REVERSAL_EXCEEDS_AMOUNT    = "REVERSAL_EXCEEDS_AMOUNT"
+	AMOUNT_TOO_SMALL           = "AMOUNT_TOO_SMALL"
+
+	DUPLICATE_OPERATION_DESCRIPTION = "DUPLICATE_OPERATION_DESCRIPTION"
+	DUPLICATE_OPERATION_KIND        = "DUPLICATE_OPERATION_KIND"
+	OPERATION_TYPE_IN_USE           = "OPERATION_TYPE_IN_USE"
+	OPERATION_TYPE_DEPRECATED       = "OPERATION_TYPE_DEPRECATED"
+	OPERATION_TYPE_NOT_EFFECTIVE    = "OPERATION_TYPE_NOT_EFFECTIVE"
+
+	AUTHORIZATION_NOT_FOUND    = "AUTHORIZATION_NOT_FOUND"
+	AUTHORIZATION_NOT_OPEN     = "AUTHORIZATION_NOT_OPEN"
+	AUTHORIZATION_EXPIRED      = "AUTHORIZATION_EXPIRED"
+	CAPTURE_EXCEEDS_AMOUNT     = "CAPTURE_EXCEEDS_AMOUNT"
+	OPERATION_NOT_AUTHORIZABLE = "OPERATION_NOT_AUTHORIZABLE"
+
+	FX_RATE_NOT_FOUND = "FX_RATE_NOT_FOUND"
+
+	INVALID_IDEMPOTENCY_KEY     = "INVALID_IDEMPOTENCY_KEY"
+	IDEMPOTENCY_KEY_REUSED      = "IDEMPOTENCY_KEY_REUSED"
+	IDEMPOTENCY_KEY_IN_PROGRESS = "IDEMPOTENCY_KEY_IN_PROGRESS"
+)
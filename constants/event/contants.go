@@ -0,0 +1,19 @@
+package event_constants
+
+const (
+	// ACCOUNT_CREATED is published by account-service once a new account is inserted.
+	ACCOUNT_CREATED = "account.created"
+	// ACCOUNT_STATUS_CHANGED is published by account-service once an account moves to a new status.
+	ACCOUNT_STATUS_CHANGED = "account.status_changed"
+	// TRANSACTION_CREATED is published by transaction-service once a transaction that moves the account is posted.
+	TRANSACTION_CREATED = "transaction.created"
+	// TRANSACTION_DECLINED is published by transaction-service once a transaction declined by the fraud rules is stored.
+	TRANSACTION_DECLINED = "transaction.declined"
+
+	// DELIVERY_SYNC runs a subscriber right away, inside the db txn of the publisher.
+	DELIVERY_SYNC = "sync"
+	// DELIVERY_SYNC_REQUIRED runs a subscriber like DELIVERY_SYNC, but its failure fails the publisher's txn.
+	DELIVERY_SYNC_REQUIRED = "sync_required"
+	// DELIVERY_ASYNC runs a subscriber in the background, in a db txn of its own, once the publisher's txn commits.
+	DELIVERY_ASYNC = "async"
+)
@@ -0,0 +1,18 @@
+package fraud_constants
+
+const (
+	VELOCITY_LIMIT_TABLE_NAME        = "velocity_limit"
+	ACCOUNT_ACTIVITY_LOCK_TABLE_NAME = "account_activity_lock"
+
+	DECISION_APPROVE = "APPROVE"
+	DECISION_REVIEW  = "REVIEW"
+	DECISION_DECLINE = "DECLINE"
+
+	REFERENCE_CURRENCY = "USD" // currency of the amount thresholds, and of velocity limits set without one
+
+	HIGH_AMOUNT_RULE_NAME         = "HIGH_AMOUNT"
+	HIGH_AMOUNT_REVIEW_THRESHOLD  = 5000  // whole units of REFERENCE_CURRENCY
+	HIGH_AMOUNT_DECLINE_THRESHOLD = 20000 // whole units of REFERENCE_CURRENCY
+
+	VELOCITY_LIMIT_RULE_NAME = "VELOCITY_LIMIT"
+)
@@ -0,0 +1,13 @@
+package fx_constants
+
+const (
+	TABLE_NAME = "fx_rate"
+
+	EFFECTIVE_DATE_LAYOUT = "2006-01-02"
+
+	CSV_MEDIA                 = "text/csv"
+	CSV_COLUMN_BASE_CURRENCY  = "base_currency"
+	CSV_COLUMN_QUOTE_CURRENCY = "quote_currency"
+	CSV_COLUMN_RATE           = "rate"
+	CSV_COLUMN_EFFECTIVE_FROM = "effective_from"
+)
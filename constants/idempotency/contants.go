@@ -0,0 +1,23 @@
+package idempotency_constants
+
+const (
+	TABLE_NAME = "idempotency_key"
+
+	// HEADER is the request header carrying the client-chosen idempotency key.
+	HEADER = "Idempotency-Key"
+	// REPLAYED_HEADER is set on responses replayed from a stored idempotency record.
+	REPLAYED_HEADER = "Idempotent-Replayed"
+	MAX_KEY_LENGTH  = 255
+	// COMPENSATION_SUFFIX derives the key of the update undoing a remote credit limit update from the key of that update.
+	COMPENSATION_SUFFIX = ":compensation"
+
+	SCOPE_CREATE_ACCOUNT        = "POST /accounts/v1"
+	SCOPE_CREATE_TRANSACTION    = "POST /transactions/v1"
+	SCOPE_REVERSE_TRANSACTION   = "POST /transactions/v1/{transactionId}/reversal"
+	SCOPE_CREATE_AUTHORIZATION  = "POST /authorizations/v1"
+	SCOPE_CAPTURE_AUTHORIZATION = "POST /authorizations/v1/{authorizationId}/capture"
+	SCOPE_VOID_AUTHORIZATION    = "POST /authorizations/v1/{authorizationId}/void"
+
+	// SCOPE_UPDATE_CREDIT_LIMIT is the internal endpoint of the remote mediator account client.
+	SCOPE_UPDATE_CREDIT_LIMIT = "POST /internal/accounts/v1/{accountId}/available-credit-limit"
+)
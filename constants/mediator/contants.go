@@ -0,0 +1,24 @@
+package mediator_constants
+
+import "time"
+
+const (
+	// MODE_LOCAL wires the mediator account and operation clients to the cores of this process.
+	MODE_LOCAL = "local"
+	// MODE_REMOTE makes the mediator account and operation clients call the internal endpoints of the owning service.
+	MODE_REMOTE = "remote"
+
+	// REQUEST_ID_HEADER carries the request id from a remote client to the owning service.
+	REQUEST_ID_HEADER = "X-Request-ID"
+
+	// DEFAULT_TIMEOUT applies when config.yml sets no timeout for a remote service.
+	DEFAULT_TIMEOUT = 5 * time.Second
+	// DEFAULT_MAX_RETRIES applies when config.yml sets no max_retries for a remote service.
+	DEFAULT_MAX_RETRIES = 2
+	// DEFAULT_RETRY_BACKOFF is the wait before the first retry, doubled for each following one.
+	DEFAULT_RETRY_BACKOFF = 100 * time.Millisecond
+	// DEFAULT_BREAKER_THRESHOLD is the number of consecutive failed calls that opens the circuit breaker.
+	DEFAULT_BREAKER_THRESHOLD = 5
+	// DEFAULT_BREAKER_COOLDOWN is how long an open circuit breaker rejects calls before letting one through.
+	DEFAULT_BREAKER_COOLDOWN = 30 * time.Second
+)
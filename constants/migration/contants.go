@@ -0,0 +1,19 @@
+package migration_constants
+
+const (
+	// TABLE_NAME is the schema-version table, one row per applied migration.
+	TABLE_NAME = "schema_version"
+	// LEGACY_TABLE_NAME is the table of the golang-migrate CLI, read once to adopt the version it reached.
+	LEGACY_TABLE_NAME = "schema_migrations"
+
+	// ADVISORY_LOCK_KEY is the postgres advisory lock held while migrations run, so two instances never run them at once.
+	ADVISORY_LOCK_KEY int64 = 0x616e74695f6d6967 // "anti_mig"
+
+	// SUBCOMMAND is the first argument of the binary that runs the migration commands instead of the server.
+	SUBCOMMAND = "migrate"
+
+	COMMAND_UP      = "up"
+	COMMAND_DOWN    = "down"
+	COMMAND_STATUS  = "status"
+	COMMAND_VERSION = "version"
+)
@@ -0,0 +1,15 @@
+package money_constants
+
+const (
+	// SCALE is the number of decimal places every amount is stored with.
+	SCALE = 4
+	// DEFAULT_CURRENCY is the currency of accounts opened without one.
+	DEFAULT_CURRENCY = "USD"
+	// DB_TYPE is the column type amounts are persisted as.
+	DB_TYPE = "NUMERIC(19,4)"
+
+	// RATE_SCALE is the number of decimal places every exchange rate is stored with.
+	RATE_SCALE = 10
+	// RATE_DB_TYPE is the column type exchange rates are persisted as.
+	RATE_DB_TYPE = "NUMERIC(18,10)"
+)
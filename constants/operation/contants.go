@@ -1,5 +1,28 @@
 package operation_constants
 
+import "time"
+
 const (
-	TABLE_NAME = "operation_type"
+	TABLE_NAME     = "operation_type"
+	FEE_TABLE_NAME = "operation_fee"
+
+	STATUS_ACTIVE     = "ACTIVE"
+	STATUS_DEPRECATED = "DEPRECATED"
+
+	// A kind selects the handler computing the transactions of an operation type.
+	KIND_STANDARD             = "STANDARD"
+	KIND_INSTALLMENT_PURCHASE = "INSTALLMENT_PURCHASE"
+	KIND_FEE                  = "FEE" // the single active operation type fees are posted with
+	KIND_MAX_LENGTH           = 32
+
+	// A coefficient gives the sign of the amounts of an operation type.
+	COEFFICIENT_DEBIT  = -1
+	COEFFICIENT_CREDIT = 1
+
+	DESCRIPTION_MAX_LENGTH = 255
+
+	// DEFAULT_CACHE_TTL applies when config.yml sets no operation_cache.ttl.
+	DEFAULT_CACHE_TTL = 5 * time.Minute
+	// DEFAULT_CACHE_NEGATIVE_TTL applies when config.yml sets no operation_cache.negative_ttl.
+	DEFAULT_CACHE_NEGATIVE_TTL = 30 * time.Second
 )
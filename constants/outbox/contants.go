@@ -0,0 +1,32 @@
+package outbox_constants
+
+import "time"
+
+const (
+	TABLE_NAME = "outbox_event"
+
+	// SINK_LOG writes each dispatched event to the application log.
+	SINK_LOG = "log"
+	// SINK_FILE appends each dispatched event as a JSON line to a file.
+	SINK_FILE = "file"
+	// SINK_WEBHOOK POSTs each dispatched event as JSON to an HTTP endpoint.
+	SINK_WEBHOOK = "webhook"
+
+	// EVENT_ID_HEADER carries the outbox id of a webhook delivery, so the receiver can drop duplicates.
+	EVENT_ID_HEADER = "X-Event-ID"
+
+	// DEFAULT_POLL_INTERVAL applies when config.yml sets no outbox.poll_interval.
+	DEFAULT_POLL_INTERVAL = time.Second
+	// DEFAULT_BATCH_SIZE applies when config.yml sets no outbox.batch_size.
+	DEFAULT_BATCH_SIZE = 100
+	// DEFAULT_MAX_ATTEMPTS applies when config.yml sets no outbox.max_attempts.
+	DEFAULT_MAX_ATTEMPTS = 20
+	// DEFAULT_BACKOFF_BASE applies when config.yml sets no outbox.backoff_base.
+	DEFAULT_BACKOFF_BASE = 5 * time.Second
+	// DEFAULT_BACKOFF_MAX applies when config.yml sets no outbox.backoff_max.
+	DEFAULT_BACKOFF_MAX = time.Hour
+	// DEFAULT_WEBHOOK_TIMEOUT applies when a webhook sink sets no timeout.
+	DEFAULT_WEBHOOK_TIMEOUT = 5 * time.Second
+	// MAX_ERROR_LENGTH bounds the delivery error kept on an outbox row.
+	MAX_ERROR_LENGTH = 1024
+)
@@ -1,5 +1,23 @@
 package transaction_constants
 
 const (
-	TABLE_NAME = "transactions"
+	TABLE_NAME             = "transactions"
+	INSTALLMENT_TABLE_NAME = "installment"
+
+	DEFAULT_PAGE_LIMIT = 20
+	MAX_PAGE_LIMIT     = 100
+
+	SORT_ORDER_ASC  = "asc"
+	SORT_ORDER_DESC = "desc"
+
+	STATUS_POSTED             = "POSTED"
+	STATUS_PARTIALLY_REVERSED = "PARTIALLY_REVERSED"
+	STATUS_REVERSED           = "REVERSED"
+
+	MIN_INSTALLMENT_COUNT = 2
+	MAX_INSTALLMENT_COUNT = 48
+
+	INSTALLMENT_STATUS_PENDING   = "PENDING"
+	INSTALLMENT_STATUS_CANCELLED = "CANCELLED"
+	INSTALLMENT_DATE_LAYOUT      = "2006-01-02"
 )
@@ -1,5 +1,3 @@
-DROP TABLE transactions;
-DROP TABLE operation_types;
-DROP TABLE account;
-DELETE FROM operation_type
-    WHERE description IN ('Normal Purchase', 'Purchase with installments', 'Withdrawal', 'Credit Voucher');
\ No newline at end of file
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS operation_type;
+DROP TABLE IF EXISTS account;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS fraud_rules,
+    DROP COLUMN IF EXISTS fraud_decision;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    ADD COLUMN fraud_decision VARCHAR(16) NOT NULL DEFAULT 'APPROVE',
+    ADD COLUMN fraud_rules TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_account_operation_created_at;
+DROP TABLE IF EXISTS velocity_limit;
@@ -0,0 +1,18 @@
+CREATE TABLE velocity_limit (
+    id SERIAL PRIMARY KEY,
+    operation_type_id INT NOT NULL REFERENCES operation_type (id),
+    window_seconds INT NOT NULL CHECK (window_seconds > 0),
+    max_count INT NOT NULL DEFAULT 0 CHECK (max_count >= 0),
+    max_amount FLOAT NOT NULL DEFAULT 0 CHECK (max_amount >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX idx_velocity_limit_operation_type_id ON velocity_limit (operation_type_id);
+CREATE INDEX idx_transactions_account_operation_created_at ON transactions (account_id, operation_type_id, created_at);
+
+-- Withdrawals: at most 5 per hour and 2000 per day.
+INSERT INTO velocity_limit (operation_type_id, window_seconds, max_count, max_amount)
+SELECT id, 3600, 5, 0 FROM operation_type WHERE description = 'Withdrawal';
+INSERT INTO velocity_limit (operation_type_id, window_seconds, max_count, max_amount)
+SELECT id, 86400, 0, 2000 FROM operation_type WHERE description = 'Withdrawal';
@@ -0,0 +1,2 @@
+ALTER TABLE account
+    DROP COLUMN IF EXISTS available_credit_limit;
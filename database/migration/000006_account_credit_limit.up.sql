@@ -0,0 +1,2 @@
+ALTER TABLE account
+    ADD COLUMN available_credit_limit FLOAT NOT NULL DEFAULT 5000 CHECK (available_credit_limit >= 0);
@@ -0,0 +1,4 @@
+ALTER TABLE transactions ADD COLUMN balance FLOAT;
+UPDATE transactions SET balance = amount;
+ALTER TABLE transactions ALTER COLUMN balance SET NOT NULL;
+CREATE INDEX idx_transactions_outstanding ON transactions (account_id, created_at) WHERE balance < 0;
@@ -0,0 +1,2 @@
+ALTER TABLE account ALTER COLUMN deleted_at SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE account DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+ALTER TABLE account
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'BLOCKED', 'CLOSED'));
+ALTER TABLE account ALTER COLUMN deleted_at DROP DEFAULT;
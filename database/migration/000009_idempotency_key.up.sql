@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_key (
+    id SERIAL PRIMARY KEY,
+    scope VARCHAR(64) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+-- Concurrent requests with the same key serialize on this index; the loser replays the winner's response.
+CREATE UNIQUE INDEX idx_idempotency_key_scope_key ON idempotency_key (scope, idempotency_key);
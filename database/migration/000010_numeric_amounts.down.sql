@@ -0,0 +1,4 @@
+ALTER TABLE velocity_limit ALTER COLUMN max_amount TYPE FLOAT;
+ALTER TABLE account ALTER COLUMN available_credit_limit TYPE FLOAT;
+ALTER TABLE transactions ALTER COLUMN balance TYPE FLOAT;
+ALTER TABLE transactions ALTER COLUMN amount TYPE FLOAT;
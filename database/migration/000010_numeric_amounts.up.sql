@@ -0,0 +1,4 @@
+ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(19,4) USING ROUND(amount::NUMERIC, 4);
+ALTER TABLE transactions ALTER COLUMN balance TYPE NUMERIC(19,4) USING ROUND(balance::NUMERIC, 4);
+ALTER TABLE account ALTER COLUMN available_credit_limit TYPE NUMERIC(19,4) USING ROUND(available_credit_limit::NUMERIC, 4);
+ALTER TABLE velocity_limit ALTER COLUMN max_amount TYPE NUMERIC(19,4) USING ROUND(max_amount::NUMERIC, 4);
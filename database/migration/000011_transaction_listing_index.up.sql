@@ -0,0 +1,3 @@
+-- Keyset pagination of GET /transactions/v1 orders by (created_at, id), optionally per account.
+CREATE INDEX idx_transactions_created_at_id ON transactions (created_at, id);
+CREATE INDEX idx_transactions_account_created_at_id ON transactions (account_id, created_at, id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS installment;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_count;
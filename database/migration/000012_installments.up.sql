@@ -0,0 +1,16 @@
+ALTER TABLE transactions ADD COLUMN installment_count INT NOT NULL DEFAULT 0;
+CREATE TABLE installment (
+    id SERIAL PRIMARY KEY,
+    transaction_id INT NOT NULL REFERENCES transactions (id),
+    account_id INT NOT NULL,
+    number INT NOT NULL,
+    amount NUMERIC(19,4) NOT NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    UNIQUE (transaction_id, number)
+);
+-- Upcoming installments of an account are listed by due date.
+CREATE INDEX idx_installment_account_due_date ON installment (account_id, due_date);
@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ADD COLUMN status VARCHAR(24) NOT NULL DEFAULT 'POSTED' CHECK (status IN ('POSTED', 'PARTIALLY_REVERSED', 'REVERSED'));
+ALTER TABLE transactions ADD COLUMN reversed_amount NUMERIC(19,4) NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN original_transaction_id INT REFERENCES transactions (id);
+CREATE INDEX idx_transactions_original_transaction_id ON transactions (original_transaction_id) WHERE original_transaction_id IS NOT NULL;
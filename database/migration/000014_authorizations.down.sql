@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_authorizations_open_expires_at;
+DROP TABLE IF EXISTS authorizations;
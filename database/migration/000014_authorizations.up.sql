@@ -0,0 +1,17 @@
+CREATE TABLE authorizations (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL,
+    operation_type_id INT NOT NULL,
+    amount NUMERIC(19,4) NOT NULL,
+    captured_amount NUMERIC(19,4) NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL DEFAULT 'AUTHORIZED' CHECK (status IN ('AUTHORIZED', 'CAPTURED', 'VOIDED', 'EXPIRED', 'DECLINED')),
+    fraud_decision VARCHAR(16) NOT NULL DEFAULT 'APPROVE',
+    fraud_rules TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    transaction_id INT REFERENCES transactions (id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+-- The sweeper scans open holds by expiry.
+CREATE INDEX idx_authorizations_open_expires_at ON authorizations (expires_at) WHERE status = 'AUTHORIZED';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_fx_rate_pair_effective_from;
+DROP TABLE IF EXISTS fx_rate;
+ALTER TABLE authorizations DROP COLUMN IF EXISTS currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS fx_rate;
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE account DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,21 @@
+-- Existing accounts and their history are in the former implicit currency.
+ALTER TABLE account ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE transactions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE transactions ADD COLUMN original_amount NUMERIC(19,4);
+UPDATE transactions SET original_amount = amount;
+ALTER TABLE transactions ALTER COLUMN original_amount SET NOT NULL;
+ALTER TABLE transactions ADD COLUMN original_currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE transactions ADD COLUMN fx_rate NUMERIC(18,10) NOT NULL DEFAULT 1;
+ALTER TABLE authorizations ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+CREATE TABLE fx_rate (
+    id SERIAL PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(18,10) NOT NULL CHECK (rate > 0),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+-- A pair has one rate per effective date; the latest one not after event time applies.
+CREATE UNIQUE INDEX idx_fx_rate_pair_effective_from ON fx_rate (base_currency, quote_currency, effective_from);
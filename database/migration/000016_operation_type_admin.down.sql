@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_operation_type_id;
+ALTER TABLE operation_type DROP CONSTRAINT IF EXISTS operation_type_coefficient_check;
+ALTER TABLE operation_type DROP COLUMN IF EXISTS status;
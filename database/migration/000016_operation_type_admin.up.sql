@@ -0,0 +1,7 @@
+ALTER TABLE operation_type
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'DEPRECATED'));
+-- Transaction amounts are scaled by the coefficient, so it may only give their sign.
+ALTER TABLE operation_type
+    ADD CONSTRAINT operation_type_coefficient_check CHECK (coefficient IN (-1, 1));
+-- Guards coefficient changes on operation types referenced by transactions.
+CREATE INDEX idx_transactions_operation_type_id ON transactions (operation_type_id);
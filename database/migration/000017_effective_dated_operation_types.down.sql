@@ -0,0 +1,6 @@
+ALTER TABLE authorizations DROP COLUMN IF EXISTS coefficient;
+ALTER TABLE transactions DROP COLUMN IF EXISTS coefficient;
+ALTER TABLE transactions DROP COLUMN IF EXISTS input_amount;
+ALTER TABLE operation_type DROP CONSTRAINT IF EXISTS operation_type_validity_check;
+ALTER TABLE operation_type DROP COLUMN IF EXISTS valid_to;
+ALTER TABLE operation_type DROP COLUMN IF EXISTS valid_from;
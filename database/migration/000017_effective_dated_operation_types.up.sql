@@ -0,0 +1,24 @@
+-- Existing operation types have always been in effect.
+ALTER TABLE operation_type ADD COLUMN valid_from TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE operation_type ALTER COLUMN valid_from SET DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE operation_type ADD COLUMN valid_to TIMESTAMP DEFAULT NULL;
+ALTER TABLE operation_type
+    ADD CONSTRAINT operation_type_validity_check CHECK (valid_to IS NULL OR valid_to > valid_from);
+-- Deprecated types stopped taking transactions when they were last updated.
+UPDATE operation_type SET valid_to = updated_at WHERE status = 'DEPRECATED' AND updated_at > valid_from;
+ALTER TABLE transactions ADD COLUMN input_amount NUMERIC(19,4);
+ALTER TABLE transactions ADD COLUMN coefficient INT;
+-- Coefficients of referenced types could not change, so the current one is the one that was applied;
+-- reversals apply its opposite. The sign the input was submitted with was not kept before, so
+-- the backfilled input is the unsigned original amount.
+UPDATE transactions t
+SET coefficient = CASE WHEN t.original_transaction_id IS NULL THEN o.coefficient ELSE -o.coefficient END,
+    input_amount = ABS(t.original_amount)
+FROM operation_type o
+WHERE o.id = t.operation_type_id;
+UPDATE transactions SET coefficient = SIGN(amount), input_amount = ABS(original_amount) WHERE coefficient IS NULL;
+ALTER TABLE transactions ALTER COLUMN input_amount SET NOT NULL;
+ALTER TABLE transactions ALTER COLUMN coefficient SET NOT NULL;
+-- Only debits can be held.
+ALTER TABLE authorizations ADD COLUMN coefficient INT NOT NULL DEFAULT -1;
+ALTER TABLE authorizations ALTER COLUMN coefficient DROP DEFAULT;
@@ -0,0 +1 @@
+ALTER TABLE operation_type DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,4 @@
+-- The kind selects the operation handler that validates and computes transactions of the type.
+ALTER TABLE operation_type
+    ADD COLUMN kind VARCHAR(32) NOT NULL DEFAULT 'STANDARD';
+UPDATE operation_type SET kind = 'INSTALLMENT_PURCHASE' WHERE description = 'Purchase with installments';
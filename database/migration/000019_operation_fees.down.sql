@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS operation_fee;
+DROP INDEX IF EXISTS idx_transactions_parent_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS parent_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS fee_code;
+DROP INDEX IF EXISTS idx_operation_type_active_fee;
+DELETE FROM operation_type WHERE kind = 'FEE'
+    AND NOT EXISTS (SELECT 1 FROM transactions WHERE transactions.operation_type_id = operation_type.id);
+ALTER TABLE account DROP COLUMN IF EXISTS tier;
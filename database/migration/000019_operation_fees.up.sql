@@ -0,0 +1,43 @@
+-- The tier selects the fees charged to the account when an operation type has tier-specific fees.
+ALTER TABLE account
+    ADD COLUMN tier VARCHAR(16) NOT NULL DEFAULT 'STANDARD' CHECK (tier IN ('STANDARD', 'GOLD', 'PLATINUM'));
+
+-- Fees are posted as debits of their own operation type, linked to the transaction they are charged on.
+-- The FEE kind identifies it; only one active operation type can have it.
+INSERT INTO operation_type (description, coefficient, kind)
+VALUES ('Fee', -1, 'FEE');
+CREATE UNIQUE INDEX idx_operation_type_active_fee ON operation_type (kind) WHERE kind = 'FEE' AND status = 'ACTIVE';
+
+ALTER TABLE transactions ADD COLUMN fee_code VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN parent_transaction_id INT DEFAULT NULL REFERENCES transactions (id);
+CREATE INDEX idx_transactions_parent_transaction_id ON transactions (parent_transaction_id);
+
+-- A fee is flat_amount plus rate times the absolute transaction amount, capped within [min_amount, max_amount],
+-- in the account currency. A fee with a tier replaces the fee of the same code without one for that tier.
+CREATE TABLE operation_fee (
+    id SERIAL PRIMARY KEY,
+    operation_type_id INT NOT NULL REFERENCES operation_type (id),
+    account_tier VARCHAR(16) DEFAULT NULL,
+    code VARCHAR(32) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    flat_amount NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (flat_amount >= 0),
+    rate NUMERIC(18,10) NOT NULL DEFAULT 0 CHECK (rate >= 0),
+    min_amount NUMERIC(19,4) NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    max_amount NUMERIC(19,4) DEFAULT NULL CHECK (max_amount IS NULL OR max_amount >= min_amount),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+CREATE UNIQUE INDEX idx_operation_fee_unique ON operation_fee (operation_type_id, currency, code, COALESCE(account_tier, ''))
+    WHERE deleted_at IS NULL;
+
+-- Withdrawals: 2.50 plus 1%, at most 10; 0.5% up to 5 for GOLD accounts and free for PLATINUM ones.
+INSERT INTO operation_fee (operation_type_id, account_tier, code, currency, flat_amount, rate, min_amount, max_amount)
+SELECT id, NULL, 'WITHDRAWAL_FEE', 'USD', 2.50, 0.01, 0, 10 FROM operation_type WHERE description = 'Withdrawal';
+INSERT INTO operation_fee (operation_type_id, account_tier, code, currency, flat_amount, rate, min_amount, max_amount)
+SELECT id, 'GOLD', 'WITHDRAWAL_FEE', 'USD', 0, 0.005, 0, 5 FROM operation_type WHERE description = 'Withdrawal';
+INSERT INTO operation_fee (operation_type_id, account_tier, code, currency, flat_amount, rate, min_amount, max_amount)
+SELECT id, 'PLATINUM', 'WITHDRAWAL_FEE', 'USD', 0, 0, 0, NULL FROM operation_type WHERE description = 'Withdrawal';
+-- Purchases with installments: 2% interest, at least 1.
+INSERT INTO operation_fee (operation_type_id, account_tier, code, currency, flat_amount, rate, min_amount, max_amount)
+SELECT id, NULL, 'INSTALLMENT_INTEREST', 'USD', 0, 0.02, 1, NULL FROM operation_type WHERE description = 'Purchase with installments';
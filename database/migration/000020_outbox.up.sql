@@ -0,0 +1,15 @@
+-- Domain events written in the same txn as the rows they describe; the relay delivers them to the
+-- configured sinks and stamps dispatched_at once every sink accepted them.
+CREATE TABLE outbox_event (
+    id SERIAL PRIMARY KEY,
+    event_name VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    dispatched_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP DEFAULT NULL
+);
+-- The relay polls pending events oldest first.
+CREATE INDEX idx_outbox_event_pending ON outbox_event (id) WHERE dispatched_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE velocity_limit DROP COLUMN IF EXISTS currency;
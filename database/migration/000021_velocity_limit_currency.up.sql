@@ -0,0 +1,2 @@
+-- Velocity limit amounts are stated in a currency; existing limits were set in USD.
+ALTER TABLE velocity_limit ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_outbox_event_pending;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS dead_at;
+ALTER TABLE outbox_event DROP COLUMN IF EXISTS next_attempt_at;
+CREATE INDEX idx_outbox_event_pending ON outbox_event (id) WHERE dispatched_at IS NULL;
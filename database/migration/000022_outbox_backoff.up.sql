@@ -0,0 +1,7 @@
+-- A failed event waits until next_attempt_at before the relay delivers it again, and is marked dead
+-- once it failed outbox.max_attempts times.
+ALTER TABLE outbox_event ADD COLUMN next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL;
+ALTER TABLE outbox_event ADD COLUMN dead_at TIMESTAMP DEFAULT NULL;
+-- The relay polls pending events that are due, oldest first.
+DROP INDEX IF EXISTS idx_outbox_event_pending;
+CREATE INDEX idx_outbox_event_pending ON outbox_event (next_attempt_at, id) WHERE dispatched_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,3 @@
+-- Fails while a deprecated type shares its description with another type.
+DROP INDEX IF EXISTS idx_operation_type_active_description;
+ALTER TABLE operation_type ADD CONSTRAINT operation_type_description_key UNIQUE (description);
@@ -0,0 +1,4 @@
+-- A description is unique among active operation types only: once a type's validity has ended, a successor
+-- with another coefficient can take its description over, and the old type is deprecated.
+ALTER TABLE operation_type DROP CONSTRAINT IF EXISTS operation_type_description_key;
+CREATE UNIQUE INDEX idx_operation_type_active_description ON operation_type (description) WHERE status = 'ACTIVE';
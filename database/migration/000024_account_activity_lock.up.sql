@@ -0,0 +1,5 @@
+-- One row per account, locked (SELECT ... FOR UPDATE) while velocity limits are evaluated, so concurrent
+-- transactions of an account are checked one after the other against the activity committed before them.
+CREATE TABLE account_activity_lock (
+    account_id INT PRIMARY KEY REFERENCES account (id)
+);
@@ -0,0 +1,8 @@
+package database_migration
+
+import "embed"
+
+// FS holds the SQL migrations, named <version>_<name>.up.sql and <version>_<name>.down.sql.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,90 @@
+package fraud_core_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	rulesV1Package "anti-fraud/fraud-service/rules/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IFraudCore defines the methods interface for the fraud rule engine.
+type IFraudCore interface {
+
+	// RegisterRule plugs a new rule into the engine.
+	RegisterRule(rule rulesV1Package.IFraudRule)
+
+	// EvaluateTransaction runs every registered rule and aggregates their outcome into a single decision.
+	EvaluateTransaction(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.FraudDecision, error)
+}
+
+// FraudCore implements IFraudCore interface.
+type FraudCore struct {
+	rules  []rulesV1Package.IFraudRule
+	logger *logrus.Logger
+}
+
+// NewFraudCore creates and return new FraudCore instance.
+func NewFraudCore(logger *logrus.Logger) *FraudCore {
+	return &FraudCore{logger: logger}
+}
+
+// RegisterRule appends a rule to the engine. Rules are evaluated in registration order.
+func (core *FraudCore) RegisterRule(rule rulesV1Package.IFraudRule) {
+	core.rules = append(core.rules, rule)
+}
+
+// EvaluateTransaction evaluates all registered rules against the payload.
+//
+// Workflow:
+//  1. Evaluate every rule in registration order, collecting the ones that fired.
+//  2. If a rule returns an error, abort and return the error.
+//  3. The final decision is the most severe decision among fired rules (DECLINE > REVIEW > APPROVE).
+//
+// Parameters:
+//   - payload: transaction attributes to evaluate.
+//   - tx:      db txn.
+//
+// Returns:
+//   - *FraudDecision: the aggregated decision with the list of fired rules.
+//   - error:          an encountered Error.
+func (core *FraudCore) EvaluateTransaction(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.FraudDecision, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("EvaluateTransaction method called in fraud core layer.")
+
+	decision := &entityCoreV1Package.FraudDecision{
+		Decision:   constantPackage.DECISION_APPROVE,
+		FiredRules: []*entityCoreV1Package.RuleResult{},
+	}
+	for _, rule := range core.rules {
+		result, err := rule.Evaluate(ctx, payload, tx)
+		if err != nil {
+			logger.Errorf("Error occured while evaluating fraud rule %s: %s", rule.Name(), err.Error())
+			return decision, err
+		}
+		if result == nil {
+			continue
+		}
+		logger.Infof("Fraud rule %s fired with decision %s", result.RuleName, result.Decision)
+		decision.FiredRules = append(decision.FiredRules, result)
+		if severity(result.Decision) > severity(decision.Decision) {
+			decision.Decision = result.Decision
+		}
+	}
+	return decision, nil
+}
+
+// severity ranks decisions so the engine can keep the most restrictive one.
+func severity(decision string) int {
+	switch decision {
+	case constantPackage.DECISION_DECLINE:
+		return 2
+	case constantPackage.DECISION_REVIEW:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,94 @@
+package fraud_core_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockFraudRule struct {
+	mock.Mock
+	name string
+}
+
+func (m *MockFraudRule) Name() string {
+	return m.name
+}
+
+func (m *MockFraudRule) Evaluate(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.RuleResult, error) {
+	args := m.Called(payload, tx)
+	result, _ := args.Get(0).(*entityCoreV1Package.RuleResult)
+	return result, args.Error(1)
+}
+
+func setupTestCore() *FraudCore {
+	return NewFraudCore(logrus.New())
+}
+
+//---------------------------//
+// Tests
+//---------------------------//
+
+func TestEvaluateTransaction_NoRules(t *testing.T) {
+	core := setupTestCore()
+
+	decision, err := core.EvaluateTransaction(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.DECISION_APPROVE, decision.Decision)
+	assert.Empty(t, decision.FiredRules)
+}
+
+func TestEvaluateTransaction_MostSevereDecisionWins(t *testing.T) {
+	core := setupTestCore()
+	reviewRule := &MockFraudRule{name: "REVIEW_RULE"}
+	declineRule := &MockFraudRule{name: "DECLINE_RULE"}
+	silentRule := &MockFraudRule{name: "SILENT_RULE"}
+	core.RegisterRule(reviewRule)
+	core.RegisterRule(declineRule)
+	core.RegisterRule(silentRule)
+
+	reviewRule.On("Evaluate", mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.RuleResult{RuleName: "REVIEW_RULE", Decision: constantPackage.DECISION_REVIEW}, nil)
+	declineRule.On("Evaluate", mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.RuleResult{RuleName: "DECLINE_RULE", Decision: constantPackage.DECISION_DECLINE}, nil)
+	silentRule.On("Evaluate", mock.Anything, mock.Anything).
+		Return(nil, nil)
+
+	decision, err := core.EvaluateTransaction(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, decision.Decision)
+	assert.Len(t, decision.FiredRules, 2)
+	assert.Equal(t, "REVIEW_RULE", decision.FiredRules[0].RuleName)
+	assert.Equal(t, "DECLINE_RULE", decision.FiredRules[1].RuleName)
+
+	reviewRule.AssertExpectations(t)
+	declineRule.AssertExpectations(t)
+	silentRule.AssertExpectations(t)
+}
+
+func TestEvaluateTransaction_RuleError(t *testing.T) {
+	core := setupTestCore()
+	failingRule := &MockFraudRule{name: "FAILING_RULE"}
+	nextRule := &MockFraudRule{name: "NEXT_RULE"}
+	core.RegisterRule(failingRule)
+	core.RegisterRule(nextRule)
+
+	failingRule.On("Evaluate", mock.Anything, mock.Anything).
+		Return(nil, errors.New("rule error"))
+
+	_, err := core.EvaluateTransaction(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rule error")
+
+	failingRule.AssertExpectations(t)
+	nextRule.AssertNotCalled(t, "Evaluate", mock.Anything, mock.Anything)
+}
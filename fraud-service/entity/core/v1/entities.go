@@ -0,0 +1,30 @@
+package fraud_entity_core_v1
+
+import utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+// FraudCheckPayload carries the transaction attributes evaluated by fraud rules.
+type FraudCheckPayload struct {
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	Currency        string             `json:"currency"` // currency of Amount
+}
+
+// RuleResult describes the outcome of a single rule that fired.
+type RuleResult struct {
+	RuleName string `json:"rule_name"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+}
+
+// FraudDecision is the aggregated outcome of the rule engine.
+type FraudDecision struct {
+	Decision   string        `json:"decision"`
+	FiredRules []*RuleResult `json:"fired_rules"`
+}
+
+// AccountActivity aggregates an account's non-declined transactions within a window.
+type AccountActivity struct {
+	Count       int64              `json:"count"`
+	TotalAmount utilMoneyV1.Amount `json:"total_amount"`
+}
@@ -0,0 +1,34 @@
+package fraud_entity_db_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"gorm.io/gorm"
+)
+
+// VelocityLimit caps how many transactions, and how much in total, an account may post
+// for an operation type within a sliding window. A zero MaxCount or MaxAmount means unlimited.
+// MaxAmount is in Currency; the account's activity is converted to it before comparing.
+type VelocityLimit struct {
+	gorm.Model
+	OperationTypeId int                `json:"operation_type_id"`
+	WindowSeconds   int                `json:"window_seconds"`
+	MaxCount        int                `json:"max_count"`
+	MaxAmount       utilMoneyV1.Amount `json:"max_amount"`
+	Currency        string             `json:"currency"`
+}
+
+func (VelocityLimit) TableName() string {
+	return constantPackage.VELOCITY_LIMIT_TABLE_NAME
+}
+
+// AccountActivityLock is the row locked while an account's velocity limits are evaluated.
+// It is created on the account's first evaluation and never changes.
+type AccountActivityLock struct {
+	AccountId int `gorm:"primaryKey;autoIncrement:false" json:"account_id"`
+}
+
+func (AccountActivityLock) TableName() string {
+	return constantPackage.ACCOUNT_ACTIVITY_LOCK_TABLE_NAME
+}
@@ -0,0 +1,45 @@
+package fraud_manager_v1
+
+import (
+	eventConstantPackage "anti-fraud/constants/event"
+	constantPackage "anti-fraud/constants/fraud"
+	coreV1Package "anti-fraud/fraud-service/core/v1"
+	repoV1Package "anti-fraud/fraud-service/repository/v1"
+	rulesV1Package "anti-fraud/fraud-service/rules/v1"
+	subscribersV1Package "anti-fraud/fraud-service/subscribers/v1"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	clientV1Package "anti-fraud/mediator-service/fraud-service-client"
+	fxClientV1Package "anti-fraud/mediator-service/fx-service-client"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FraudManager wires all components required to run fraud-service.
+type FraudManager struct {
+	logger   *logrus.Logger
+	eventBus eventBusV1Package.IEventBus
+	fxClient fxClientV1Package.IFxClient
+	coreV1   coreV1Package.IFraudCore
+}
+
+// NewFraudManager create and return new instance of FraudManager.
+func NewFraudManager(logger *logrus.Logger, eventBus eventBusV1Package.IEventBus, fxClient fxClientV1Package.IFxClient) *FraudManager {
+
+	return &FraudManager{logger: logger, eventBus: eventBus, fxClient: fxClient}
+}
+
+// Init instantiate and wire all components, register default rules and event subscribers for fraud-service.
+func (mw *FraudManager) Init() {
+	repoV1 := repoV1Package.NewFraudRepository(mw.logger)
+	mw.coreV1 = coreV1Package.NewFraudCore(mw.logger)
+	mw.coreV1.RegisterRule(rulesV1Package.NewHighAmountRule(mw.fxClient, constantPackage.REFERENCE_CURRENCY, utilMoneyV1.FromInt(constantPackage.HIGH_AMOUNT_REVIEW_THRESHOLD), utilMoneyV1.FromInt(constantPackage.HIGH_AMOUNT_DECLINE_THRESHOLD)))
+	mw.coreV1.RegisterRule(rulesV1Package.NewVelocityRule(repoV1, mw.fxClient))
+	transactionSubscriber := subscribersV1Package.NewTransactionSubscriber()
+	mw.eventBus.Subscribe(eventConstantPackage.TRANSACTION_DECLINED, eventConstantPackage.DELIVERY_ASYNC, "fraud-service.decline-alert", transactionSubscriber.OnTransactionDeclined)
+}
+
+// ConfigureClient configure core instance of fraud service in fraud-client.
+func (mw *FraudManager) ConfigureClient(client clientV1Package.IFraudClient) {
+	client.SetupCore(mw.coreV1)
+}
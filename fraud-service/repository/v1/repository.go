@@ -0,0 +1,125 @@
+package fraud_repo_v1
+
+import (
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	transactionConstantPackage "anti-fraud/constants/transaction"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fraud-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFraudRepository defines methods interface for fraud-related db operations.
+type IFraudRepository interface {
+
+	// GetVelocityLimits retrieves every velocity limit configured for an operation type.
+	GetVelocityLimits(ctx context.Context, operationTypeId int, tx *gorm.DB) ([]*entityDbV1Package.VelocityLimit, error)
+
+	// LockAccountActivity locks the account's activity until the txn ends, serializing concurrent velocity checks.
+	LockAccountActivity(ctx context.Context, accountId int, tx *gorm.DB) error
+
+	// GetAccountActivity aggregates the account's transactions of an operation type created since the given time.
+	GetAccountActivity(ctx context.Context, accountId int, operationTypeId int, since time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountActivity, error)
+}
+
+// FraudRepository implements IFraudRepository interface.
+type FraudRepository struct {
+	logger *logrus.Logger
+}
+
+// NewFraudRepository creates and return new instance of FraudRepository.
+func NewFraudRepository(logger *logrus.Logger) *FraudRepository {
+	return &FraudRepository{logger: logger}
+}
+
+// GetVelocityLimits lists the velocity limits of an operation type.
+//
+// Parameters:
+//   - operationTypeId: operation type the limits apply to.
+//   - tx:              db txn.
+//
+// Returns:
+//   - Velocity limits (empty when none is configured).
+//   - An encountered Error.
+func (repo *FraudRepository) GetVelocityLimits(ctx context.Context, operationTypeId int, tx *gorm.DB) ([]*entityDbV1Package.VelocityLimit, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetVelocityLimits method called in fraud repo layer.")
+	limits := []*entityDbV1Package.VelocityLimit{}
+	result := tx.WithContext(ctx).Table(fraudConstantPackage.VELOCITY_LIMIT_TABLE_NAME).
+		Where("operation_type_id = ?", operationTypeId).Find(&limits)
+	if result.Error != nil {
+		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
+	}
+	return limits, result.Error
+}
+
+// LockAccountActivity locks the account's row of the account activity lock table (SELECT ... FOR UPDATE).
+// The account row itself is not locked: in remote mode its credit limit is updated by another txn,
+// which would wait on this one forever.
+//
+// Steps:
+//  1. Create the account's lock row on its first evaluation; a concurrent insert of it waits for the first one.
+//  2. Lock the row, so a concurrent evaluation of the account waits until this txn commits or rolls back
+//     and then reads the activity it added.
+//
+// Parameters:
+//   - accountId: account whose activity is evaluated.
+//   - tx:        db txn holding the lock.
+//
+// Returns:
+//   - An encountered Error.
+func (repo *FraudRepository) LockAccountActivity(ctx context.Context, accountId int, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("LockAccountActivity method called in fraud repo layer.")
+	lock := &entityDbV1Package.AccountActivityLock{AccountId: accountId}
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+	if result.Error != nil {
+		logger.Errorf("Error occured while creating account activity lock: %s", result.Error.Error())
+		return result.Error
+	}
+	result = tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ?", accountId).Take(lock)
+	if result.Error != nil {
+		logger.Errorf("Error occured while locking account activity: %s", result.Error.Error())
+	}
+	return result.Error
+}
+
+// GetAccountActivity counts and sums (by absolute amount) the account's transactions.
+//
+// Steps:
+//  1. Filter the transactions table by account, operation type and created_at >= since.
+//  2. Ignore declined transactions, they never moved money, and reversals, they do not add to the activity.
+//  3. Return the count and the total absolute amount. A reversed transaction still counts,
+//     but only with the amount left once its reversals are taken off.
+//
+// Parameters:
+//   - accountId:       account to aggregate.
+//   - operationTypeId: operation type to aggregate.
+//   - since:           start of the sliding window.
+//   - tx:              db txn.
+//
+// Returns:
+//   - Aggregated account activity.
+//   - An encountered Error.
+func (repo *FraudRepository) GetAccountActivity(ctx context.Context, accountId int, operationTypeId int, since time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountActivity, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAccountActivity method called in fraud repo layer.")
+	var activity entityCoreV1Package.AccountActivity
+	result := tx.WithContext(ctx).Table(transactionConstantPackage.TABLE_NAME).
+		Select("COUNT(*) AS count, COALESCE(SUM(ABS(amount) - reversed_amount), 0) AS total_amount").
+		Where("account_id = ? AND operation_type_id = ? AND created_at >= ?", accountId, operationTypeId, since).
+		Where("fraud_decision <> ?", fraudConstantPackage.DECISION_DECLINE).
+		Where("original_transaction_id IS NULL").
+		Where("deleted_at IS NULL").
+		Scan(&activity)
+	if result.Error != nil {
+		logger.Errorf("Error occured while aggregating account activity: %s", result.Error.Error())
+	}
+	return &activity, result.Error
+}
@@ -0,0 +1,115 @@
+package fraud_repo_v1
+
+import (
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	entityDbV1Package "anti-fraud/fraud-service/entity/db/v1"
+	transactionEntityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&entityDbV1Package.VelocityLimit{}, &entityDbV1Package.AccountActivityLock{}, &transactionEntityDbV1Package.Transaction{})
+	if err != nil {
+		t.Fatalf("failed to migrate schema: %v", err)
+	}
+	return db
+}
+
+func TestGetVelocityLimits_Success(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+
+	db.Create(&entityDbV1Package.VelocityLimit{OperationTypeId: 3, WindowSeconds: 3600, MaxCount: 5})
+	db.Create(&entityDbV1Package.VelocityLimit{OperationTypeId: 3, WindowSeconds: 86400, MaxAmount: utilMoneyV1.MustParse("2000")})
+	db.Create(&entityDbV1Package.VelocityLimit{OperationTypeId: 1, WindowSeconds: 60, MaxCount: 1})
+
+	limits, err := repo.GetVelocityLimits(context.Background(), 3, db)
+	assert.NoError(t, err)
+	assert.Len(t, limits, 2)
+}
+
+func TestGetVelocityLimits_None(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+
+	limits, err := repo.GetVelocityLimits(context.Background(), 3, db)
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+}
+
+func TestGetAccountActivity_Success(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+	now := time.Now()
+	reversedId := uint(1)
+
+	transactions := []*transactionEntityDbV1Package.Transaction{
+		{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-100"), ReversedAmount: utilMoneyV1.MustParse("25"), FraudDecision: fraudConstantPackage.DECISION_APPROVE},
+		{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-50.5"), FraudDecision: fraudConstantPackage.DECISION_REVIEW},
+		{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-999"), FraudDecision: fraudConstantPackage.DECISION_DECLINE},
+		{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-10"), FraudDecision: fraudConstantPackage.DECISION_APPROVE},
+		{AccountId: 2, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10"), FraudDecision: fraudConstantPackage.DECISION_APPROVE},
+		{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("25"), FraudDecision: fraudConstantPackage.DECISION_APPROVE, OriginalTransactionId: &reversedId},
+	}
+	for _, transaction := range transactions {
+		db.Create(transaction)
+	}
+	old := &transactionEntityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-70"), FraudDecision: fraudConstantPackage.DECISION_APPROVE}
+	db.Create(old)
+	db.Model(old).Update("created_at", now.Add(-2*time.Hour))
+
+	activity, err := repo.GetAccountActivity(context.Background(), 1, 3, now.Add(-time.Hour), db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), activity.Count)
+	assert.Equal(t, utilMoneyV1.MustParse("125.5"), activity.TotalAmount)
+}
+
+func TestGetAccountActivity_DBError(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	if err == nil {
+		sqlDB.Close()
+	}
+
+	_, err = repo.GetAccountActivity(context.Background(), 1, 3, time.Now(), db)
+	assert.Error(t, err)
+}
+
+func TestLockAccountActivity_CreatesTheLockOnce(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+
+	assert.NoError(t, repo.LockAccountActivity(context.Background(), 1, db))
+	assert.NoError(t, repo.LockAccountActivity(context.Background(), 1, db))
+	assert.NoError(t, repo.LockAccountActivity(context.Background(), 2, db))
+
+	var count int64
+	db.Model(&entityDbV1Package.AccountActivityLock{}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestLockAccountActivity_DBError(t *testing.T) {
+	repo := NewFraudRepository(logrus.New())
+	db := setupTestDB(t)
+	sqlDB, err := db.DB()
+	if err == nil {
+		sqlDB.Close()
+	}
+
+	err = repo.LockAccountActivity(context.Background(), 1, db)
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package fraud_rules_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// convertAmount converts amount from currency from into currency to at the latest fx rate.
+//
+// Steps:
+//  1. An empty currency is taken as the reference currency; equal currencies need no conversion.
+//  2. Otherwise fetch the rate through the fx client and convert the amount with it.
+//  3. When no rate is known yet, return a nil Amount and no error, so the rule can ask for a review
+//     rather than compare amounts of different currencies.
+//
+// Returns:
+//   - *Amount: the converted amount, or nil if no rate is known.
+//   - error:   an encountered Error while fetching the rate or converting.
+func convertAmount(ctx context.Context, fxClient fxClientPackageV1.IFxClient, amount utilMoneyV1.Amount, from string, to string, tx *gorm.DB) (*utilMoneyV1.Amount, error) {
+	logger := utilContextV1.Logger(ctx)
+	from, to = currencyOrReference(from), currencyOrReference(to)
+	if from == to {
+		return &amount, nil
+	}
+	rate, err := fxClient.GetRate(ctx, from, to, time.Now(), tx)
+	if err != nil {
+		if utilErrorsV1.IsNotFound(err) {
+			logger.Warnf("No fx rate from %s to %s, fraud amounts cannot be compared", from, to)
+			return nil, nil
+		}
+		logger.Errorf("Error occured while fetching fx rate: %s", err.Error())
+		return nil, err
+	}
+	converted, err := utilMoneyV1.Convert(amount, rate, to)
+	if err != nil {
+		logger.Errorf("Error occured while converting amount: %s", err.Error())
+		return nil, err
+	}
+	return &converted, nil
+}
+
+// currencyOrReference returns currency, or the reference currency if it is empty.
+func currencyOrReference(currency string) string {
+	if currency == "" {
+		return constantPackage.REFERENCE_CURRENCY
+	}
+	return currency
+}
+
+// missingRateResult is the REVIEW result of a rule that could not convert an amount to compare it.
+func missingRateResult(ruleName string, from string, to string) *entityCoreV1Package.RuleResult {
+	return &entityCoreV1Package.RuleResult{
+		RuleName: ruleName,
+		Decision: constantPackage.DECISION_REVIEW,
+		Reason:   fmt.Sprintf("no fx rate from %s to %s", currencyOrReference(from), currencyOrReference(to)),
+	}
+}
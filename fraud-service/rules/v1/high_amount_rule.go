@@ -0,0 +1,65 @@
+package fraud_rules_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// HighAmountRule flags transactions whose absolute amount crosses the configured thresholds,
+// stated in a single currency the amount is converted to.
+type HighAmountRule struct {
+	fxClient         fxClientPackageV1.IFxClient
+	currency         string
+	reviewThreshold  utilMoneyV1.Amount
+	declineThreshold utilMoneyV1.Amount
+}
+
+// NewHighAmountRule creates and return new HighAmountRule instance, with thresholds in currency.
+func NewHighAmountRule(fxClient fxClientPackageV1.IFxClient, currency string, reviewThreshold utilMoneyV1.Amount, declineThreshold utilMoneyV1.Amount) *HighAmountRule {
+	return &HighAmountRule{fxClient: fxClient, currency: currency, reviewThreshold: reviewThreshold, declineThreshold: declineThreshold}
+}
+
+// Name returns the rule name.
+func (rule *HighAmountRule) Name() string {
+	return constantPackage.HIGH_AMOUNT_RULE_NAME
+}
+
+// Evaluate compares the absolute transaction amount with the rule thresholds.
+//
+// Steps:
+//  1. Convert the amount from the payload currency to the currency of the thresholds; fire with
+//     a REVIEW decision if no fx rate is known to do so.
+//  2. If the amount reaches the decline threshold, fire with a DECLINE decision.
+//  3. Else if the amount reaches the review threshold, fire with a REVIEW decision.
+//  4. Otherwise the rule does not fire and nil is returned.
+func (rule *HighAmountRule) Evaluate(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.RuleResult, error) {
+	converted, err := convertAmount(ctx, rule.fxClient, payload.Amount.Abs(), payload.Currency, rule.currency, tx)
+	if err != nil {
+		return nil, err
+	}
+	if converted == nil {
+		return missingRateResult(rule.Name(), payload.Currency, rule.currency), nil
+	}
+	amount := *converted
+	if amount.Cmp(rule.declineThreshold) >= 0 {
+		return &entityCoreV1Package.RuleResult{
+			RuleName: rule.Name(),
+			Decision: constantPackage.DECISION_DECLINE,
+			Reason:   fmt.Sprintf("amount %s %s reaches decline threshold %s", amount, rule.currency, rule.declineThreshold),
+		}, nil
+	}
+	if amount.Cmp(rule.reviewThreshold) >= 0 {
+		return &entityCoreV1Package.RuleResult{
+			RuleName: rule.Name(),
+			Decision: constantPackage.DECISION_REVIEW,
+			Reason:   fmt.Sprintf("amount %s %s reaches review threshold %s", amount, rule.currency, rule.reviewThreshold),
+		}, nil
+	}
+	return nil, nil
+}
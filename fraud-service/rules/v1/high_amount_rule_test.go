@@ -0,0 +1,71 @@
+package fraud_rules_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestHighAmountRule_NotFired(t *testing.T) {
+	rule := NewHighAmountRule(new(MockFxClient), constantPackage.REFERENCE_CURRENCY, utilMoneyV1.FromInt(100), utilMoneyV1.FromInt(1000))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-99.99")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestHighAmountRule_Review(t *testing.T) {
+	rule := NewHighAmountRule(new(MockFxClient), constantPackage.REFERENCE_CURRENCY, utilMoneyV1.FromInt(100), utilMoneyV1.FromInt(1000))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-100")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.HIGH_AMOUNT_RULE_NAME, result.RuleName)
+	assert.Equal(t, constantPackage.DECISION_REVIEW, result.Decision)
+}
+
+func TestHighAmountRule_Decline(t *testing.T) {
+	rule := NewHighAmountRule(new(MockFxClient), constantPackage.REFERENCE_CURRENCY, utilMoneyV1.FromInt(100), utilMoneyV1.FromInt(1000))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("1500")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, result.Decision)
+}
+
+func TestHighAmountRule_ConvertsToThresholdCurrency(t *testing.T) {
+	mockFx := new(MockFxClient)
+	rule := NewHighAmountRule(mockFx, "USD", utilMoneyV1.FromInt(5000), utilMoneyV1.FromInt(20000))
+	mockFx.On("GetRate", "JPY", "USD", mock.Anything, mock.Anything).Return(utilMoneyV1.MustParseRate("0.0067"), nil)
+
+	// ¥20,000 is about $134, well under the thresholds it would cross as a plain number.
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-20000"), Currency: "JPY"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	// ¥1,000,000 is about $6,700.
+	result, err = rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-1000000"), Currency: "JPY"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.DECISION_REVIEW, result.Decision)
+	assert.Contains(t, result.Reason, "6700 USD")
+	mockFx.AssertExpectations(t)
+}
+
+func TestHighAmountRule_FxError(t *testing.T) {
+	mockFx := new(MockFxClient)
+	rule := NewHighAmountRule(mockFx, "USD", utilMoneyV1.FromInt(5000), utilMoneyV1.FromInt(20000))
+	mockFx.On("GetRate", "EUR", "USD", mock.Anything, mock.Anything).Return(utilMoneyV1.Rate{}, errors.New("db error"))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{Amount: utilMoneyV1.MustParse("-10"), Currency: "EUR"}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
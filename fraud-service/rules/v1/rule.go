@@ -0,0 +1,18 @@
+package fraud_rules_v1
+
+import (
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// IFraudRule defines the methods interface every fraud rule plugged into the rule engine must implement.
+type IFraudRule interface {
+
+	// Name returns the unique rule name reported when the rule fires.
+	Name() string
+
+	// Evaluate inspects the payload and returns a RuleResult when the rule fires, or nil otherwise.
+	Evaluate(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.RuleResult, error)
+}
@@ -0,0 +1,93 @@
+package fraud_rules_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	repoV1Package "anti-fraud/fraud-service/repository/v1"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VelocityRule declines transactions that would breach a per-account velocity limit
+// configured for the transaction's operation type.
+type VelocityRule struct {
+	repoV1   repoV1Package.IFraudRepository
+	fxClient fxClientPackageV1.IFxClient
+}
+
+// NewVelocityRule creates and return new VelocityRule instance.
+func NewVelocityRule(repoV1 repoV1Package.IFraudRepository, fxClient fxClientPackageV1.IFxClient) *VelocityRule {
+	return &VelocityRule{repoV1: repoV1, fxClient: fxClient}
+}
+
+// Name returns the rule name.
+func (rule *VelocityRule) Name() string {
+	return constantPackage.VELOCITY_LIMIT_RULE_NAME
+}
+
+// Evaluate checks the transaction against every velocity limit of its operation type.
+//
+// Steps:
+//  1. Lock the account's activity until the txn ends, so concurrent transactions of the account
+//     cannot all pass a limit that only one of them fits in.
+//  2. Load the velocity limits configured for the operation type.
+//  3. For each limit, aggregate the account's activity within the limit's sliding window.
+//  4. Fire with a DECLINE decision when counting this transaction would exceed the
+//     count limit or the amount limit. The activity of the account is in the payload currency,
+//     so its total is converted to the currency of the limit first; the rule fires with a
+//     REVIEW decision if no fx rate is known to do so.
+//  5. Otherwise the rule does not fire and nil is returned.
+func (rule *VelocityRule) Evaluate(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.RuleResult, error) {
+	logger := utilContextV1.Logger(ctx)
+	err := rule.repoV1.LockAccountActivity(ctx, payload.AccountId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while locking account activity: %s", err.Error())
+		return nil, err
+	}
+	limits, err := rule.repoV1.GetVelocityLimits(ctx, payload.OperationTypeId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching velocity limits: %s", err.Error())
+		return nil, err
+	}
+
+	amount := payload.Amount.Abs()
+	now := time.Now()
+	for _, limit := range limits {
+		window := time.Duration(limit.WindowSeconds) * time.Second
+		activity, err := rule.repoV1.GetAccountActivity(ctx, payload.AccountId, payload.OperationTypeId, now.Add(-window), tx)
+		if err != nil {
+			logger.Errorf("Error occured while fetching account activity: %s", err.Error())
+			return nil, err
+		}
+		if limit.MaxCount > 0 && activity.Count+1 > int64(limit.MaxCount) {
+			return &entityCoreV1Package.RuleResult{
+				RuleName: rule.Name(),
+				Decision: constantPackage.DECISION_DECLINE,
+				Reason:   fmt.Sprintf("more than %d transactions within %s", limit.MaxCount, window),
+			}, nil
+		}
+		if limit.MaxAmount.Sign() <= 0 {
+			continue
+		}
+		total, err := convertAmount(ctx, rule.fxClient, activity.TotalAmount.Add(amount), payload.Currency, limit.Currency, tx)
+		if err != nil {
+			return nil, err
+		}
+		if total == nil {
+			return missingRateResult(rule.Name(), payload.Currency, limit.Currency), nil
+		}
+		if total.Cmp(limit.MaxAmount) > 0 {
+			return &entityCoreV1Package.RuleResult{
+				RuleName: rule.Name(),
+				Decision: constantPackage.DECISION_DECLINE,
+				Reason:   fmt.Sprintf("total amount above %s %s within %s", limit.MaxAmount, currencyOrReference(limit.Currency), window),
+			}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,265 @@
+package fraud_rules_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fraud-service/entity/db/v1"
+	repoV1Package "anti-fraud/fraud-service/repository/v1"
+	fxCoreV1Package "anti-fraud/fx-service/core/v1"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
+	transactionEntityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MockFraudRepository struct {
+	mock.Mock
+}
+
+func (m *MockFraudRepository) GetVelocityLimits(ctx context.Context, operationTypeId int, tx *gorm.DB) ([]*entityDbV1Package.VelocityLimit, error) {
+	args := m.Called(operationTypeId, tx)
+	limits, _ := args.Get(0).([]*entityDbV1Package.VelocityLimit)
+	return limits, args.Error(1)
+}
+
+func (m *MockFraudRepository) LockAccountActivity(ctx context.Context, accountId int, tx *gorm.DB) error {
+	args := m.Called(accountId, tx)
+	return args.Error(0)
+}
+
+func (m *MockFraudRepository) GetAccountActivity(ctx context.Context, accountId int, operationTypeId int, since time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountActivity, error) {
+	args := m.Called(accountId, operationTypeId, since, tx)
+	activity, _ := args.Get(0).(*entityCoreV1Package.AccountActivity)
+	return activity, args.Error(1)
+}
+
+type MockFxClient struct {
+	mock.Mock
+}
+
+func (m *MockFxClient) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (utilMoneyV1.Rate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, at, tx)
+	rate, _ := args.Get(0).(utilMoneyV1.Rate)
+	return rate, args.Error(1)
+}
+
+func (m *MockFxClient) SetupCore(core fxCoreV1Package.IFxCore) {
+	m.Called(core)
+}
+
+var _ fxClientPackageV1.IFxClient = (*MockFxClient)(nil)
+
+func TestVelocityRule_NoLimits(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).Return([]*entityDbV1Package.VelocityLimit{}, nil)
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVelocityRule_WithinLimits(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return([]*entityDbV1Package.VelocityLimit{{WindowSeconds: 3600, MaxCount: 5, MaxAmount: utilMoneyV1.MustParse("100")}}, nil)
+	mockRepo.On("GetAccountActivity", 1, 3, mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.AccountActivity{Count: 4, TotalAmount: utilMoneyV1.MustParse("90")}, nil)
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVelocityRule_CountExceeded(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return([]*entityDbV1Package.VelocityLimit{{WindowSeconds: 3600, MaxCount: 5}}, nil)
+	mockRepo.On("GetAccountActivity", 1, 3, mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.AccountActivity{Count: 5, TotalAmount: utilMoneyV1.MustParse("10")}, nil)
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.VELOCITY_LIMIT_RULE_NAME, result.RuleName)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, result.Decision)
+	assert.Contains(t, result.Reason, "more than 5 transactions")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVelocityRule_AmountExceeded(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return([]*entityDbV1Package.VelocityLimit{{WindowSeconds: 86400, MaxAmount: utilMoneyV1.MustParse("2000")}}, nil)
+	mockRepo.On("GetAccountActivity", 1, 3, mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.AccountActivity{Count: 1, TotalAmount: utilMoneyV1.MustParse("1990")}, nil)
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10.01")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, result.Decision)
+	assert.Contains(t, result.Reason, "total amount above 2000 ")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVelocityRule_RepoError(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return(([]*entityDbV1Package.VelocityLimit)(nil), errors.New("db error"))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestVelocityRule_LockError(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	rule := NewVelocityRule(mockRepo, new(MockFxClient))
+
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(errors.New("db error"))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "GetVelocityLimits", mock.Anything, mock.Anything)
+}
+
+func TestVelocityRule_ConcurrentTransactionsCannotAllPassTheLimit(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "velocity.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open DB: %v", err)
+	}
+	err = db.AutoMigrate(&entityDbV1Package.VelocityLimit{}, &entityDbV1Package.AccountActivityLock{}, &transactionEntityDbV1Package.Transaction{})
+	if err != nil {
+		t.Fatalf("failed to migrate schema: %v", err)
+	}
+	db.Create(&entityDbV1Package.VelocityLimit{OperationTypeId: 3, WindowSeconds: 3600, MaxCount: 2})
+	rule := NewVelocityRule(repoV1Package.NewFraudRepository(logrus.New()), new(MockFxClient))
+
+	// Each transaction is evaluated and stored in its own txn, as CreateTransaction does.
+	const attempts = 6
+	start := make(chan struct{})
+	decisions := make(chan string, attempts)
+	errs := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			tx := db.Begin()
+			result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10")}, tx)
+			if err != nil {
+				tx.Rollback()
+				errs <- err
+				return
+			}
+			decision := constantPackage.DECISION_APPROVE
+			if result != nil {
+				decision = result.Decision
+			}
+			err = tx.Create(&transactionEntityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10"), FraudDecision: decision}).Error
+			if err == nil {
+				err = tx.Commit().Error
+			}
+			if err != nil {
+				tx.Rollback()
+				errs <- err
+				return
+			}
+			decisions <- decision
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(decisions)
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+	approved := 0
+	for decision := range decisions {
+		if decision == constantPackage.DECISION_APPROVE {
+			approved++
+		}
+	}
+	assert.Equal(t, 2, approved)
+}
+
+func TestVelocityRule_AmountConvertedToLimitCurrency(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	mockFx := new(MockFxClient)
+	rule := NewVelocityRule(mockRepo, mockFx)
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return([]*entityDbV1Package.VelocityLimit{{WindowSeconds: 86400, MaxAmount: utilMoneyV1.MustParse("2000"), Currency: "USD"}}, nil)
+	mockRepo.On("GetAccountActivity", 1, 3, mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.AccountActivity{Count: 1, TotalAmount: utilMoneyV1.MustParse("200000")}, nil)
+	mockFx.On("GetRate", "JPY", "USD", mock.Anything, mock.Anything).Return(utilMoneyV1.MustParseRate("0.0067"), nil)
+
+	// ¥200,000 + ¥50,000 is about $1,675: within the limit, though the raw number is far above 2000.
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-50000"), Currency: "JPY"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	// ¥300,000 more brings it to about $3,685.
+	result, err = rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-350000"), Currency: "JPY"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, result.Decision)
+	assert.Contains(t, result.Reason, "total amount above 2000 USD")
+	mockRepo.AssertExpectations(t)
+	mockFx.AssertExpectations(t)
+}
+
+func TestVelocityRule_MissingRateReviews(t *testing.T) {
+	mockRepo := new(MockFraudRepository)
+	mockFx := new(MockFxClient)
+	rule := NewVelocityRule(mockRepo, mockFx)
+	mockRepo.On("LockAccountActivity", 1, mock.Anything).Return(nil)
+
+	mockRepo.On("GetVelocityLimits", 3, mock.Anything).
+		Return([]*entityDbV1Package.VelocityLimit{{WindowSeconds: 86400, MaxAmount: utilMoneyV1.MustParse("2000"), Currency: "USD"}}, nil)
+	mockRepo.On("GetAccountActivity", 1, 3, mock.Anything, mock.Anything).
+		Return(&entityCoreV1Package.AccountActivity{}, nil)
+	mockFx.On("GetRate", "EUR", "USD", mock.Anything, mock.Anything).
+		Return(utilMoneyV1.Rate{}, utilErrorsV1.NewNotFoundError("FX_RATE_NOT_FOUND", "no rate"))
+
+	result, err := rule.Evaluate(context.Background(), &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10"), Currency: "EUR"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, constantPackage.DECISION_REVIEW, result.Decision)
+	assert.Contains(t, result.Reason, "no fx rate from EUR to USD")
+}
@@ -0,0 +1,37 @@
+package fraud_subscribers_v1
+
+import (
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// TransactionSubscriber reacts to transaction events on behalf of fraud-service.
+type TransactionSubscriber struct{}
+
+// NewTransactionSubscriber create new instance of TransactionSubscriber.
+func NewTransactionSubscriber() *TransactionSubscriber {
+
+	return &TransactionSubscriber{}
+}
+
+// OnTransactionDeclined raises a fraud alert in the logs for every transaction declined by the fraud rules.
+func (subscriber *TransactionSubscriber) OnTransactionDeclined(ctx context.Context, event eventBusV1Package.Event, tx *gorm.DB) error {
+	declined, ok := event.(*eventBusV1Package.TransactionDeclined)
+	if !ok {
+		return nil
+	}
+	utilContextV1.Logger(ctx).WithFields(logrus.Fields{
+		"alert":             "fraud_decline",
+		"transaction_id":    declined.TransactionId,
+		"account_id":        declined.AccountId,
+		"operation_type_id": declined.OperationTypeId,
+		"amount":            declined.Amount.String(),
+		"currency":          declined.Currency,
+		"fraud_rules":       declined.FraudRules,
+	}).Warn("Transaction declined by fraud rules.")
+	return nil
+}
@@ -0,0 +1,29 @@
+package fraud_subscribers_v1
+
+import (
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnTransactionDeclined_RaisesAlert(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	ctx := utilContextV1.WithLogger(context.Background(), logrus.NewEntry(logger))
+
+	err := NewTransactionSubscriber().OnTransactionDeclined(ctx, &eventBusV1Package.TransactionDeclined{
+		TransactionId: 9, AccountId: 1, Amount: utilMoneyV1.MustParse("-900"), Currency: "USD", FraudRules: []string{"HIGH_AMOUNT"},
+	}, nil)
+
+	assert.NoError(t, err)
+	entry := hook.LastEntry()
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "fraud_decline", entry.Data["alert"])
+	assert.Equal(t, uint(9), entry.Data["transaction_id"])
+	assert.Equal(t, "-900", entry.Data["amount"])
+}
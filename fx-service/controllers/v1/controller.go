@@ -0,0 +1,163 @@
+package fx_controller_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/fx"
+	coreV1Package "anti-fraud/fx-service/core/v1"
+	entityHttpV1Package "anti-fraud/fx-service/entity/http/v1"
+	mapperV1Package "anti-fraud/fx-service/mapper/v1"
+
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/sirupsen/logrus"
+
+	"encoding/json"
+	"mime"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IFxController defines methods interface for HTTP handler.
+type IFxController interface {
+
+	// LoadRates handles an admin HTTP request to load a batch of exchange rates, as JSON or CSV.
+	LoadRates(w http.ResponseWriter, r *http.Request)
+
+	// GetRate handles an HTTP request to fetch the exchange rate of a currency pair valid at a time.
+	GetRate(w http.ResponseWriter, r *http.Request)
+}
+
+// FxController implements IFxController interface.
+type FxController struct {
+	coreV1 coreV1Package.IFxCore
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+// NewFxController creates and returns new FxController instance.
+func NewFxController(coreV1 coreV1Package.IFxCore, db *gorm.DB, logger *logrus.Logger) *FxController {
+	return &FxController{coreV1: coreV1, db: db, logger: logger}
+}
+
+// LoadRates handles the HTTP request for loading exchange rates.
+//
+// Workflow:
+//  1. Decode the body: CSV with a header row when the Content-Type is text/csv, else JSON.
+//  2. Validate every rate; one invalid rate rejects the whole batch.
+//  3. Upsert the rates via the core layer inside a db txn. Reloading a rate is harmless,
+//     so no Idempotency-Key is needed.
+//  4. Return http response with the loaded rates.
+func (controller *FxController) LoadRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Decode HTTP input payload.
+	var loadReq *entityHttpV1Package.LoadFxRatesRequest
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == constantPackage.CSV_MEDIA {
+		loadReq, err = entityHttpV1Package.ParseFxRatesCSV(r.Body)
+	} else {
+		loadReq = &entityHttpV1Package.LoadFxRatesRequest{}
+		err = json.NewDecoder(r.Body).Decode(loadReq)
+	}
+	if err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.Infof("LoadRates endpoint called with %d rates.", len(loadReq.Rates))
+
+	// 2. Validate payload.
+	err = loadReq.Validate()
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+	ratePayloads, err := mapperV1Package.FxRatePayloadsMapper(loadReq)
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	// 3. Load the rates via the core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	fxRates, err := controller.coreV1.LoadRates(ctx, ratePayloads, tx)
+	if err != nil {
+		logger.Errorf("Error loading fx rates: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Build and send the JSON response.
+	logger.Infof("Loaded %d fx rates", len(fxRates))
+	response := map[string]interface{}{
+		"success":  true,
+		"fx_rates": mapperV1Package.FxRateListResponseMapper(fxRates),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRate handles the HTTP request for fetching the exchange rate valid at a time.
+//
+// Workflow:
+//  1. Parse and validate the base_currency, quote_currency and at query parameters.
+//  2. Fetch the rate via the core layer inside a db txn.
+//  3. Return http response with the rate.
+func (controller *FxController) GetRate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Parse the query parameters.
+	rateReq, err := entityHttpV1Package.ParseGetFxRateRequest(r.URL.Query(), time.Now())
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	logger.Infof("GetRate endpoint called for %s/%s at %s", rateReq.BaseCurrency, rateReq.QuoteCurrency, rateReq.At)
+
+	// 2. Fetch the rate via the core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	fxRate, err := controller.coreV1.GetRate(ctx, rateReq.BaseCurrency, rateReq.QuoteCurrency, rateReq.At, tx)
+	if err != nil {
+		logger.Errorf("Error fetching fx rate: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success": true,
+		"fx_rate": mapperV1Package.FxRateResponseMapper(fxRate),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,177 @@
+package fx_controller_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+//--------------------------------//
+// Mock for IFxCore
+//--------------------------------//
+
+type MockFxCore struct {
+	mock.Mock
+}
+
+func (m *MockFxCore) LoadRates(ctx context.Context, ratePayloads []*entityCoreV1Package.FxRatePayload, tx *gorm.DB) ([]*entityDbV1Package.FxRate, error) {
+	args := m.Called(ratePayloads, tx)
+	fxRates, _ := args.Get(0).([]*entityDbV1Package.FxRate)
+	return fxRates, args.Error(1)
+}
+
+func (m *MockFxCore) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, at, tx)
+	fxRate, _ := args.Get(0).(*entityDbV1Package.FxRate)
+	return fxRate, args.Error(1)
+}
+
+//----------------------------------------------//
+// Test Helpers
+//----------------------------------------------//
+
+func setupTestController(t *testing.T) (*FxController, *MockFxCore) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	mockCore := new(MockFxCore)
+	return NewFxController(mockCore, db, logrus.New()), mockCore
+}
+
+var march1 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+//------------------------------------------------//
+// LoadRates
+//------------------------------------------------//
+
+func TestLoadRates_JSON(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("LoadRates", []*entityCoreV1Package.FxRatePayload{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.085"), EffectiveFrom: march1},
+	}, mock.Anything).Return([]*entityDbV1Package.FxRate{
+		{Model: gorm.Model{ID: 4}, BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.085"), EffectiveFrom: march1},
+	}, nil)
+
+	body := `{"rates": [{"base_currency": "EUR", "quote_currency": "USD", "rate": 1.085, "effective_from": "2024-03-01"}]}`
+	rr := httptest.NewRecorder()
+	controller.LoadRates(rr, httptest.NewRequest(http.MethodPost, "/fx-rates/v1", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"fx_rate_id":4`)
+	assert.Contains(t, rr.Body.String(), `"rate":1.085`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestLoadRates_CSV(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("LoadRates", []*entityCoreV1Package.FxRatePayload{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.085"), EffectiveFrom: march1},
+		{BaseCurrency: "USD", QuoteCurrency: "JPY", Rate: utilMoneyV1.MustParseRate("150.12"), EffectiveFrom: march1.Add(9 * time.Hour)},
+	}, mock.Anything).Return([]*entityDbV1Package.FxRate{}, nil)
+
+	body := "effective_from,base_currency,quote_currency,rate\n2024-03-01,EUR,USD,1.085\n2024-03-01T09:00:00Z,USD,JPY,150.12\n"
+	req := httptest.NewRequest(http.MethodPost, "/fx-rates/v1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	rr := httptest.NewRecorder()
+	controller.LoadRates(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockCore.AssertExpectations(t)
+}
+
+func TestLoadRates_InvalidCSV(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	for _, body := range []string{"base_currency,quote_currency,rate\nEUR,USD,1.08\n", "base_currency,quote_currency,rate,effective_from\nEUR,USD,abc,2024-03-01\n"} {
+		req := httptest.NewRequest(http.MethodPost, "/fx-rates/v1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		rr := httptest.NewRecorder()
+		controller.LoadRates(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_REQUEST_BODY, body)
+	}
+	mockCore.AssertNotCalled(t, "LoadRates", mock.Anything, mock.Anything)
+}
+
+func TestLoadRates_ValidationError(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	for _, body := range []string{
+		`{"rates": []}`,
+		`{"rates": [{"base_currency": "EUR", "quote_currency": "EUR", "rate": 1, "effective_from": "2024-03-01"}]}`,
+		`{"rates": [{"base_currency": "XXX", "quote_currency": "USD", "rate": 1, "effective_from": "2024-03-01"}]}`,
+		`{"rates": [{"base_currency": "EUR", "quote_currency": "USD", "rate": 0, "effective_from": "2024-03-01"}]}`,
+		`{"rates": [{"base_currency": "EUR", "quote_currency": "USD", "rate": 1.08, "effective_from": "March 1"}]}`,
+	} {
+		rr := httptest.NewRecorder()
+		controller.LoadRates(rr, httptest.NewRequest(http.MethodPost, "/fx-rates/v1", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "LoadRates", mock.Anything, mock.Anything)
+}
+
+//------------------------------------------------//
+// GetRate
+//------------------------------------------------//
+
+func TestGetRate_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockCore.On("GetRate", "EUR", "USD", at, mock.Anything).
+		Return(&entityDbV1Package.FxRate{Model: gorm.Model{ID: 4}, BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.085"), EffectiveFrom: march1}, nil)
+
+	rr := httptest.NewRecorder()
+	controller.GetRate(rr, httptest.NewRequest(http.MethodGet, "/fx-rates/v1?base_currency=EUR&quote_currency=USD&at=2024-03-01T12:00:00Z", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"rate":1.085`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestGetRate_NotFound(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("GetRate", "EUR", "USD", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.FX_RATE_NOT_FOUND, "no EUR/USD fx rate"))
+
+	rr := httptest.NewRecorder()
+	controller.GetRate(rr, httptest.NewRequest(http.MethodGet, "/fx-rates/v1?base_currency=EUR&quote_currency=USD", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.FX_RATE_NOT_FOUND)
+}
+
+func TestGetRate_InvalidQuery(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	for _, query := range []string{"?base_currency=EUR", "?base_currency=EUR&quote_currency=EUR", "?base_currency=EUR&quote_currency=USD&at=yesterday"} {
+		rr := httptest.NewRecorder()
+		controller.GetRate(rr, httptest.NewRequest(http.MethodGet, "/fx-rates/v1"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER, query)
+	}
+	mockCore.AssertNotCalled(t, "GetRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,86 @@
+package fx_core_v1
+
+import (
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	mapperV1Package "anti-fraud/fx-service/mapper/v1"
+	repoV1Package "anti-fraud/fx-service/repository/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IFxCore defines the methods interface for core business logic for exchange rates.
+type IFxCore interface {
+
+	// LoadRates persists a batch of exchange rates, replacing those loaded for the same pair and effective time.
+	LoadRates(ctx context.Context, ratePayloads []*entityCoreV1Package.FxRatePayload, tx *gorm.DB) ([]*entityDbV1Package.FxRate, error)
+
+	// GetRate fetches the exchange rate of a currency pair valid at a time.
+	GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error)
+}
+
+// FxCore implements IFxCore interface.
+type FxCore struct {
+	repoV1 repoV1Package.IFxRepository
+	logger *logrus.Logger
+}
+
+// NewFxCore creates and return new FxCore instance.
+func NewFxCore(repoV1 repoV1Package.IFxRepository, logger *logrus.Logger) *FxCore {
+	return &FxCore{repoV1: repoV1, logger: logger}
+}
+
+// LoadRates loads a batch of exchange rates.
+//
+// Steps:
+//  1. Map the payloads to DB entities.
+//  2. Upsert them in one statement, so the batch is loaded all or nothing.
+//
+// Parameters:
+//   - ratePayloads: validated exchange rates.
+//   - tx:           db txn.
+//
+// Returns:
+//   - The persisted exchange rates.
+//   - error: an encountered Error.
+func (core *FxCore) LoadRates(ctx context.Context, ratePayloads []*entityCoreV1Package.FxRatePayload, tx *gorm.DB) ([]*entityDbV1Package.FxRate, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("LoadRates method called in fx core layer.")
+
+	fxRates := make([]*entityDbV1Package.FxRate, 0, len(ratePayloads))
+	for _, ratePayload := range ratePayloads {
+		fxRates = append(fxRates, mapperV1Package.FxRateMapper(ratePayload))
+	}
+	err := core.repoV1.UpsertRates(ctx, fxRates, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting fx rates: %s", err.Error())
+		return nil, err
+	}
+	return fxRates, nil
+}
+
+// GetRate fetches the exchange rate of a currency pair valid at a time: the one with the
+// latest effective_from at or before at.
+//
+// Parameters:
+//   - baseCurrency:  currency converted from.
+//   - quoteCurrency: currency converted to.
+//   - at:            the time the rate should be valid at, e.g. a transaction's event time.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The exchange rate.
+//   - error: a not found Error if no rate is effective yet, or any other encountered Error.
+func (core *FxCore) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetRate method called in fx core layer.")
+	fxRate, err := core.repoV1.GetRate(ctx, baseCurrency, quoteCurrency, at, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching fx rate: %s", err.Error())
+	}
+	return fxRate, err
+}
@@ -0,0 +1,81 @@
+package fx_core_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+//-------------------------------------------//
+// Mock for IFxRepository
+//-------------------------------------------//
+
+type MockFxRepository struct {
+	mock.Mock
+}
+
+func (m *MockFxRepository) UpsertRates(ctx context.Context, fxRates []*entityDbV1Package.FxRate, tx *gorm.DB) error {
+	args := m.Called(fxRates, tx)
+	return args.Error(0)
+}
+
+func (m *MockFxRepository) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, at, tx)
+	fxRate, _ := args.Get(0).(*entityDbV1Package.FxRate)
+	return fxRate, args.Error(1)
+}
+
+//-------------------------------------------//
+// Unit Tests for FxCore
+//-------------------------------------------//
+
+func TestLoadRates_Success(t *testing.T) {
+	mockRepo := new(MockFxRepository)
+	core := NewFxCore(mockRepo, logrus.New())
+
+	effectiveFrom := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("UpsertRates", []*entityDbV1Package.FxRate{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.08"), EffectiveFrom: effectiveFrom},
+	}, mock.Anything).Return(nil)
+
+	fxRates, err := core.LoadRates(context.Background(), []*entityCoreV1Package.FxRatePayload{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.08"), EffectiveFrom: effectiveFrom},
+	}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Len(t, fxRates, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoadRates_Error(t *testing.T) {
+	mockRepo := new(MockFxRepository)
+	core := NewFxCore(mockRepo, logrus.New())
+
+	mockRepo.On("UpsertRates", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	_, err := core.LoadRates(context.Background(), []*entityCoreV1Package.FxRatePayload{{BaseCurrency: "EUR", QuoteCurrency: "USD"}}, &gorm.DB{})
+	assert.EqualError(t, err, "db error")
+}
+
+func TestGetRate_NotFound(t *testing.T) {
+	mockRepo := new(MockFxRepository)
+	core := NewFxCore(mockRepo, logrus.New())
+
+	at := time.Now()
+	mockRepo.On("GetRate", "EUR", "USD", at, mock.Anything).
+		Return(&entityDbV1Package.FxRate{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.FX_RATE_NOT_FOUND, "no EUR/USD fx rate"))
+
+	_, err := core.GetRate(context.Background(), "EUR", "USD", at, &gorm.DB{})
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	mockRepo.AssertExpectations(t)
+}
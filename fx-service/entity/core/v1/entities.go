@@ -0,0 +1,15 @@
+package fx_entity_core_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// FxRatePayload holds one exchange rate to load; a rate already loaded for the same pair and
+// effective time is replaced.
+type FxRatePayload struct {
+	BaseCurrency  string           `json:"base_currency"`
+	QuoteCurrency string           `json:"quote_currency"`
+	Rate          utilMoneyV1.Rate `json:"rate"`
+	EffectiveFrom time.Time        `json:"effective_from"`
+}
@@ -0,0 +1,23 @@
+package fx_entity_db_v1
+
+import (
+	constantPackage "anti-fraud/constants/fx"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FxRate is the exchange rate from BaseCurrency to QuoteCurrency, valid from EffectiveFrom
+// until the next rate of the same pair takes effect.
+type FxRate struct {
+	gorm.Model
+	BaseCurrency  string           `json:"base_currency" gorm:"uniqueIndex:idx_fx_rate_pair_effective_from"`
+	QuoteCurrency string           `json:"quote_currency" gorm:"uniqueIndex:idx_fx_rate_pair_effective_from"`
+	Rate          utilMoneyV1.Rate `json:"rate"` // quote units worth one base unit
+	EffectiveFrom time.Time        `json:"effective_from" gorm:"uniqueIndex:idx_fx_rate_pair_effective_from"`
+}
+
+func (FxRate) TableName() string {
+	return constantPackage.TABLE_NAME
+}
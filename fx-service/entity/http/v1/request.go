@@ -0,0 +1,144 @@
+package fx_entity_http_v1
+
+import (
+	constantPackage "anti-fraud/constants/fx"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FxRateRequest is one exchange rate: one base_currency unit is worth rate quote_currency units
+// from effective_from on.
+type FxRateRequest struct {
+	BaseCurrency  string           `json:"base_currency"`
+	QuoteCurrency string           `json:"quote_currency"`
+	Rate          utilMoneyV1.Rate `json:"rate"`
+	EffectiveFrom string           `json:"effective_from"` // RFC 3339 timestamp or YYYY-MM-DD date, from midnight UTC
+}
+
+func (rateRequest *FxRateRequest) Validate() error {
+	if err := utilMoneyV1.ValidateCurrency(rateRequest.BaseCurrency); err != nil {
+		return fmt.Errorf("base_currency: %v", err)
+	}
+	if err := utilMoneyV1.ValidateCurrency(rateRequest.QuoteCurrency); err != nil {
+		return fmt.Errorf("quote_currency: %v", err)
+	}
+	if rateRequest.BaseCurrency == rateRequest.QuoteCurrency {
+		return errors.New("base_currency and quote_currency should differ")
+	}
+	if rateRequest.Rate.Sign() <= 0 {
+		return errors.New("rate should be positive")
+	}
+	_, err := ParseEffectiveFrom(rateRequest.EffectiveFrom)
+	return err
+}
+
+// LoadFxRatesRequest holds a batch of exchange rates, loaded all or nothing.
+type LoadFxRatesRequest struct {
+	Rates []FxRateRequest `json:"rates"`
+}
+
+func (loadRequest *LoadFxRatesRequest) Validate() error {
+	if len(loadRequest.Rates) == 0 {
+		return errors.New("rates should not be empty")
+	}
+	for i := range loadRequest.Rates {
+		if err := loadRequest.Rates[i].Validate(); err != nil {
+			return fmt.Errorf("rates[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// ParseFxRatesCSV reads a batch of exchange rates from CSV. The header row names the columns
+// base_currency, quote_currency, rate and effective_from, in any order.
+func ParseFxRatesCSV(reader io.Reader) (*LoadFxRatesRequest, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %v", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{constantPackage.CSV_COLUMN_BASE_CURRENCY, constantPackage.CSV_COLUMN_QUOTE_CURRENCY, constantPackage.CSV_COLUMN_RATE, constantPackage.CSV_COLUMN_EFFECTIVE_FROM} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header: missing column %s", name)
+		}
+	}
+
+	loadRequest := &LoadFxRatesRequest{Rates: []FxRateRequest{}}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := csvReader.FieldPos(0)
+		rate, err := utilMoneyV1.ParseRate(record[columns[constantPackage.CSV_COLUMN_RATE]])
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %v", line, err)
+		}
+		loadRequest.Rates = append(loadRequest.Rates, FxRateRequest{
+			BaseCurrency:  strings.TrimSpace(record[columns[constantPackage.CSV_COLUMN_BASE_CURRENCY]]),
+			QuoteCurrency: strings.TrimSpace(record[columns[constantPackage.CSV_COLUMN_QUOTE_CURRENCY]]),
+			Rate:          rate,
+			EffectiveFrom: strings.TrimSpace(record[columns[constantPackage.CSV_COLUMN_EFFECTIVE_FROM]]),
+		})
+	}
+	return loadRequest, nil
+}
+
+// GetFxRateRequest holds the query parameters of the rate lookup.
+type GetFxRateRequest struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	At            time.Time // the rate valid at this time is returned
+}
+
+// ParseGetFxRateRequest reads the rate lookup query parameters; at defaults to now.
+func ParseGetFxRateRequest(query url.Values, now time.Time) (*GetFxRateRequest, error) {
+	rateRequest := &GetFxRateRequest{
+		BaseCurrency:  query.Get("base_currency"),
+		QuoteCurrency: query.Get("quote_currency"),
+		At:            now,
+	}
+	if err := utilMoneyV1.ValidateCurrency(rateRequest.BaseCurrency); err != nil {
+		return nil, fmt.Errorf("base_currency: %v", err)
+	}
+	if err := utilMoneyV1.ValidateCurrency(rateRequest.QuoteCurrency); err != nil {
+		return nil, fmt.Errorf("quote_currency: %v", err)
+	}
+	if rateRequest.BaseCurrency == rateRequest.QuoteCurrency {
+		return nil, errors.New("base_currency and quote_currency should differ")
+	}
+	if raw := query.Get("at"); raw != "" {
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, errors.New("at should be an RFC 3339 timestamp")
+		}
+		rateRequest.At = at
+	}
+	return rateRequest, nil
+}
+
+// ParseEffectiveFrom reads an RFC 3339 timestamp, or a YYYY-MM-DD date taken as midnight UTC.
+func ParseEffectiveFrom(raw string) (time.Time, error) {
+	if effectiveFrom, err := time.Parse(time.RFC3339, raw); err == nil {
+		return effectiveFrom, nil
+	}
+	effectiveFrom, err := time.Parse(constantPackage.EFFECTIVE_DATE_LAYOUT, raw)
+	if err != nil {
+		return time.Time{}, errors.New("effective_from should be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	return effectiveFrom, nil
+}
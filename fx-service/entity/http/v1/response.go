@@ -0,0 +1,15 @@
+package fx_entity_http_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// FxRateResponse is the read model of an exchange rate.
+type FxRateResponse struct {
+	FxRateID      int              `json:"fx_rate_id"`
+	BaseCurrency  string           `json:"base_currency"`
+	QuoteCurrency string           `json:"quote_currency"`
+	Rate          utilMoneyV1.Rate `json:"rate"`
+	EffectiveFrom time.Time        `json:"effective_from"`
+}
@@ -0,0 +1,92 @@
+package fx_manager_v1
+
+import (
+	controllerV1Package "anti-fraud/fx-service/controllers/v1"
+	coreV1Package "anti-fraud/fx-service/core/v1"
+	entityHttpV1Package "anti-fraud/fx-service/entity/http/v1"
+	mapperV1Package "anti-fraud/fx-service/mapper/v1"
+	repoV1Package "anti-fraud/fx-service/repository/v1"
+	routerV1Package "anti-fraud/fx-service/routes/v1"
+
+	clientV1Package "anti-fraud/mediator-service/fx-service-client"
+	configPackage "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// FxManager wires all components required to run fx-service.
+type FxManager struct {
+	db     *gorm.DB
+	router *mux.Router
+	logger *logrus.Logger
+	config configPackage.FxConfig
+	coreV1 coreV1Package.IFxCore
+}
+
+// NewFxManager create and return new instance of FxManager.
+func NewFxManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, config configPackage.FxConfig) *FxManager {
+
+	return &FxManager{db: db, router: router, logger: logger, config: config}
+}
+
+// Init instantiate and wire all components, register routes for fx-service.
+func (mw *FxManager) Init() {
+
+	middlewareHandler := middlewareHandlerPackageV1.NewMiddlewareHandler(mw.logger)
+	repoV1 := repoV1Package.NewFxRepository(mw.logger)
+	mw.coreV1 = coreV1Package.NewFxCore(repoV1, mw.logger)
+	controllerV1 := controllerV1Package.NewFxController(mw.coreV1, mw.db, mw.logger)
+	router := routerV1Package.NewFxRoutes(controllerV1, mw.router, middlewareHandler)
+	router.Init()
+}
+
+// ConfigureClient configure core instance of fx service in fx-client.
+func (mw *FxManager) ConfigureClient(client clientV1Package.IFxClient) {
+	client.SetupCore(mw.coreV1)
+}
+
+// LoadRatesFile loads the CSV file of exchange rates named in the config, if any, in one db txn.
+// It uses the same format and validation as the admin endpoint.
+func (mw *FxManager) LoadRatesFile() error {
+	if mw.config.RatesFile == "" {
+		return nil
+	}
+	logger := mw.logger.WithField("rates_file", mw.config.RatesFile)
+
+	file, err := os.Open(mw.config.RatesFile)
+	if err != nil {
+		return fmt.Errorf("failed to open fx rates file: %v", err)
+	}
+	defer file.Close()
+
+	loadReq, err := entityHttpV1Package.ParseFxRatesCSV(file)
+	if err == nil {
+		err = loadReq.Validate()
+	}
+	if err != nil {
+		return fmt.Errorf("invalid fx rates file: %v", err)
+	}
+	ratePayloads, err := mapperV1Package.FxRatePayloadsMapper(loadReq)
+	if err != nil {
+		return fmt.Errorf("invalid fx rates file: %v", err)
+	}
+
+	ctx, tx := utilContextV1.Begin(utilContextV1.WithLogger(context.Background(), logger), mw.db)
+	defer utilContextV1.Rollback(ctx, tx)
+	if _, err := mw.coreV1.LoadRates(ctx, ratePayloads, tx); err != nil {
+		return fmt.Errorf("failed to load fx rates file: %v", err)
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		return fmt.Errorf("failed to load fx rates file: %v", err)
+	}
+	logger.Infof("Loaded %d fx rates.", len(ratePayloads))
+	return nil
+}
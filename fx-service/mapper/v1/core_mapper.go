@@ -0,0 +1,24 @@
+package fx_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityHttpV1Package "anti-fraud/fx-service/entity/http/v1"
+)
+
+// FxRatePayloadsMapper maps a validated batch of rates; effective times are kept in UTC.
+func FxRatePayloadsMapper(loadRequest *entityHttpV1Package.LoadFxRatesRequest) ([]*entityCoreV1Package.FxRatePayload, error) {
+	payloads := make([]*entityCoreV1Package.FxRatePayload, 0, len(loadRequest.Rates))
+	for _, rateRequest := range loadRequest.Rates {
+		effectiveFrom, err := entityHttpV1Package.ParseEffectiveFrom(rateRequest.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, &entityCoreV1Package.FxRatePayload{
+			BaseCurrency:  rateRequest.BaseCurrency,
+			QuoteCurrency: rateRequest.QuoteCurrency,
+			Rate:          rateRequest.Rate,
+			EffectiveFrom: effectiveFrom.UTC(),
+		})
+	}
+	return payloads, nil
+}
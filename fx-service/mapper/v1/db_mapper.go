@@ -0,0 +1,15 @@
+package fx_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+)
+
+func FxRateMapper(ratePayload *entityCoreV1Package.FxRatePayload) *entityDbV1Package.FxRate {
+	return &entityDbV1Package.FxRate{
+		BaseCurrency:  ratePayload.BaseCurrency,
+		QuoteCurrency: ratePayload.QuoteCurrency,
+		Rate:          ratePayload.Rate,
+		EffectiveFrom: ratePayload.EffectiveFrom,
+	}
+}
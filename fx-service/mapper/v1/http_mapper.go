@@ -0,0 +1,24 @@
+package fx_mapper_v1
+
+import (
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	entityHttpV1Package "anti-fraud/fx-service/entity/http/v1"
+)
+
+func FxRateResponseMapper(fxRate *entityDbV1Package.FxRate) *entityHttpV1Package.FxRateResponse {
+	return &entityHttpV1Package.FxRateResponse{
+		FxRateID:      int(fxRate.ID),
+		BaseCurrency:  fxRate.BaseCurrency,
+		QuoteCurrency: fxRate.QuoteCurrency,
+		Rate:          fxRate.Rate,
+		EffectiveFrom: fxRate.EffectiveFrom,
+	}
+}
+
+func FxRateListResponseMapper(fxRates []*entityDbV1Package.FxRate) []*entityHttpV1Package.FxRateResponse {
+	response := make([]*entityHttpV1Package.FxRateResponse, 0, len(fxRates))
+	for _, fxRate := range fxRates {
+		response = append(response, FxRateResponseMapper(fxRate))
+	}
+	return response
+}
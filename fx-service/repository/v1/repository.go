@@ -0,0 +1,94 @@
+package fx_repo_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/fx"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IFxRepository defines methods interface for exchange rate db operations.
+type IFxRepository interface {
+
+	// UpsertRates persists exchange rates, replacing those loaded for the same pair and effective time.
+	UpsertRates(ctx context.Context, fxRates []*entityDbV1Package.FxRate, tx *gorm.DB) error
+
+	// GetRate fetches the exchange rate of a currency pair valid at a time.
+	GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error)
+}
+
+// FxRepository implements IFxRepository methods.
+type FxRepository struct {
+	logger *logrus.Logger
+}
+
+// NewFxRepository returns a new FxRepository instance.
+func NewFxRepository(logger *logrus.Logger) *FxRepository {
+	return &FxRepository{logger: logger}
+}
+
+// UpsertRates inserts exchange rates; a rate for a (base_currency, quote_currency, effective_from)
+// already in the table gets the new rate, so loading the same file twice changes nothing.
+//
+// Parameters:
+//   - fxRates: exchange rate db entities.
+//   - tx:      db txn.
+//
+// Returns:
+//   - An error if the insert fails, otherwise nil.
+func (repo *FxRepository) UpsertRates(ctx context.Context, fxRates []*entityDbV1Package.FxRate, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpsertRates method called in fx repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}, {Name: "effective_from"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+		}).
+		Create(&fxRates)
+	if result.Error != nil {
+		logger.Errorf("Failed to upsert fx rates: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetRate fetches the exchange rate of a currency pair valid at a time.
+//
+// Steps:
+//  1. Filter by the pair and an effective_from at or before at.
+//  2. Take the latest of those rates; if there is none, return a not found Error.
+//
+// Parameters:
+//   - baseCurrency:  currency converted from.
+//   - quoteCurrency: currency converted to.
+//   - at:            the time the rate should be valid at.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The exchange rate.
+//   - error: an encountered Error.
+func (repo *FxRepository) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetRate method called in fx repo layer.")
+	fxRate := &entityDbV1Package.FxRate{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("base_currency = ? AND quote_currency = ? AND effective_from <= ?", baseCurrency, quoteCurrency, at).
+		Order("effective_from DESC").
+		First(fxRate)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		logger.Errorf("Failed to find %s/%s fx rate valid at %s", baseCurrency, quoteCurrency, at)
+		return fxRate, utilErrorsV1.NewNotFoundError(errorConstantPackage.FX_RATE_NOT_FOUND, fmt.Sprintf("no %s/%s fx rate effective at %s", baseCurrency, quoteCurrency, at.UTC().Format(time.RFC3339)))
+	}
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching fx rate: %s", result.Error.Error())
+	}
+	return fxRate, result.Error
+}
@@ -0,0 +1,77 @@
+package fx_repo_v1
+
+import (
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	err = db.AutoMigrate(&entityDbV1Package.FxRate{})
+	if err != nil {
+		t.Fatalf("failed to migrate schema: %v", err)
+	}
+
+	return db
+}
+
+var (
+	march1 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	march2 = time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+)
+
+func TestGetRate_EffectiveAt(t *testing.T) {
+	repo := NewFxRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.UpsertRates(ctx, []*entityDbV1Package.FxRate{
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.08"), EffectiveFrom: march1},
+		{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.09"), EffectiveFrom: march2},
+		{BaseCurrency: "USD", QuoteCurrency: "EUR", Rate: utilMoneyV1.MustParseRate("0.92"), EffectiveFrom: march1},
+	}, db))
+
+	fxRate, err := repo.GetRate(ctx, "EUR", "USD", march1.Add(12*time.Hour), db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParseRate("1.08"), fxRate.Rate)
+
+	fxRate, err = repo.GetRate(ctx, "EUR", "USD", march2, db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParseRate("1.09"), fxRate.Rate)
+
+	_, err = repo.GetRate(ctx, "EUR", "USD", march1.Add(-time.Second), db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+
+	_, err = repo.GetRate(ctx, "GBP", "USD", march2, db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+}
+
+func TestUpsertRates_ReplacesSameEffectiveFrom(t *testing.T) {
+	repo := NewFxRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.UpsertRates(ctx, []*entityDbV1Package.FxRate{{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.08"), EffectiveFrom: march1}}, db))
+	assert.NoError(t, repo.UpsertRates(ctx, []*entityDbV1Package.FxRate{{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.0825"), EffectiveFrom: march1}}, db))
+
+	var count int64
+	db.Model(&entityDbV1Package.FxRate{}).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	fxRate, err := repo.GetRate(ctx, "EUR", "USD", march2, db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParseRate("1.0825"), fxRate.Rate)
+}
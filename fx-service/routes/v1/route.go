@@ -0,0 +1,29 @@
+package fx_route_v1
+
+import (
+	controllerV1Package "anti-fraud/fx-service/controllers/v1"
+
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/gorilla/mux"
+)
+
+type FxRoutes struct {
+	controller        controllerV1Package.IFxController
+	muxRouter         *mux.Router
+	middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler
+}
+
+// NewFxRoutes create and return an instance of FxRoutes.
+func NewFxRoutes(controller controllerV1Package.IFxController, router *mux.Router, middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler) *FxRoutes {
+	return &FxRoutes{controller: controller, muxRouter: router, middlewareHandler: middlewareHandler}
+
+}
+
+// Init register route for fx-service.
+func (routes *FxRoutes) Init() {
+	handlerFunc := routes.middlewareHandler.MiddlewareHandlerFunc
+
+	routes.muxRouter.HandleFunc("/fx-rates/v1", handlerFunc(routes.controller.LoadRates)).Methods("POST")
+	routes.muxRouter.HandleFunc("/fx-rates/v1", handlerFunc(routes.controller.GetRate)).Methods("GET")
+}
@@ -2,14 +2,34 @@ package main
 
 import (
 	account_manager_v1 "anti-fraud/account-service/manager/v1"
+	authorization_manager_v1 "anti-fraud/authorization-service/manager/v1"
+	fraud_manager_v1 "anti-fraud/fraud-service/manager/v1"
+	fx_manager_v1 "anti-fraud/fx-service/manager/v1"
 	operation_manager_v1 "anti-fraud/operation-service/manager/v1"
 	transaction_manager_v1 "anti-fraud/transaction-service/manager/v1"
+	"context"
+	"errors"
+	"flag"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	configConstantPackage "anti-fraud/constants/config"
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	migrationConstantPackage "anti-fraud/constants/migration"
+	databaseMigration "anti-fraud/database/migration"
 	operationClientV1Package "anti-fraud/mediator-service/operation-service-client"
+	configPackage "anti-fraud/utils-server/config"
+	migrationV1Package "anti-fraud/utils-server/migration/v1"
 	dbConnPackage "anti-fraud/utils-server/utils/v1"
 
 	accountClientV1Package "anti-fraud/mediator-service/account-service-client"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	fraudClientV1Package "anti-fraud/mediator-service/fraud-service-client"
+	fxClientV1Package "anti-fraud/mediator-service/fx-service-client"
+	outboxV1Package "anti-fraud/mediator-service/outbox"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
@@ -21,37 +41,166 @@ func main() {
 	logger.SetFormatter(&logrus.JSONFormatter{})
 	logger.SetLevel(logrus.InfoLevel)
 
+	// "migrate <command>" runs a migration command instead of the server; the config flags follow it.
+	args := os.Args[1:]
+	var migrateArgs []string
+	runMigrate := len(args) > 0 && args[0] == migrationConstantPackage.SUBCOMMAND
+	if runMigrate {
+		migrateArgs, args = migrationV1Package.SplitArgs(args[1:])
+	}
+
+	// Load config: defaults, then the YAML file, then env vars, then flags.
+	config, err := configPackage.LoadConfig(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	level, _ := logrus.ParseLevel(config.Logging.Level)
+	logger.SetLevel(level)
+	if config.Logging.Format == configConstantPackage.LOG_FORMAT_TEXT {
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	}
+
 	router := mux.NewRouter()
 
 	// Establish db connection
-	db, err := dbConnPackage.EstablishDBConnection()
+	db, err := dbConnPackage.EstablishDBConnection(config.Database)
 	if err != nil {
 		logger.Fatalf("Error: %v", err)
 	}
 
-	// Operation Client
-	operationClient := operationClientV1Package.NewOperationClient(logger)
+	// Migrations embedded in the binary; the server refuses to start on a schema behind them.
+	migrator, err := migrationV1Package.NewMigrator(db, logger, databaseMigration.FS)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	if runMigrate {
+		if err := migrationV1Package.Run(context.Background(), migrator, migrateArgs, os.Stdout); err != nil {
+			logger.Fatalf("Error: %v", err)
+		}
+		return
+	}
+	if err := migrator.CheckSchema(context.Background()); err != nil {
+		logger.Fatalf("Refusing to serve: %v", err)
+	}
+
+	// Operation and Account Clients, in-process or calling the internal endpoints of the owning service.
+	var operationClient operationClientV1Package.IOperationClient
+	var accountClient accountClientV1Package.IAccountClient
+	if config.Mediator.Mode == mediatorConstantPackage.MODE_REMOTE {
+		operationClient = operationClientV1Package.NewRemoteOperationClient(logger, config.Mediator.Operation)
+		accountClient = accountClientV1Package.NewRemoteAccountClient(logger, config.Mediator.Account)
+	} else {
+		operationClient = operationClientV1Package.NewOperationClient(logger, config.OperationCache)
+		accountClient = accountClientV1Package.NewAccountClient(logger)
+	}
+	logger.Infof("Mediator account and operation clients run in %s mode.", config.Mediator.Mode)
+
+	// In-process Account Client, for the subscribers delivered in the txn of account-service: a remote
+	// call from there would wait on the account rows that txn has locked.
+	inTxnAccountClient := accountClientV1Package.NewAccountClient(logger)
+
+	// Fraud Client
+	fraudClient := fraudClientV1Package.NewFraudClient(logger)
+
+	// Transaction Client
+	transactionClient := transactionClientV1Package.NewTransactionClient(logger)
+
+	// Fx Client
+	fxClient := fxClientV1Package.NewFxClient(logger)
 
-	// Account Client
-	accountClient := accountClientV1Package.NewAccountClient(logger)
+	// Event Bus, on which managers publish domain events and register their subscribers.
+	// Every event is also written to the outbox, in the publisher's db txn.
+	outboxStore := outboxV1Package.NewOutboxStore(logger)
+	eventBus := eventBusV1Package.NewEventBus(db, logger, outboxStore)
+
+	// Outbox Relay, delivering outbox events to the configured sinks.
+	outboxSinks, err := outboxV1Package.NewSinks(logger, config.Outbox.Sinks)
+	if err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+	outboxRelay := outboxV1Package.NewOutboxRelay(outboxStore, outboxSinks, db, logger, config.Outbox)
 
 	// Account Service
-	accountManagerV1 := account_manager_v1.NewAccountManager(db, router, logger)
+	accountManagerV1 := account_manager_v1.NewAccountManager(db, router, logger, transactionClient, eventBus)
 	accountManagerV1.Init()
 	accountManagerV1.ConfigureClient(accountClient)
+	accountManagerV1.ConfigureClient(inTxnAccountClient)
 
 	// Transaction Service
-	transactionManagerV1 := transaction_manager_v1.NewTransactionManager(db, router, logger, operationClient, accountClient)
+	transactionManagerV1 := transaction_manager_v1.NewTransactionManager(db, router, logger, operationClient, accountClient, fraudClient, fxClient, eventBus)
 	transactionManagerV1.Init()
+	transactionManagerV1.ConfigureClient(transactionClient)
 
 	// Operation Service
-	operationManagerV1 := operation_manager_v1.NewOperationManager(logger)
+	operationManagerV1 := operation_manager_v1.NewOperationManager(db, router, logger, transactionClient)
 	operationManagerV1.Init()
 	operationManagerV1.ConfigureClient(operationClient)
 
+	// Fraud Service
+	fraudManagerV1 := fraud_manager_v1.NewFraudManager(logger, eventBus, fxClient)
+	fraudManagerV1.Init()
+	fraudManagerV1.ConfigureClient(fraudClient)
+
+	// Fx Service
+	fxManagerV1 := fx_manager_v1.NewFxManager(db, router, logger, config.Fx)
+	fxManagerV1.Init()
+	fxManagerV1.ConfigureClient(fxClient)
+	if err := fxManagerV1.LoadRatesFile(); err != nil {
+		logger.Fatalf("Error: %v", err)
+	}
+
+	// Authorization Service
+	authorizationManagerV1 := authorization_manager_v1.NewAuthorizationManager(db, router, logger, config.Authorization, operationClient, accountClient, inTxnAccountClient, fraudClient, transactionClient, eventBus)
+	authorizationManagerV1.Init()
+
 	logger.Info("All components has been wired.")
 
-	if err := http.ListenAndServe(":8080", router); err != nil {
+	// Release expired authorization holds in the background.
+	if config.Features.AuthorizationSweeper {
+		authorizationManagerV1.StartSweeper()
+	}
+
+	// Deliver outbox events in the background.
+	if config.Features.OutboxRelay {
+		outboxRelay.Start()
+	}
+
+	server := &http.Server{
+		Addr:         config.Server.Addr,
+		Handler:      router,
+		ReadTimeout:  config.Server.ReadTimeout,
+		WriteTimeout: config.Server.WriteTimeout,
+		IdleTimeout:  config.Server.IdleTimeout,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Infof("Listening on %s.", config.Server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// Serve until SIGINT or SIGTERM, then drain in-flight requests before stopping the background jobs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err := <-serverErr:
 		logger.Fatalf("Failed to start server: %v\n", err)
+	case <-ctx.Done():
+	}
+	logger.Info("Shutting down.")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("Error occured while shutting down the server: %v", err)
+	}
+	if config.Features.AuthorizationSweeper {
+		authorizationManagerV1.StopSweeper()
+	}
+	if config.Features.OutboxRelay {
+		outboxRelay.Stop()
 	}
+	eventBus.Wait()
+	logger.Info("Shutdown complete.")
 }
@@ -2,6 +2,9 @@ package mediator_account_client_v1
 
 import (
 	coreV1Package "anti-fraud/account-service/core/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -14,7 +17,10 @@ type IAccountClient interface {
 	SetupCore(accountCoreV1 coreV1Package.IAccountCore)
 
 	// GetAccount retrieves an account by its ID, returning a local Account struct.
-	GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*Account, error)
+	GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*Account, error)
+
+	// UpdateAvailableCreditLimit applies a signed delta to the account's available credit limit.
+	UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error
 }
 
 // AccountClient implements IAccountClient(interface)
@@ -48,13 +54,41 @@ func (client *AccountClient) SetupCore(accountCoreV1 coreV1Package.IAccountCore)
 // Returns:
 //   - *Account: The mediator-level account struct (e.g. DocumentNumber).
 //   - error:    an encountered Error.
-func (client *AccountClient) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*Account, error) {
+func (client *AccountClient) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*Account, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetAccount method called in mediator-service for account client.")
 
-	account, err := client.accountCoreV1.GetAccount(logger, accountId, tx)
+	account, err := client.accountCoreV1.GetAccount(ctx, accountId, tx)
 	if err != nil {
 		logger.Errorf("Error occured while fetching account data via account service: %s", err.Error())
 		return &Account{}, err
 	}
-	return &Account{Id: int(account.ID), DocumentNumber: account.DocumentNumber}, nil
+	return &Account{
+		Id:                   int(account.ID),
+		DocumentNumber:       account.DocumentNumber,
+		AvailableCreditLimit: account.AvailableCreditLimit,
+		Status:               account.Status,
+		Currency:             account.Currency,
+		Tier:                 account.Tier,
+	}, nil
+}
+
+// UpdateAvailableCreditLimit calls the core's UpdateAvailableCreditLimit method.
+//
+// Parameters:
+//   - accountId: The unique ID of the account to update.
+//   - delta:     signed amount added to the limit (negative draws down, positive restores).
+//   - tx:        db txn.
+//
+// Returns:
+//   - error: an encountered Error, e.g. when the limit is insufficient.
+func (client *AccountClient) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAvailableCreditLimit method called in mediator-service for account client.")
+
+	err := client.accountCoreV1.UpdateAvailableCreditLimit(ctx, accountId, delta, tx)
+	if err != nil {
+		logger.Errorf("Error occured while updating available credit limit via account service: %s", err.Error())
+	}
+	return err
 }
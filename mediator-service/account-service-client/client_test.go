@@ -2,8 +2,11 @@ package mediator_account_client_v1
 
 import (
 	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	entityCoreV1Package "anti-fraud/account-service/entity/core/v1"
 
@@ -21,18 +24,35 @@ type MockAccountCore struct {
 	mock.Mock
 }
 
-func (m *MockAccountCore) CreateAccount(logger *logrus.Entry, payload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountCore) CreateAccount(ctx context.Context, payload *entityCoreV1Package.CreateAccountPayload, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(payload, tx)
 	acc, _ := args.Get(0).(*entityDbV1Package.Account)
 	return acc, args.Error(1)
 }
 
-func (m *MockAccountCore) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+func (m *MockAccountCore) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*entityDbV1Package.Account, error) {
 	args := m.Called(accountId, tx)
 	acc, _ := args.Get(0).(*entityDbV1Package.Account)
 	return acc, args.Error(1)
 }
 
+func (m *MockAccountCore) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	args := m.Called(accountId, delta, tx)
+	return args.Error(0)
+}
+
+func (m *MockAccountCore) ChangeAccountStatus(ctx context.Context, accountId int, status string, tx *gorm.DB) (*entityDbV1Package.Account, error) {
+	args := m.Called(accountId, status, tx)
+	account, _ := args.Get(0).(*entityDbV1Package.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockAccountCore) GetAccountStatement(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) (*entityCoreV1Package.AccountStatement, error) {
+	args := m.Called(accountId, from, to, tx)
+	statement, _ := args.Get(0).(*entityCoreV1Package.AccountStatement)
+	return statement, args.Error(1)
+}
+
 //-------------------------------------------//
 // Unit Tests for AccountClient
 //-------------------------------------------//
@@ -48,7 +68,7 @@ func TestAccountClient_GetAccount_Success(t *testing.T) {
 	mockCore.On("GetAccount", accountId, mock.Anything).
 		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 123}, DocumentNumber: "ABC123"}, nil)
 
-	result, err := client.GetAccount(logrus.NewEntry(logrus.New()), accountId, &gorm.DB{})
+	result, err := client.GetAccount(context.Background(), accountId, &gorm.DB{})
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, 123, result.Id)
@@ -68,7 +88,7 @@ func TestAccountClient_GetAccount_Error(t *testing.T) {
 	mockCore.On("GetAccount", accountId, mock.Anything).
 		Return((*entityDbV1Package.Account)(nil), errors.New("db error"))
 
-	result, err := client.GetAccount(logrus.NewEntry(logrus.New()), accountId, &gorm.DB{})
+	result, err := client.GetAccount(context.Background(), accountId, &gorm.DB{})
 	assert.Error(t, err)
 	assert.Equal(t, int(result.Id), 0)
 
@@ -84,9 +104,37 @@ func TestAccountClient_SetupCore(t *testing.T) {
 	mockCore.On("GetAccount", 1, mock.Anything).
 		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 1}}, nil)
 
-	res, err := client.GetAccount(logrus.NewEntry(logrus.New()), 1, &gorm.DB{})
+	res, err := client.GetAccount(context.Background(), 1, &gorm.DB{})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, res.Id)
 
 	mockCore.AssertExpectations(t)
 }
+
+func TestAccountClient_UpdateAvailableCreditLimit_Success(t *testing.T) {
+	client := NewAccountClient(logrus.New())
+	mockCore := new(MockAccountCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50"), mock.Anything).Return(nil)
+
+	err := client.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-50"), &gorm.DB{})
+	assert.NoError(t, err)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestAccountClient_UpdateAvailableCreditLimit_Error(t *testing.T) {
+	client := NewAccountClient(logrus.New())
+	mockCore := new(MockAccountCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50"), mock.Anything).
+		Return(errors.New("insufficient available credit limit for account_id: 1"))
+
+	err := client.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-50"), &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient available credit limit")
+
+	mockCore.AssertExpectations(t)
+}
@@ -1,7 +1,13 @@
 package mediator_account_client_v1
 
+import utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
 // Account is a simple struct representing the mediator-level view of an account.
 type Account struct {
-	Id             int
-	DocumentNumber string
+	Id                   int
+	DocumentNumber       string
+	AvailableCreditLimit utilMoneyV1.Amount
+	Status               string
+	Currency             string
+	Tier                 string // selects tier-specific fees
 }
@@ -0,0 +1,154 @@
+package mediator_account_client_v1
+
+import (
+	coreV1Package "anti-fraud/account-service/core/v1"
+	entityHttpV1Package "anti-fraud/account-service/entity/http/v1"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	utilRemoteV1 "anti-fraud/utils-server/remote/v1"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RemoteAccountClient implements IAccountClient over HTTP/JSON, calling the internal endpoints of
+// an account-service deployed on its own.
+//
+// The owning service runs every call in its own db txn, so the tx argument is ignored: an available
+// credit limit update is committed by account-service, whatever becomes of the caller's txn. To keep
+// the update in step with the caller's txn, the client registers a compensating update, of the
+// opposite delta, that runs if the caller's txn rolls back, or the savepoint of the event subscriber
+// the update is made in (see utilContextV1.Savepoint).
+type RemoteAccountClient struct {
+	client *utilRemoteV1.Client
+	logger *logrus.Logger
+}
+
+// NewRemoteAccountClient create new instance of RemoteAccountClient, calling account-service as configured.
+func NewRemoteAccountClient(logger *logrus.Logger, config utilConfig.RemoteServiceConfig) *RemoteAccountClient {
+
+	return &RemoteAccountClient{client: utilRemoteV1.NewClient("account-service", config), logger: logger}
+}
+
+// SetupCore does nothing: a remote client reaches account-service over HTTP, not through its core.
+func (client *RemoteAccountClient) SetupCore(accountCoreV1 coreV1Package.IAccountCore) {}
+
+// GetAccount fetches an account from the internal account endpoint.
+//
+// Parameters:
+//   - accountId: The unique ID of the account to fetch.
+//   - tx:        ignored, see RemoteAccountClient.
+//
+// Returns:
+//   - *Account: The mediator-level account struct.
+//   - error:    the error answered by account-service, or a 503 one when it cannot be reached.
+func (client *RemoteAccountClient) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*Account, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetAccount method called in mediator-service for remote account client.")
+
+	var response struct {
+		Account entityHttpV1Package.CreateAccountResponse `json:"account"`
+	}
+	err := client.client.Do(ctx, &utilRemoteV1.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("/internal/accounts/v1/%d", accountId),
+	}, &response)
+	if err != nil {
+		logger.Errorf("Error occured while fetching account data via account service: %s", err.Error())
+		return &Account{}, err
+	}
+	id, err := strconv.Atoi(response.Account.AccountID)
+	if err != nil {
+		logger.Errorf("Error occured while decoding account id answered by account service: %s", err.Error())
+		return &Account{}, err
+	}
+	return &Account{
+		Id:                   id,
+		DocumentNumber:       response.Account.DocumentNumber,
+		AvailableCreditLimit: response.Account.AvailableCreditLimit,
+		Status:               response.Account.Status,
+		Currency:             response.Account.Currency,
+		Tier:                 response.Account.Tier,
+	}, nil
+}
+
+// UpdateAvailableCreditLimit applies a signed delta through the internal account endpoint.
+// One Idempotency-Key is sent with every retry, so the delta is applied at most once.
+//
+// Steps:
+//  1. POST the delta with a new Idempotency-Key.
+//  2. Unless account-service rejected it, register a compensation on the rollback of the caller's txn,
+//     or savepoint, see compensate. A failure answered as 5xx, or no answer at all, may still have applied the delta.
+//
+// Parameters:
+//   - accountId: The unique ID of the account to update.
+//   - delta:     signed amount added to the limit (negative draws down, positive restores).
+//   - tx:        ignored, see RemoteAccountClient.
+//
+// Returns:
+//   - error: the error answered by account-service, e.g. when the limit is insufficient.
+func (client *RemoteAccountClient) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateAvailableCreditLimit method called in mediator-service for remote account client.")
+
+	idempotencyKey := uuid.New().String()
+	err := client.updateAvailableCreditLimit(ctx, accountId, delta, idempotencyKey)
+	if err != nil {
+		logger.Errorf("Error occured while updating available credit limit via account service: %s", err.Error())
+		if appError, ok := utilErrorsV1.AsAppError(err); ok && appError.Status < http.StatusInternalServerError {
+			return err
+		}
+	}
+	applied := err == nil
+	compensationCtx := context.WithoutCancel(ctx)
+	utilContextV1.OnRollback(ctx, func() {
+		client.compensate(compensationCtx, accountId, delta, idempotencyKey, applied)
+	})
+	return err
+}
+
+// compensate undoes an update whose caller's txn rolled back, by applying the opposite delta.
+//
+// Steps:
+//  1. If the outcome of the update is unknown, send it again with its Idempotency-Key: account-service
+//     replays it if it was applied, or applies it now. Either way it is applied once, and can be undone.
+//     A rejection means it was never applied, and there is nothing to undo.
+//  2. Apply the opposite delta, with an Idempotency-Key derived from the update's one.
+//
+// A compensation that fails is logged at error level, with what is needed to repair the limit by hand.
+func (client *RemoteAccountClient) compensate(ctx context.Context, accountId int, delta utilMoneyV1.Amount, idempotencyKey string, applied bool) {
+	logger := utilContextV1.Logger(ctx).WithFields(logrus.Fields{"account_id": accountId, "delta": delta.String(), "idempotency_key": idempotencyKey})
+	if !applied {
+		if err := client.updateAvailableCreditLimit(ctx, accountId, delta, idempotencyKey); err != nil {
+			if appError, ok := utilErrorsV1.AsAppError(err); ok && appError.Status < http.StatusInternalServerError {
+				logger.Infof("Credit limit update was never applied, nothing to compensate: %s", err.Error())
+				return
+			}
+			logger.Errorf("Compensation failed, outcome of the credit limit update unknown, check the limit by hand: %s", err.Error())
+			return
+		}
+	}
+	if err := client.updateAvailableCreditLimit(ctx, accountId, delta.Neg(), idempotencyKey+idempotencyConstantPackage.COMPENSATION_SUFFIX); err != nil {
+		logger.Errorf("Compensation failed, credit limit left updated, restore it by hand: %s", err.Error())
+		return
+	}
+	logger.Info("Credit limit update compensated after the caller's txn rolled back.")
+}
+
+// updateAvailableCreditLimit POSTs a delta to the internal account endpoint.
+func (client *RemoteAccountClient) updateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, idempotencyKey string) error {
+	return client.client.Do(ctx, &utilRemoteV1.Request{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/internal/accounts/v1/%d/available-credit-limit", accountId),
+		Body:    &entityHttpV1Package.UpdateAvailableCreditLimitRequest{Delta: &delta},
+		Headers: map[string]string{idempotencyConstantPackage.HEADER: idempotencyKey},
+	}, nil)
+}
@@ -0,0 +1,170 @@
+package mediator_account_client_v1
+
+import (
+	controllerV1Package "anti-fraud/account-service/controllers/v1"
+	entityDbV1Package "anti-fraud/account-service/entity/db/v1"
+	routerV1Package "anti-fraud/account-service/routes/v1"
+	constantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupRemoteAccountClient serves the account routes, backed by a mock core, and points a remote client at them.
+func setupRemoteAccountClient(t *testing.T) (*RemoteAccountClient, *MockAccountCore) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&utilIdempotencyV1.IdempotencyRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	logger := logrus.New()
+	mockCore := new(MockAccountCore)
+	router := mux.NewRouter()
+	controller := controllerV1Package.NewAccountController(nil, mockCore, utilIdempotencyV1.NewIdempotencyStore(logger), db, logger)
+	routerV1Package.NewAccountRoutes(controller, router, middlewareHandlerPackageV1.NewMiddlewareHandler(logger)).Init()
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	client := NewRemoteAccountClient(logger, utilConfig.RemoteServiceConfig{
+		BaseURL:          server.URL,
+		Timeout:          time.Second,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+	})
+	return client, mockCore
+}
+
+func TestRemoteAccountClient_GetAccount_Success(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("GetAccount", 123, mock.Anything).
+		Return(&entityDbV1Package.Account{Model: gorm.Model{ID: 123}, DocumentNumber: "ABC123", AvailableCreditLimit: utilMoneyV1.MustParse("500.25"), Status: constantPackage.STATUS_ACTIVE, Currency: "USD", Tier: constantPackage.TIER_GOLD}, nil)
+
+	result, err := client.GetAccount(context.Background(), 123, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Account{Id: 123, DocumentNumber: "ABC123", AvailableCreditLimit: utilMoneyV1.MustParse("500.25"), Status: constantPackage.STATUS_ACTIVE, Currency: "USD", Tier: constantPackage.TIER_GOLD}, result)
+	mockCore.AssertExpectations(t)
+}
+
+func TestRemoteAccountClient_GetAccount_NotFound(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("GetAccount", 999, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account not found"))
+
+	_, err := client.GetAccount(context.Background(), 999, nil)
+
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	appError, _ := utilErrorsV1.AsAppError(err)
+	assert.Equal(t, errorConstantPackage.ACCOUNT_NOT_FOUND, appError.Code)
+}
+
+func TestRemoteAccountClient_UpdateAvailableCreditLimit(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50.5"), mock.Anything).Return(nil)
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-5000"), mock.Anything).
+		Return(utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, "insufficient available credit limit for account_id: 1"))
+
+	err := client.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-50.5"), nil)
+	assert.NoError(t, err)
+
+	err = client.UpdateAvailableCreditLimit(context.Background(), 1, utilMoneyV1.MustParse("-5000"), nil)
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, appError.Status)
+	assert.Equal(t, errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, appError.Code)
+	mockCore.AssertExpectations(t)
+}
+
+// beginCallerTxn begins the db txn of a caller of the remote client, on a db of its own.
+func beginCallerTxn(t *testing.T) (context.Context, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	return utilContextV1.Begin(context.Background(), db)
+}
+
+func TestRemoteAccountClient_UpdateAvailableCreditLimit_CompensatedOnRollback(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50.5"), mock.Anything).Return(nil).Once()
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("50.5"), mock.Anything).Return(nil).Once()
+
+	ctx, tx := beginCallerTxn(t)
+	err := client.UpdateAvailableCreditLimit(ctx, 1, utilMoneyV1.MustParse("-50.5"), tx)
+	assert.NoError(t, err)
+	mockCore.AssertNumberOfCalls(t, "UpdateAvailableCreditLimit", 1)
+
+	// The caller fails after the limit call, e.g. on its transaction insert.
+	utilContextV1.Rollback(ctx, tx)
+
+	mockCore.AssertExpectations(t)
+	mockCore.AssertNumberOfCalls(t, "UpdateAvailableCreditLimit", 2)
+}
+
+func TestRemoteAccountClient_UpdateAvailableCreditLimit_NotCompensatedOnCommit(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50.5"), mock.Anything).Return(nil).Once()
+
+	ctx, tx := beginCallerTxn(t)
+	assert.NoError(t, client.UpdateAvailableCreditLimit(ctx, 1, utilMoneyV1.MustParse("-50.5"), tx))
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+	utilContextV1.Rollback(ctx, tx) // deferred by callers, a no-op once committed
+
+	mockCore.AssertNumberOfCalls(t, "UpdateAvailableCreditLimit", 1)
+}
+
+func TestRemoteAccountClient_UpdateAvailableCreditLimit_RejectedNotCompensated(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-5000"), mock.Anything).
+		Return(utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, "insufficient available credit limit for account_id: 1"))
+
+	ctx, tx := beginCallerTxn(t)
+	assert.Error(t, client.UpdateAvailableCreditLimit(ctx, 1, utilMoneyV1.MustParse("-5000"), tx))
+	utilContextV1.Rollback(ctx, tx)
+
+	mockCore.AssertNumberOfCalls(t, "UpdateAvailableCreditLimit", 1)
+}
+
+func TestRemoteAccountClient_UpdateAvailableCreditLimit_UnknownOutcomeReplayedThenCompensated(t *testing.T) {
+	client, mockCore := setupRemoteAccountClient(t)
+
+	// The update fails with a 5xx, so the client cannot tell whether the delta was applied.
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50.5"), mock.Anything).
+		Return(utilErrorsV1.NewServiceUnavailableError("db down", nil)).Once()
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50.5"), mock.Anything).Return(nil).Once()
+	mockCore.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("50.5"), mock.Anything).Return(nil).Once()
+
+	ctx, tx := beginCallerTxn(t)
+	assert.Error(t, client.UpdateAvailableCreditLimit(ctx, 1, utilMoneyV1.MustParse("-50.5"), tx))
+	utilContextV1.Rollback(ctx, tx)
+
+	mockCore.AssertExpectations(t)
+}
@@ -0,0 +1,187 @@
+package mediator_event_bus_v1
+
+import (
+	constantPackage "anti-fraud/constants/event"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Handler reacts to one event. tx is the publisher's db txn for a DELIVERY_SYNC subscriber, and a
+// db txn of its own for a DELIVERY_ASYNC one; it is committed unless the handler returns an error.
+type Handler func(ctx context.Context, event Event, tx *gorm.DB) error
+
+// IEventBus defines methods interface for publishing domain events between services of this process.
+type IEventBus interface {
+	// Subscribe registers a named handler for every event of eventName, delivered as DELIVERY_SYNC,
+	// DELIVERY_SYNC_REQUIRED or DELIVERY_ASYNC.
+	Subscribe(eventName string, delivery string, subscriber string, handler Handler)
+
+	// Publish records an event, then delivers it to its subscribers. A recording failure, or the failure
+	// of a DELIVERY_SYNC_REQUIRED subscriber, is returned so the publisher rolls back; other subscriber
+	// failures are logged.
+	Publish(ctx context.Context, event Event, tx *gorm.DB) error
+}
+
+// IEventRecorder persists published events in the publisher's db txn, e.g. in a transactional outbox.
+type IEventRecorder interface {
+	Record(ctx context.Context, event Event, tx *gorm.DB) error
+}
+
+// subscription is one handler registered for an event name.
+type subscription struct {
+	subscriber string
+	delivery   string
+	handler    Handler
+}
+
+// EventBus implements IEventBus in-process.
+//
+// A DELIVERY_SYNC subscriber runs inside the publisher's db txn, behind a savepoint: if it fails, its
+// writes are rolled back to the savepoint, the compensations it registered with OnRollback run, and the
+// publisher carries on. A DELIVERY_SYNC_REQUIRED
+// subscriber runs the same way, but its failure is returned to the publisher, whose whole txn must
+// then roll back: the publisher's change and the subscriber's reaction commit together or not at all.
+// A DELIVERY_ASYNC subscriber
+// runs in a goroutine once the publisher's txn commits, in a db txn of its own; it never runs for a
+// txn that rolls back, and async subscribers of one event run in no particular order.
+//
+// With a recorder, every event is first persisted in the publisher's txn, so it reaches consumers
+// outside this process if and only if the publisher commits.
+type EventBus struct {
+	db            *gorm.DB
+	logger        *logrus.Logger
+	recorder      IEventRecorder
+	mu            sync.RWMutex
+	subscriptions map[string][]*subscription
+	savepoints    atomic.Int64
+	inFlight      sync.WaitGroup
+}
+
+// NewEventBus create new instance of EventBus, running async deliveries against db. recorder may be nil.
+func NewEventBus(db *gorm.DB, logger *logrus.Logger, recorder IEventRecorder) *EventBus {
+
+	return &EventBus{db: db, logger: logger, recorder: recorder, subscriptions: map[string][]*subscription{}}
+}
+
+// Subscribe registers handler for the events named eventName.
+//
+// Parameters:
+//   - eventName:  name of the events to receive, one of constants/event.
+//   - delivery:   DELIVERY_SYNC, DELIVERY_SYNC_REQUIRED or DELIVERY_ASYNC.
+//   - subscriber: name of the subscriber, used in logs.
+//   - handler:    the function called for each event.
+func (bus *EventBus) Subscribe(eventName string, delivery string, subscriber string, handler Handler) {
+	if delivery != constantPackage.DELIVERY_SYNC && delivery != constantPackage.DELIVERY_SYNC_REQUIRED && delivery != constantPackage.DELIVERY_ASYNC {
+		panic(fmt.Sprintf("event bus: unknown delivery %q for subscriber %s", delivery, subscriber))
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.subscriptions[eventName] = append(bus.subscriptions[eventName], &subscription{subscriber: subscriber, delivery: delivery, handler: handler})
+	bus.logger.Infof("Subscriber %s registered for %s events, delivered %s.", subscriber, eventName, delivery)
+}
+
+// Publish delivers event to the subscribers of its name, in registration order.
+//
+// Steps:
+//  1. Record the event in tx, if the bus has a recorder; on failure, deliver nothing.
+//  2. Run each DELIVERY_SYNC and DELIVERY_SYNC_REQUIRED subscriber now, inside tx, behind a savepoint.
+//     A DELIVERY_SYNC_REQUIRED subscriber that fails stops the delivery, and its Error is returned.
+//  3. Register each DELIVERY_ASYNC subscriber to run once the txn of ctx commits.
+//
+// Parameters:
+//   - ctx:   carries the txn begun by the publisher's controller, whose commit starts async deliveries.
+//   - event: the event to publish.
+//   - tx:    db txn of the publisher.
+//
+// Returns:
+//   - error: the recording Error, or the Error of a DELIVERY_SYNC_REQUIRED subscriber, which should roll tx back.
+func (bus *EventBus) Publish(ctx context.Context, event Event, tx *gorm.DB) error {
+	if bus.recorder != nil {
+		if err := bus.recorder.Record(ctx, event, tx); err != nil {
+			utilContextV1.Logger(ctx).Errorf("Error occured while recording %s event: %s", event.Name(), err.Error())
+			return err
+		}
+	}
+
+	bus.mu.RLock()
+	subscriptions := bus.subscriptions[event.Name()]
+	bus.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if sub.delivery != constantPackage.DELIVERY_ASYNC {
+			if err := bus.deliverInTxn(ctx, sub, event, tx); err != nil && sub.delivery == constantPackage.DELIVERY_SYNC_REQUIRED {
+				return err
+			}
+			continue
+		}
+		sub := sub
+		utilContextV1.AfterCommit(ctx, func() {
+			bus.inFlight.Add(1)
+			go func() {
+				defer bus.inFlight.Done()
+				bus.deliverAsync(context.WithoutCancel(ctx), sub, event)
+			}()
+		})
+	}
+	return nil
+}
+
+// Wait blocks until every async delivery started so far has finished.
+func (bus *EventBus) Wait() {
+	bus.inFlight.Wait()
+}
+
+// deliverInTxn runs a sync subscriber inside the publisher's txn, rolling its writes back and running its
+// compensations if it fails, and returns its Error, or the one that kept it from running.
+func (bus *EventBus) deliverInTxn(ctx context.Context, sub *subscription, event Event, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx).WithFields(logrus.Fields{"event": event.Name(), "subscriber": sub.subscriber})
+
+	savepoint := fmt.Sprintf("event_subscriber_%d", bus.savepoints.Add(1))
+	if err := tx.SavePoint(savepoint).Error; err != nil {
+		logger.Errorf("Error occured while creating savepoint, event not delivered: %s", err.Error())
+		return err
+	}
+	savepointCtx := utilContextV1.Savepoint(ctx)
+	err := call(savepointCtx, sub, event, tx)
+	if err != nil {
+		logger.Errorf("Subscriber failed, rolling its writes back: %s", err.Error())
+		if err := tx.RollbackTo(savepoint).Error; err != nil {
+			logger.Errorf("Error occured while rolling back to savepoint: %s", err.Error())
+		}
+		utilContextV1.RollbackSavepoint(savepointCtx)
+		return err
+	}
+	utilContextV1.ReleaseSavepoint(savepointCtx)
+	return nil
+}
+
+// deliverAsync runs an async subscriber in a db txn of its own, committed unless the subscriber fails.
+func (bus *EventBus) deliverAsync(ctx context.Context, sub *subscription, event Event) {
+	logger := utilContextV1.Logger(ctx).WithFields(logrus.Fields{"event": event.Name(), "subscriber": sub.subscriber})
+
+	ctx, tx := utilContextV1.Begin(ctx, bus.db)
+	if err := call(ctx, sub, event, tx); err != nil {
+		logger.Errorf("Subscriber failed: %s", err.Error())
+		utilContextV1.Rollback(ctx, tx)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error occured while committing subscriber txn: %s", err.Error())
+	}
+}
+
+// call runs the handler of sub, turning a panic into an error so it cannot take the publisher down.
+func call(ctx context.Context, sub *subscription, event Event, tx *gorm.DB) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("subscriber panicked: %v", recovered)
+		}
+	}()
+	return sub.handler(ctx, event, tx)
+}
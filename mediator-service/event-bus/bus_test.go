@@ -0,0 +1,221 @@
+package mediator_event_bus_v1
+
+import (
+	constantPackage "anti-fraud/constants/event"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// note is a row written by publishers and subscribers of the tests.
+type note struct {
+	ID   uint
+	Text string
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	// A file db, so async deliveries on another connection see the committed rows.
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "bus.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&note{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func notes(t *testing.T, db *gorm.DB) []string {
+	var rows []note
+	if err := db.Order("id").Find(&rows).Error; err != nil {
+		t.Fatalf("failed to read notes: %v", err)
+	}
+	texts := []string{}
+	for _, row := range rows {
+		texts = append(texts, row.Text)
+	}
+	return texts
+}
+
+func writeNote(text string) Handler {
+	return func(ctx context.Context, event Event, tx *gorm.DB) error {
+		return tx.Create(&note{Text: text}).Error
+	}
+}
+
+func TestPublish_SyncSubscriberWritesInPublisherTxn(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	tx.Create(&note{Text: "publisher"})
+	assert.NoError(t, bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx))
+	tx.Rollback()
+
+	assert.Empty(t, notes(t, db), "the subscriber's writes roll back with the publisher's txn")
+}
+
+func TestPublish_FailingSyncSubscriberIsRolledBackAlone(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "failing", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		tx.Create(&note{Text: "failing"})
+		return errors.New("boom")
+	})
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "panicking", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		panic("boom")
+	})
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	tx.Create(&note{Text: "publisher"})
+	assert.NoError(t, bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx))
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+
+	assert.Equal(t, []string{"publisher", "subscriber"}, notes(t, db))
+}
+
+func TestPublish_FailingSyncSubscriberRunsItsCompensations(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	compensated := []string{}
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "failing", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		utilContextV1.OnRollback(ctx, func() { compensated = append(compensated, "failing") })
+		return errors.New("boom")
+	})
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "succeeding", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		utilContextV1.OnRollback(ctx, func() { compensated = append(compensated, "succeeding") })
+		return nil
+	})
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	assert.NoError(t, bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx))
+	assert.Equal(t, []string{"failing"}, compensated, "a subscriber rolled back to its savepoint is compensated at once")
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+	assert.Equal(t, []string{"failing"}, compensated, "a subscriber committed with the publisher is not")
+
+	ctx, tx = utilContextV1.Begin(context.Background(), db)
+	assert.NoError(t, bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx))
+	utilContextV1.Rollback(ctx, tx)
+	assert.Equal(t, []string{"failing", "failing", "succeeding"}, compensated, "a subscriber is compensated with the publisher's rollback")
+}
+
+func TestPublish_FailingRequiredSubscriberFailsPublish(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.ACCOUNT_STATUS_CHANGED, constantPackage.DELIVERY_SYNC_REQUIRED, "required", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		tx.Create(&note{Text: "required"})
+		return errors.New("boom")
+	})
+	bus.Subscribe(constantPackage.ACCOUNT_STATUS_CHANGED, constantPackage.DELIVERY_SYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	tx.Create(&note{Text: "publisher"})
+	err := bus.Publish(ctx, &AccountStatusChanged{AccountId: 1}, tx)
+	assert.EqualError(t, err, "boom")
+	utilContextV1.Rollback(ctx, tx)
+
+	assert.Empty(t, notes(t, db), "the publisher rolls back along with its required subscriber")
+}
+
+func TestPublish_RequiredSubscriberCommitsWithPublisher(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.ACCOUNT_STATUS_CHANGED, constantPackage.DELIVERY_SYNC_REQUIRED, "required", writeNote("required"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	tx.Create(&note{Text: "publisher"})
+	assert.NoError(t, bus.Publish(ctx, &AccountStatusChanged{AccountId: 1}, tx))
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+
+	assert.Equal(t, []string{"publisher", "required"}, notes(t, db))
+}
+
+func TestPublish_AsyncSubscriberRunsAfterCommit(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	received := make(chan Event, 1)
+	bus.Subscribe(constantPackage.TRANSACTION_DECLINED, constantPackage.DELIVERY_ASYNC, "writer", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		received <- event
+		return tx.Create(&note{Text: "subscriber"}).Error
+	})
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	tx.Create(&note{Text: "publisher"})
+	assert.NoError(t, bus.Publish(ctx, &TransactionDeclined{TransactionId: 7}, tx))
+	assert.Len(t, received, 0, "nothing is delivered before the commit")
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+	bus.Wait()
+
+	assert.Equal(t, uint(7), (<-received).(*TransactionDeclined).TransactionId)
+	assert.Equal(t, []string{"publisher", "subscriber"}, notes(t, db))
+}
+
+func TestPublish_AsyncSubscriberSkippedOnRollback(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.TRANSACTION_CREATED, constantPackage.DELIVERY_ASYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	assert.NoError(t, bus.Publish(ctx, &TransactionCreated{TransactionId: 7}, tx))
+	tx.Rollback()
+	bus.Wait()
+
+	assert.Empty(t, notes(t, db))
+}
+
+func TestPublish_FailingAsyncSubscriberIsRolledBack(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.TRANSACTION_CREATED, constantPackage.DELIVERY_ASYNC, "failing", func(ctx context.Context, event Event, tx *gorm.DB) error {
+		tx.Create(&note{Text: "failing"})
+		return errors.New("boom")
+	})
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	assert.NoError(t, bus.Publish(ctx, &TransactionCreated{TransactionId: 7}, tx))
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+	bus.Wait()
+
+	assert.Empty(t, notes(t, db))
+}
+
+func TestPublish_OnlyMatchingSubscribers(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), nil)
+	bus.Subscribe(constantPackage.ACCOUNT_STATUS_CHANGED, constantPackage.DELIVERY_SYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	assert.NoError(t, bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx))
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+
+	assert.Empty(t, notes(t, db))
+}
+
+// failingRecorder rejects every event.
+type failingRecorder struct{}
+
+func (recorder failingRecorder) Record(ctx context.Context, event Event, tx *gorm.DB) error {
+	return errors.New("outbox unavailable")
+}
+
+func TestPublish_RecorderFailureDeliversNothing(t *testing.T) {
+	db := setupTestDB(t)
+	bus := NewEventBus(db, logrus.New(), failingRecorder{})
+	bus.Subscribe(constantPackage.ACCOUNT_CREATED, constantPackage.DELIVERY_SYNC, "writer", writeNote("subscriber"))
+
+	ctx, tx := utilContextV1.Begin(context.Background(), db)
+	err := bus.Publish(ctx, &AccountCreated{AccountId: 1}, tx)
+	assert.EqualError(t, err, "outbox unavailable")
+	assert.NoError(t, utilContextV1.Commit(ctx, tx))
+
+	assert.Empty(t, notes(t, db))
+}
@@ -0,0 +1,66 @@
+package mediator_event_bus_v1
+
+import (
+	constantPackage "anti-fraud/constants/event"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// Event is a domain fact published by one service for the others to react to.
+type Event interface {
+	// Name identifies the type of the event, one of the names in constants/event.
+	Name() string
+}
+
+// AccountCreated is published once a new account is inserted.
+type AccountCreated struct {
+	AccountId            int                `json:"account_id"`
+	DocumentNumber       string             `json:"document_number"`
+	AvailableCreditLimit utilMoneyV1.Amount `json:"available_credit_limit"`
+	Currency             string             `json:"currency"`
+	Tier                 string             `json:"tier"`
+	OccurredAt           time.Time          `json:"occurred_at"`
+}
+
+// Name returns ACCOUNT_CREATED.
+func (event *AccountCreated) Name() string { return constantPackage.ACCOUNT_CREATED }
+
+// AccountStatusChanged is published once an account moves from one lifecycle status to another.
+type AccountStatusChanged struct {
+	AccountId  int       `json:"account_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Name returns ACCOUNT_STATUS_CHANGED.
+func (event *AccountStatusChanged) Name() string { return constantPackage.ACCOUNT_STATUS_CHANGED }
+
+// TransactionCreated is published once a transaction that moves the account is posted,
+// whether submitted directly or settling a captured authorization.
+type TransactionCreated struct {
+	TransactionId   uint               `json:"transaction_id"`
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"` // signed, in the account currency
+	Currency        string             `json:"currency"`
+	FraudDecision   string             `json:"fraud_decision"`
+	OccurredAt      time.Time          `json:"occurred_at"`
+}
+
+// Name returns TRANSACTION_CREATED.
+func (event *TransactionCreated) Name() string { return constantPackage.TRANSACTION_CREATED }
+
+// TransactionDeclined is published once a transaction declined by the fraud rules is stored for audit.
+type TransactionDeclined struct {
+	TransactionId   uint               `json:"transaction_id"`
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"` // signed, in the account currency
+	Currency        string             `json:"currency"`
+	FraudRules      []string           `json:"fraud_rules"` // names of the fraud rules that fired
+	OccurredAt      time.Time          `json:"occurred_at"`
+}
+
+// Name returns TRANSACTION_DECLINED.
+func (event *TransactionDeclined) Name() string { return constantPackage.TRANSACTION_DECLINED }
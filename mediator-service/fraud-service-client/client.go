@@ -0,0 +1,74 @@
+package mediator_fraud_client_v1
+
+import (
+	coreV1Package "anti-fraud/fraud-service/core/v1"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IFraudClient defines methods interface for interacting with the fraud core service via a mediator pattern.
+type IFraudClient interface {
+	// SetupCore injects the IFraudCore dependency.
+	SetupCore(fraudCoreV1 coreV1Package.IFraudCore)
+
+	// EvaluateTransaction runs the fraud rule engine for the given transaction.
+	EvaluateTransaction(ctx context.Context, check *TransactionCheck, tx *gorm.DB) (*Decision, error)
+}
+
+// FraudClient implements IFraudClient, acting as a mediator to the fraud core service.
+type FraudClient struct {
+	fraudCoreV1 coreV1Package.IFraudCore
+	logger      *logrus.Logger
+}
+
+// NewFraudClient create new instance of FraudClient.
+func NewFraudClient(logger *logrus.Logger) *FraudClient {
+
+	return &FraudClient{logger: logger}
+}
+
+// SetupCore injects the IFraudCore into this client.
+func (client *FraudClient) SetupCore(fraudCoreV1 coreV1Package.IFraudCore) {
+	client.fraudCoreV1 = fraudCoreV1
+}
+
+// EvaluateTransaction calls the fraud core rule engine.
+//
+// Steps:
+//  1. Map the mediator-level check to the fraud core payload.
+//  2. Delegate to the fraud core to evaluate all registered rules.
+//  3. Map the decision to a mediator-level Decision struct.
+//
+// Parameters:
+//   - check: transaction attributes to evaluate.
+//   - tx:    db txn.
+//
+// Returns:
+//   - *Decision: The mediator-level decision with the names of the rules that fired.
+//   - error:     an encountered Error.
+func (client *FraudClient) EvaluateTransaction(ctx context.Context, check *TransactionCheck, tx *gorm.DB) (*Decision, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("EvaluateTransaction method called in mediator-service for fraud client.")
+
+	payload := &entityCoreV1Package.FraudCheckPayload{
+		AccountId:       check.AccountId,
+		OperationTypeId: check.OperationTypeId,
+		Amount:          check.Amount,
+		Currency:        check.Currency,
+	}
+	decision, err := client.fraudCoreV1.EvaluateTransaction(ctx, payload, tx)
+	if err != nil {
+		logger.Errorf("Error occured while evaluating transaction via fraud service: %s", err.Error())
+		return &Decision{}, err
+	}
+
+	firedRules := make([]string, 0, len(decision.FiredRules))
+	for _, rule := range decision.FiredRules {
+		firedRules = append(firedRules, rule.RuleName)
+	}
+	return &Decision{Decision: decision.Decision, FiredRules: firedRules}, nil
+}
@@ -0,0 +1,76 @@
+package mediator_fraud_client_v1
+
+import (
+	constantPackage "anti-fraud/constants/fraud"
+	entityCoreV1Package "anti-fraud/fraud-service/entity/core/v1"
+	rulesV1Package "anti-fraud/fraud-service/rules/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+//-------------------------------------------//
+// Mock for IFraudCore
+//-------------------------------------------//
+
+type MockFraudCore struct {
+	mock.Mock
+}
+
+func (m *MockFraudCore) RegisterRule(rule rulesV1Package.IFraudRule) {
+	m.Called(rule)
+}
+
+func (m *MockFraudCore) EvaluateTransaction(ctx context.Context, payload *entityCoreV1Package.FraudCheckPayload, tx *gorm.DB) (*entityCoreV1Package.FraudDecision, error) {
+	args := m.Called(payload, tx)
+	decision, _ := args.Get(0).(*entityCoreV1Package.FraudDecision)
+	return decision, args.Error(1)
+}
+
+//-------------------------------------------//
+// Unit Tests for FraudClient
+//-------------------------------------------//
+
+func TestFraudClient_EvaluateTransaction_Success(t *testing.T) {
+	client := NewFraudClient(logrus.New())
+	mockCore := new(MockFraudCore)
+	client.SetupCore(mockCore)
+
+	expectedPayload := &entityCoreV1Package.FraudCheckPayload{AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("10"), Currency: "EUR"}
+	mockCore.On("EvaluateTransaction", expectedPayload, mock.Anything).
+		Return(&entityCoreV1Package.FraudDecision{
+			Decision: constantPackage.DECISION_REVIEW,
+			FiredRules: []*entityCoreV1Package.RuleResult{
+				{RuleName: "RULE_A", Decision: constantPackage.DECISION_REVIEW},
+			},
+		}, nil)
+
+	decision, err := client.EvaluateTransaction(context.Background(), &TransactionCheck{AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("10"), Currency: "EUR"}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.DECISION_REVIEW, decision.Decision)
+	assert.Equal(t, []string{"RULE_A"}, decision.FiredRules)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestFraudClient_EvaluateTransaction_Error(t *testing.T) {
+	client := NewFraudClient(logrus.New())
+	mockCore := new(MockFraudCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return((*entityCoreV1Package.FraudDecision)(nil), errors.New("engine error"))
+
+	decision, err := client.EvaluateTransaction(context.Background(), &TransactionCheck{AccountId: 1}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Equal(t, "", decision.Decision)
+
+	mockCore.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package mediator_fraud_client_v1
+
+import utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+// TransactionCheck is the mediator-level view of a transaction submitted for fraud evaluation.
+type TransactionCheck struct {
+	AccountId       int
+	OperationTypeId int
+	Amount          utilMoneyV1.Amount
+	Currency        string // currency of Amount
+}
+
+// Decision is the mediator-level view of a fraud decision.
+type Decision struct {
+	Decision   string
+	FiredRules []string
+}
@@ -0,0 +1,65 @@
+package mediator_fx_client_v1
+
+import (
+	coreV1Package "anti-fraud/fx-service/core/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IFxClient defines methods interface for exchange rates via the fx core service.
+type IFxClient interface {
+	// SetupCore injects the IFxCore dependency.
+	SetupCore(fxCoreV1 coreV1Package.IFxCore)
+
+	// GetRate fetches the exchange rate from baseCurrency to quoteCurrency valid at a time.
+	GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (utilMoneyV1.Rate, error)
+}
+
+// FxClient implements IFxClient, acting as a mediator to the fx core service.
+type FxClient struct {
+	fxCoreV1 coreV1Package.IFxCore
+	logger   *logrus.Logger
+}
+
+// NewFxClient create new instance of FxClient.
+func NewFxClient(logger *logrus.Logger) *FxClient {
+
+	return &FxClient{logger: logger}
+}
+
+// SetupCore injects the IFxCore into this client.
+func (client *FxClient) SetupCore(fxCoreV1 coreV1Package.IFxCore) {
+	client.fxCoreV1 = fxCoreV1
+}
+
+// GetRate retrieves the exchange rate valid at a time.
+//
+// Steps:
+//  1. Delegate to fx core layer to fetch the latest rate effective at or before at.
+//  2. Return the rate, or the error (a not found Error when no rate is effective yet).
+//
+// Parameters:
+//   - baseCurrency:  currency converted from.
+//   - quoteCurrency: currency converted to.
+//   - at:            the time the rate should be valid at.
+//   - tx:            db txn.
+//
+// Returns:
+//   - Rate:  quote units worth one base unit.
+//   - error: an encountered Error.
+func (client *FxClient) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (utilMoneyV1.Rate, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetRate method called in mediator-service for fx client.")
+
+	fxRate, err := client.fxCoreV1.GetRate(ctx, baseCurrency, quoteCurrency, at, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching fx rate via fx service: %s", err.Error())
+		return utilMoneyV1.Rate{}, err
+	}
+	return fxRate.Rate, nil
+}
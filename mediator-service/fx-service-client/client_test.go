@@ -0,0 +1,71 @@
+package mediator_fx_client_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/fx-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/fx-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+//-------------------------------------------//
+// Mock for IFxCore
+//-------------------------------------------//
+
+type MockFxCore struct {
+	mock.Mock
+}
+
+func (m *MockFxCore) LoadRates(ctx context.Context, ratePayloads []*entityCoreV1Package.FxRatePayload, tx *gorm.DB) ([]*entityDbV1Package.FxRate, error) {
+	args := m.Called(ratePayloads, tx)
+	fxRates, _ := args.Get(0).([]*entityDbV1Package.FxRate)
+	return fxRates, args.Error(1)
+}
+
+func (m *MockFxCore) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (*entityDbV1Package.FxRate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, at, tx)
+	fxRate, _ := args.Get(0).(*entityDbV1Package.FxRate)
+	return fxRate, args.Error(1)
+}
+
+//-------------------------------------------//
+// Unit Tests for FxClient
+//-------------------------------------------//
+
+func TestFxClient_GetRate_Success(t *testing.T) {
+	client := NewFxClient(logrus.New())
+	mockCore := new(MockFxCore)
+	client.SetupCore(mockCore)
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockCore.On("GetRate", "EUR", "USD", at, mock.Anything).
+		Return(&entityDbV1Package.FxRate{BaseCurrency: "EUR", QuoteCurrency: "USD", Rate: utilMoneyV1.MustParseRate("1.085")}, nil)
+
+	rate, err := client.GetRate(context.Background(), "EUR", "USD", at, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParseRate("1.085"), rate)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestFxClient_GetRate_NotFound(t *testing.T) {
+	client := NewFxClient(logrus.New())
+	mockCore := new(MockFxCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("GetRate", "JPY", "USD", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.FX_RATE_NOT_FOUND, "no JPY/USD fx rate"))
+
+	_, err := client.GetRate(context.Background(), "JPY", "USD", time.Now(), &gorm.DB{})
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+
+	mockCore.AssertExpectations(t)
+}
@@ -0,0 +1,130 @@
+package mediator_ops_client_v1
+
+import (
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationCacheStats is a snapshot of the counters of the operation type cache; operation-service
+// owns the type, as its cache-stats endpoint reports it.
+type OperationCacheStats = entityCoreV1Package.OperationCacheStats
+
+// operationCacheEntry holds either a loaded operation type or the not found error of an unknown id.
+type operationCacheEntry struct {
+	operation *entityDbV1Package.Operation
+	err       error
+	expiresAt time.Time
+}
+
+// operationCache is a concurrency-safe cache of operation types by id, with a TTL per entry.
+//
+// An invalidation bumps a generation counter, so a load that started before the invalidation
+// cannot put the stale operation type back once it completes.
+type operationCache struct {
+	mu          sync.RWMutex
+	entries     map[int]*operationCacheEntry
+	generation  uint64
+	pruneAt     int // prune expired entries once the map grows to this size
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// operationCacheMinPruneSize is the map size below which expired entries are left to be overwritten.
+const operationCacheMinPruneSize = 64
+
+// newOperationCache creates a cache; a ttl <= 0 disables it, a negativeTTL <= 0 disables negative caching.
+func newOperationCache(ttl time.Duration, negativeTTL time.Duration) *operationCache {
+	return &operationCache{
+		entries:     make(map[int]*operationCacheEntry),
+		pruneAt:     operationCacheMinPruneSize,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+	}
+}
+
+// get returns the cached entry of an id, if any is live, counting the hit or miss.
+// The returned generation is passed back to put once the missing entry is loaded.
+func (cache *operationCache) get(operationId int) (*operationCacheEntry, uint64, bool) {
+	cache.mu.RLock()
+	entry, ok := cache.entries[operationId]
+	generation := cache.generation
+	cache.mu.RUnlock()
+
+	if ok && cache.now().Before(entry.expiresAt) {
+		cache.hits.Add(1)
+		if entry.operation != nil {
+			// Hand out a copy, so a caller cannot alter the cached operation type.
+			copied := *entry.operation
+			return &operationCacheEntry{operation: &copied, expiresAt: entry.expiresAt}, generation, true
+		}
+		return entry, generation, true
+	}
+	cache.misses.Add(1)
+	return nil, generation, false
+}
+
+// put caches a loaded operation type, or the not found error of an unknown id, unless the cache
+// was invalidated since the matching get.
+func (cache *operationCache) put(operationId int, operation *entityDbV1Package.Operation, err error, generation uint64) {
+	ttl := cache.ttl
+	if err != nil {
+		ttl = cache.negativeTTL
+	}
+	if cache.ttl <= 0 || ttl <= 0 {
+		return
+	}
+
+	entry := &operationCacheEntry{err: err, expiresAt: cache.now().Add(ttl)}
+	if operation != nil {
+		copied := *operation
+		entry.operation = &copied
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if generation != cache.generation {
+		return
+	}
+	cache.entries[operationId] = entry
+	if len(cache.entries) >= cache.pruneAt {
+		cache.prune()
+	}
+}
+
+// prune drops the expired entries, so unknown ids looked up once do not pile up. Called with mu held.
+func (cache *operationCache) prune() {
+	now := cache.now()
+	for operationId, entry := range cache.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(cache.entries, operationId)
+		}
+	}
+	cache.pruneAt = 2 * len(cache.entries)
+	if cache.pruneAt < operationCacheMinPruneSize {
+		cache.pruneAt = operationCacheMinPruneSize
+	}
+}
+
+// invalidate drops the entry of an id and discards the loads in flight.
+func (cache *operationCache) invalidate(operationId int) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.entries, operationId)
+	cache.generation++
+}
+
+// stats returns a snapshot of the counters.
+func (cache *operationCache) stats() OperationCacheStats {
+	cache.mu.RLock()
+	entries := len(cache.entries)
+	cache.mu.RUnlock()
+	return OperationCacheStats{Hits: cache.hits.Load(), Misses: cache.misses.Load(), Entries: entries}
+}
@@ -0,0 +1,119 @@
+package mediator_ops_client_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newTestCache(ttl time.Duration, negativeTTL time.Duration) (*operationCache, *time.Time) {
+	cache := newOperationCache(ttl, negativeTTL)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestOperationCache_Expires(t *testing.T) {
+	cache, now := newTestCache(time.Minute, time.Second)
+
+	_, generation, _ := cache.get(1)
+	cache.put(1, &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}, nil, generation)
+
+	*now = now.Add(59 * time.Second)
+	entry, _, ok := cache.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, -1, entry.operation.Coefficient)
+
+	*now = now.Add(time.Second)
+	_, _, ok = cache.get(1)
+	assert.False(t, ok)
+	assert.Equal(t, OperationCacheStats{Hits: 1, Misses: 2, Entries: 1}, cache.stats())
+}
+
+func TestOperationCache_NegativeTTL(t *testing.T) {
+	cache, now := newTestCache(time.Minute, time.Second)
+
+	notFound := utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 99 not found in database")
+	_, generation, _ := cache.get(99)
+	cache.put(99, nil, notFound, generation)
+
+	entry, _, ok := cache.get(99)
+	assert.True(t, ok)
+	assert.Equal(t, notFound, entry.err)
+
+	*now = now.Add(time.Second)
+	_, _, ok = cache.get(99)
+	assert.False(t, ok)
+}
+
+func TestOperationCache_HandsOutCopies(t *testing.T) {
+	cache, _ := newTestCache(time.Minute, time.Second)
+
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}
+	_, generation, _ := cache.get(1)
+	cache.put(1, operation, nil, generation)
+	operation.Coefficient = 1
+
+	entry, _, _ := cache.get(1)
+	entry.operation.Coefficient = 1
+
+	entry, _, _ = cache.get(1)
+	assert.Equal(t, -1, entry.operation.Coefficient)
+}
+
+func TestOperationCache_InvalidateDiscardsLoadInFlight(t *testing.T) {
+	cache, _ := newTestCache(time.Minute, time.Second)
+
+	_, generation, _ := cache.get(1)
+	cache.invalidate(1)
+	cache.put(1, &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}, nil, generation)
+
+	_, _, ok := cache.get(1)
+	assert.False(t, ok)
+}
+
+func TestOperationCache_PrunesExpiredEntries(t *testing.T) {
+	cache, now := newTestCache(time.Minute, time.Second)
+
+	notFound := utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "not found")
+	for operationId := 1; operationId < operationCacheMinPruneSize; operationId++ {
+		_, generation, _ := cache.get(operationId)
+		cache.put(operationId, nil, notFound, generation)
+	}
+	*now = now.Add(time.Second)
+
+	_, generation, _ := cache.get(0)
+	cache.put(0, &entityDbV1Package.Operation{Model: gorm.Model{ID: 0}}, nil, generation)
+	assert.Equal(t, 1, cache.stats().Entries)
+}
+
+func TestOperationCache_ConcurrentAccess(t *testing.T) {
+	cache := newOperationCache(time.Minute, time.Second)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				operationId := i % 5
+				if _, generation, ok := cache.get(operationId); !ok {
+					cache.put(operationId, &entityDbV1Package.Operation{Model: gorm.Model{ID: uint(operationId)}}, nil, generation)
+				}
+				if i%50 == worker {
+					cache.invalidate(operationId)
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	stats := cache.stats()
+	assert.Equal(t, int64(8*200), stats.Hits+stats.Misses)
+}
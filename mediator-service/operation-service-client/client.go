@@ -2,6 +2,13 @@ package mediator_ops_client_v1
 
 import (
 	coreV1Package "anti-fraud/operation-service/core/v1"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -10,48 +17,171 @@ import (
 // IOperationClient defines methods interface for operation-related data
 // via the operation core service.
 type IOperationClient interface {
-	// SetupCore injects the IOperationCore dependency.
+	// SetupCore injects the IOperationCore dependency and subscribes the cache to operation type changes.
 	SetupCore(operationCoreV1 coreV1Package.IOperationCore)
 
-	// GetOperationCoefficient fetches the coefficient for a specific operation ID.
-	GetOperationCoefficient(logger *logrus.Entry, operationId int, tx *gorm.DB) (int, error)
+	// GetOperationCoefficient fetches the coefficient for a specific operation ID, as of the transaction time.
+	GetOperationCoefficient(ctx context.Context, operationId int, at time.Time, tx *gorm.DB) (int, error)
+
+	// ComputeOperation computes the final amount and side effects of an input with the handler of its operation type.
+	ComputeOperation(ctx context.Context, input *OperationInput, tx *gorm.DB) (*OperationResult, error)
+
+	// ComputeFees computes the fees charged on a transaction of an operation type, for the account tier.
+	ComputeFees(ctx context.Context, input *FeeInput, tx *gorm.DB) ([]*Fee, error)
+
+	// CacheStats returns the counters of the operation type cache of the client.
+	CacheStats() OperationCacheStats
 }
 
 // OperationClient implements IOperationClient, acting as a mediator to the operation core service.
+// Operation types are read on every transaction, so the client caches them, unknown ids included.
 type OperationClient struct {
 	operationCoreV1 coreV1Package.IOperationCore
 	logger          *logrus.Logger
+	cache           *operationCache
 }
 
-// NewOperationClient create new instance of OperationClient.
-func NewOperationClient(logger *logrus.Logger) *OperationClient {
+// NewOperationClient create new instance of OperationClient, caching operation types as configured.
+func NewOperationClient(logger *logrus.Logger, cacheConfig utilConfig.OperationCacheConfig) *OperationClient {
 
-	return &OperationClient{logger: logger}
+	return &OperationClient{logger: logger, cache: newOperationCache(cacheConfig.TTL, cacheConfig.NegativeTTL)}
 }
 
-// SetupCore injects the IOperationCore into this client.
+// SetupCore injects the IOperationCore into this client, and drops an operation type from the cache
+// whenever the operation admin API changes it.
 func (client *OperationClient) SetupCore(operationCoreV1 coreV1Package.IOperationCore) {
 	client.operationCoreV1 = operationCoreV1
+	operationCoreV1.OnOperationChanged(client.InvalidateOperation)
+}
+
+// InvalidateOperation drops an operation type from the cache, so the next lookup loads it again.
+func (client *OperationClient) InvalidateOperation(operationId int) {
+	client.cache.invalidate(operationId)
+}
+
+// CacheStats returns the hit and miss counts and the size of the operation type cache.
+func (client *OperationClient) CacheStats() OperationCacheStats {
+	return client.cache.stats()
+}
+
+// getOperation returns an operation type from the cache, loading it through the operation core on a miss.
+// A not found error is cached as well, so repeated lookups of an unknown id do not reach the DB.
+func (client *OperationClient) getOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	entry, generation, ok := client.cache.get(operationId)
+	if ok {
+		return entry.operation, entry.err
+	}
+
+	stats := client.cache.stats()
+	logger.Infof("Operation type %d is not cached, loading it (cache hits: %d, misses: %d).", operationId, stats.Hits, stats.Misses)
+	operation, err := client.operationCoreV1.GetOperation(ctx, operationId, tx)
+	if err != nil {
+		if utilErrorsV1.IsNotFound(err) {
+			client.cache.put(operationId, nil, err, generation)
+		}
+		return nil, err
+	}
+	client.cache.put(operationId, operation, nil, generation)
+	return operation, nil
 }
 
 // GetOperationCoefficient retrieves the coefficient for the given operation ID.
 //
 // Steps:
-//  1. Delegate to operation core layer to fetch the coefficient from the DB.
-//  2. Return the coefficient or an encountered error.
+//  1. Fetch the operation type from the cache, or from the DB through the operation core layer.
+//  2. Delegate to operation core layer to check the type is in effect and return its coefficient.
 //
 // Parameters:
 //   - operationId: Unique identifier for the operation.
+//   - at:          event time of the transaction the coefficient is applied to.
 //   - tx:          db txn.
 //
 // Returns:
 //   - int:   The coefficient associated with the operation ID.
 //   - error: an encountered Error.
-func (client *OperationClient) GetOperationCoefficient(logger *logrus.Entry, operationId int, tx *gorm.DB) (int, error) {
+func (client *OperationClient) GetOperationCoefficient(ctx context.Context, operationId int, at time.Time, tx *gorm.DB) (int, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetOperationCoefficient method called in mediator-service for operation client.")
-	coef, err := client.operationCoreV1.GetOperationCoefficient(logger, operationId, tx)
+	operation, err := client.getOperation(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching operation via operation service: %s", err.Error())
+		return 0, err
+	}
+	coef, err := client.operationCoreV1.GetOperationCoefficient(ctx, operation, at)
 	if err != nil {
 		logger.Errorf("Error occured while fetching coefficient associated on operation via operation service: %s", err.Error())
 	}
 	return coef, err
 }
+
+// ComputeOperation calls the core's ComputeOperation method.
+//
+// Steps:
+//  1. Fetch the operation type from the cache, or from the DB through the operation core layer.
+//  2. Map the mediator-level input to the operation-service input.
+//  3. Delegate to operation core layer, which resolves the handler of the operation type.
+//  4. Map the outcome to a mediator-level OperationResult.
+//
+// Parameters:
+//   - input: account, operation type, amount as submitted and event time of the operation.
+//   - tx:    db txn.
+//
+// Returns:
+//   - *OperationResult: final signed amount, applied coefficient and side effects.
+//   - error:            an encountered Error.
+func (client *OperationClient) ComputeOperation(ctx context.Context, input *OperationInput, tx *gorm.DB) (*OperationResult, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeOperation method called in mediator-service for operation client.")
+	operation, err := client.getOperation(ctx, input.OperationTypeId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching operation via operation service: %s", err.Error())
+		return nil, err
+	}
+	result, err := client.operationCoreV1.ComputeOperation(ctx, operation, &entityCoreV1Package.OperationInput{
+		AccountId:        input.AccountId,
+		OperationTypeId:  input.OperationTypeId,
+		Amount:           input.Amount,
+		InstallmentCount: input.InstallmentCount,
+		Hold:             input.Hold,
+		At:               input.At,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error occured while computing operation via operation service: %s", err.Error())
+		return nil, err
+	}
+	return &OperationResult{
+		Amount:           result.Amount,
+		Coefficient:      result.Coefficient,
+		InstallmentCount: result.InstallmentCount,
+	}, nil
+}
+
+// ComputeFees calls the core's ComputeFees method.
+//
+// Parameters:
+//   - input: operation type, account tier, currency and final amount of the transaction.
+//   - tx:    db txn.
+//
+// Returns:
+//   - []*Fee: the fees to charge, by code; empty when none applies.
+//   - error:  an encountered Error.
+func (client *OperationClient) ComputeFees(ctx context.Context, input *FeeInput, tx *gorm.DB) ([]*Fee, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeFees method called in mediator-service for operation client.")
+	operationFees, err := client.operationCoreV1.ComputeFees(ctx, &entityCoreV1Package.FeeInput{
+		OperationTypeId: input.OperationTypeId,
+		AccountTier:     input.AccountTier,
+		Currency:        input.Currency,
+		Amount:          input.Amount,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error occured while computing fees via operation service: %s", err.Error())
+		return nil, err
+	}
+	fees := make([]*Fee, 0, len(operationFees))
+	for _, operationFee := range operationFees {
+		fees = append(fees, &Fee{Code: operationFee.Code, Amount: operationFee.Amount, OperationTypeId: operationFee.OperationTypeId})
+	}
+	return fees, nil
+}
@@ -1,8 +1,17 @@
 package mediator_ops_client_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	handlersV1Package "anti-fraud/operation-service/handlers/v1"
+	utilConfig "anti-fraud/utils-server/config"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -18,44 +27,142 @@ type MockOperationCore struct {
 	mock.Mock
 }
 
-func (m *MockOperationCore) GetOperationCoefficient(logger *logrus.Entry, operationId int, tx *gorm.DB) (int, error) {
-	args := m.Called(operationId, tx)
+func (m *MockOperationCore) RegisterHandler(handler handlersV1Package.IOperationHandler) {
+	m.Called(handler)
+}
+
+func (m *MockOperationCore) OnOperationChanged(listener func(operationId int)) {
+	m.Called(listener)
+}
+
+func (m *MockOperationCore) NotifyOperationChanged(ctx context.Context, operationId int) {
+	m.Called(operationId)
+}
+
+func (m *MockOperationCore) ComputeOperation(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	args := m.Called(operation, input, tx)
+	result, _ := args.Get(0).(*entityCoreV1Package.OperationResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOperationCore) ComputeFees(ctx context.Context, input *entityCoreV1Package.FeeInput, tx *gorm.DB) ([]*entityCoreV1Package.Fee, error) {
+	args := m.Called(input, tx)
+	fees, _ := args.Get(0).([]*entityCoreV1Package.Fee)
+	return fees, args.Error(1)
+}
+
+func (m *MockOperationCore) GetOperationCoefficient(ctx context.Context, operation *entityDbV1Package.Operation, at time.Time) (int, error) {
+	args := m.Called(operation, at)
 	coef, _ := args.Get(0).(int)
 	return coef, args.Error(1)
 }
 
+func (m *MockOperationCore) GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error) {
+	args := m.Called(status, tx)
+	operations, _ := args.Get(0).([]*entityDbV1Package.Operation)
+	return operations, args.Error(1)
+}
+
+func (m *MockOperationCore) CreateOperation(ctx context.Context, createPayload *entityCoreV1Package.CreateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(createPayload, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) UpdateOperation(ctx context.Context, operationId int, updatePayload *entityCoreV1Package.UpdateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, updatePayload, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) DeprecateOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
 //-------------------------------------------//
 // Unit Tests for OperationClient
 //-------------------------------------------//
 
-func TestOperationClient_GetOperationCoefficient_Success(t *testing.T) {
-	logger := logrus.New()
-	client := NewOperationClient(logger)
+func setupTestClient(cacheConfig utilConfig.OperationCacheConfig) (*OperationClient, *MockOperationCore) {
+	client := NewOperationClient(logrus.New(), cacheConfig)
 	mockCore := new(MockOperationCore)
-
+	mockCore.On("OnOperationChanged", mock.Anything).Return()
 	client.SetupCore(mockCore)
+	return client, mockCore
+}
+
+var testCacheConfig = utilConfig.OperationCacheConfig{TTL: time.Minute, NegativeTTL: time.Minute}
 
-	mockCore.On("GetOperationCoefficient", 10, mock.Anything).
+func TestOperationClient_GetOperationCoefficient_Success(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 10}, Coefficient: 3}
+	mockCore.On("GetOperation", 10, mock.Anything).Return(operation, nil)
+	mockCore.On("GetOperationCoefficient", operation, mock.Anything).
 		Return(3, nil)
 
-	coef, err := client.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 10, &gorm.DB{})
+	coef, err := client.GetOperationCoefficient(context.Background(), 10, time.Now(), &gorm.DB{})
 	assert.NoError(t, err)
 	assert.Equal(t, 3, coef)
 
 	mockCore.AssertExpectations(t)
 }
 
-func TestOperationClient_GetOperationCoefficient_Error(t *testing.T) {
-	logger := logrus.New()
-	client := NewOperationClient(logger)
-	mockCore := new(MockOperationCore)
+func TestOperationClient_ComputeOperation(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
 
-	client.SetupCore(mockCore)
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}
+	mockCore.On("GetOperation", 2, mock.Anything).Return(operation, nil)
+	mockCore.On("ComputeOperation", operation, &entityCoreV1Package.OperationInput{AccountId: 1, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("100"), InstallmentCount: 3, At: at}, mock.Anything).
+		Return(&entityCoreV1Package.OperationResult{Amount: utilMoneyV1.MustParse("-100"), Coefficient: -1, InstallmentCount: 3}, nil)
+
+	result, err := client.ComputeOperation(context.Background(), &OperationInput{AccountId: 1, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("100"), InstallmentCount: 3, At: at}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, &OperationResult{Amount: utilMoneyV1.MustParse("-100"), Coefficient: -1, InstallmentCount: 3}, result)
+	mockCore.AssertExpectations(t)
+}
+
+func TestOperationClient_ComputeOperation_Error(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	mockCore.On("GetOperation", 2, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 2}}, nil)
+	mockCore.On("ComputeOperation", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+
+	result, err := client.ComputeOperation(context.Background(), &OperationInput{OperationTypeId: 2}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestOperationClient_ComputeFees(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	mockCore.On("ComputeFees", &entityCoreV1Package.FeeInput{OperationTypeId: 3, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-100")}, mock.Anything).
+		Return([]*entityCoreV1Package.Fee{{Code: "WITHDRAWAL_FEE", Amount: utilMoneyV1.MustParse("2.5"), OperationTypeId: 9}}, nil)
 
-	mockCore.On("GetOperationCoefficient", 20, mock.Anything).
+	fees, err := client.ComputeFees(context.Background(), &FeeInput{OperationTypeId: 3, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-100")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Fee{{Code: "WITHDRAWAL_FEE", Amount: utilMoneyV1.MustParse("2.5"), OperationTypeId: 9}}, fees)
+	mockCore.AssertExpectations(t)
+}
+
+func TestOperationClient_GetOperationCoefficient_Error(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 20}}
+	mockCore.On("GetOperation", 20, mock.Anything).Return(operation, nil)
+	mockCore.On("GetOperationCoefficient", operation, mock.Anything).
 		Return(0, errors.New("some error"))
 
-	coef, err := client.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 20, &gorm.DB{})
+	coef, err := client.GetOperationCoefficient(context.Background(), 20, time.Now(), &gorm.DB{})
 	assert.Error(t, err)
 	assert.Equal(t, 0, coef)
 
@@ -63,16 +170,109 @@ func TestOperationClient_GetOperationCoefficient_Error(t *testing.T) {
 }
 
 func TestOperationClient_SetupCore(t *testing.T) {
-	logger := logrus.New()
-	client := NewOperationClient(logger)
-	mockCore := new(MockOperationCore)
-	client.SetupCore(mockCore)
+	client, mockCore := setupTestClient(testCacheConfig)
 
-	mockCore.On("GetOperationCoefficient", 1, mock.Anything).Return(5, nil)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: 5}
+	mockCore.On("GetOperation", 1, mock.Anything).Return(operation, nil)
+	mockCore.On("GetOperationCoefficient", operation, mock.Anything).Return(5, nil)
 
-	c, err := client.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 1, &gorm.DB{})
+	c, err := client.GetOperationCoefficient(context.Background(), 1, time.Now(), &gorm.DB{})
 	assert.NoError(t, err)
 	assert.Equal(t, 5, c)
 
 	mockCore.AssertExpectations(t)
 }
+
+//-------------------------------------------//
+// Operation type cache
+//-------------------------------------------//
+
+func TestOperationClient_CachesOperation(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}
+	mockCore.On("GetOperation", 1, mock.Anything).Return(operation, nil).Once()
+	mockCore.On("GetOperationCoefficient", mock.Anything, mock.Anything).Return(-1, nil)
+
+	for i := 0; i < 3; i++ {
+		coef, err := client.GetOperationCoefficient(context.Background(), 1, time.Now(), &gorm.DB{})
+		assert.NoError(t, err)
+		assert.Equal(t, -1, coef)
+	}
+
+	mockCore.AssertNumberOfCalls(t, "GetOperation", 1)
+	assert.Equal(t, OperationCacheStats{Hits: 2, Misses: 1, Entries: 1}, client.CacheStats())
+}
+
+func TestOperationClient_CachesNotFound(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	notFound := utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 99 not found in database")
+	mockCore.On("GetOperation", 99, mock.Anything).Return(nil, notFound).Once()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetOperationCoefficient(context.Background(), 99, time.Now(), &gorm.DB{})
+		assert.True(t, utilErrorsV1.IsNotFound(err))
+	}
+
+	mockCore.AssertNumberOfCalls(t, "GetOperation", 1)
+	mockCore.AssertNotCalled(t, "GetOperationCoefficient", mock.Anything, mock.Anything)
+}
+
+func TestOperationClient_DoesNotCacheOtherErrors(t *testing.T) {
+	client, mockCore := setupTestClient(testCacheConfig)
+
+	mockCore.On("GetOperation", 2, mock.Anything).Return(nil, errors.New("db error"))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetOperationCoefficient(context.Background(), 2, time.Now(), &gorm.DB{})
+		assert.EqualError(t, err, "db error")
+	}
+
+	mockCore.AssertNumberOfCalls(t, "GetOperation", 2)
+	assert.Equal(t, 0, client.CacheStats().Entries)
+}
+
+func TestOperationClient_InvalidatedOnOperationChanged(t *testing.T) {
+	client := NewOperationClient(logrus.New(), testCacheConfig)
+	mockCore := new(MockOperationCore)
+
+	var listener func(operationId int)
+	mockCore.On("OnOperationChanged", mock.Anything).Run(func(args mock.Arguments) {
+		listener = args.Get(0).(func(operationId int))
+	}).Return()
+	client.SetupCore(mockCore)
+
+	mockCore.On("GetOperation", 1, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}, nil).Once()
+	mockCore.On("GetOperation", 1, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: 1}, nil).Once()
+	for _, coefficient := range []int{-1, 1} {
+		mockCore.On("GetOperationCoefficient", mock.MatchedBy(func(operation *entityDbV1Package.Operation) bool { return operation.Coefficient == coefficient }), mock.Anything).
+			Return(coefficient, nil)
+	}
+
+	coef, err := client.GetOperationCoefficient(context.Background(), 1, time.Now(), &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, coef)
+
+	listener(1)
+
+	coef, err = client.GetOperationCoefficient(context.Background(), 1, time.Now(), &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, coef)
+	mockCore.AssertNumberOfCalls(t, "GetOperation", 2)
+}
+
+func TestOperationClient_CacheDisabled(t *testing.T) {
+	client, mockCore := setupTestClient(utilConfig.OperationCacheConfig{TTL: -1})
+
+	mockCore.On("GetOperation", 1, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}, nil)
+	mockCore.On("GetOperationCoefficient", mock.Anything, mock.Anything).Return(-1, nil)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetOperationCoefficient(context.Background(), 1, time.Now(), &gorm.DB{})
+		assert.NoError(t, err)
+	}
+
+	mockCore.AssertNumberOfCalls(t, "GetOperation", 2)
+	assert.Equal(t, OperationCacheStats{Hits: 0, Misses: 2, Entries: 0}, client.CacheStats())
+}
@@ -0,0 +1,38 @@
+package mediator_ops_client_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// OperationInput is the mediator-level request to compute a transaction or authorization hold.
+type OperationInput struct {
+	AccountId        int
+	OperationTypeId  int
+	Amount           utilMoneyV1.Amount // as submitted, sign included
+	InstallmentCount int                // 0 when not given
+	Hold             bool               // an authorization hold, settled later by a capture
+	At               time.Time          // event time
+}
+
+// OperationResult is the mediator-level outcome of the handler of an operation type.
+type OperationResult struct {
+	Amount           utilMoneyV1.Amount // final signed amount
+	Coefficient      int                // coefficient applied to the input
+	InstallmentCount int                // number of installments Amount is split in, 0 for none
+}
+
+// FeeInput is the mediator-level transaction the fees of its operation type are charged on.
+type FeeInput struct {
+	OperationTypeId int
+	AccountTier     string
+	Currency        string             // the account currency
+	Amount          utilMoneyV1.Amount // final signed amount, in Currency
+}
+
+// Fee is the mediator-level view of one fee charged on a transaction, positive, in the account currency.
+type Fee struct {
+	Code            string
+	Amount          utilMoneyV1.Amount
+	OperationTypeId int // the operation type the fee is posted with
+}
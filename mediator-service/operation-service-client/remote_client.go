@@ -0,0 +1,141 @@
+package mediator_ops_client_v1
+
+import (
+	coreV1Package "anti-fraud/operation-service/core/v1"
+	entityHttpV1Package "anti-fraud/operation-service/entity/http/v1"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilRemoteV1 "anti-fraud/utils-server/remote/v1"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RemoteOperationClient implements IOperationClient over HTTP/JSON, calling the internal endpoints of
+// an operation-service deployed on its own. Operation types are not cached: the owning service reads them
+// in its own db txn, so the tx argument is ignored.
+type RemoteOperationClient struct {
+	client *utilRemoteV1.Client
+	logger *logrus.Logger
+}
+
+// NewRemoteOperationClient create new instance of RemoteOperationClient, calling operation-service as configured.
+func NewRemoteOperationClient(logger *logrus.Logger, config utilConfig.RemoteServiceConfig) *RemoteOperationClient {
+
+	return &RemoteOperationClient{client: utilRemoteV1.NewClient("operation-service", config), logger: logger}
+}
+
+// SetupCore does nothing: a remote client reaches operation-service over HTTP, not through its core.
+func (client *RemoteOperationClient) SetupCore(operationCoreV1 coreV1Package.IOperationCore) {}
+
+// CacheStats returns zero counters: a remote client caches nothing.
+func (client *RemoteOperationClient) CacheStats() OperationCacheStats {
+	return OperationCacheStats{}
+}
+
+// GetOperationCoefficient fetches the coefficient of an operation type from the internal operation endpoint.
+//
+// Parameters:
+//   - operationId: Unique identifier for the operation.
+//   - at:          event time of the transaction the coefficient is applied to.
+//   - tx:          ignored, see RemoteOperationClient.
+//
+// Returns:
+//   - int:   The coefficient associated with the operation ID.
+//   - error: the error answered by operation-service, or a 503 one when it cannot be reached.
+func (client *RemoteOperationClient) GetOperationCoefficient(ctx context.Context, operationId int, at time.Time, tx *gorm.DB) (int, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOperationCoefficient method called in mediator-service for remote operation client.")
+
+	var response entityHttpV1Package.CoefficientResponse
+	err := client.client.Do(ctx, &utilRemoteV1.Request{
+		Method: http.MethodGet,
+		Path:   fmt.Sprintf("/internal/operation-types/v1/%d/coefficient", operationId),
+		Query:  url.Values{"at": []string{at.Format(time.RFC3339Nano)}},
+	}, &response)
+	if err != nil {
+		logger.Errorf("Error occured while fetching coefficient associated on operation via operation service: %s", err.Error())
+		return 0, err
+	}
+	return response.Coefficient, nil
+}
+
+// ComputeOperation computes an input with the handler of its operation type through the internal operation endpoint.
+//
+// Parameters:
+//   - input: account, operation type, amount as submitted and event time of the operation.
+//   - tx:    ignored, see RemoteOperationClient.
+//
+// Returns:
+//   - *OperationResult: final signed amount, applied coefficient and side effects.
+//   - error:            the error answered by operation-service, or a 503 one when it cannot be reached.
+func (client *RemoteOperationClient) ComputeOperation(ctx context.Context, input *OperationInput, tx *gorm.DB) (*OperationResult, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeOperation method called in mediator-service for remote operation client.")
+
+	var response struct {
+		Result entityHttpV1Package.OperationResultResponse `json:"result"`
+	}
+	err := client.client.Do(ctx, &utilRemoteV1.Request{
+		Method: http.MethodPost,
+		Path:   fmt.Sprintf("/internal/operation-types/v1/%d/compute", input.OperationTypeId),
+		Body: &entityHttpV1Package.ComputeOperationRequest{
+			AccountId:        input.AccountId,
+			Amount:           input.Amount,
+			InstallmentCount: input.InstallmentCount,
+			Hold:             input.Hold,
+			At:               input.At,
+		},
+	}, &response)
+	if err != nil {
+		logger.Errorf("Error occured while computing operation via operation service: %s", err.Error())
+		return nil, err
+	}
+	return &OperationResult{
+		Amount:           response.Result.Amount,
+		Coefficient:      response.Result.Coefficient,
+		InstallmentCount: response.Result.InstallmentCount,
+	}, nil
+}
+
+// ComputeFees computes the fees charged on a transaction through the internal operation endpoint.
+//
+// Parameters:
+//   - input: operation type, account tier, currency and final amount of the transaction.
+//   - tx:    ignored, see RemoteOperationClient.
+//
+// Returns:
+//   - []*Fee: the fees to charge, by code; empty when none applies.
+//   - error:  the error answered by operation-service, or a 503 one when it cannot be reached.
+func (client *RemoteOperationClient) ComputeFees(ctx context.Context, input *FeeInput, tx *gorm.DB) ([]*Fee, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeFees method called in mediator-service for remote operation client.")
+
+	var response struct {
+		Fees []*entityHttpV1Package.FeeResponse `json:"fees"`
+	}
+	err := client.client.Do(ctx, &utilRemoteV1.Request{
+		Method: http.MethodPost,
+		Path:   "/internal/operation-types/v1/fees",
+		Body: &entityHttpV1Package.ComputeFeesRequest{
+			OperationTypeId: input.OperationTypeId,
+			AccountTier:     input.AccountTier,
+			Currency:        input.Currency,
+			Amount:          input.Amount,
+		},
+	}, &response)
+	if err != nil {
+		logger.Errorf("Error occured while computing fees via operation service: %s", err.Error())
+		return nil, err
+	}
+	fees := make([]*Fee, 0, len(response.Fees))
+	for _, fee := range response.Fees {
+		fees = append(fees, &Fee{Code: fee.Code, Amount: fee.Amount, OperationTypeId: fee.OperationTypeId})
+	}
+	return fees, nil
+}
@@ -0,0 +1,108 @@
+package mediator_ops_client_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	controllerV1Package "anti-fraud/operation-service/controllers/v1"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	routerV1Package "anti-fraud/operation-service/routes/v1"
+	utilConfig "anti-fraud/utils-server/config"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupRemoteOperationClient serves the operation routes, backed by a mock core, and points a remote client at them.
+func setupRemoteOperationClient(t *testing.T) (*RemoteOperationClient, *MockOperationCore) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+
+	logger := logrus.New()
+	mockCore := new(MockOperationCore)
+	router := mux.NewRouter()
+	controller := controllerV1Package.NewOperationController(mockCore, db, logger)
+	routerV1Package.NewOperationRoutes(controller, router, middlewareHandlerPackageV1.NewMiddlewareHandler(logger)).Init()
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	client := NewRemoteOperationClient(logger, utilConfig.RemoteServiceConfig{
+		BaseURL:          server.URL,
+		Timeout:          time.Second,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+	})
+	return client, mockCore
+}
+
+func TestRemoteOperationClient_GetOperationCoefficient(t *testing.T) {
+	client, mockCore := setupRemoteOperationClient(t)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}
+	at := time.Date(2026, 3, 1, 10, 0, 0, 123, time.UTC)
+
+	mockCore.On("GetOperation", 1, mock.Anything).Return(operation, nil)
+	mockCore.On("GetOperationCoefficient", operation, at).Return(-1, nil)
+
+	coef, err := client.GetOperationCoefficient(context.Background(), 1, at, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, -1, coef)
+	mockCore.AssertExpectations(t)
+}
+
+func TestRemoteOperationClient_GetOperationCoefficient_NotFound(t *testing.T) {
+	client, mockCore := setupRemoteOperationClient(t)
+
+	mockCore.On("GetOperation", 9, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 9 not found in database"))
+
+	_, err := client.GetOperationCoefficient(context.Background(), 9, time.Now(), nil)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appError.Status)
+	assert.Equal(t, errorConstantPackage.OPERATION_TYPE_NOT_FOUND, appError.Code)
+}
+
+func TestRemoteOperationClient_ComputeOperation(t *testing.T) {
+	client, mockCore := setupRemoteOperationClient(t)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mockCore.On("GetOperation", 2, mock.Anything).Return(operation, nil)
+	mockCore.On("ComputeOperation", operation, &entityCoreV1Package.OperationInput{AccountId: 7, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("90.10"), InstallmentCount: 3, At: at}, mock.Anything).
+		Return(&entityCoreV1Package.OperationResult{Amount: utilMoneyV1.MustParse("-90.10"), Coefficient: -1, InstallmentCount: 3}, nil)
+
+	result, err := client.ComputeOperation(context.Background(), &OperationInput{AccountId: 7, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("90.10"), InstallmentCount: 3, At: at}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &OperationResult{Amount: utilMoneyV1.MustParse("-90.10"), Coefficient: -1, InstallmentCount: 3}, result)
+	mockCore.AssertExpectations(t)
+}
+
+func TestRemoteOperationClient_ComputeFees(t *testing.T) {
+	client, mockCore := setupRemoteOperationClient(t)
+
+	mockCore.On("ComputeFees", &entityCoreV1Package.FeeInput{OperationTypeId: 4, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-200")}, mock.Anything).
+		Return([]*entityCoreV1Package.Fee{{Code: "WITHDRAWAL", Amount: utilMoneyV1.MustParse("2.5"), OperationTypeId: 9}}, nil)
+
+	fees, err := client.ComputeFees(context.Background(), &FeeInput{OperationTypeId: 4, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-200")}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Fee{{Code: "WITHDRAWAL", Amount: utilMoneyV1.MustParse("2.5"), OperationTypeId: 9}}, fees)
+	mockCore.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package mediator_outbox_v1
+
+import (
+	constantPackage "anti-fraud/constants/outbox"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is a domain event waiting in the db to be delivered to the sinks.
+type OutboxEvent struct {
+	gorm.Model
+	EventName     string     `json:"event_name"`
+	Payload       string     `json:"payload"`         // the event encoded as JSON
+	Attempts      int        `json:"attempts"`        // failed delivery rounds so far
+	LastError     string     `json:"last_error"`      // error of the last failed round
+	NextAttemptAt time.Time  `json:"next_attempt_at"` // the relay leaves the event alone until then
+	DispatchedAt  *time.Time `json:"dispatched_at"`   // set once every sink accepted the event
+	DeadAt        *time.Time `json:"dead_at"`         // set once the event failed max attempts, so it is not delivered again
+}
+
+func (OutboxEvent) TableName() string {
+	return constantPackage.TABLE_NAME
+}
+
+// Message is the envelope delivered to a sink. Delivery is at least once: a consumer should drop an
+// Id it has already processed.
+type Message struct {
+	Id         uint            `json:"id"`
+	EventName  string          `json:"event_name"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
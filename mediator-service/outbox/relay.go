@@ -0,0 +1,170 @@
+package mediator_outbox_v1
+
+import (
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IOutboxRelay defines methods interface for the background job delivering outbox events to the sinks.
+type IOutboxRelay interface {
+
+	// Start runs a relay round every interval in a background goroutine until Stop is called.
+	Start()
+
+	// Stop ends the background goroutine, waiting for a round in progress to finish.
+	Stop()
+
+	// Relay delivers one batch of pending events in its own db txn and returns how many were dispatched.
+	Relay() (int, error)
+}
+
+// OutboxRelay implements IOutboxRelay interface.
+//
+// Delivery is at least once: an event is marked dispatched only after every sink accepted it, so a
+// failure of one sink, or a crash before the commit, delivers it again, to every sink, on a later round.
+// A failed event waits an exponential backoff before its next delivery, and is marked dead after
+// MaxAttempts failures; it does not hold the others back, so events may reach a sink out of order.
+type OutboxRelay struct {
+	store    IOutboxStore
+	sinks    []ISink
+	db       *gorm.DB
+	logger   *logrus.Logger
+	config   utilConfig.OutboxConfig
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutboxRelay creates and returns new OutboxRelay instance, polling and backing off as config sets.
+func NewOutboxRelay(store IOutboxStore, sinks []ISink, db *gorm.DB, logger *logrus.Logger, config utilConfig.OutboxConfig) *OutboxRelay {
+	return &OutboxRelay{store: store, sinks: sinks, db: db, logger: logger, config: config, stop: make(chan struct{}), done: make(chan struct{})}
+}
+
+// Start launches the relay loop.
+//
+// Workflow:
+//  1. Wait for the next tick of the interval, or for Stop.
+//  2. Relay batches until one dispatches fewer events than the batch size, so a backlog is cleared
+//     within one tick. Failed rounds are retried on the next tick, failed events once their backoff ends.
+func (relay *OutboxRelay) Start() {
+	relay.logger.Infof("Outbox relay started, interval: %s, sinks: %d", relay.config.PollInterval, len(relay.sinks))
+	go func() {
+		defer close(relay.done)
+		ticker := time.NewTicker(relay.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-relay.stop:
+				return
+			case <-ticker.C:
+				for {
+					dispatched, err := relay.Relay()
+					if err != nil || dispatched < relay.config.BatchSize {
+						break
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop signals the relay loop to end and waits for it. It must only be called after Start.
+func (relay *OutboxRelay) Stop() {
+	relay.stopOnce.Do(func() { close(relay.stop) })
+	<-relay.done
+}
+
+// Relay delivers the pending events.
+//
+// Workflow:
+//  1. Begin db txn.
+//  2. Fetch and lock one batch of pending events that are due.
+//  3. Deliver each to every sink; mark it dispatched if all accepted it, or count the failed attempt,
+//     backing the event off or marking it dead. A sink that is unavailable ends the round there, so the
+//     rows are not kept locked while the rest of the batch fails the same way; they are not counted as failed.
+//  4. Commit db txn.
+//
+// Returns:
+//   - The number of events dispatched.
+//   - An encountered Error.
+func (relay *OutboxRelay) Relay() (int, error) {
+	logger := relay.logger.WithField("job", "outbox_relay")
+	ctx, tx := utilContextV1.Begin(utilContextV1.WithLogger(context.Background(), logger), relay.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	outboxEvents, err := relay.store.GetPendingEvents(ctx, relay.config.BatchSize, time.Now(), tx)
+	if err != nil {
+		logger.Errorf("Error fetching pending outbox events: %v", err)
+		return 0, err
+	}
+	dispatched := 0
+	failed := 0
+	for _, outboxEvent := range outboxEvents {
+		if deliveryErr := relay.deliver(ctx, outboxEvent); deliveryErr != nil {
+			if err := relay.markFailed(ctx, outboxEvent, deliveryErr, tx); err != nil {
+				return 0, err
+			}
+			failed++
+			if errors.Is(deliveryErr, ErrSinkUnavailable) {
+				logger.Warnf("Ending the relay round after %d of %d outbox events: %v", dispatched+failed, len(outboxEvents), deliveryErr)
+				break
+			}
+			continue
+		}
+		if err := relay.store.MarkDispatched(ctx, outboxEvent, time.Now(), tx); err != nil {
+			return 0, err
+		}
+		dispatched++
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		return 0, err
+	}
+	if dispatched > 0 || failed > 0 {
+		logger.Infof("Dispatched %d outbox events, %d failed", dispatched, failed)
+	}
+	return dispatched, nil
+}
+
+// markFailed counts a failed delivery of an event: it is retried after a backoff of BackoffBase doubled
+// for each earlier failure, capped at BackoffMax, or marked dead once it failed MaxAttempts times.
+func (relay *OutboxRelay) markFailed(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	now := time.Now()
+	if outboxEvent.Attempts+1 >= relay.config.MaxAttempts {
+		logger.Errorf("Delivery of outbox event %d (%s) failed %d times, marking it dead: %v", outboxEvent.ID, outboxEvent.EventName, outboxEvent.Attempts+1, deliveryErr)
+		return relay.store.MarkDead(ctx, outboxEvent, deliveryErr, now, tx)
+	}
+	backoff := relay.config.BackoffBase
+	for i := 0; i < outboxEvent.Attempts && backoff < relay.config.BackoffMax; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, relay.config.BackoffMax)
+	logger.Warnf("Delivery of outbox event %d (%s) failed, retrying in %s: %v", outboxEvent.ID, outboxEvent.EventName, backoff, deliveryErr)
+	return relay.store.MarkFailed(ctx, outboxEvent, deliveryErr, now.Add(backoff), tx)
+}
+
+// deliver hands one event to every sink, stopping at the first failure.
+func (relay *OutboxRelay) deliver(ctx context.Context, outboxEvent *OutboxEvent) error {
+	message := &Message{
+		Id:         outboxEvent.ID,
+		EventName:  outboxEvent.EventName,
+		OccurredAt: outboxEvent.CreatedAt,
+		Payload:    json.RawMessage(outboxEvent.Payload),
+	}
+	for _, sink := range relay.sinks {
+		if err := sink.Deliver(ctx, message); err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package mediator_outbox_v1
+
+import (
+	utilConfig "anti-fraud/utils-server/config"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// recordingSink keeps the messages it accepts, and fails while err is set.
+type recordingSink struct {
+	messages []*Message
+	calls    int
+	err      error
+}
+
+func (sink *recordingSink) Name() string { return "recording" }
+
+func (sink *recordingSink) Deliver(ctx context.Context, message *Message) error {
+	sink.calls++
+	if sink.err != nil {
+		return sink.err
+	}
+	sink.messages = append(sink.messages, message)
+	return nil
+}
+
+func testRelayConfig(pollInterval time.Duration) utilConfig.OutboxConfig {
+	return utilConfig.OutboxConfig{PollInterval: pollInterval, BatchSize: 10, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: 90 * time.Second}
+}
+
+// makeDue moves the next attempt of every event to the past, as if their backoff had ended.
+func makeDue(db *gorm.DB) {
+	db.Model(&OutboxEvent{}).Where("1 = 1").Update("next_attempt_at", time.Now().Add(-time.Second))
+}
+
+func TestRelay_DispatchesToEverySink(t *testing.T) {
+	db := setupTestDB(t)
+	db.Create(&OutboxEvent{EventName: "account.created", Payload: `{"account_id":1}`})
+	db.Create(&OutboxEvent{EventName: "account.created", Payload: `{"account_id":2}`})
+	first, second := &recordingSink{}, &recordingSink{}
+	relay := NewOutboxRelay(NewOutboxStore(logrus.New()), []ISink{first, second}, db, logrus.New(), testRelayConfig(time.Minute))
+
+	dispatched, err := relay.Relay()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dispatched)
+	assert.Len(t, first.messages, 2)
+	assert.Len(t, second.messages, 2)
+	assert.Equal(t, "account.created", first.messages[0].EventName)
+	assert.JSONEq(t, `{"account_id":1}`, string(first.messages[0].Payload))
+
+	dispatched, err = relay.Relay()
+	assert.NoError(t, err)
+	assert.Zero(t, dispatched, "dispatched events are not delivered again")
+}
+
+func TestRelay_FailedSinkRetriesLater(t *testing.T) {
+	db := setupTestDB(t)
+	db.Create(&OutboxEvent{EventName: "account.created", Payload: `{}`})
+	healthy, failing := &recordingSink{}, &recordingSink{err: errors.New("webhook down")}
+	relay := NewOutboxRelay(NewOutboxStore(logrus.New()), []ISink{healthy, failing}, db, logrus.New(), testRelayConfig(time.Minute))
+
+	dispatched, err := relay.Relay()
+	assert.NoError(t, err)
+	assert.Zero(t, dispatched)
+	var pending OutboxEvent
+	db.First(&pending)
+	assert.Equal(t, 1, pending.Attempts)
+	assert.Equal(t, "sink recording: webhook down", pending.LastError)
+
+	dispatched, err = relay.Relay()
+	assert.NoError(t, err)
+	assert.Zero(t, dispatched)
+	assert.Equal(t, 1, failing.calls, "the event is not delivered again before its backoff ends")
+
+	failing.err = nil
+	makeDue(db)
+	dispatched, err = relay.Relay()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+	assert.Len(t, healthy.messages, 2, "delivery is at least once: the healthy sink gets the event again")
+	assert.Len(t, failing.messages, 1)
+}
+
+func TestRelay_BacksOffThenMarksDead(t *testing.T) {
+	db := setupTestDB(t)
+	db.Create(&OutboxEvent{EventName: "account.created", Payload: `{}`})
+	sink := &recordingSink{err: errors.New("bad request")}
+	relay := NewOutboxRelay(NewOutboxStore(logrus.New()), []ISink{sink}, db, logrus.New(), testRelayConfig(time.Minute))
+
+	var outboxEvent OutboxEvent
+	for _, backoff := range []time.Duration{time.Minute, 90 * time.Second} {
+		start := time.Now()
+		relay.Relay()
+		db.First(&outboxEvent)
+		assert.WithinRange(t, outboxEvent.NextAttemptAt, start.Add(backoff), time.Now().Add(backoff), "doubled from backoff_base, capped at backoff_max")
+		assert.Nil(t, outboxEvent.DeadAt)
+		makeDue(db)
+	}
+
+	relay.Relay()
+	db.First(&outboxEvent)
+	assert.Equal(t, 3, outboxEvent.Attempts)
+	assert.NotNil(t, outboxEvent.DeadAt, "dead after max_attempts failures")
+
+	sink.err = nil
+	makeDue(db)
+	dispatched, err := relay.Relay()
+	assert.NoError(t, err)
+	assert.Zero(t, dispatched, "a dead event is not delivered again")
+	assert.Equal(t, 3, sink.calls)
+}
+
+func TestRelay_UnavailableSinkEndsRound(t *testing.T) {
+	db := setupTestDB(t)
+	for i := 0; i < 3; i++ {
+		db.Create(&OutboxEvent{EventName: "account.created", Payload: `{}`})
+	}
+	sink := &recordingSink{err: fmt.Errorf("%w: connection refused", ErrSinkUnavailable)}
+	relay := NewOutboxRelay(NewOutboxStore(logrus.New()), []ISink{sink}, db, logrus.New(), testRelayConfig(time.Minute))
+
+	dispatched, err := relay.Relay()
+
+	assert.NoError(t, err)
+	assert.Zero(t, dispatched)
+	assert.Equal(t, 1, sink.calls, "the rest of the batch is not tried against an unavailable sink")
+	var outboxEvents []*OutboxEvent
+	db.Order("id").Find(&outboxEvents)
+	assert.Equal(t, []int{1, 0, 0}, []int{outboxEvents[0].Attempts, outboxEvents[1].Attempts, outboxEvents[2].Attempts})
+
+	// A message-specific failure does not end the round.
+	sink.err = errors.New("bad request")
+	relay.Relay()
+	assert.Equal(t, 3, sink.calls)
+}
+
+func TestRelay_StartAndStop(t *testing.T) {
+	db := setupTestDB(t)
+	db.Create(&OutboxEvent{EventName: "account.created", Payload: `{}`})
+	sink := &recordingSink{}
+	relay := NewOutboxRelay(NewOutboxStore(logrus.New()), []ISink{sink}, db, logrus.New(), testRelayConfig(10*time.Millisecond))
+
+	relay.Start()
+	assert.Eventually(t, func() bool {
+		var count int64
+		db.Model(&OutboxEvent{}).Where("dispatched_at IS NOT NULL").Count(&count)
+		return count == 1
+	}, time.Second, 10*time.Millisecond)
+	relay.Stop()
+}
@@ -0,0 +1,162 @@
+package mediator_outbox_v1
+
+import (
+	constantPackage "anti-fraud/constants/outbox"
+	utilConfig "anti-fraud/utils-server/config"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrSinkUnavailable wraps the delivery errors of a sink that cannot be reached at all, as opposed to
+// one rejecting a given message: the relay ends its round on it, since the next events would fail the same way.
+var ErrSinkUnavailable = errors.New("sink unavailable")
+
+// ISink defines methods interface for a destination of outbox events.
+type ISink interface {
+
+	// Name identifies the sink in logs.
+	Name() string
+
+	// Deliver hands one message over; an error makes the relay deliver it again on a later round.
+	// An error wrapping ErrSinkUnavailable also ends the round.
+	Deliver(ctx context.Context, message *Message) error
+}
+
+// NewSinks builds the sinks configured in outbox.sinks.
+func NewSinks(logger *logrus.Logger, configs []utilConfig.OutboxSinkConfig) ([]ISink, error) {
+	sinks := make([]ISink, 0, len(configs))
+	for _, config := range configs {
+		switch config.Type {
+		case constantPackage.SINK_LOG:
+			sinks = append(sinks, NewLogSink(logger))
+		case constantPackage.SINK_FILE:
+			sinks = append(sinks, NewFileSink(config.Path))
+		case constantPackage.SINK_WEBHOOK:
+			sinks = append(sinks, NewWebhookSink(config))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink type %q", config.Type)
+		}
+	}
+	return sinks, nil
+}
+
+// LogSink writes messages to the application log.
+type LogSink struct {
+	logger *logrus.Logger
+}
+
+// NewLogSink creates and returns new LogSink instance.
+func NewLogSink(logger *logrus.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+// Name returns SINK_LOG.
+func (sink *LogSink) Name() string { return constantPackage.SINK_LOG }
+
+// Deliver logs the message at info level.
+func (sink *LogSink) Deliver(ctx context.Context, message *Message) error {
+	sink.logger.WithFields(logrus.Fields{
+		"outbox_id":   message.Id,
+		"event":       message.EventName,
+		"occurred_at": message.OccurredAt,
+		"payload":     string(message.Payload),
+	}).Info("Domain event dispatched.")
+	return nil
+}
+
+// FileSink appends messages as JSON lines to a file, creating it if needed.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates and returns new FileSink instance.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Name returns SINK_FILE.
+func (sink *FileSink) Name() string { return constantPackage.SINK_FILE }
+
+// Deliver appends the message as one JSON line and syncs the file, so an accepted message survives a crash.
+func (sink *FileSink) Deliver(ctx context.Context, message *Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	file, err := os.OpenFile(sink.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSinkUnavailable, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%w: %w", ErrSinkUnavailable, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("%w: %w", ErrSinkUnavailable, err)
+	}
+	return nil
+}
+
+// WebhookSink POSTs messages as JSON to an HTTP endpoint; any answer but a 2xx is a failed delivery.
+// A transport error, a 429 or a 5xx answer means the endpoint is unavailable.
+type WebhookSink struct {
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// NewWebhookSink creates and returns new WebhookSink instance.
+func NewWebhookSink(config utilConfig.OutboxSinkConfig) *WebhookSink {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = constantPackage.DEFAULT_WEBHOOK_TIMEOUT
+	}
+	return &WebhookSink{url: config.URL, headers: config.Headers, httpClient: &http.Client{Timeout: timeout}}
+}
+
+// Name returns SINK_WEBHOOK.
+func (sink *WebhookSink) Name() string { return constantPackage.SINK_WEBHOOK }
+
+// Deliver POSTs the message, with its outbox id in the X-Event-ID header.
+func (sink *WebhookSink) Deliver(ctx context.Context, message *Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(constantPackage.EVENT_ID_HEADER, strconv.FormatUint(uint64(message.Id), 10))
+	for name, value := range sink.headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := sink.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSinkUnavailable, err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: webhook %s answered %d", ErrSinkUnavailable, sink.url, response.StatusCode)
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s answered %d", sink.url, response.StatusCode)
+	}
+	return nil
+}
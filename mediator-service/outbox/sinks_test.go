@@ -0,0 +1,89 @@
+package mediator_outbox_v1
+
+import (
+	constantPackage "anti-fraud/constants/outbox"
+	utilConfig "anti-fraud/utils-server/config"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMessage(id uint) *Message {
+	return &Message{Id: id, EventName: "account.created", OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Payload: json.RawMessage(`{"account_id":1}`)}
+}
+
+func TestNewSinks(t *testing.T) {
+	sinks, err := NewSinks(logrus.New(), []utilConfig.OutboxSinkConfig{
+		{Type: constantPackage.SINK_LOG},
+		{Type: constantPackage.SINK_FILE, Path: "events.jsonl"},
+		{Type: constantPackage.SINK_WEBHOOK, URL: "http://localhost/events"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, sinks, 3)
+
+	_, err = NewSinks(logrus.New(), []utilConfig.OutboxSinkConfig{{Type: "kafka"}})
+	assert.Error(t, err)
+}
+
+func TestFileSink_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+
+	assert.NoError(t, sink.Deliver(context.Background(), testMessage(1)))
+	assert.NoError(t, sink.Deliver(context.Background(), testMessage(2)))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, `{"id":2,"event_name":"account.created","occurred_at":"2024-01-02T03:04:05Z","payload":{"account_id":1}}`, lines[1])
+}
+
+func TestWebhookSink_PostsMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "7", r.Header.Get(constantPackage.EVENT_ID_HEADER))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"event_name":"account.created"`)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(utilConfig.OutboxSinkConfig{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+
+	assert.NoError(t, sink.Deliver(context.Background(), testMessage(7)))
+}
+
+func TestWebhookSink_ErrorAnswerFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(utilConfig.OutboxSinkConfig{URL: server.URL})
+
+	err := sink.Deliver(context.Background(), testMessage(7))
+	assert.ErrorContains(t, err, "answered 500")
+	assert.ErrorIs(t, err, ErrSinkUnavailable)
+}
+
+func TestWebhookSink_RejectedMessageIsNotUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(utilConfig.OutboxSinkConfig{URL: server.URL})
+
+	err := sink.Deliver(context.Background(), testMessage(7))
+	assert.ErrorContains(t, err, "answered 400")
+	assert.NotErrorIs(t, err, ErrSinkUnavailable)
+}
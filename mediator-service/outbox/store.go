@@ -0,0 +1,137 @@
+package mediator_outbox_v1
+
+import (
+	constantPackage "anti-fraud/constants/outbox"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IOutboxStore defines methods interface for outbox-related db operations.
+type IOutboxStore interface {
+
+	// Record writes an event to the outbox in the publisher's db txn; it implements eventBusV1Package.IEventRecorder.
+	Record(ctx context.Context, event eventBusV1Package.Event, tx *gorm.DB) error
+
+	// GetPendingEvents locks and returns the oldest events neither dispatched nor dead, that are due at now.
+	GetPendingEvents(ctx context.Context, limit int, now time.Time, tx *gorm.DB) ([]*OutboxEvent, error)
+
+	// MarkDispatched stamps an event as delivered to every sink.
+	MarkDispatched(ctx context.Context, outboxEvent *OutboxEvent, at time.Time, tx *gorm.DB) error
+
+	// MarkFailed counts a failed delivery round of an event, keeping it pending until nextAttemptAt.
+	MarkFailed(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, nextAttemptAt time.Time, tx *gorm.DB) error
+
+	// MarkDead counts the last failed delivery round of an event and stamps it dead, so it is never polled again.
+	MarkDead(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, at time.Time, tx *gorm.DB) error
+}
+
+// OutboxStore implements IOutboxStore interface.
+type OutboxStore struct {
+	logger *logrus.Logger
+}
+
+// NewOutboxStore creates and returns new OutboxStore instance.
+func NewOutboxStore(logger *logrus.Logger) *OutboxStore {
+	return &OutboxStore{logger: logger}
+}
+
+// Record encodes an event as JSON and inserts it in the outbox.
+//
+// Parameters:
+//   - event: the published event.
+//   - tx:    db txn of the publisher, so the event commits or rolls back with the rows it describes.
+//
+// Returns:
+//   - An error if the event cannot be encoded or inserted, otherwise nil.
+func (store *OutboxStore) Record(ctx context.Context, event eventBusV1Package.Event, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("Failed to encode %s event: %v", event.Name(), err)
+		return err
+	}
+	result := tx.WithContext(ctx).Create(&OutboxEvent{EventName: event.Name(), Payload: string(payload), NextAttemptAt: time.Now()})
+	if result.Error != nil {
+		logger.Errorf("Failed to record %s event in outbox: %v", event.Name(), result.Error)
+	}
+	return result.Error
+}
+
+// GetPendingEvents fetches a batch of events to deliver.
+//
+// Steps:
+//  1. Select events without dispatched_at nor dead_at, whose next_attempt_at has come, oldest first.
+//  2. Lock them, skipping rows locked by another relay, so relays of several instances share the work.
+//  3. Fetch at most limit rows, so one relay round stays a short db txn.
+//
+// Parameters:
+//   - limit: maximum number of events returned.
+//   - now:   the time of the relay round; events backing off past it are skipped.
+//   - tx:    db txn.
+//
+// Returns:
+//   - Pending events.
+//   - error: an encountered Error.
+func (store *OutboxStore) GetPendingEvents(ctx context.Context, limit int, now time.Time, tx *gorm.DB) ([]*OutboxEvent, error) {
+	logger := utilContextV1.Logger(ctx)
+	outboxEvents := []*OutboxEvent{}
+	result := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("dispatched_at IS NULL AND dead_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&outboxEvents)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching pending outbox events: %s", result.Error.Error())
+	}
+	return outboxEvents, result.Error
+}
+
+// MarkDispatched sets dispatched_at on an event, so it is never polled again.
+func (store *OutboxStore) MarkDispatched(ctx context.Context, outboxEvent *OutboxEvent, at time.Time, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	outboxEvent.DispatchedAt = &at
+	result := tx.WithContext(ctx).Model(outboxEvent).Update("dispatched_at", at)
+	if result.Error != nil {
+		logger.Errorf("Error occured while marking outbox event %d dispatched: %s", outboxEvent.ID, result.Error.Error())
+	}
+	return result.Error
+}
+
+// MarkFailed increments the attempts of an event, keeps the delivery error, truncated to MAX_ERROR_LENGTH,
+// and sets next_attempt_at, so the relay leaves the event alone until then.
+func (store *OutboxStore) MarkFailed(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, nextAttemptAt time.Time, tx *gorm.DB) error {
+	outboxEvent.NextAttemptAt = nextAttemptAt
+	return store.recordFailure(ctx, outboxEvent, deliveryErr, map[string]interface{}{"next_attempt_at": nextAttemptAt}, tx)
+}
+
+// MarkDead increments the attempts of an event, keeps the delivery error and sets dead_at, so it is never polled again.
+func (store *OutboxStore) MarkDead(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, at time.Time, tx *gorm.DB) error {
+	outboxEvent.DeadAt = &at
+	return store.recordFailure(ctx, outboxEvent, deliveryErr, map[string]interface{}{"dead_at": at}, tx)
+}
+
+// recordFailure updates the attempts and last error of an event along with the given columns.
+func (store *OutboxStore) recordFailure(ctx context.Context, outboxEvent *OutboxEvent, deliveryErr error, updates map[string]interface{}, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	lastError := deliveryErr.Error()
+	if len(lastError) > constantPackage.MAX_ERROR_LENGTH {
+		lastError = lastError[:constantPackage.MAX_ERROR_LENGTH]
+	}
+	outboxEvent.Attempts++
+	outboxEvent.LastError = lastError
+	updates["attempts"] = outboxEvent.Attempts
+	updates["last_error"] = lastError
+	result := tx.WithContext(ctx).Model(outboxEvent).Updates(updates)
+	if result.Error != nil {
+		logger.Errorf("Error occured while recording failed delivery of outbox event %d: %s", outboxEvent.ID, result.Error.Error())
+	}
+	return result.Error
+}
@@ -0,0 +1,116 @@
+package mediator_outbox_v1
+
+import (
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	if err := db.AutoMigrate(&OutboxEvent{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestRecord_EncodesEventInTxn(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewOutboxStore(logrus.New())
+	ctx := context.Background()
+
+	tx := db.Begin()
+	err := store.Record(ctx, &eventBusV1Package.TransactionCreated{TransactionId: 7, AccountId: 1, Amount: utilMoneyV1.MustParse("-12.5"), Currency: "USD"}, tx)
+	assert.NoError(t, err)
+	tx.Rollback()
+
+	var count int64
+	db.Model(&OutboxEvent{}).Count(&count)
+	assert.Zero(t, count, "the event rolls back with the publisher's txn")
+
+	assert.NoError(t, store.Record(ctx, &eventBusV1Package.TransactionCreated{TransactionId: 7, AccountId: 1, Amount: utilMoneyV1.MustParse("-12.5"), Currency: "USD"}, db))
+	var recorded OutboxEvent
+	db.First(&recorded)
+	assert.Equal(t, "transaction.created", recorded.EventName)
+	assert.Contains(t, recorded.Payload, `"transaction_id":7`)
+	assert.Contains(t, recorded.Payload, `"amount":-12.5`)
+	assert.Nil(t, recorded.DispatchedAt)
+}
+
+func TestGetPendingEvents_OldestUndispatchedFirst(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewOutboxStore(logrus.New())
+	ctx := context.Background()
+	dispatchedAt := time.Now()
+
+	outboxEvents := []*OutboxEvent{
+		{EventName: "a", Payload: "{}"},
+		{EventName: "b", Payload: "{}", DispatchedAt: &dispatchedAt},
+		{EventName: "c", Payload: "{}"},
+		{EventName: "d", Payload: "{}"},
+		{EventName: "e", Payload: "{}", NextAttemptAt: dispatchedAt.Add(time.Minute)},
+		{EventName: "f", Payload: "{}", DeadAt: &dispatchedAt},
+	}
+	for _, outboxEvent := range outboxEvents {
+		db.Create(outboxEvent)
+	}
+
+	pending, err := store.GetPendingEvents(ctx, 10, dispatchedAt, db)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "d"}, []string{pending[0].EventName, pending[1].EventName, pending[2].EventName}, "events backing off or dead are skipped")
+	assert.Len(t, pending, 3)
+
+	pending, err = store.GetPendingEvents(ctx, 2, dispatchedAt, db)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "a", pending[0].EventName)
+	assert.Equal(t, "c", pending[1].EventName)
+}
+
+func TestMarkDispatchedAndFailed(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewOutboxStore(logrus.New())
+	ctx := context.Background()
+
+	now := time.Now()
+	dispatched := &OutboxEvent{EventName: "a", Payload: "{}"}
+	failed := &OutboxEvent{EventName: "b", Payload: "{}"}
+	dead := &OutboxEvent{EventName: "c", Payload: "{}"}
+	db.Create(dispatched)
+	db.Create(failed)
+	db.Create(dead)
+
+	assert.NoError(t, store.MarkDispatched(ctx, dispatched, now, db))
+	assert.NoError(t, store.MarkFailed(ctx, failed, errors.New(strings.Repeat("x", 2000)), now.Add(time.Minute), db))
+	assert.NoError(t, store.MarkDead(ctx, dead, errors.New("gone"), now, db))
+
+	pending, err := store.GetPendingEvents(ctx, 10, now, db)
+	assert.NoError(t, err)
+	assert.Empty(t, pending, "the failed event backs off until its next attempt")
+
+	pending, err = store.GetPendingEvents(ctx, 10, now.Add(time.Minute), db)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, failed.ID, pending[0].ID)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Len(t, pending[0].LastError, 1024)
+
+	var stored OutboxEvent
+	db.First(&stored, dead.ID)
+	assert.Equal(t, 1, stored.Attempts)
+	assert.Equal(t, "gone", stored.LastError)
+	assert.NotNil(t, stored.DeadAt)
+}
@@ -0,0 +1,177 @@
+package mediator_transaction_client_v1
+
+import (
+	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ITransactionCore lists the transaction core methods this client delegates to.
+// It is declared here rather than imported from transaction-service/core/v1, because that
+// package already depends on the account client and account-service depends on this client.
+type ITransactionCore interface {
+	GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+	GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error)
+	PostCapturedTransaction(ctx context.Context, capturePayload *entityCoreV1Package.CapturedTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+	CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error)
+}
+
+// ITransactionClient defines methods interface for interacting with the transaction core service via a mediator pattern.
+type ITransactionClient interface {
+	// SetupCore allows for the injection of ITransactionCore, enabling this client
+	// to delegate transaction reads without directly depending on repository logic.
+	SetupCore(transactionCoreV1 ITransactionCore)
+
+	// GetPostedTransactions retrieves the account's non-declined transactions created within [from, to), oldest first.
+	GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*Transaction, error)
+
+	// GetPostedBalance retrieves the sum of the account's non-declined transaction amounts before a time.
+	GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error)
+
+	// PostCapturedTransaction posts the transaction settling a captured authorization hold.
+	PostCapturedTransaction(ctx context.Context, capture *CapturedTransaction, tx *gorm.DB) (*Transaction, error)
+
+	// CountOperationTypeTransactions retrieves how many transactions reference an operation type.
+	CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error)
+}
+
+// TransactionClient implements ITransactionClient(interface)
+type TransactionClient struct {
+	transactionCoreV1 ITransactionCore
+	logger            *logrus.Logger
+}
+
+// NewTransactionClient create new instance of TransactionClient.
+func NewTransactionClient(logger *logrus.Logger) *TransactionClient {
+
+	return &TransactionClient{logger: logger}
+}
+
+// SetupCore injects the ITransactionCore dependency, enabling the client to call transaction service core methods.
+func (client *TransactionClient) SetupCore(transactionCoreV1 ITransactionCore) {
+	client.transactionCoreV1 = transactionCoreV1
+}
+
+// GetPostedTransactions calls the core's GetPostedTransactions method.
+//
+// Steps:
+//  1. Invoke the transactionCoreV1.GetPostedTransactions to fetch the records from transaction-service.
+//  2. If an error occurs, return it.
+//  3. Otherwise, map each record to a mediator-level Transaction struct.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - from:      start of the period, inclusive.
+//   - to:        end of the period, exclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - []*Transaction: the mediator-level transactions, oldest first.
+//   - error:          an encountered Error.
+func (client *TransactionClient) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedTransactions method called in mediator-service for transaction client.")
+
+	records, err := client.transactionCoreV1.GetPostedTransactions(ctx, accountId, from, to, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching posted transactions via transaction service: %s", err.Error())
+		return nil, err
+	}
+	transactions := make([]*Transaction, 0, len(records))
+	for _, record := range records {
+		transactions = append(transactions, &Transaction{
+			Id:              int(record.ID),
+			OperationTypeId: record.OperationTypeId,
+			Amount:          record.Amount,
+			EventDate:       record.CreatedAt,
+		})
+	}
+	return transactions, nil
+}
+
+// GetPostedBalance calls the core's GetPostedBalance method.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - before:    exclusive upper bound on the transaction creation time.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The balance.
+//   - error: an encountered Error.
+func (client *TransactionClient) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedBalance method called in mediator-service for transaction client.")
+
+	balance, err := client.transactionCoreV1.GetPostedBalance(ctx, accountId, before, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching posted balance via transaction service: %s", err.Error())
+	}
+	return balance, err
+}
+
+// PostCapturedTransaction calls the core's PostCapturedTransaction method.
+//
+// Steps:
+//  1. Map the mediator-level capture to the transaction-service payload.
+//  2. Invoke the transactionCoreV1.PostCapturedTransaction to persist the transaction.
+//  3. Map the created record to a mediator-level Transaction struct.
+//
+// Parameters:
+//   - capture: account, operation type, final signed amount and fraud decision of the capture.
+//   - tx:      db txn.
+//
+// Returns:
+//   - *Transaction: the mediator-level posted transaction.
+//   - error:        an encountered Error.
+func (client *TransactionClient) PostCapturedTransaction(ctx context.Context, capture *CapturedTransaction, tx *gorm.DB) (*Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("PostCapturedTransaction method called in mediator-service for transaction client.")
+
+	record, err := client.transactionCoreV1.PostCapturedTransaction(ctx, &entityCoreV1Package.CapturedTransactionPayload{
+		AccountId:       capture.AccountId,
+		OperationTypeId: capture.OperationTypeId,
+		Amount:          capture.Amount,
+		Currency:        capture.Currency,
+		FraudDecision:   capture.FraudDecision,
+		FraudRules:      capture.FraudRules,
+		InputAmount:     capture.InputAmount,
+		Coefficient:     capture.Coefficient,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error occured while posting captured transaction via transaction service: %s", err.Error())
+		return nil, err
+	}
+	return &Transaction{
+		Id:              int(record.ID),
+		OperationTypeId: record.OperationTypeId,
+		Amount:          record.Amount,
+		EventDate:       record.CreatedAt,
+	}, nil
+}
+
+// CountOperationTypeTransactions calls the core's CountOperationTypeTransactions method.
+//
+// Parameters:
+//   - operationTypeId: ID of the operation type.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The number of transactions, declined ones included.
+//   - error: an encountered Error.
+func (client *TransactionClient) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CountOperationTypeTransactions method called in mediator-service for transaction client.")
+
+	count, err := client.transactionCoreV1.CountOperationTypeTransactions(ctx, operationTypeId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while counting operation type transactions via transaction service: %s", err.Error())
+	}
+	return count, err
+}
@@ -0,0 +1,147 @@
+package mediator_transaction_client_v1
+
+import (
+	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+//-------------------------------------------//
+// Mock for ITransactionCore
+//-------------------------------------------//
+
+type MockTransactionCore struct {
+	mock.Mock
+}
+
+func (m *MockTransactionCore) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionCore) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionCore) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionCore) PostCapturedTransaction(ctx context.Context, capturePayload *entityCoreV1Package.CapturedTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	args := m.Called(capturePayload, tx)
+	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+//-------------------------------------------//
+// Unit Tests for TransactionClient
+//-------------------------------------------//
+
+func TestTransactionClient_GetPostedTransactions_Success(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	createdAt := from.Add(time.Hour)
+	mockCore.On("GetPostedTransactions", 1, from, to, mock.Anything).
+		Return([]*entityDbV1Package.Transaction{
+			{Model: gorm.Model{ID: 7, CreatedAt: createdAt}, AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("120.50")},
+		}, nil)
+
+	result, err := client.GetPostedTransactions(context.Background(), 1, from, to, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 7, result[0].Id)
+	assert.Equal(t, 4, result[0].OperationTypeId)
+	assert.Equal(t, "120.5", result[0].Amount.String())
+	assert.Equal(t, createdAt, result[0].EventDate)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestTransactionClient_GetPostedTransactions_Error(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("GetPostedTransactions", 1, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("db error"))
+
+	result, err := client.GetPostedTransactions(context.Background(), 1, time.Now(), time.Now(), &gorm.DB{})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestTransactionClient_GetPostedBalance(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockCore.On("GetPostedBalance", 1, before, mock.Anything).Return(utilMoneyV1.MustParse("-75.25"), nil)
+
+	balance, err := client.GetPostedBalance(context.Background(), 1, before, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParse("-75.25"), balance)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestTransactionClient_PostCapturedTransaction_Success(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("PostCapturedTransaction", &entityCoreV1Package.CapturedTransactionPayload{
+		AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-40"), FraudDecision: "APPROVE", FraudRules: "",
+	}, mock.Anything).Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 9}, AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-40")}, nil)
+
+	result, err := client.PostCapturedTransaction(context.Background(), &CapturedTransaction{
+		AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-40"), FraudDecision: "APPROVE",
+	}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, result.Id)
+	assert.Equal(t, "-40", result.Amount.String())
+	mockCore.AssertExpectations(t)
+}
+
+func TestTransactionClient_PostCapturedTransaction_Error(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("PostCapturedTransaction", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	result, err := client.PostCapturedTransaction(context.Background(), &CapturedTransaction{AccountId: 1}, &gorm.DB{})
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "db error")
+}
+
+func TestTransactionClient_CountOperationTypeTransactions(t *testing.T) {
+	client := NewTransactionClient(logrus.New())
+	mockCore := new(MockTransactionCore)
+	client.SetupCore(mockCore)
+
+	mockCore.On("CountOperationTypeTransactions", 4, mock.Anything).Return(int64(3), nil)
+
+	count, err := client.CountOperationTypeTransactions(context.Background(), 4, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	mockCore.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+package mediator_transaction_client_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// Transaction is a simple struct representing the mediator-level view of a posted transaction.
+type Transaction struct {
+	Id              int
+	OperationTypeId int
+	Amount          utilMoneyV1.Amount
+	EventDate       time.Time
+}
+
+// CapturedTransaction is the mediator-level request to post the transaction of a captured authorization hold.
+type CapturedTransaction struct {
+	AccountId       int
+	OperationTypeId int
+	Amount          utilMoneyV1.Amount // final and signed
+	Currency        string             // account currency the hold was placed in
+	FraudDecision   string
+	FraudRules      string             // comma separated names of the fraud rules that fired at authorization
+	InputAmount     utilMoneyV1.Amount // captured amount as requested
+	Coefficient     int                // coefficient applied when the hold was authorized
+}
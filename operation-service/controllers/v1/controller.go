@@ -0,0 +1,519 @@
+package operation_controller_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	coreV1Package "anti-fraud/operation-service/core/v1"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	entityHttpV1Package "anti-fraud/operation-service/entity/http/v1"
+	mapperV1Package "anti-fraud/operation-service/mapper/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilV1 "anti-fraud/utils-server/middleware/v1"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"encoding/json"
+	"net/http"
+)
+
+// IOperationController defines the methods interface for operation type administration HTTP handlers.
+type IOperationController interface {
+
+	// CreateOperation handles the creation of a new operation type.
+	CreateOperation(w http.ResponseWriter, r *http.Request)
+
+	// ListOperations lists the operation types, optionally of one status.
+	ListOperations(w http.ResponseWriter, r *http.Request)
+
+	// GetOperationDetails retrieves an operation type by its ID.
+	GetOperationDetails(w http.ResponseWriter, r *http.Request)
+
+	// UpdateOperation changes the description or coefficient of an operation type.
+	UpdateOperation(w http.ResponseWriter, r *http.Request)
+
+	// DeprecateOperation stops an operation type from accepting new transactions.
+	DeprecateOperation(w http.ResponseWriter, r *http.Request)
+
+	// GetOperationCoefficient returns the coefficient of an operation type at an event time, for remote mediator clients.
+	GetOperationCoefficient(w http.ResponseWriter, r *http.Request)
+
+	// ComputeOperation computes an input with the handler of its operation type, for remote mediator clients.
+	ComputeOperation(w http.ResponseWriter, r *http.Request)
+
+	// ComputeFees computes the fees charged on a transaction, for remote mediator clients.
+	ComputeFees(w http.ResponseWriter, r *http.Request)
+
+	// GetCacheStats returns the counters of the operation type cache of the mediator operation client.
+	GetCacheStats(w http.ResponseWriter, r *http.Request)
+
+	// SetupCacheStats injects the source of the counters GetCacheStats reports.
+	SetupCacheStats(cacheStats ICacheStats)
+}
+
+// ICacheStats reports the counters of an operation type cache; the mediator operation client implements it.
+type ICacheStats interface {
+	CacheStats() entityCoreV1Package.OperationCacheStats
+}
+
+// OperationController implements IOperationController interface.
+type OperationController struct {
+	coreV1     coreV1Package.IOperationCore
+	db         *gorm.DB
+	logger     *logrus.Logger
+	cacheStats ICacheStats
+}
+
+// NewOperationController creates and returns a new OperationController instance.
+func NewOperationController(coreV1 coreV1Package.IOperationCore, db *gorm.DB, logger *logrus.Logger) *OperationController {
+	return &OperationController{coreV1: coreV1, db: db, logger: logger}
+}
+
+// CreateOperation is an HTTP handler that creates a new operation type.
+//
+// Workflow:
+//  1. Decode and validate the JSON payload into CreateOperationRequest.
+//  2. Begin db txn.
+//  3. Invoke the core layer to create the operation type. A retry is answered with 409, as
+//     descriptions are unique, so no Idempotency-Key is needed.
+//  4. Commit the txn on success (or rollback on error), and notify the caches of operation types.
+//  5. Return a JSON response with the new operation type.
+func (controller *OperationController) CreateOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Decode and validate HTTP input payload.
+	var createReq entityHttpV1Package.CreateOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&createReq); err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", createReq).Info("CreateOperation endpoint called.")
+
+	if err := createReq.Validate(); err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	// 2. Begin new db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	// 3. Create the operation type via core layer.
+	operation, err := controller.coreV1.CreateOperation(ctx, mapperV1Package.CreateOperationPayloadMapper(&createReq), tx)
+	if err != nil {
+		logger.Errorf("Error creating operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Commit txn, then drop a cached "not found" for the new id.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	controller.coreV1.NotifyOperationChanged(ctx, int(operation.ID))
+
+	logger.Infof("Operation type created successfully: %v", operation)
+
+	// 5. Build and send the JSON response.
+	controller.writeOperation(w, operation)
+}
+
+// ListOperations is an HTTP handler that lists the operation types.
+//
+// Workflow:
+//  1. Parse the optional status query parameter.
+//  2. Fetch the operation types via the core layer inside a db txn.
+//  3. Return a JSON response with the operation types.
+func (controller *OperationController) ListOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Parse the query parameters.
+	status, err := entityHttpV1Package.ParseListOperationsRequest(r.URL.Query())
+	if err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	logger.Infof("ListOperations endpoint called with status: %q", status)
+
+	// 2. Fetch the operation types via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operations, err := controller.coreV1.ListOperations(ctx, status, tx)
+	if err != nil {
+		logger.Errorf("Error listing operation types: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success":         true,
+		"operation_types": mapperV1Package.OperationListResponseMapper(operations),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetOperationDetails is an HTTP handler that retrieves an operation type by its ID.
+//
+// Workflow:
+//  1. Extract the "operationTypeId" from the URL path and convert it to an int.
+//  2. Fetch the operation type via the core layer inside a db txn.
+//  3. Return a JSON response with the operation type.
+func (controller *OperationController) GetOperationDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "operationTypeId" from URL params.
+	operationId, ok := controller.operationIdFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger.Infof("GetOperationDetails endpoint called for operationTypeId: %d", operationId)
+
+	// 2. Fetch the operation type via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operation, err := controller.coreV1.GetOperation(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error fetching operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	controller.writeOperation(w, operation)
+}
+
+// UpdateOperation is an HTTP handler that changes the description or coefficient of an operation type.
+//
+// Workflow:
+//  1. Extract the "operationTypeId" from the URL path and convert it to an int.
+//  2. Decode and validate the JSON payload into UpdateOperationRequest.
+//  3. Apply the changes via the core layer inside a db txn, then notify the caches of operation types.
+//  4. Return a JSON response with the updated operation type.
+func (controller *OperationController) UpdateOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "operationTypeId" from URL params.
+	operationId, ok := controller.operationIdFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+
+	// 2. Decode and validate HTTP input payload.
+	var updateReq entityHttpV1Package.UpdateOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", updateReq).Infof("UpdateOperation endpoint called for operationTypeId: %d", operationId)
+
+	if err := updateReq.Validate(); err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	// 3. Apply the changes via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operation, err := controller.coreV1.UpdateOperation(ctx, operationId, mapperV1Package.UpdateOperationPayloadMapper(&updateReq), tx)
+	if err != nil {
+		logger.Errorf("Error updating operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	controller.coreV1.NotifyOperationChanged(ctx, operationId)
+
+	// 4. Build and send the JSON response.
+	controller.writeOperation(w, operation)
+}
+
+// DeprecateOperation is an HTTP handler that deprecates an operation type.
+//
+// Workflow:
+//  1. Extract the "operationTypeId" from the URL path and convert it to an int.
+//  2. Deprecate the operation type via the core layer inside a db txn, then notify the caches of operation types.
+//  3. Return a JSON response with the deprecated operation type.
+func (controller *OperationController) DeprecateOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "operationTypeId" from URL params.
+	operationId, ok := controller.operationIdFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+
+	logger.Infof("DeprecateOperation endpoint called for operationTypeId: %d", operationId)
+
+	// 2. Deprecate the operation type via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operation, err := controller.coreV1.DeprecateOperation(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error deprecating operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	controller.coreV1.NotifyOperationChanged(ctx, operationId)
+
+	// 3. Build and send the JSON response.
+	controller.writeOperation(w, operation)
+}
+
+// GetOperationCoefficient is the internal HTTP handler behind the remote mediator operation client.
+//
+// Workflow:
+//  1. Extract the "operationTypeId" from the URL path and the "at" event time from the query.
+//  2. Fetch the operation type and check it is in effect at that time via the core layer, inside a db txn.
+//  3. Return a JSON response with the coefficient.
+func (controller *OperationController) GetOperationCoefficient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "operationTypeId" from URL params and the event time.
+	operationId, ok := controller.operationIdFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+	at, err := entityHttpV1Package.ParseCoefficientRequest(r.URL.Query(), time.Now().UTC())
+	if err != nil {
+		logger.Errorf("Validation failed: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	logger.Infof("GetOperationCoefficient endpoint called for operationTypeId: %d at: %s", operationId, at)
+
+	// 2. Fetch the operation type and its coefficient via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operation, err := controller.coreV1.GetOperation(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error fetching operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	coefficient, err := controller.coreV1.GetOperationCoefficient(ctx, operation, at)
+	if err != nil {
+		logger.Errorf("Error fetching coefficient of operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entityHttpV1Package.CoefficientResponse{Success: true, Coefficient: coefficient})
+}
+
+// ComputeOperation is the internal HTTP handler behind the remote mediator operation client.
+//
+// Workflow:
+//  1. Extract the "operationTypeId" from the URL path and decode the JSON payload into ComputeOperationRequest.
+//  2. Fetch the operation type and compute the input with its handler via the core layer, inside a db txn.
+//  3. Return a JSON response with the operation result.
+func (controller *OperationController) ComputeOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the "operationTypeId" from URL params and decode HTTP input payload.
+	operationId, ok := controller.operationIdFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+	var computeReq entityHttpV1Package.ComputeOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&computeReq); err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", computeReq).Infof("ComputeOperation endpoint called for operationTypeId: %d", operationId)
+
+	// 2. Compute the input via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	operation, err := controller.coreV1.GetOperation(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error fetching operation type: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	result, err := controller.coreV1.ComputeOperation(ctx, operation, mapperV1Package.OperationInputMapper(operationId, &computeReq), tx)
+	if err != nil {
+		logger.Errorf("Error computing operation: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success": true,
+		"result":  mapperV1Package.OperationResultResponseMapper(result),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ComputeFees is the internal HTTP handler behind the remote mediator operation client.
+//
+// Workflow:
+//  1. Decode the JSON payload into ComputeFeesRequest.
+//  2. Compute the fees via the core layer inside a db txn.
+//  3. Return a JSON response with the fees.
+func (controller *OperationController) ComputeFees(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Decode HTTP input payload.
+	var feesReq entityHttpV1Package.ComputeFeesRequest
+	if err := json.NewDecoder(r.Body).Decode(&feesReq); err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", feesReq).Info("ComputeFees endpoint called.")
+
+	// 2. Compute the fees via core layer.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx) // Rollback if we exit prematurely.
+
+	fees, err := controller.coreV1.ComputeFees(ctx, mapperV1Package.FeeInputMapper(&feesReq), tx)
+	if err != nil {
+		logger.Errorf("Error computing fees: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Build and send the JSON response.
+	response := map[string]interface{}{
+		"success": true,
+		"fees":    mapperV1Package.FeeListResponseMapper(fees),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// SetupCacheStats injects the source of the cache counters into this controller.
+func (controller *OperationController) SetupCacheStats(cacheStats ICacheStats) {
+	controller.cacheStats = cacheStats
+}
+
+// GetCacheStats is an internal HTTP handler reporting how well the operation type cache of this instance does.
+//
+// Workflow:
+//  1. Read the counters of the cache of the mediator operation client; zero when none is set up.
+//  2. Return a JSON response with the hits, misses and entries.
+func (controller *OperationController) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	requestID := utilV1.GetRequestID(r.Context())
+	logger := controller.logger.WithField("request_id", requestID)
+	logger.Info("GetCacheStats endpoint called")
+
+	// 1. Read the counters.
+	stats := entityCoreV1Package.OperationCacheStats{}
+	if controller.cacheStats != nil {
+		stats = controller.cacheStats.CacheStats()
+	}
+
+	// 2. Build and send the JSON response.
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mapperV1Package.CacheStatsResponseMapper(stats))
+}
+
+// operationIdFromPath reads the "operationTypeId" URL parameter, answering 400 when it is not an int.
+func (controller *OperationController) operationIdFromPath(w http.ResponseWriter, r *http.Request, logger *logrus.Entry) (int, bool) {
+	operationId, err := strconv.Atoi(mux.Vars(r)["operationTypeId"])
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return 0, false
+	}
+	return operationId, true
+}
+
+// writeOperation sends the JSON response shared by the endpoints returning one operation type.
+func (controller *OperationController) writeOperation(w http.ResponseWriter, operation *entityDbV1Package.Operation) {
+	response := map[string]interface{}{
+		"success":        true,
+		"operation_type": mapperV1Package.OperationResponseMapper(operation),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,384 @@
+package operation_controller_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	handlersV1Package "anti-fraud/operation-service/handlers/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+//--------------------------------//
+// Mock for IOperationCore
+//--------------------------------//
+
+type MockOperationCore struct {
+	mock.Mock
+}
+
+func (m *MockOperationCore) RegisterHandler(handler handlersV1Package.IOperationHandler) {
+	m.Called(handler)
+}
+
+func (m *MockOperationCore) OnOperationChanged(listener func(operationId int)) {
+	m.Called(listener)
+}
+
+func (m *MockOperationCore) NotifyOperationChanged(ctx context.Context, operationId int) {
+	m.Called(operationId)
+}
+
+func (m *MockOperationCore) ComputeOperation(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	args := m.Called(operation, input, tx)
+	result, _ := args.Get(0).(*entityCoreV1Package.OperationResult)
+	return result, args.Error(1)
+}
+
+func (m *MockOperationCore) ComputeFees(ctx context.Context, input *entityCoreV1Package.FeeInput, tx *gorm.DB) ([]*entityCoreV1Package.Fee, error) {
+	args := m.Called(input, tx)
+	fees, _ := args.Get(0).([]*entityCoreV1Package.Fee)
+	return fees, args.Error(1)
+}
+
+func (m *MockOperationCore) GetOperationCoefficient(ctx context.Context, operation *entityDbV1Package.Operation, at time.Time) (int, error) {
+	args := m.Called(operation, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOperationCore) GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error) {
+	args := m.Called(status, tx)
+	operations, _ := args.Get(0).([]*entityDbV1Package.Operation)
+	return operations, args.Error(1)
+}
+
+func (m *MockOperationCore) CreateOperation(ctx context.Context, createPayload *entityCoreV1Package.CreateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(createPayload, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) UpdateOperation(ctx context.Context, operationId int, updatePayload *entityCoreV1Package.UpdateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, updatePayload, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockOperationCore) DeprecateOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, tx)
+	operation, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return operation, args.Error(1)
+}
+
+//----------------------------------------------//
+// Test Helpers
+//----------------------------------------------//
+
+func setupTestController(t *testing.T) (*OperationController, *MockOperationCore) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	mockCore := new(MockOperationCore)
+	return NewOperationController(mockCore, db, logrus.New()), mockCore
+}
+
+func withOperationId(req *http.Request, operationId string) *http.Request {
+	return mux.SetURLVars(req, map[string]string{"operationTypeId": operationId})
+}
+
+//------------------------------------------------//
+// CreateOperation
+//------------------------------------------------//
+
+func TestCreateOperation_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CreateOperation", &entityCoreV1Package.CreateOperationPayload{Description: "Cashback", Coefficient: 1}, mock.Anything).
+		Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 5}, Description: "Cashback", Coefficient: 1, Status: constantPackage.STATUS_ACTIVE}, nil)
+	mockCore.On("NotifyOperationChanged", 5).Return()
+
+	rr := httptest.NewRecorder()
+	controller.CreateOperation(rr, httptest.NewRequest(http.MethodPost, "/operation-types/v1", strings.NewReader(`{"description": " Cashback ", "coefficient": 1}`)))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"operation_type_id":5`)
+	assert.Contains(t, rr.Body.String(), `"status":"ACTIVE"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateOperation_ValidationError(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	for _, body := range []string{
+		`{"coefficient": 1}`,
+		`{"description": "  ", "coefficient": 1}`,
+		`{"description": "Cashback"}`,
+		`{"description": "Cashback", "coefficient": 0}`,
+		`{"description": "Cashback", "coefficient": 2}`,
+		`{"description": "` + strings.Repeat("a", 256) + `", "coefficient": 1}`,
+		`{"description": "Cashback", "coefficient": 1, "valid_from": "2026-02-01T00:00:00Z", "valid_to": "2026-01-01T00:00:00Z"}`,
+	} {
+		rr := httptest.NewRecorder()
+		controller.CreateOperation(rr, httptest.NewRequest(http.MethodPost, "/operation-types/v1", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_Duplicate(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("CreateOperation", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION, "duplicate operation type found with description: Withdrawal"))
+
+	rr := httptest.NewRecorder()
+	controller.CreateOperation(rr, httptest.NewRequest(http.MethodPost, "/operation-types/v1", strings.NewReader(`{"description": "Withdrawal", "coefficient": -1}`)))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION)
+}
+
+//------------------------------------------------//
+// ListOperations / GetOperationDetails
+//------------------------------------------------//
+
+func TestListOperations_StatusFilter(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("ListOperations", constantPackage.STATUS_DEPRECATED, mock.Anything).
+		Return([]*entityDbV1Package.Operation{{Model: gorm.Model{ID: 3}, Description: "Withdrawal", Coefficient: -1, Status: constantPackage.STATUS_DEPRECATED}}, nil)
+
+	rr := httptest.NewRecorder()
+	controller.ListOperations(rr, httptest.NewRequest(http.MethodGet, "/operation-types/v1?status=deprecated", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"operation_types":[{"operation_type_id":3`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestListOperations_InvalidStatus(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.ListOperations(rr, httptest.NewRequest(http.MethodGet, "/operation-types/v1?status=retired", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER)
+	mockCore.AssertNotCalled(t, "ListOperations", mock.Anything, mock.Anything)
+}
+
+func TestGetOperationDetails_NotFound(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("GetOperation", 9, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 9 not found in database"))
+
+	rr := httptest.NewRecorder()
+	controller.GetOperationDetails(rr, withOperationId(httptest.NewRequest(http.MethodGet, "/operation-types/v1/9", nil), "9"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.OPERATION_TYPE_NOT_FOUND)
+}
+
+func TestGetOperationDetails_InvalidId(t *testing.T) {
+	controller, _ := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.GetOperationDetails(rr, withOperationId(httptest.NewRequest(http.MethodGet, "/operation-types/v1/abc", nil), "abc"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_PATH_PARAMETER)
+}
+
+//------------------------------------------------//
+// UpdateOperation / DeprecateOperation
+//------------------------------------------------//
+
+func TestUpdateOperation_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	description := "Card Purchase"
+	mockCore.On("UpdateOperation", 1, &entityCoreV1Package.UpdateOperationPayload{Description: &description}, mock.Anything).
+		Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Description: description, Coefficient: -1, Status: constantPackage.STATUS_ACTIVE}, nil)
+	mockCore.On("NotifyOperationChanged", 1).Return()
+
+	rr := httptest.NewRecorder()
+	controller.UpdateOperation(rr, withOperationId(httptest.NewRequest(http.MethodPatch, "/operation-types/v1/1", strings.NewReader(`{"description": "Card Purchase"}`)), "1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"description":"Card Purchase"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestUpdateOperation_EmptyBody(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.UpdateOperation(rr, withOperationId(httptest.NewRequest(http.MethodPatch, "/operation-types/v1/1", strings.NewReader(`{}`)), "1"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED)
+	mockCore.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_InUse(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("UpdateOperation", 1, mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewConflictError(errorConstantPackage.OPERATION_TYPE_IN_USE, "operation_type_id: 1 is referenced by 4 transactions, its coefficient cannot change"))
+
+	rr := httptest.NewRecorder()
+	controller.UpdateOperation(rr, withOperationId(httptest.NewRequest(http.MethodPatch, "/operation-types/v1/1", strings.NewReader(`{"coefficient": 1}`)), "1"))
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.OPERATION_TYPE_IN_USE)
+	mockCore.AssertNotCalled(t, "NotifyOperationChanged", mock.Anything)
+}
+
+func TestDeprecateOperation_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("DeprecateOperation", 3, mock.Anything).
+		Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 3}, Description: "Withdrawal", Coefficient: -1, Status: constantPackage.STATUS_DEPRECATED}, nil)
+	mockCore.On("NotifyOperationChanged", 3).Return()
+
+	rr := httptest.NewRecorder()
+	controller.DeprecateOperation(rr, withOperationId(httptest.NewRequest(http.MethodPost, "/operation-types/v1/3/deprecate", nil), "3"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"DEPRECATED"`)
+	mockCore.AssertExpectations(t)
+}
+
+//------------------------------------------------//
+// Internal endpoints of the remote mediator client
+//------------------------------------------------//
+
+func TestGetOperationCoefficient_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1, Status: constantPackage.STATUS_ACTIVE}
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mockCore.On("GetOperation", 1, mock.Anything).Return(operation, nil)
+	mockCore.On("GetOperationCoefficient", operation, at).Return(-1, nil)
+
+	rr := httptest.NewRecorder()
+	controller.GetOperationCoefficient(rr, withOperationId(httptest.NewRequest(http.MethodGet, "/internal/operation-types/v1/1/coefficient?at=2026-03-01T10:00:00Z", nil), "1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"coefficient":-1`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestGetOperationCoefficient_InvalidAt(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.GetOperationCoefficient(rr, withOperationId(httptest.NewRequest(http.MethodGet, "/internal/operation-types/v1/1/coefficient?at=yesterday", nil), "1"))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER)
+	mockCore.AssertNotCalled(t, "GetOperation", mock.Anything, mock.Anything)
+}
+
+func TestComputeOperation_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1, Kind: constantPackage.KIND_INSTALLMENT_PURCHASE}
+
+	mockCore.On("GetOperation", 2, mock.Anything).Return(operation, nil)
+	mockCore.On("ComputeOperation", operation, mock.MatchedBy(func(input *entityCoreV1Package.OperationInput) bool {
+		return input.OperationTypeId == 2 && input.AccountId == 7 && input.InstallmentCount == 3
+	}), mock.Anything).Return(&entityCoreV1Package.OperationResult{Amount: utilMoneyV1.MustParse("-90"), Coefficient: -1, InstallmentCount: 3}, nil)
+
+	rr := httptest.NewRecorder()
+	body := `{"account_id": 7, "amount": "90", "installment_count": 3, "at": "2026-03-01T10:00:00Z"}`
+	controller.ComputeOperation(rr, withOperationId(httptest.NewRequest(http.MethodPost, "/internal/operation-types/v1/2/compute", strings.NewReader(body)), "2"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"amount":-90`)
+	assert.Contains(t, rr.Body.String(), `"installment_count":3`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestComputeOperation_NotFound(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("GetOperation", 9, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 9 not found in database"))
+
+	rr := httptest.NewRecorder()
+	controller.ComputeOperation(rr, withOperationId(httptest.NewRequest(http.MethodPost, "/internal/operation-types/v1/9/compute", strings.NewReader(`{"account_id": 7, "amount": "90"}`)), "9"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.OPERATION_TYPE_NOT_FOUND)
+}
+
+func TestComputeFees_Success(t *testing.T) {
+	controller, mockCore := setupTestController(t)
+
+	mockCore.On("ComputeFees", &entityCoreV1Package.FeeInput{OperationTypeId: 4, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-200")}, mock.Anything).
+		Return([]*entityCoreV1Package.Fee{{Code: "WITHDRAWAL", Amount: utilMoneyV1.MustParse("2.5")}}, nil)
+
+	rr := httptest.NewRecorder()
+	body := `{"operation_type_id": 4, "account_tier": "GOLD", "currency": "USD", "amount": "-200"}`
+	controller.ComputeFees(rr, httptest.NewRequest(http.MethodPost, "/internal/operation-types/v1/fees", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"code":"WITHDRAWAL"`)
+	mockCore.AssertExpectations(t)
+}
+
+//------------------------------------------------//
+// GetCacheStats
+//------------------------------------------------//
+
+type stubCacheStats entityCoreV1Package.OperationCacheStats
+
+func (stats stubCacheStats) CacheStats() entityCoreV1Package.OperationCacheStats {
+	return entityCoreV1Package.OperationCacheStats(stats)
+}
+
+func TestGetCacheStats_Success(t *testing.T) {
+	controller, _ := setupTestController(t)
+	controller.SetupCacheStats(stubCacheStats{Hits: 41, Misses: 3, Entries: 2})
+
+	rr := httptest.NewRecorder()
+	controller.GetCacheStats(rr, httptest.NewRequest(http.MethodGet, "/internal/operation-types/v1/cache-stats", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"success":true,"hits":41,"misses":3,"entries":2}`, rr.Body.String())
+}
+
+func TestGetCacheStats_NoCache(t *testing.T) {
+	controller, _ := setupTestController(t)
+
+	rr := httptest.NewRecorder()
+	controller.GetCacheStats(rr, httptest.NewRequest(http.MethodGet, "/internal/operation-types/v1/cache-stats", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"success":true,"hits":0,"misses":0,"entries":0}`, rr.Body.String())
+}
@@ -1,7 +1,20 @@
 package operation_core_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	handlersV1Package "anti-fraud/operation-service/handlers/v1"
+	mapperV1Package "anti-fraud/operation-service/mapper/v1"
 	repoV1Package "anti-fraud/operation-service/repository/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -10,41 +23,567 @@ import (
 // IOperationCore defines the methods interface for operation-related core business logic.
 type IOperationCore interface {
 
-	// GetOperationCoefficient returns the coefficient for a given operation ID.
-	GetOperationCoefficient(logger *logrus.Entry, operationId int, tx *gorm.DB) (int, error)
+	// RegisterHandler plugs the handler of an operation kind into the core.
+	RegisterHandler(handler handlersV1Package.IOperationHandler)
+
+	// OnOperationChanged registers a listener told about every operation type changed through the admin API.
+	OnOperationChanged(listener func(operationId int))
+
+	// NotifyOperationChanged tells the listeners that an operation type changed, once the change is committed.
+	NotifyOperationChanged(ctx context.Context, operationId int)
+
+	// ComputeOperation computes the final amount and side effects of an input with the handler of its operation type,
+	// fetched by the caller.
+	ComputeOperation(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error)
+
+	// ComputeFees computes the fees charged on a transaction of an operation type, for the account tier.
+	ComputeFees(ctx context.Context, input *entityCoreV1Package.FeeInput, tx *gorm.DB) ([]*entityCoreV1Package.Fee, error)
+
+	// GetOperationCoefficient returns the coefficient of an operation type fetched by the caller, as of the transaction time.
+	GetOperationCoefficient(ctx context.Context, operation *entityDbV1Package.Operation, at time.Time) (int, error)
+
+	// GetOperation retrieves an operation type by its ID.
+	GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// ListOperations retrieves the operation types, optionally of one status.
+	ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error)
+
+	// CreateOperation creates a new operation type if its description is not already used.
+	CreateOperation(ctx context.Context, createPayload *entityCoreV1Package.CreateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// UpdateOperation changes the description, coefficient or validity window of an active operation type.
+	UpdateOperation(ctx context.Context, operationId int, updatePayload *entityCoreV1Package.UpdateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// DeprecateOperation stops an operation type from accepting new transactions.
+	DeprecateOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error)
 }
 
 // OperationCore implements IOperationCore interface.
 type OperationCore struct {
-	repoV1 repoV1Package.IOperationRepository
-	logger *logrus.Logger
+	repoV1            repoV1Package.IOperationRepository
+	logger            *logrus.Logger
+	transactionClient transactionClientV1Package.ITransactionClient
+	handlers          map[string]handlersV1Package.IOperationHandler
+	listeners         []func(operationId int)
 }
 
 // NewOperationCore creates and return new OperationCore instance.
-func NewOperationCore(repoV1 repoV1Package.IOperationRepository, logger *logrus.Logger) *OperationCore {
-	return &OperationCore{repoV1: repoV1, logger: logger}
+func NewOperationCore(repoV1 repoV1Package.IOperationRepository, logger *logrus.Logger, transactionClient transactionClientV1Package.ITransactionClient) *OperationCore {
+	return &OperationCore{repoV1: repoV1, logger: logger, transactionClient: transactionClient, handlers: map[string]handlersV1Package.IOperationHandler{}}
 }
 
-// GetOperationCoefficient retrieves the coefficient for the specified operationId.
+// RegisterHandler registers a handler under its kind, replacing any handler already registered for it.
+func (core *OperationCore) RegisterHandler(handler handlersV1Package.IOperationHandler) {
+	core.handlers[handler.Kind()] = handler
+}
+
+// OnOperationChanged registers a listener, such as a cache of operation types. Listeners are registered
+// while the services are wired, before any request is served.
+func (core *OperationCore) OnOperationChanged(listener func(operationId int)) {
+	core.listeners = append(core.listeners, listener)
+}
+
+// NotifyOperationChanged calls every listener with the ID of the changed operation type. The controller
+// calls it after committing, so a listener reloading the type cannot see the state from before the change.
+func (core *OperationCore) NotifyOperationChanged(ctx context.Context, operationId int) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Infof("Notifying %d listener(s) of a change to operation type %d.", len(core.listeners), operationId)
+	for _, listener := range core.listeners {
+		listener(operationId)
+	}
+}
+
+// ComputeOperation computes an input with the handler of its operation type.
 //
 // Workflow:
-//  1. Retrieves the operation record from the repository by operationId.
-//  2. If the repository call returns an error (e.g., not found), return Error.
-//  3. Returns the Coefficient field from the retrieved operation.
+//  1. The operation type, fetched by the caller, must be active and in effect at the input's event time.
+//  2. Looks up the handler registered for the kind of the operation type.
+//  3. Lets the handler validate the input, then compute its final amount and side effects.
 //
 // Parameters:
-//   - operationId: The unique id to find operation from db.
-//   - tx:          db txn.
+//   - operation: the operation type of the input.
+//   - input:     amount as submitted, installment count, hold flag and event time of the operation.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The final signed amount, applied coefficient and side effects.
+//   - An encountered Error.
+func (core *OperationCore) ComputeOperation(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeOperation method called in operation core layer.")
+
+	// 1. Check the operation type is in effect.
+	err := checkEffective(ctx, operation, input.At)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Resolve its handler.
+	handler, ok := core.handlers[operation.Kind]
+	if !ok {
+		logger.Errorf("Error: no handler registered for operation kind %s", operation.Kind)
+		return nil, fmt.Errorf("no handler registered for operation kind %s", operation.Kind)
+	}
+
+	// 3. Validate and compute the input.
+	err = handler.Validate(ctx, operation, input)
+	if err != nil {
+		return nil, err
+	}
+	result, err := handler.Compute(ctx, operation, input, tx)
+	if err != nil {
+		logger.Errorf("Error occured while computing operation: %s", err.Error())
+	}
+	return result, err
+}
+
+// ComputeFees computes the fees charged on a transaction.
+//
+// Workflow:
+//  1. Retrieves the fees of the operation type in the account currency.
+//  2. Keeps, for each fee code, the fee of the account tier if there is one, else the fee for every tier.
+//  3. Computes each fee as its flat amount plus its rate times the absolute transaction amount,
+//     rounded to the minor unit of the currency and capped within [min, max]. Zero fees are left out.
+//  4. When a fee is charged, resolves the active operation type of kind FEE the fees are posted with.
+//
+// Parameters:
+//   - input: operation type, account tier, currency and final amount of the transaction.
+//   - tx:    db txn.
+//
+// Returns:
+//   - The fees, by code; empty when the operation type charges none.
+//   - An encountered Error.
+func (core *OperationCore) ComputeFees(ctx context.Context, input *entityCoreV1Package.FeeInput, tx *gorm.DB) ([]*entityCoreV1Package.Fee, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ComputeFees method called in operation core layer.")
+
+	// 1. Fetch the fees of the operation type.
+	operationFees, err := core.repoV1.GetOperationFees(ctx, input.OperationTypeId, input.Currency, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching operation fees: %s", err.Error())
+		return nil, err
+	}
+
+	// 2. Resolve one fee per code; the repository returns them grouped by code.
+	applicable := []*entityDbV1Package.OperationFee{}
+	byCode := map[string]int{}
+	for _, operationFee := range operationFees {
+		if operationFee.AccountTier != nil && *operationFee.AccountTier != input.AccountTier {
+			continue
+		}
+		index, seen := byCode[operationFee.Code]
+		if !seen {
+			byCode[operationFee.Code] = len(applicable)
+			applicable = append(applicable, operationFee)
+		} else if operationFee.AccountTier != nil {
+			applicable[index] = operationFee
+		}
+	}
+
+	// 3. Compute them.
+	fees := []*entityCoreV1Package.Fee{}
+	for _, operationFee := range applicable {
+		amount, err := computeFee(operationFee, input.Amount, input.Currency)
+		if err != nil {
+			logger.Errorf("Error occured while computing fee %s: %s", operationFee.Code, err.Error())
+			return nil, err
+		}
+		if amount.IsZero() {
+			continue
+		}
+		fees = append(fees, &entityCoreV1Package.Fee{Code: operationFee.Code, Amount: amount})
+	}
+	if len(fees) == 0 {
+		return fees, nil
+	}
+
+	// 4. Resolve the operation type of the fees.
+	feeOperation, err := core.repoV1.GetActiveOperationByKind(ctx, constantPackage.KIND_FEE, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching the fee operation type: %s", err.Error())
+		return nil, err
+	}
+	for _, fee := range fees {
+		fee.OperationTypeId = int(feeOperation.ID)
+	}
+	return fees, nil
+}
+
+// computeFee applies one fee to a transaction amount, in the given currency.
+func computeFee(operationFee *entityDbV1Package.OperationFee, amount utilMoneyV1.Amount, currency string) (utilMoneyV1.Amount, error) {
+	fee := operationFee.FlatAmount
+	if operationFee.Rate.Sign() > 0 {
+		variable, err := utilMoneyV1.Convert(amount.Abs(), operationFee.Rate, currency)
+		if err != nil {
+			return utilMoneyV1.Zero, err
+		}
+		fee = fee.Add(variable)
+	}
+	if fee.Cmp(operationFee.MinAmount) < 0 {
+		fee = operationFee.MinAmount
+	}
+	if operationFee.MaxAmount != nil && fee.Cmp(*operationFee.MaxAmount) > 0 {
+		fee = *operationFee.MaxAmount
+	}
+	return fee, nil
+}
+
+// GetOperationCoefficient returns the coefficient of an operation type, as of the transaction time.
+//
+// Workflow:
+//  1. A deprecated operation type accepts no new transactions: return an unprocessable Error.
+//  2. The coefficient only applies within [ValidFrom, ValidTo): return an unprocessable Error outside of it.
+//  3. Returns the Coefficient field of the operation type.
+//
+// Parameters:
+//   - operation: the operation type, fetched by the caller.
+//   - at:        event time of the transaction the coefficient is applied to.
 //
 // Returns:
 //   - int:   The coefficient associated with the operation type.
 //   - error: an encountered Error.
-func (core *OperationCore) GetOperationCoefficient(logger *logrus.Entry, operationId int, tx *gorm.DB) (int, error) {
+func (core *OperationCore) GetOperationCoefficient(ctx context.Context, operation *entityDbV1Package.Operation, at time.Time) (int, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetOperationCoefficient method called in operation core layer.")
-	operation, err := core.repoV1.GetOperation(logger, operationId, tx)
+	err := checkEffective(ctx, operation, at)
 	if err != nil {
-		logger.Errorf("Error occured while fetching coefficient associated with operation: %s", err.Error())
 		return 0, err
 	}
 	return operation.Coefficient, nil
 }
+
+// checkEffective rejects an operation type that accepts no new transactions at the given time.
+func checkEffective(ctx context.Context, operation *entityDbV1Package.Operation, at time.Time) error {
+	logger := utilContextV1.Logger(ctx)
+	if operation.Status == constantPackage.STATUS_DEPRECATED {
+		logger.Errorf("Error: operation id %d is deprecated", operation.ID)
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_TYPE_DEPRECATED, fmt.Sprintf("operation_type_id: %d is deprecated", operation.ID))
+	}
+	if !effectiveAt(operation, at) {
+		logger.Errorf("Error: operation id %d is not effective at %s", operation.ID, at.Format(time.RFC3339))
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_TYPE_NOT_EFFECTIVE, fmt.Sprintf("operation_type_id: %d is not effective at %s", operation.ID, at.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// validateKindCoefficient rejects a coefficient the kind of the operation type does not allow: the
+// installment purchase handler always computes debits, and fees are always posted as debits.
+func validateKindCoefficient(ctx context.Context, operation *entityDbV1Package.Operation) error {
+	logger := utilContextV1.Logger(ctx)
+	debitOnly := operation.Kind == constantPackage.KIND_INSTALLMENT_PURCHASE || operation.Kind == constantPackage.KIND_FEE
+	if debitOnly && operation.Coefficient != constantPackage.COEFFICIENT_DEBIT {
+		logger.Errorf("Error: operation kind %s with coefficient %d", operation.Kind, operation.Coefficient)
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("coefficient of kind %s should be %d (debit)", operation.Kind, constantPackage.COEFFICIENT_DEBIT))
+	}
+	return nil
+}
+
+// checkNoActiveKind rejects a new operation type of a kind only one active type can have, such as FEE,
+// while an active type already has it.
+func (core *OperationCore) checkNoActiveKind(ctx context.Context, kind string, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	existing, err := core.repoV1.GetActiveOperationByKind(ctx, kind, tx)
+	if err != nil {
+		if utilErrorsV1.IsNotFound(err) {
+			return nil
+		}
+		logger.Errorf("Error occured while fetching the active operation type of kind %s: %s", kind, err.Error())
+		return err
+	}
+	logger.Errorf("Error: operation_type_id %d is already of kind %s", existing.ID, kind)
+	return utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_OPERATION_KIND, fmt.Sprintf("operation_type_id: %d is already the active operation type of kind %s", existing.ID, kind))
+}
+
+// effectiveAt reports whether at falls within the validity window of operation.
+func effectiveAt(operation *entityDbV1Package.Operation, at time.Time) bool {
+	if at.Before(operation.ValidFrom) {
+		return false
+	}
+	return operation.ValidTo == nil || at.Before(*operation.ValidTo)
+}
+
+// GetOperation retrieves an operation type by its ID.
+//
+// Parameters:
+//   - operationId: ID of the operation type.
+//   - tx:          db txn.
+//
+// Returns:
+//   - db entity Operation.
+//   - An encountered Error, not found included.
+func (core *OperationCore) GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOperation method called in operation core layer.")
+	return core.repoV1.GetOperation(ctx, operationId, tx)
+}
+
+// ListOperations retrieves the operation types in ID order.
+//
+// Parameters:
+//   - status: ACTIVE or DEPRECATED to filter by status, empty for all.
+//   - tx:     db txn.
+//
+// Returns:
+//   - The operation types.
+//   - An encountered Error.
+func (core *OperationCore) ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ListOperations method called in operation core layer.")
+	operations, err := core.repoV1.ListOperations(ctx, status, tx)
+	if err != nil {
+		logger.Errorf("Error occured while listing operations: %s", err.Error())
+	}
+	return operations, err
+}
+
+// CreateOperation creates a new operation type.
+//
+// Steps:
+//  1. Reject a description already used by another active operation type, unless the validity of that
+//     type has ended: it is then deprecated, so its successor can take the description over.
+//  2. Map the payload to a DB entity, ACTIVE, STANDARD and valid from now unless told otherwise.
+//     Its kind must have a registered handler, and an INSTALLMENT_PURCHASE type must be a debit.
+//     Fees are posted with a single active FEE type, so a second one is rejected.
+//  3. Reject an empty validity window, then persist it.
+//
+// Parameters:
+//   - createPayload: description and coefficient of the operation type.
+//   - tx:            db txn.
+//
+// Returns:
+//   - A pointer to the newly created Operation entity.
+//   - An encountered Error.
+func (core *OperationCore) CreateOperation(ctx context.Context, createPayload *entityCoreV1Package.CreateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CreateOperation method called in operation core layer.")
+
+	// 1. Check for an existing operation type with the same description.
+	err := core.checkDuplicateDescription(ctx, createPayload.Description, 0, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Map the operation type.
+	now := time.Now()
+	operation := mapperV1Package.OperationMapper(createPayload, now)
+	if _, ok := core.handlers[operation.Kind]; !ok {
+		logger.Errorf("Error: no handler registered for operation kind %s", operation.Kind)
+		return nil, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("unknown kind: %s", operation.Kind))
+	}
+	err = validateKindCoefficient(ctx, operation)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Kind == constantPackage.KIND_FEE {
+		err = core.checkNoActiveKind(ctx, operation.Kind, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Validate its validity window and persist it.
+	err = validateValidity(ctx, operation, createPayload.ValidTo, now)
+	if err != nil {
+		return nil, err
+	}
+	err = core.repoV1.CreateOperation(ctx, operation, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting operation: %s", err.Error())
+		return nil, err
+	}
+	return operation, nil
+}
+
+// UpdateOperation changes the description, coefficient or validity window of an operation type.
+//
+// Steps:
+//  1. Fetch and lock the operation type; a deprecated one cannot be changed.
+//  2. Reject a new description already used by another active operation type whose validity has not ended.
+//  3. A coefficient change would flip the sign of the amounts already posted with the type, so it is
+//     rejected once a transaction references the type. An INSTALLMENT_PURCHASE type is always a debit,
+//     as its handler computes negative amounts whatever the coefficient.
+//  4. Moving valid_from could leave posted transactions outside the window, so it is rejected as well
+//     once the type is in use. valid_to may not be set in the past for the same reason.
+//  5. Persist the changes.
+//
+// Parameters:
+//   - operationId:   ID of the operation type.
+//   - updatePayload: fields to change, nil for those left as is.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The updated operation type.
+//   - An encountered Error.
+func (core *OperationCore) UpdateOperation(ctx context.Context, operationId int, updatePayload *entityCoreV1Package.UpdateOperationPayload, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateOperation method called in operation core layer.")
+
+	// 1. Fetch, lock and validate the operation type.
+	operation, err := core.repoV1.GetOperationForUpdate(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching operation: %s", err.Error())
+		return nil, err
+	}
+	if operation.Status == constantPackage.STATUS_DEPRECATED {
+		logger.Errorf("Error: operation id %d is deprecated", operationId)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_TYPE_DEPRECATED, fmt.Sprintf("operation_type_id: %d is deprecated", operationId))
+	}
+
+	// 2. Apply the new description.
+	if updatePayload.Description != nil && *updatePayload.Description != operation.Description {
+		err = core.checkDuplicateDescription(ctx, *updatePayload.Description, operation.ID, tx)
+		if err != nil {
+			return nil, err
+		}
+		operation.Description = *updatePayload.Description
+	}
+
+	// 3. Apply the new coefficient, unless the type is in use.
+	if updatePayload.Coefficient != nil && *updatePayload.Coefficient != operation.Coefficient {
+		operation.Coefficient = *updatePayload.Coefficient
+		err = validateKindCoefficient(ctx, operation)
+		if err != nil {
+			return nil, err
+		}
+		err = core.checkNotInUse(ctx, operationId, "coefficient", tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Apply the new validity window.
+	now := time.Now()
+	if updatePayload.ValidFrom != nil && !updatePayload.ValidFrom.Equal(operation.ValidFrom) {
+		err = core.checkNotInUse(ctx, operationId, "valid_from", tx)
+		if err != nil {
+			return nil, err
+		}
+		operation.ValidFrom = *updatePayload.ValidFrom
+	}
+	if updatePayload.ValidTo != nil {
+		operation.ValidTo = updatePayload.ValidTo
+	}
+	err = validateValidity(ctx, operation, updatePayload.ValidTo, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Persist the changes.
+	err = core.repoV1.UpdateOperation(ctx, operation, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting operation: %s", err.Error())
+		return nil, err
+	}
+	return operation, nil
+}
+
+// DeprecateOperation deprecates an operation type.
+//
+// Steps:
+//  1. Fetch and lock the operation type; it must be ACTIVE.
+//  2. The active FEE type posts every configured fee: it cannot be deprecated while fees are configured,
+//     or every transaction charged a fee would fail.
+//  3. Mark it DEPRECATED and end its validity now, unless it already ended. The transactions already
+//     posted with it are kept, and can still be reversed.
+//
+// Parameters:
+//   - operationId: ID of the operation type.
+//   - tx:          db txn.
+//
+// Returns:
+//   - The deprecated operation type.
+//   - An encountered Error.
+func (core *OperationCore) DeprecateOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("DeprecateOperation method called in operation core layer.")
+
+	operation, err := core.repoV1.GetOperationForUpdate(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching operation: %s", err.Error())
+		return nil, err
+	}
+	if operation.Status == constantPackage.STATUS_DEPRECATED {
+		logger.Errorf("Error: operation id %d is already deprecated", operationId)
+		return nil, utilErrorsV1.NewConflictError(errorConstantPackage.INVALID_STATUS_TRANSITION, fmt.Sprintf("operation_type_id: %d is already deprecated", operationId))
+	}
+	if operation.Kind == constantPackage.KIND_FEE {
+		count, err := core.repoV1.CountOperationFees(ctx, tx)
+		if err != nil {
+			logger.Errorf("Error occured while counting operation fees: %s", err.Error())
+			return nil, err
+		}
+		if count > 0 {
+			logger.Errorf("Error: operation id %d posts the %d configured fees", operationId, count)
+			return nil, utilErrorsV1.NewConflictError(errorConstantPackage.OPERATION_TYPE_IN_USE, fmt.Sprintf("operation_type_id: %d posts the %d configured fees and cannot be deprecated", operationId, count))
+		}
+	}
+	operation.Status = constantPackage.STATUS_DEPRECATED
+	now := time.Now()
+	if operation.ValidTo == nil || operation.ValidTo.After(now) {
+		operation.ValidTo = &now
+	}
+	err = core.repoV1.UpdateOperation(ctx, operation, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting operation: %s", err.Error())
+		return nil, err
+	}
+	return operation, nil
+}
+
+// checkDuplicateDescription rejects a description used by an active operation type other than exceptId.
+// An active type whose validity has already ended accepts no new transactions, so it gives the description
+// up instead: it is deprecated in the same db txn, and the caches drop it once the txn commits.
+func (core *OperationCore) checkDuplicateDescription(ctx context.Context, description string, exceptId uint, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	operationFound, err := core.repoV1.CheckDuplicateOperation(ctx, description, tx)
+	if err != nil {
+		logger.Errorf("Error occured while checking for duplicate operation: %s", err.Error())
+		return err
+	}
+	if operationFound.ID == 0 || operationFound.ID == exceptId {
+		return nil
+	}
+	if operationFound.ValidTo == nil || operationFound.ValidTo.After(time.Now()) {
+		logger.Error("Error: Duplicate operation found")
+		return utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION, fmt.Sprintf("duplicate operation type found with description: %s", description))
+	}
+
+	logger.Infof("Operation id %d expired at %s, deprecating it to reuse its description", operationFound.ID, operationFound.ValidTo.Format(time.RFC3339))
+	operationFound.Status = constantPackage.STATUS_DEPRECATED
+	err = core.repoV1.UpdateOperation(ctx, operationFound, tx)
+	if err != nil {
+		logger.Errorf("Error occured while deprecating expired operation: %s", err.Error())
+		return err
+	}
+	utilContextV1.AfterCommit(ctx, func() { core.NotifyOperationChanged(ctx, int(operationFound.ID)) })
+	return nil
+}
+
+// checkNotInUse rejects a change of field once a transaction references the operation type.
+func (core *OperationCore) checkNotInUse(ctx context.Context, operationId int, field string, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	count, err := core.transactionClient.CountOperationTypeTransactions(ctx, operationId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while counting transactions of operation: %s", err.Error())
+		return err
+	}
+	if count > 0 {
+		logger.Errorf("Error: operation id %d is referenced by %d transactions", operationId, count)
+		return utilErrorsV1.NewConflictError(errorConstantPackage.OPERATION_TYPE_IN_USE, fmt.Sprintf("operation_type_id: %d is referenced by %d transactions, its %s cannot change", operationId, count, field))
+	}
+	return nil
+}
+
+// validateValidity rejects an empty validity window, or a new valid_to in the past.
+func validateValidity(ctx context.Context, operation *entityDbV1Package.Operation, newValidTo *time.Time, now time.Time) error {
+	logger := utilContextV1.Logger(ctx)
+	if operation.ValidTo != nil && !operation.ValidTo.After(operation.ValidFrom) {
+		logger.Error("Error: valid_to is not after valid_from")
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, "valid_to should be after valid_from")
+	}
+	if newValidTo != nil && newValidTo.Before(now) {
+		logger.Error("Error: valid_to is in the past")
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, "valid_to should not be in the past")
+	}
+	return nil
+}
@@ -1,10 +1,20 @@
 package operation_core_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	handlersV1Package "anti-fraud/operation-service/handlers/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
+	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -16,19 +26,115 @@ type MockOperationRepository struct {
 	mock.Mock
 }
 
-func (m *MockOperationRepository) GetOperation(logger *logrus.Entry, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+func (m *MockOperationRepository) GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
 	args := m.Called(operationId, tx)
 	op, _ := args.Get(0).(*entityDbV1Package.Operation)
 	return op, args.Error(1)
 }
 
+func (m *MockOperationRepository) GetOperationForUpdate(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(operationId, tx)
+	op, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return op, args.Error(1)
+}
+
+func (m *MockOperationRepository) GetActiveOperationByKind(ctx context.Context, kind string, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(kind, tx)
+	op, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return op, args.Error(1)
+}
+
+func (m *MockOperationRepository) CheckDuplicateOperation(ctx context.Context, description string, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	args := m.Called(description, tx)
+	op, _ := args.Get(0).(*entityDbV1Package.Operation)
+	return op, args.Error(1)
+}
+
+func (m *MockOperationRepository) ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error) {
+	args := m.Called(status, tx)
+	ops, _ := args.Get(0).([]*entityDbV1Package.Operation)
+	return ops, args.Error(1)
+}
+
+func (m *MockOperationRepository) CreateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error {
+	args := m.Called(operation, tx)
+	return args.Error(0)
+}
+
+func (m *MockOperationRepository) UpdateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error {
+	args := m.Called(operation, tx)
+	return args.Error(0)
+}
+
+func (m *MockOperationRepository) GetOperationFees(ctx context.Context, operationTypeId int, currency string, tx *gorm.DB) ([]*entityDbV1Package.OperationFee, error) {
+	args := m.Called(operationTypeId, currency, tx)
+	fees, _ := args.Get(0).([]*entityDbV1Package.OperationFee)
+	return fees, args.Error(1)
+}
+
+func (m *MockOperationRepository) CountOperationFees(ctx context.Context, tx *gorm.DB) (int64, error) {
+	args := m.Called(tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockTransactionClient struct {
+	mock.Mock
+}
+
+func (m *MockTransactionClient) SetupCore(transactionCoreV1 transactionClientV1Package.ITransactionCore) {
+	m.Called(transactionCoreV1)
+}
+
+func (m *MockTransactionClient) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*transactionClientV1Package.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*transactionClientV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionClient) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionClient) PostCapturedTransaction(ctx context.Context, capture *transactionClientV1Package.CapturedTransaction, tx *gorm.DB) (*transactionClientV1Package.Transaction, error) {
+	args := m.Called(capture, tx)
+	transaction, _ := args.Get(0).(*transactionClientV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *MockTransactionClient) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func setupTestCore() (*OperationCore, *MockOperationRepository) {
+	core, mockRepo, _ := setupAdminTestCore()
+	return core, mockRepo
+}
+
+func setupAdminTestCore() (*OperationCore, *MockOperationRepository, *MockTransactionClient) {
 	logger := logrus.New()
 	mockRepo := new(MockOperationRepository)
+	mockClient := new(MockTransactionClient)
 
-	core := NewOperationCore(mockRepo, logger)
+	core := NewOperationCore(mockRepo, logger, mockClient)
+	core.RegisterHandler(handlersV1Package.NewStandardHandler())
+	core.RegisterHandler(handlersV1Package.NewInstallmentPurchaseHandler())
+	core.RegisterHandler(handlersV1Package.NewFeeHandler())
 
-	return core, mockRepo
+	return core, mockRepo, mockClient
+}
+
+func assertAppError(t *testing.T, err error, status int, code string) {
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	if assert.True(t, ok, "expected an AppError, got %v", err) {
+		assert.Equal(t, status, appErr.Status)
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func activeOperation(id uint, coefficient int) *entityDbV1Package.Operation {
+	return &entityDbV1Package.Operation{Model: gorm.Model{ID: id}, Description: "Normal Purchase", Coefficient: coefficient, Status: constantPackage.STATUS_ACTIVE}
 }
 
 //---------------------------//
@@ -36,42 +142,479 @@ func setupTestCore() (*OperationCore, *MockOperationRepository) {
 //---------------------------//
 
 func TestGetOperationCoefficient_Success(t *testing.T) {
-	core, mockRepo := setupTestCore()
+	core, _ := setupTestCore()
 
-	mockRepo.On("GetOperation", 1, mock.Anything).
-		Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: 3}, nil)
-
-	coef, err := core.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 1, &gorm.DB{})
+	coef, err := core.GetOperationCoefficient(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: 3}, time.Now())
 	assert.NoError(t, err)
 	assert.Equal(t, 3, coef)
-
-	mockRepo.AssertExpectations(t)
 }
 
-func TestGetOperationCoefficient_NotFoundError(t *testing.T) {
+func TestGetOperation_NotFoundError(t *testing.T) {
 	core, mockRepo := setupTestCore()
 
 	mockRepo.On("GetOperation", 99, mock.Anything).
 		Return((*entityDbV1Package.Operation)(nil), errors.New("operation id: 99 not found in database"))
 
-	coef, err := core.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 99, &gorm.DB{})
+	operation, err := core.GetOperation(context.Background(), 99, &gorm.DB{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "99 not found")
-	assert.Equal(t, 0, coef)
+	assert.Nil(t, operation)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetOperationCoefficient_RepoError(t *testing.T) {
+func TestGetOperation_RepoError(t *testing.T) {
 	core, mockRepo := setupTestCore()
 
 	mockRepo.On("GetOperation", 2, mock.Anything).
 		Return((*entityDbV1Package.Operation)(nil), errors.New("db error"))
 
-	coef, err := core.GetOperationCoefficient(logrus.NewEntry(logrus.New()), 2, &gorm.DB{})
+	operation, err := core.GetOperation(context.Background(), 2, &gorm.DB{})
 	assert.Error(t, err)
-	assert.Equal(t, 0, coef)
+	assert.Nil(t, operation)
 	assert.Contains(t, err.Error(), "db error")
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGetOperationCoefficient_Deprecated(t *testing.T) {
+	core, _ := setupTestCore()
+
+	deprecated := activeOperation(5, -1)
+	deprecated.Status = constantPackage.STATUS_DEPRECATED
+
+	_, err := core.GetOperationCoefficient(context.Background(), deprecated, time.Now())
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_TYPE_DEPRECATED)
+}
+
+func TestGetOperationCoefficient_OutsideValidity(t *testing.T) {
+	core, _ := setupTestCore()
+
+	validFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	validTo := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	operation := activeOperation(5, -1)
+	operation.ValidFrom = validFrom
+	operation.ValidTo = &validTo
+
+	coef, err := core.GetOperationCoefficient(context.Background(), operation, validFrom)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, coef)
+
+	_, err = core.GetOperationCoefficient(context.Background(), operation, validFrom.Add(-time.Second))
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_TYPE_NOT_EFFECTIVE)
+
+	_, err = core.GetOperationCoefficient(context.Background(), operation, validTo)
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_TYPE_NOT_EFFECTIVE)
+}
+
+func TestComputeOperation_StandardKind(t *testing.T) {
+	core, _ := setupTestCore()
+
+	operation := activeOperation(1, -1)
+	operation.Kind = constantPackage.KIND_STANDARD
+
+	result, err := core.ComputeOperation(context.Background(), operation, &entityCoreV1Package.OperationInput{OperationTypeId: 1, Amount: utilMoneyV1.MustParse("12.5"), At: time.Now()}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "-12.5", result.Amount.String())
+	assert.Equal(t, -1, result.Coefficient)
+	assert.Equal(t, 0, result.InstallmentCount)
+}
+
+func TestComputeOperation_Deprecated(t *testing.T) {
+	core, _ := setupTestCore()
+
+	operation := activeOperation(1, -1)
+	operation.Kind = constantPackage.KIND_STANDARD
+	operation.Status = constantPackage.STATUS_DEPRECATED
+
+	_, err := core.ComputeOperation(context.Background(), operation, &entityCoreV1Package.OperationInput{OperationTypeId: 1, Amount: utilMoneyV1.MustParse("12.5"), At: time.Now()}, &gorm.DB{})
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_TYPE_DEPRECATED)
+}
+
+func TestComputeOperation_HandlerValidation(t *testing.T) {
+	core, _ := setupTestCore()
+
+	operation := activeOperation(2, -1)
+	operation.Kind = constantPackage.KIND_INSTALLMENT_PURCHASE
+
+	_, err := core.ComputeOperation(context.Background(), operation, &entityCoreV1Package.OperationInput{OperationTypeId: 2, Amount: utilMoneyV1.MustParse("100"), At: time.Now()}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+}
+
+func TestComputeOperation_FeeKind(t *testing.T) {
+	core, _ := setupTestCore()
+
+	operation := activeOperation(9, -1)
+	operation.Kind = constantPackage.KIND_FEE
+
+	_, err := core.ComputeOperation(context.Background(), operation, &entityCoreV1Package.OperationInput{OperationTypeId: 9, Amount: utilMoneyV1.MustParse("2.5"), At: time.Now()}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+}
+
+func TestComputeOperation_UnregisteredKind(t *testing.T) {
+	core, _ := setupTestCore()
+
+	operation := activeOperation(7, -1)
+	operation.Kind = "CASHBACK"
+
+	_, err := core.ComputeOperation(context.Background(), operation, &entityCoreV1Package.OperationInput{OperationTypeId: 7, Amount: utilMoneyV1.MustParse("100"), At: time.Now()}, &gorm.DB{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no handler registered for operation kind CASHBACK")
+}
+
+func TestNotifyOperationChanged(t *testing.T) {
+	core, _ := setupTestCore()
+
+	var notified []int
+	core.OnOperationChanged(func(operationId int) { notified = append(notified, operationId) })
+	core.OnOperationChanged(func(operationId int) { notified = append(notified, -operationId) })
+
+	core.NotifyOperationChanged(context.Background(), 4)
+	assert.Equal(t, []int{4, -4}, notified)
+}
+
+func TestComputeFees_TierOverridesDefault(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	gold := "GOLD"
+	platinum := "PLATINUM"
+	maxAmount := utilMoneyV1.MustParse("10")
+	mockRepo.On("GetOperationFees", 3, "USD", mock.Anything).Return([]*entityDbV1Package.OperationFee{
+		{Code: "ATM_SURCHARGE", FlatAmount: utilMoneyV1.MustParse("1")},
+		{Code: "ATM_SURCHARGE", AccountTier: &platinum},
+		{Code: "WITHDRAWAL_FEE", FlatAmount: utilMoneyV1.MustParse("2"), Rate: utilMoneyV1.MustParseRate("0.01"), MaxAmount: &maxAmount},
+		{Code: "WITHDRAWAL_FEE", AccountTier: &gold, Rate: utilMoneyV1.MustParseRate("0.005"), MinAmount: utilMoneyV1.MustParse("1.5")},
+	}, nil)
+	mockRepo.On("GetActiveOperationByKind", constantPackage.KIND_FEE, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 9}}, nil)
+
+	input := &entityCoreV1Package.FeeInput{OperationTypeId: 3, Currency: "USD", Amount: utilMoneyV1.MustParse("-250.50")}
+	for _, tc := range []struct {
+		tier     string
+		expected map[string]string
+	}{
+		// 2 + 1% of 250.50 = 4.505, rounded to 4.51.
+		{"STANDARD", map[string]string{"ATM_SURCHARGE": "1", "WITHDRAWAL_FEE": "4.51"}},
+		// 0.5% of 250.50 = 1.2525, raised to the 1.5 minimum.
+		{"GOLD", map[string]string{"ATM_SURCHARGE": "1", "WITHDRAWAL_FEE": "1.5"}},
+		// The zero surcharge of the tier is left out.
+		{"PLATINUM", map[string]string{"WITHDRAWAL_FEE": "4.51"}},
+	} {
+		input.AccountTier = tc.tier
+		fees, err := core.ComputeFees(context.Background(), input, &gorm.DB{})
+		assert.NoError(t, err)
+		computed := map[string]string{}
+		for _, fee := range fees {
+			computed[fee.Code] = fee.Amount.String()
+		}
+		assert.Equal(t, tc.expected, computed, tc.tier)
+	}
+}
+
+func TestComputeFees_MaxAmount(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	maxAmount := utilMoneyV1.MustParse("10")
+	mockRepo.On("GetOperationFees", 2, "USD", mock.Anything).Return([]*entityDbV1Package.OperationFee{
+		{Code: "INSTALLMENT_INTEREST", Rate: utilMoneyV1.MustParseRate("0.02"), MaxAmount: &maxAmount},
+	}, nil)
+	mockRepo.On("GetActiveOperationByKind", constantPackage.KIND_FEE, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 9}}, nil)
+
+	fees, err := core.ComputeFees(context.Background(), &entityCoreV1Package.FeeInput{OperationTypeId: 2, Currency: "USD", Amount: utilMoneyV1.MustParse("-1000")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Len(t, fees, 1)
+	assert.Equal(t, "10", fees[0].Amount.String())
+	assert.Equal(t, 9, fees[0].OperationTypeId, "fees are posted with the active FEE type")
+}
+
+func TestComputeFees_NoFeeSkipsFeeOperationType(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("GetOperationFees", 1, "USD", mock.Anything).Return([]*entityDbV1Package.OperationFee{}, nil)
+
+	fees, err := core.ComputeFees(context.Background(), &entityCoreV1Package.FeeInput{OperationTypeId: 1, Currency: "USD", Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Empty(t, fees)
+	mockRepo.AssertNotCalled(t, "GetActiveOperationByKind", mock.Anything, mock.Anything)
+}
+
+func TestComputeFees_NoFeeOperationType(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("GetOperationFees", 3, "USD", mock.Anything).Return([]*entityDbV1Package.OperationFee{
+		{Code: "WITHDRAWAL_FEE", FlatAmount: utilMoneyV1.MustParse("2.5")},
+	}, nil)
+	mockRepo.On("GetActiveOperationByKind", constantPackage.KIND_FEE, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "no active operation type of kind: FEE"))
+
+	_, err := core.ComputeFees(context.Background(), &entityCoreV1Package.FeeInput{OperationTypeId: 3, Currency: "USD", Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+}
+
+func TestComputeFees_RepoError(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("GetOperationFees", 3, "USD", mock.Anything).Return(nil, errors.New("db error"))
+
+	_, err := core.ComputeFees(context.Background(), &entityCoreV1Package.FeeInput{OperationTypeId: 3, Currency: "USD", Amount: utilMoneyV1.MustParse("-10")}, &gorm.DB{})
+	assert.EqualError(t, err, "db error")
+}
+
+func TestCreateOperation_Success(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Cashback", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+	mockRepo.On("CreateOperation", mock.MatchedBy(func(operation *entityDbV1Package.Operation) bool {
+		return operation.Description == "Cashback" && operation.Coefficient == 1 && operation.Kind == constantPackage.KIND_STANDARD && operation.Status == constantPackage.STATUS_ACTIVE
+	}), mock.Anything).Return(nil)
+
+	before := time.Now()
+	operation, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Cashback", Coefficient: 1}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_ACTIVE, operation.Status)
+	assert.WithinDuration(t, before, operation.ValidFrom, time.Minute)
+	assert.Nil(t, operation.ValidTo)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOperation_UnknownKind(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Cashback", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Cashback", Coefficient: 1, Kind: "CASHBACK"}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_InstallmentPurchaseCredit(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Installment refund", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Installment refund", Coefficient: 1, Kind: constantPackage.KIND_INSTALLMENT_PURCHASE}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_SecondFeeType(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Card fee", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+	mockRepo.On("GetActiveOperationByKind", constantPackage.KIND_FEE, mock.Anything).Return(&entityDbV1Package.Operation{Model: gorm.Model{ID: 9}}, nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Card fee", Coefficient: -1, Kind: constantPackage.KIND_FEE}, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.DUPLICATE_OPERATION_KIND)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_FeeCredit(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Fee refund", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Fee refund", Coefficient: 1, Kind: constantPackage.KIND_FEE}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_EmptyValidity(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Cashback", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+
+	validFrom := time.Now().Add(48 * time.Hour)
+	validTo := validFrom.Add(-time.Hour)
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Cashback", Coefficient: 1, ValidFrom: &validFrom, ValidTo: &validTo}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_DuplicateDescription(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	mockRepo.On("CheckDuplicateOperation", "Normal Purchase", mock.Anything).Return(activeOperation(1, -1), nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Normal Purchase", Coefficient: -1}, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION)
+	mockRepo.AssertNotCalled(t, "CreateOperation", mock.Anything, mock.Anything)
+}
+
+func TestCreateOperation_SuccessorOfExpiredType(t *testing.T) {
+	core, mockRepo := setupTestCore()
+	notified := []int{}
+	core.OnOperationChanged(func(operationId int) { notified = append(notified, operationId) })
+
+	expired := activeOperation(1, -1)
+	validTo := time.Now().Add(-time.Hour)
+	expired.ValidTo = &validTo
+	mockRepo.On("CheckDuplicateOperation", "Normal Purchase", mock.Anything).Return(expired, nil)
+	mockRepo.On("UpdateOperation", mock.MatchedBy(func(operation *entityDbV1Package.Operation) bool {
+		return operation.ID == 1 && operation.Status == constantPackage.STATUS_DEPRECATED
+	}), mock.Anything).Return(nil)
+	mockRepo.On("CreateOperation", mock.Anything, mock.Anything).Return(nil)
+
+	operation, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Normal Purchase", Coefficient: 1}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Normal Purchase", operation.Description)
+	assert.Equal(t, 1, operation.Coefficient)
+	assert.Equal(t, []int{1}, notified, "the caches drop the deprecated predecessor")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateOperation_DescriptionOfTypeEndingLater(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	ending := activeOperation(1, -1)
+	validTo := time.Now().Add(time.Hour)
+	ending.ValidTo = &validTo
+	mockRepo.On("CheckDuplicateOperation", "Normal Purchase", mock.Anything).Return(ending, nil)
+
+	_, err := core.CreateOperation(context.Background(), &entityCoreV1Package.CreateOperationPayload{Description: "Normal Purchase", Coefficient: 1}, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION)
+	mockRepo.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_CoefficientOfUnusedType(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	operation := activeOperation(5, -1)
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(operation, nil)
+	mockClient.On("CountOperationTypeTransactions", 5, mock.Anything).Return(int64(0), nil)
+	mockRepo.On("UpdateOperation", operation, mock.Anything).Return(nil)
+
+	coefficient := 1
+	updated, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{Coefficient: &coefficient}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.Coefficient)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateOperation_CoefficientOfReferencedType(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(activeOperation(5, -1), nil)
+	mockClient.On("CountOperationTypeTransactions", 5, mock.Anything).Return(int64(3), nil)
+
+	coefficient := 1
+	_, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{Coefficient: &coefficient}, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.OPERATION_TYPE_IN_USE)
+	mockRepo.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_CoefficientOfInstallmentPurchase(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	operation := activeOperation(7, -1)
+	operation.Kind = constantPackage.KIND_INSTALLMENT_PURCHASE
+	mockRepo.On("GetOperationForUpdate", 7, mock.Anything).Return(operation, nil)
+
+	coefficient := 1
+	_, err := core.UpdateOperation(context.Background(), 7, &entityCoreV1Package.UpdateOperationPayload{Coefficient: &coefficient}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	mockClient.AssertNotCalled(t, "CountOperationTypeTransactions", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_DescriptionOfReferencedType(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	operation := activeOperation(1, -1)
+	mockRepo.On("GetOperationForUpdate", 1, mock.Anything).Return(operation, nil)
+	mockRepo.On("CheckDuplicateOperation", "Card Purchase", mock.Anything).Return(&entityDbV1Package.Operation{}, nil)
+	mockRepo.On("UpdateOperation", operation, mock.Anything).Return(nil)
+
+	description := "Card Purchase"
+	coefficient := -1
+	updated, err := core.UpdateOperation(context.Background(), 1, &entityCoreV1Package.UpdateOperationPayload{Description: &description, Coefficient: &coefficient}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Card Purchase", updated.Description)
+	mockClient.AssertNotCalled(t, "CountOperationTypeTransactions", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_ValidFromOfReferencedType(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(activeOperation(5, -1), nil)
+	mockClient.On("CountOperationTypeTransactions", 5, mock.Anything).Return(int64(3), nil)
+
+	validFrom := time.Now()
+	_, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{ValidFrom: &validFrom}, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.OPERATION_TYPE_IN_USE)
+	mockRepo.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_ValidTo(t *testing.T) {
+	core, mockRepo, mockClient := setupAdminTestCore()
+
+	operation := activeOperation(5, -1)
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(operation, nil)
+	mockRepo.On("UpdateOperation", operation, mock.Anything).Return(nil)
+
+	past := time.Now().Add(-time.Hour)
+	_, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{ValidTo: &past}, &gorm.DB{})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+
+	future := time.Now().Add(time.Hour)
+	updated, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{ValidTo: &future}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, future, *updated.ValidTo)
+	mockClient.AssertNotCalled(t, "CountOperationTypeTransactions", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOperation_Deprecated(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	deprecated := activeOperation(5, -1)
+	deprecated.Status = constantPackage.STATUS_DEPRECATED
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(deprecated, nil)
+
+	description := "Renamed"
+	_, err := core.UpdateOperation(context.Background(), 5, &entityCoreV1Package.UpdateOperationPayload{Description: &description}, &gorm.DB{})
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_TYPE_DEPRECATED)
+}
+
+func TestDeprecateOperation(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	operation := activeOperation(5, -1)
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(operation, nil).Once()
+	mockRepo.On("UpdateOperation", operation, mock.Anything).Return(nil)
+
+	before := time.Now()
+	deprecated, err := core.DeprecateOperation(context.Background(), 5, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_DEPRECATED, deprecated.Status)
+	assert.WithinDuration(t, before, *deprecated.ValidTo, time.Minute)
+
+	mockRepo.On("GetOperationForUpdate", 5, mock.Anything).Return(deprecated, nil)
+	_, err = core.DeprecateOperation(context.Background(), 5, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.INVALID_STATUS_TRANSITION)
+}
+
+func TestDeprecateOperation_FeeTypeWithConfiguredFees(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	operation := activeOperation(9, -1)
+	operation.Kind = constantPackage.KIND_FEE
+	mockRepo.On("GetOperationForUpdate", 9, mock.Anything).Return(operation, nil)
+	mockRepo.On("CountOperationFees", mock.Anything).Return(int64(4), nil)
+
+	_, err := core.DeprecateOperation(context.Background(), 9, &gorm.DB{})
+	assertAppError(t, err, http.StatusConflict, errorConstantPackage.OPERATION_TYPE_IN_USE)
+	assert.Equal(t, constantPackage.STATUS_ACTIVE, operation.Status)
+	mockRepo.AssertNotCalled(t, "UpdateOperation", mock.Anything, mock.Anything)
+}
+
+func TestDeprecateOperation_FeeTypeWithoutFees(t *testing.T) {
+	core, mockRepo := setupTestCore()
+
+	operation := activeOperation(9, -1)
+	operation.Kind = constantPackage.KIND_FEE
+	mockRepo.On("GetOperationForUpdate", 9, mock.Anything).Return(operation, nil)
+	mockRepo.On("CountOperationFees", mock.Anything).Return(int64(0), nil)
+	mockRepo.On("UpdateOperation", operation, mock.Anything).Return(nil)
+
+	deprecated, err := core.DeprecateOperation(context.Background(), 9, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_DEPRECATED, deprecated.Status)
+}
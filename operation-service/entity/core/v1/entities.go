@@ -0,0 +1,61 @@
+package operation_entity_core_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+type CreateOperationPayload struct {
+	Description string     `json:"description"`
+	Coefficient int        `json:"coefficient"`
+	Kind        string     `json:"kind"`       // empty for STANDARD
+	ValidFrom   *time.Time `json:"valid_from"` // nil for now
+	ValidTo     *time.Time `json:"valid_to"`   // nil for open ended
+}
+
+// UpdateOperationPayload holds the fields to change; a nil field is left as is.
+type UpdateOperationPayload struct {
+	Description *string    `json:"description"`
+	Coefficient *int       `json:"coefficient"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidTo     *time.Time `json:"valid_to"`
+}
+
+// OperationInput is what a transaction, or an authorization hold, submits for an operation type.
+type OperationInput struct {
+	AccountId        int                `json:"account_id"`
+	OperationTypeId  int                `json:"operation_type_id"`
+	Amount           utilMoneyV1.Amount `json:"amount"`            // as submitted, sign included
+	InstallmentCount int                `json:"installment_count"` // 0 when not given
+	Hold             bool               `json:"hold"`              // an authorization hold, settled later by a capture
+	At               time.Time          `json:"at"`                // event time, the operation type must be in effect then
+}
+
+// FeeInput is a transaction the fees of its operation type are charged on.
+type FeeInput struct {
+	OperationTypeId int
+	AccountTier     string
+	Currency        string             // the account currency
+	Amount          utilMoneyV1.Amount // final signed amount, in Currency
+}
+
+// Fee is one fee charged on a transaction, positive, in the account currency.
+type Fee struct {
+	Code            string
+	Amount          utilMoneyV1.Amount
+	OperationTypeId int // the active FEE operation type the fee is posted with
+}
+
+// OperationResult is the outcome an operation handler computes for an input.
+type OperationResult struct {
+	Amount           utilMoneyV1.Amount `json:"amount"`            // final signed amount
+	Coefficient      int                `json:"coefficient"`       // coefficient of the operation type applied to the input
+	InstallmentCount int                `json:"installment_count"` // number of installments Amount is split in, 0 for none
+}
+
+// OperationCacheStats is a snapshot of the counters of the operation type cache of the mediator operation client.
+type OperationCacheStats struct {
+	Hits    int64 // lookups served from the cache, not found ids included
+	Misses  int64 // lookups that had to load the operation type from the operation service
+	Entries int   // operation types and unknown ids currently held, expired ones included until pruned
+}
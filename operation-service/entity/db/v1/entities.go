@@ -2,16 +2,40 @@ package account_entity_db_v1
 
 import (
 	constantPackage "anti-fraud/constants/operation"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type Operation struct {
 	gorm.Model
-	Description string `json:"description"`
-	Coefficient int    `json:"coefficient"`
+	Description string     `json:"description"`
+	Coefficient int        `json:"coefficient"`
+	Kind        string     `json:"kind"`       // selects the handler computing its transactions
+	Status      string     `json:"status"`     // ACTIVE or DEPRECATED; a deprecated type accepts no new transactions
+	ValidFrom   time.Time  `json:"valid_from"` // first instant the coefficient applies to transactions
+	ValidTo     *time.Time `json:"valid_to"`   // exclusive end of validity, nil while open ended
 }
 
 func (Operation) TableName() string {
 	return constantPackage.TABLE_NAME
 }
+
+// OperationFee is one fee charged on the transactions of an operation type, in one account currency.
+// The fee is FlatAmount plus Rate times the absolute transaction amount, capped within [MinAmount, MaxAmount].
+type OperationFee struct {
+	gorm.Model
+	OperationTypeId int                 `json:"operation_type_id"`
+	AccountTier     *string             `json:"account_tier"` // nil applies to every tier without a fee of the same code
+	Code            string              `json:"code"`         // names the fee, e.g. WITHDRAWAL_FEE
+	Currency        string              `json:"currency"`     // account currency the amounts are in
+	FlatAmount      utilMoneyV1.Amount  `json:"flat_amount"`
+	Rate            utilMoneyV1.Rate    `json:"rate"` // fraction of the transaction amount, 0.025 for 2.5%
+	MinAmount       utilMoneyV1.Amount  `json:"min_amount"`
+	MaxAmount       *utilMoneyV1.Amount `json:"max_amount"` // nil when uncapped
+}
+
+func (OperationFee) TableName() string {
+	return constantPackage.FEE_TABLE_NAME
+}
@@ -0,0 +1,128 @@
+package operation_entity_http_v1
+
+import (
+	constantPackage "anti-fraud/constants/operation"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type CreateOperationRequest struct {
+	Description string     `json:"description"`
+	Coefficient *int       `json:"coefficient"`
+	Kind        string     `json:"kind"`       // handler of the operation type, defaults to STANDARD
+	ValidFrom   *time.Time `json:"valid_from"` // RFC 3339, defaults to now
+	ValidTo     *time.Time `json:"valid_to"`   // RFC 3339, exclusive; open ended when omitted
+}
+
+func (createRequest *CreateOperationRequest) Validate() error {
+	if err := validateDescription(createRequest.Description); err != nil {
+		return err
+	}
+	if createRequest.Coefficient == nil {
+		return errors.New("coefficient is mandatory")
+	}
+	if err := validateCoefficient(*createRequest.Coefficient); err != nil {
+		return err
+	}
+	if len(createRequest.Kind) > constantPackage.KIND_MAX_LENGTH {
+		return fmt.Errorf("kind should be at most %d characters", constantPackage.KIND_MAX_LENGTH)
+	}
+	return validateValidity(createRequest.ValidFrom, createRequest.ValidTo)
+}
+
+// UpdateOperationRequest holds the fields to change; at least one is required.
+type UpdateOperationRequest struct {
+	Description *string    `json:"description"`
+	Coefficient *int       `json:"coefficient"`
+	ValidFrom   *time.Time `json:"valid_from"`
+	ValidTo     *time.Time `json:"valid_to"`
+}
+
+func (updateRequest *UpdateOperationRequest) Validate() error {
+	if updateRequest.Description == nil && updateRequest.Coefficient == nil && updateRequest.ValidFrom == nil && updateRequest.ValidTo == nil {
+		return errors.New("description, coefficient, valid_from or valid_to is mandatory")
+	}
+	if updateRequest.Description != nil {
+		if err := validateDescription(*updateRequest.Description); err != nil {
+			return err
+		}
+	}
+	if updateRequest.Coefficient != nil {
+		if err := validateCoefficient(*updateRequest.Coefficient); err != nil {
+			return err
+		}
+	}
+	return validateValidity(updateRequest.ValidFrom, updateRequest.ValidTo)
+}
+
+// ComputeOperationRequest is the body of the internal endpoint remote mediator clients compute
+// a transaction or authorization hold with; the operation type comes from the URL path.
+type ComputeOperationRequest struct {
+	AccountId        int                `json:"account_id"`
+	Amount           utilMoneyV1.Amount `json:"amount"`            // as submitted, sign included
+	InstallmentCount int                `json:"installment_count"` // 0 when not given
+	Hold             bool               `json:"hold"`
+	At               time.Time          `json:"at"` // event time
+}
+
+// ComputeFeesRequest is the body of the internal endpoint remote mediator clients compute fees with.
+type ComputeFeesRequest struct {
+	OperationTypeId int                `json:"operation_type_id"`
+	AccountTier     string             `json:"account_tier"`
+	Currency        string             `json:"currency"`
+	Amount          utilMoneyV1.Amount `json:"amount"` // final signed amount, in Currency
+}
+
+// ParseCoefficientRequest reads the event time a coefficient is asked for, defaulting to now.
+func ParseCoefficientRequest(query url.Values, now time.Time) (time.Time, error) {
+	raw := query.Get("at")
+	if raw == "" {
+		return now, nil
+	}
+	at, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, errors.New("at should be an RFC 3339 timestamp")
+	}
+	return at, nil
+}
+
+// ParseListOperationsRequest reads the optional status filter of the operation type listing.
+func ParseListOperationsRequest(query url.Values) (string, error) {
+	status := strings.ToUpper(query.Get("status"))
+	if status != "" && status != constantPackage.STATUS_ACTIVE && status != constantPackage.STATUS_DEPRECATED {
+		return "", fmt.Errorf("status should be %s or %s", constantPackage.STATUS_ACTIVE, constantPackage.STATUS_DEPRECATED)
+	}
+	return status, nil
+}
+
+func validateDescription(description string) error {
+	if strings.TrimSpace(description) == "" {
+		return errors.New("description should not be empty")
+	}
+	if len(description) > constantPackage.DESCRIPTION_MAX_LENGTH {
+		return fmt.Errorf("description should be at most %d characters", constantPackage.DESCRIPTION_MAX_LENGTH)
+	}
+	return nil
+}
+
+// validateCoefficient only accepts a sign: transaction amounts are scaled by it, so any other
+// value would change how much is charged.
+func validateCoefficient(coefficient int) error {
+	if coefficient != constantPackage.COEFFICIENT_DEBIT && coefficient != constantPackage.COEFFICIENT_CREDIT {
+		return fmt.Errorf("coefficient should be %d (debit) or %d (credit)", constantPackage.COEFFICIENT_DEBIT, constantPackage.COEFFICIENT_CREDIT)
+	}
+	return nil
+}
+
+// validateValidity checks the validity window when both of its ends are given; the core
+// checks it again against the stored end that is not.
+func validateValidity(validFrom *time.Time, validTo *time.Time) error {
+	if validFrom != nil && validTo != nil && !validTo.After(*validFrom) {
+		return errors.New("valid_to should be after valid_from")
+	}
+	return nil
+}
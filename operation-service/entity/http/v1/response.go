@@ -0,0 +1,48 @@
+package operation_entity_http_v1
+
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// OperationResponse is the read model of an operation type.
+type OperationResponse struct {
+	OperationTypeID int        `json:"operation_type_id"`
+	Description     string     `json:"description"`
+	Coefficient     int        `json:"coefficient"`
+	Kind            string     `json:"kind"`
+	Status          string     `json:"status"`
+	ValidFrom       time.Time  `json:"valid_from"`
+	ValidTo         *time.Time `json:"valid_to"` // null while open ended
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// CoefficientResponse is the answer of the internal coefficient endpoint.
+type CoefficientResponse struct {
+	Success     bool `json:"success"`
+	Coefficient int  `json:"coefficient"`
+}
+
+// CacheStatsResponse is the answer of the internal cache-stats endpoint: the counters of the operation type
+// cache of the mediator operation client of this instance.
+type CacheStatsResponse struct {
+	Success bool  `json:"success"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Entries int   `json:"entries"`
+}
+
+// OperationResultResponse is the outcome of the handler of an operation type, answered to remote mediator clients.
+type OperationResultResponse struct {
+	Amount           utilMoneyV1.Amount `json:"amount"`
+	Coefficient      int                `json:"coefficient"`
+	InstallmentCount int                `json:"installment_count"`
+}
+
+// FeeResponse is one fee charged on a transaction, answered to remote mediator clients.
+type FeeResponse struct {
+	Code            string             `json:"code"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	OperationTypeId int                `json:"operation_type_id"` // the operation type the fee is posted with
+}
@@ -0,0 +1,44 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// FeeHandler guards the operation type fees are posted with. Fee transactions are only built by the
+// transaction core while charging the fees of another transaction, which computes no operation, so
+// every input reaching the handler comes from a client and is rejected.
+type FeeHandler struct{}
+
+// NewFeeHandler creates and return new FeeHandler instance.
+func NewFeeHandler() *FeeHandler {
+	return &FeeHandler{}
+}
+
+// Kind returns the handler kind.
+func (handler *FeeHandler) Kind() string {
+	return constantPackage.KIND_FEE
+}
+
+// Validate rejects any input: a fee can neither be posted nor authorized directly.
+func (handler *FeeHandler) Validate(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Errorf("Error: operation_type_id %d is only charged as a fee", operation.ID)
+	return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("operation_type_id: %d is only charged as a fee", operation.ID))
+}
+
+// Compute signs the absolute input as a debit, like the fees charged by the transaction core.
+func (handler *FeeHandler) Compute(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	return &entityCoreV1Package.OperationResult{
+		Amount:      input.Amount.Abs().Neg(),
+		Coefficient: constantPackage.COEFFICIENT_DEBIT,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFeeHandler_RejectsClientInput(t *testing.T) {
+	handler := NewFeeHandler()
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 9}, Coefficient: -1}
+
+	err := handler.Validate(context.Background(), operation, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("2.5")})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+
+	err = handler.Validate(context.Background(), operation, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("2.5"), Hold: true})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+}
+
+func TestFeeHandler_ComputeDebit(t *testing.T) {
+	handler := NewFeeHandler()
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 9}, Coefficient: -1}
+
+	result, err := handler.Compute(context.Background(), operation, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("2.5")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "-2.5", result.Amount.String())
+	assert.Equal(t, -1, result.Coefficient)
+}
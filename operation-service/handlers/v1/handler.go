@@ -0,0 +1,23 @@
+package operation_handlers_v1
+
+import (
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// IOperationHandler defines the methods interface every operation handler plugged into the operation core must implement.
+// An operation type is computed by the handler registered for its kind.
+type IOperationHandler interface {
+
+	// Kind returns the unique operation kind the handler computes.
+	Kind() string
+
+	// Validate checks the input is acceptable for the operation type, and returns an app error otherwise.
+	Validate(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput) error
+
+	// Compute returns the final amount of a validated input along with its side effects.
+	Compute(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error)
+}
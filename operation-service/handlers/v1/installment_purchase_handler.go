@@ -0,0 +1,58 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	transactionConstantPackage "anti-fraud/constants/transaction"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// InstallmentPurchaseHandler computes purchases paid in installments. The purchase carries its full amount,
+// and the transaction service splits it in the installment count the handler returns.
+type InstallmentPurchaseHandler struct{}
+
+// NewInstallmentPurchaseHandler creates and return new InstallmentPurchaseHandler instance.
+func NewInstallmentPurchaseHandler() *InstallmentPurchaseHandler {
+	return &InstallmentPurchaseHandler{}
+}
+
+// Kind returns the handler kind.
+func (handler *InstallmentPurchaseHandler) Kind() string {
+	return constantPackage.KIND_INSTALLMENT_PURCHASE
+}
+
+// Validate requires an installment count within the allowed range. Installment purchases cannot be
+// authorized, as their schedule is set when they are posted.
+func (handler *InstallmentPurchaseHandler) Validate(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput) error {
+	logger := utilContextV1.Logger(ctx)
+	if input.Hold {
+		logger.Errorf("Error: operation_type_id %d cannot be authorized", operation.ID)
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_NOT_AUTHORIZABLE, fmt.Sprintf("operation_type_id: %d cannot be authorized", operation.ID))
+	}
+	if input.InstallmentCount == 0 {
+		logger.Errorf("Error: operation_type_id %d needs an installment count", operation.ID)
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("installment_count is mandatory for operation_type_id: %d", operation.ID))
+	}
+	if input.InstallmentCount < transactionConstantPackage.MIN_INSTALLMENT_COUNT || input.InstallmentCount > transactionConstantPackage.MAX_INSTALLMENT_COUNT {
+		logger.Errorf("Error: installment count %d is out of range", input.InstallmentCount)
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("installment_count should be between %d and %d", transactionConstantPackage.MIN_INSTALLMENT_COUNT, transactionConstantPackage.MAX_INSTALLMENT_COUNT))
+	}
+	return nil
+}
+
+// Compute signs the absolute input as a debit, whatever the coefficient of the operation type, and
+// returns the installment count to split it in.
+func (handler *InstallmentPurchaseHandler) Compute(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	return &entityCoreV1Package.OperationResult{
+		Amount:           input.Amount.Abs().MulInt(constantPackage.COEFFICIENT_DEBIT),
+		Coefficient:      constantPackage.COEFFICIENT_DEBIT,
+		InstallmentCount: input.InstallmentCount,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestInstallmentPurchaseHandler_Compute(t *testing.T) {
+	handler := NewInstallmentPurchaseHandler()
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}
+	input := &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("120"), InstallmentCount: 3}
+
+	assert.NoError(t, handler.Validate(context.Background(), operation, input))
+	result, err := handler.Compute(context.Background(), operation, input, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "-120", result.Amount.String())
+	assert.Equal(t, -1, result.Coefficient)
+	assert.Equal(t, 3, result.InstallmentCount)
+}
+
+func TestInstallmentPurchaseHandler_MissingInstallmentCount(t *testing.T) {
+	handler := NewInstallmentPurchaseHandler()
+
+	err := handler.Validate(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("120")})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+}
+
+func TestInstallmentPurchaseHandler_InstallmentCountOutOfRange(t *testing.T) {
+	handler := NewInstallmentPurchaseHandler()
+
+	for _, count := range []int{1, 49} {
+		err := handler.Validate(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("120"), InstallmentCount: count})
+		assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+	}
+}
+
+func TestInstallmentPurchaseHandler_Hold(t *testing.T) {
+	handler := NewInstallmentPurchaseHandler()
+
+	err := handler.Validate(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 2}, Coefficient: -1}, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("120"), InstallmentCount: 3, Hold: true})
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_NOT_AUTHORIZABLE)
+}
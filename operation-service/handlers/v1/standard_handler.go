@@ -0,0 +1,51 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/operation"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// StandardHandler computes operation types whose amount is the input signed by their coefficient,
+// such as purchases, withdrawals and credit vouchers.
+type StandardHandler struct{}
+
+// NewStandardHandler creates and return new StandardHandler instance.
+func NewStandardHandler() *StandardHandler {
+	return &StandardHandler{}
+}
+
+// Kind returns the handler kind.
+func (handler *StandardHandler) Kind() string {
+	return constantPackage.KIND_STANDARD
+}
+
+// Validate rejects installments, which standard operation types are not paid in, and holds on credits,
+// as only debits draw the credit limit down.
+func (handler *StandardHandler) Validate(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput) error {
+	logger := utilContextV1.Logger(ctx)
+	if input.InstallmentCount != 0 {
+		logger.Errorf("Error: operation_type_id %d is not paid in installments", operation.ID)
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, fmt.Sprintf("installment_count is not accepted for operation_type_id: %d", operation.ID))
+	}
+	if input.Hold && operation.Coefficient >= 0 {
+		logger.Errorf("Error: operation_type_id %d cannot be authorized", operation.ID)
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_NOT_AUTHORIZABLE, fmt.Sprintf("operation_type_id: %d cannot be authorized", operation.ID))
+	}
+	return nil
+}
+
+// Compute multiplies the absolute input by the coefficient of the operation type, exactly.
+func (handler *StandardHandler) Compute(ctx context.Context, operation *entityDbV1Package.Operation, input *entityCoreV1Package.OperationInput, tx *gorm.DB) (*entityCoreV1Package.OperationResult, error) {
+	return &entityCoreV1Package.OperationResult{
+		Amount:      input.Amount.Abs().MulInt(operation.Coefficient),
+		Coefficient: operation.Coefficient,
+	}, nil
+}
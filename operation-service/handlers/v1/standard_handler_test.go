@@ -0,0 +1,60 @@
+package operation_handlers_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func assertAppError(t *testing.T, err error, status int, code string) {
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, status, appErr.Status)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestStandardHandler_ComputeDebit(t *testing.T) {
+	handler := NewStandardHandler()
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}
+	input := &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("50.25")}
+
+	assert.NoError(t, handler.Validate(context.Background(), operation, input))
+	result, err := handler.Compute(context.Background(), operation, input, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "-50.25", result.Amount.String())
+	assert.Equal(t, -1, result.Coefficient)
+	assert.Equal(t, 0, result.InstallmentCount)
+}
+
+func TestStandardHandler_ComputeCredit(t *testing.T) {
+	handler := NewStandardHandler()
+	operation := &entityDbV1Package.Operation{Model: gorm.Model{ID: 4}, Coefficient: 1}
+
+	result, err := handler.Compute(context.Background(), operation, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("-30")}, &gorm.DB{})
+	assert.NoError(t, err)
+	assert.Equal(t, "30", result.Amount.String())
+	assert.Equal(t, 1, result.Coefficient)
+}
+
+func TestStandardHandler_RejectsInstallmentCount(t *testing.T) {
+	handler := NewStandardHandler()
+
+	err := handler.Validate(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 1}, Coefficient: -1}, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("10"), InstallmentCount: 3})
+	assertAppError(t, err, http.StatusBadRequest, errorConstantPackage.VALIDATION_FAILED)
+}
+
+func TestStandardHandler_HoldOnCredit(t *testing.T) {
+	handler := NewStandardHandler()
+
+	err := handler.Validate(context.Background(), &entityDbV1Package.Operation{Model: gorm.Model{ID: 4}, Coefficient: 1}, &entityCoreV1Package.OperationInput{Amount: utilMoneyV1.MustParse("10"), Hold: true})
+	assertAppError(t, err, http.StatusUnprocessableEntity, errorConstantPackage.OPERATION_NOT_AUTHORIZABLE)
+}
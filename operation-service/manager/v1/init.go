@@ -2,31 +2,51 @@ package operation_manager_v1
 
 import (
 	clientV1Package "anti-fraud/mediator-service/operation-service-client"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	controllerV1Package "anti-fraud/operation-service/controllers/v1"
 	coreV1Package "anti-fraud/operation-service/core/v1"
+	handlersV1Package "anti-fraud/operation-service/handlers/v1"
 	repoV1Package "anti-fraud/operation-service/repository/v1"
+	routerV1Package "anti-fraud/operation-service/routes/v1"
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-// OperationManager wires all components required to run account-service.
+// OperationManager wires all components required to run operation-service.
 type OperationManager struct {
-	logger *logrus.Logger
-	coreV1 coreV1Package.IOperationCore
+	db                *gorm.DB
+	router            *mux.Router
+	logger            *logrus.Logger
+	transactionClient transactionClientV1Package.ITransactionClient
+	coreV1            coreV1Package.IOperationCore
+	controllerV1      controllerV1Package.IOperationController
 }
 
 // NewOperationManager create and return new instance of OperationManager.
-func NewOperationManager(logger *logrus.Logger) *OperationManager {
+func NewOperationManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, transactionClient transactionClientV1Package.ITransactionClient) *OperationManager {
 
-	return &OperationManager{logger: logger}
+	return &OperationManager{db: db, router: router, logger: logger, transactionClient: transactionClient}
 }
 
-// Init instantiate and wire all components, register routes for operation-service.
+// Init instantiate and wire all components, register operation handlers and routes for operation-service.
 func (mw *OperationManager) Init() {
+	managerHandler := middlewareHandlerPackageV1.NewMiddlewareHandler(mw.logger)
 	repoV1 := repoV1Package.NewOperationRepository(mw.logger)
-	mw.coreV1 = coreV1Package.NewOperationCore(repoV1, mw.logger)
+	mw.coreV1 = coreV1Package.NewOperationCore(repoV1, mw.logger, mw.transactionClient)
+	mw.coreV1.RegisterHandler(handlersV1Package.NewStandardHandler())
+	mw.coreV1.RegisterHandler(handlersV1Package.NewInstallmentPurchaseHandler())
+	mw.coreV1.RegisterHandler(handlersV1Package.NewFeeHandler())
+	mw.controllerV1 = controllerV1Package.NewOperationController(mw.coreV1, mw.db, mw.logger)
+	router := routerV1Package.NewOperationRoutes(mw.controllerV1, mw.router, managerHandler)
+	router.Init()
 }
 
-// ConfigureClient configure core instance of operation service in operation-client.
+// ConfigureClient configure core instance of operation service in operation-client, and the client
+// whose cache the cache-stats endpoint reports on.
 func (mw *OperationManager) ConfigureClient(client clientV1Package.IOperationClient) {
 	client.SetupCore(mw.coreV1)
+	mw.controllerV1.SetupCacheStats(client)
 }
@@ -0,0 +1,50 @@
+package operation_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityHttpV1Package "anti-fraud/operation-service/entity/http/v1"
+	"strings"
+)
+
+func CreateOperationPayloadMapper(createRequest *entityHttpV1Package.CreateOperationRequest) *entityCoreV1Package.CreateOperationPayload {
+	return &entityCoreV1Package.CreateOperationPayload{
+		Description: strings.TrimSpace(createRequest.Description),
+		Coefficient: *createRequest.Coefficient,
+		Kind:        strings.ToUpper(strings.TrimSpace(createRequest.Kind)),
+		ValidFrom:   createRequest.ValidFrom,
+		ValidTo:     createRequest.ValidTo,
+	}
+}
+
+func UpdateOperationPayloadMapper(updateRequest *entityHttpV1Package.UpdateOperationRequest) *entityCoreV1Package.UpdateOperationPayload {
+	updatePayload := &entityCoreV1Package.UpdateOperationPayload{
+		Coefficient: updateRequest.Coefficient,
+		ValidFrom:   updateRequest.ValidFrom,
+		ValidTo:     updateRequest.ValidTo,
+	}
+	if updateRequest.Description != nil {
+		description := strings.TrimSpace(*updateRequest.Description)
+		updatePayload.Description = &description
+	}
+	return updatePayload
+}
+
+func OperationInputMapper(operationId int, computeRequest *entityHttpV1Package.ComputeOperationRequest) *entityCoreV1Package.OperationInput {
+	return &entityCoreV1Package.OperationInput{
+		AccountId:        computeRequest.AccountId,
+		OperationTypeId:  operationId,
+		Amount:           computeRequest.Amount,
+		InstallmentCount: computeRequest.InstallmentCount,
+		Hold:             computeRequest.Hold,
+		At:               computeRequest.At,
+	}
+}
+
+func FeeInputMapper(feesRequest *entityHttpV1Package.ComputeFeesRequest) *entityCoreV1Package.FeeInput {
+	return &entityCoreV1Package.FeeInput{
+		OperationTypeId: feesRequest.OperationTypeId,
+		AccountTier:     feesRequest.AccountTier,
+		Currency:        feesRequest.Currency,
+		Amount:          feesRequest.Amount,
+	}
+}
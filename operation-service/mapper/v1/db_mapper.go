@@ -0,0 +1,28 @@
+package operation_mapper_v1
+
+import (
+	constantPackage "anti-fraud/constants/operation"
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	"time"
+)
+
+// OperationMapper builds an ACTIVE operation type, STANDARD and valid from now unless the payload says otherwise.
+func OperationMapper(createPayload *entityCoreV1Package.CreateOperationPayload, now time.Time) *entityDbV1Package.Operation {
+	kind := createPayload.Kind
+	if kind == "" {
+		kind = constantPackage.KIND_STANDARD
+	}
+	validFrom := now
+	if createPayload.ValidFrom != nil {
+		validFrom = *createPayload.ValidFrom
+	}
+	return &entityDbV1Package.Operation{
+		Description: createPayload.Description,
+		Coefficient: createPayload.Coefficient,
+		Kind:        kind,
+		Status:      constantPackage.STATUS_ACTIVE,
+		ValidFrom:   validFrom,
+		ValidTo:     createPayload.ValidTo,
+	}
+}
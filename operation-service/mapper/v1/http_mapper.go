@@ -0,0 +1,54 @@
+package operation_mapper_v1
+
+import (
+	entityCoreV1Package "anti-fraud/operation-service/entity/core/v1"
+	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	entityHttpV1Package "anti-fraud/operation-service/entity/http/v1"
+)
+
+func OperationResponseMapper(operation *entityDbV1Package.Operation) *entityHttpV1Package.OperationResponse {
+	return &entityHttpV1Package.OperationResponse{
+		OperationTypeID: int(operation.ID),
+		Description:     operation.Description,
+		Coefficient:     operation.Coefficient,
+		Kind:            operation.Kind,
+		Status:          operation.Status,
+		ValidFrom:       operation.ValidFrom,
+		ValidTo:         operation.ValidTo,
+		CreatedAt:       operation.CreatedAt,
+		UpdatedAt:       operation.UpdatedAt,
+	}
+}
+
+func OperationListResponseMapper(operations []*entityDbV1Package.Operation) []*entityHttpV1Package.OperationResponse {
+	response := make([]*entityHttpV1Package.OperationResponse, 0, len(operations))
+	for _, operation := range operations {
+		response = append(response, OperationResponseMapper(operation))
+	}
+	return response
+}
+
+func OperationResultResponseMapper(result *entityCoreV1Package.OperationResult) *entityHttpV1Package.OperationResultResponse {
+	return &entityHttpV1Package.OperationResultResponse{
+		Amount:           result.Amount,
+		Coefficient:      result.Coefficient,
+		InstallmentCount: result.InstallmentCount,
+	}
+}
+
+func FeeListResponseMapper(fees []*entityCoreV1Package.Fee) []*entityHttpV1Package.FeeResponse {
+	response := make([]*entityHttpV1Package.FeeResponse, 0, len(fees))
+	for _, fee := range fees {
+		response = append(response, &entityHttpV1Package.FeeResponse{Code: fee.Code, Amount: fee.Amount, OperationTypeId: fee.OperationTypeId})
+	}
+	return response
+}
+
+func CacheStatsResponseMapper(stats entityCoreV1Package.OperationCacheStats) *entityHttpV1Package.CacheStatsResponse {
+	return &entityHttpV1Package.CacheStatsResponse{
+		Success: true,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		Entries: stats.Entries,
+	}
+}
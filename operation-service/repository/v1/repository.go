@@ -1,19 +1,49 @@
 package operation_repo_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
 	constantPackage "anti-fraud/constants/operation"
 	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IOperationRepository defines methods interface for performing db operations
 type IOperationRepository interface {
 
 	// GetOperation retrieves the operation record by its unique ID.
-	GetOperation(logger *logrus.Entry, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+	GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// GetOperationForUpdate retrieves and locks the operation record by its unique ID.
+	GetOperationForUpdate(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// GetActiveOperationByKind retrieves the active operation record of a kind only one active type can have.
+	GetActiveOperationByKind(ctx context.Context, kind string, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// CheckDuplicateOperation checks if an active operation with the given description already exists.
+	CheckDuplicateOperation(ctx context.Context, description string, tx *gorm.DB) (*entityDbV1Package.Operation, error)
+
+	// ListOperations retrieves the operation records, optionally of one status, by ID.
+	ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error)
+
+	// CreateOperation persists a new operation record to the db.
+	CreateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error
+
+	// UpdateOperation persists the description, coefficient and status of an operation record.
+	UpdateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error
+
+	// GetOperationFees retrieves the fees of an operation type in one account currency, for every tier.
+	GetOperationFees(ctx context.Context, operationTypeId int, currency string, tx *gorm.DB) ([]*entityDbV1Package.OperationFee, error)
+
+	// CountOperationFees counts the fees configured on every operation type.
+	CountOperationFees(ctx context.Context, tx *gorm.DB) (int64, error)
 }
 
 // OperationRepository implements IOperationRepository interface.
@@ -40,15 +70,194 @@ func NewOperationRepository(logger *logrus.Logger) *OperationRepository {
 // Returns:
 //   - A pointer to the retrieved Operation entity.
 //   - An encountered Error.
-func (repo *OperationRepository) GetOperation(logger *logrus.Entry, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+func (repo *OperationRepository) GetOperation(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("GetOperation method called in operation repo layer.")
 	var operation entityDbV1Package.Operation
-	result := tx.Table(constantPackage.TABLE_NAME).First(&operation, operationId)
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).First(&operation, operationId)
 	if result.Error != nil && result.Error == gorm.ErrRecordNotFound {
 		logger.Errorf("Error: operationId not found in database.")
-		return &operation, fmt.Errorf("operation id: %d not found in database", operationId)
+		return &operation, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, fmt.Sprintf("operation id: %d not found in database", operationId))
+	} else if result.Error != nil {
+		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
+	}
+	return &operation, result.Error
+}
+
+// GetOperationForUpdate finds an operation record by its ID and locks it (SELECT ... FOR UPDATE),
+// so an update cannot race with another update or with the in-use check guarding it.
+//
+// Parameters:
+//   - operationId: The unique identifier of the operation to retrieve.
+//   - tx:          db txn.
+//
+// Returns:
+//   - A pointer to the retrieved Operation entity.
+//   - An encountered Error, not found included.
+func (repo *OperationRepository) GetOperationForUpdate(ctx context.Context, operationId int, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOperationForUpdate method called in operation repo layer.")
+	return repo.GetOperation(ctx, operationId, tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
+// GetActiveOperationByKind finds the active operation record of a kind, such as FEE, that a unique index
+// keeps to a single active operation type.
+//
+// Parameters:
+//   - kind: kind of the operation type.
+//   - tx:   db txn.
+//
+// Returns:
+//   - A pointer to the retrieved Operation entity.
+//   - An encountered Error, not found included.
+func (repo *OperationRepository) GetActiveOperationByKind(ctx context.Context, kind string, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetActiveOperationByKind method called in operation repo layer.")
+	var operation entityDbV1Package.Operation
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("kind = ? AND status = ?", kind, constantPackage.STATUS_ACTIVE).First(&operation)
+	if result.Error != nil && result.Error == gorm.ErrRecordNotFound {
+		logger.Errorf("Error: no active operation type of kind %s in database.", kind)
+		return &operation, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, fmt.Sprintf("no active operation type of kind: %s", kind))
+	} else if result.Error != nil {
+		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
+	}
+	return &operation, result.Error
+}
+
+// CheckDuplicateOperation determines if an active operation with the specified description already exists,
+// and locks it. Descriptions are unique among active operation types only, so there is at most one.
+//
+// Parameters:
+//   - description: filter used to find the operation.
+//   - tx:          db txn.
+//
+// Returns:
+//   - db entity operation, empty when none has the description.
+//   - Encountered Error.
+func (repo *OperationRepository) CheckDuplicateOperation(ctx context.Context, description string, tx *gorm.DB) (*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CheckDuplicateOperation method called in operation repo layer.")
+	var operation entityDbV1Package.Operation
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("description = ? AND status = ?", description, constantPackage.STATUS_ACTIVE).First(&operation)
+	if result.Error != nil && result.Error == gorm.ErrRecordNotFound {
+		return &operation, nil
 	} else if result.Error != nil {
 		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
 	}
 	return &operation, result.Error
 }
+
+// ListOperations fetches the operation records in ID order.
+//
+// Parameters:
+//   - status: ACTIVE or DEPRECATED to filter by status, empty for all.
+//   - tx:     db txn.
+//
+// Returns:
+//   - The operations.
+//   - An encountered Error.
+func (repo *OperationRepository) ListOperations(ctx context.Context, status string, tx *gorm.DB) ([]*entityDbV1Package.Operation, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ListOperations method called in operation repo layer.")
+	operations := []*entityDbV1Package.Operation{}
+	query := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Where("deleted_at IS NULL")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	result := query.Order("id ASC").Find(&operations)
+	if result.Error != nil {
+		logger.Errorf("Error occured while listing operations: %s", result.Error.Error())
+	}
+	return operations, result.Error
+}
+
+// CreateOperation inserts a new operation record into the db.
+// A unique violation means a concurrent request created an active operation type with the same description
+// after the duplicate check: it is returned as a conflict Error.
+//
+// Parameters:
+//   - operation: operation db entity.
+//   - tx:        db txn.
+//
+// Returns:
+//   - An error if the insert fails, otherwise nil.
+func (repo *OperationRepository) CreateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CreateOperation method called in operation repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Create(operation)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		logger.Errorf("Failed to create operation, duplicate description: %s", operation.Description)
+		return utilErrorsV1.NewConflictError(errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION, fmt.Sprintf("duplicate operation type found with description: %s", operation.Description))
+	} else if result.Error != nil {
+		logger.Errorf("Failed to create operation: %v", result.Error)
+	}
+	return result.Error
+}
+
+// UpdateOperation updates the description, coefficient, status and validity window of an operation.
+//
+// Parameters:
+//   - operation: operation db entity carrying the new values.
+//   - tx:        db txn.
+//
+// Returns:
+//   - An error if the update fails, otherwise nil.
+func (repo *OperationRepository) UpdateOperation(ctx context.Context, operation *entityDbV1Package.Operation, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateOperation method called in operation repo layer.")
+	result := tx.WithContext(ctx).Model(operation).
+		Select("description", "coefficient", "status", "valid_from", "valid_to").
+		Updates(operation)
+	if result.Error != nil {
+		logger.Errorf("Failed to update operation: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetOperationFees lists the fees of an operation type in one account currency.
+//
+// Parameters:
+//   - operationTypeId: operation type the fees are charged on.
+//   - currency:        account currency of the fees.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The fees of every tier, by code then ID (empty when none is configured).
+//   - An encountered Error.
+func (repo *OperationRepository) GetOperationFees(ctx context.Context, operationTypeId int, currency string, tx *gorm.DB) ([]*entityDbV1Package.OperationFee, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOperationFees method called in operation repo layer.")
+	fees := []*entityDbV1Package.OperationFee{}
+	result := tx.WithContext(ctx).Table(constantPackage.FEE_TABLE_NAME).
+		Where("operation_type_id = ? AND currency = ? AND deleted_at IS NULL", operationTypeId, currency).
+		Order("code ASC, id ASC").
+		Find(&fees)
+	if result.Error != nil {
+		logger.Errorf("Error occured while running GET query on db: %s", result.Error.Error())
+	}
+	return fees, result.Error
+}
+
+// CountOperationFees counts the fees configured, whatever their operation type, tier or currency.
+//
+// Parameters:
+//   - tx: db txn.
+//
+// Returns:
+//   - The number of fees.
+//   - An encountered Error.
+func (repo *OperationRepository) CountOperationFees(ctx context.Context, tx *gorm.DB) (int64, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CountOperationFees method called in operation repo layer.")
+	var count int64
+	result := tx.WithContext(ctx).Table(constantPackage.FEE_TABLE_NAME).
+		Where("deleted_at IS NULL").
+		Count(&count)
+	if result.Error != nil {
+		logger.Errorf("Error occured while counting operation fees: %s", result.Error.Error())
+	}
+	return count, result.Error
+}
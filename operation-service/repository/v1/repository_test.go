@@ -1,8 +1,13 @@
 package operation_repo_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
 	constantPackage "anti-fraud/constants/operation"
 	entityDbV1Package "anti-fraud/operation-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -17,7 +22,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("failed to open in-memory DB: %v", err)
 	}
 
-	if err := db.AutoMigrate(&entityDbV1Package.Operation{}); err != nil {
+	if err := db.AutoMigrate(&entityDbV1Package.Operation{}, &entityDbV1Package.OperationFee{}); err != nil {
 		t.Fatalf("failed to migrate Operation schema: %v", err)
 	}
 
@@ -35,7 +40,7 @@ func TestGetOperation_Success(t *testing.T) {
 	result := db.Table(constantPackage.TABLE_NAME).Create(&op)
 	assert.NoError(t, result.Error, "failed to insert operation")
 
-	found, err := repo.GetOperation(logrus.NewEntry(logrus.New()), int(op.ID), db)
+	found, err := repo.GetOperation(context.Background(), int(op.ID), db)
 	assert.NoError(t, err)
 	assert.NotNil(t, found)
 	assert.Equal(t, op.ID, found.ID)
@@ -47,7 +52,7 @@ func TestGetOperation_NotFound(t *testing.T) {
 	repo := NewOperationRepository(logger)
 	db := setupTestDB(t)
 
-	found, err := repo.GetOperation(logrus.NewEntry(logrus.New()), 999, db)
+	found, err := repo.GetOperation(context.Background(), 999, db)
 
 	assert.Error(t, err)
 	assert.Equal(t, 0, int(found.ID))
@@ -64,8 +69,147 @@ func TestGetOperation_DBError(t *testing.T) {
 		sqlDB.Close()
 	}
 
-	found, err := repo.GetOperation(logrus.NewEntry(logrus.New()), 1, db)
+	found, err := repo.GetOperation(context.Background(), 1, db)
 	assert.Error(t, err)
 	assert.Equal(t, 0, int(found.ID))
 
 }
+
+func TestListOperations_StatusFilter(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	db.Create(&entityDbV1Package.Operation{Description: "Normal Purchase", Coefficient: -1, Status: constantPackage.STATUS_ACTIVE})
+	db.Create(&entityDbV1Package.Operation{Description: "Withdrawal", Coefficient: -1, Status: constantPackage.STATUS_DEPRECATED})
+
+	all, err := repo.ListOperations(context.Background(), "", db)
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, "Normal Purchase", all[0].Description)
+
+	deprecated, err := repo.ListOperations(context.Background(), constantPackage.STATUS_DEPRECATED, db)
+	assert.NoError(t, err)
+	assert.Len(t, deprecated, 1)
+	assert.Equal(t, "Withdrawal", deprecated[0].Description)
+}
+
+func TestCheckDuplicateOperation(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	existing := &entityDbV1Package.Operation{Description: "Credit Voucher", Coefficient: 1, Status: constantPackage.STATUS_ACTIVE}
+	db.Create(existing)
+
+	found, err := repo.CheckDuplicateOperation(context.Background(), "Credit Voucher", db)
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, found.ID)
+
+	found, err = repo.CheckDuplicateOperation(context.Background(), "Cashback", db)
+	assert.NoError(t, err)
+	assert.Zero(t, found.ID)
+
+	db.Create(&entityDbV1Package.Operation{Description: "Cashback", Coefficient: -1, Status: constantPackage.STATUS_DEPRECATED})
+	found, err = repo.CheckDuplicateOperation(context.Background(), "Cashback", db)
+	assert.NoError(t, err)
+	assert.Zero(t, found.ID, "a deprecated type does not hold its description")
+}
+
+func TestGetActiveOperationByKind(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	_, err := repo.GetActiveOperationByKind(ctx, constantPackage.KIND_FEE, db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+
+	db.Create(&entityDbV1Package.Operation{Description: "Old Fee", Coefficient: -1, Kind: constantPackage.KIND_FEE, Status: constantPackage.STATUS_DEPRECATED})
+	fee := &entityDbV1Package.Operation{Description: "Fee", Coefficient: -1, Kind: constantPackage.KIND_FEE, Status: constantPackage.STATUS_ACTIVE}
+	db.Create(fee)
+	db.Create(&entityDbV1Package.Operation{Description: "Withdrawal", Coefficient: -1, Kind: constantPackage.KIND_STANDARD, Status: constantPackage.STATUS_ACTIVE})
+
+	found, err := repo.GetActiveOperationByKind(ctx, constantPackage.KIND_FEE, db)
+	assert.NoError(t, err)
+	assert.Equal(t, fee.ID, found.ID)
+}
+
+func TestCreateAndUpdateOperation(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	operation := &entityDbV1Package.Operation{Description: "Cashback", Coefficient: 1, Status: constantPackage.STATUS_ACTIVE}
+	assert.NoError(t, repo.CreateOperation(ctx, operation, db))
+	assert.NotZero(t, operation.ID)
+
+	operation.Description = "Cashback Reward"
+	operation.Coefficient = -1
+	operation.Status = constantPackage.STATUS_DEPRECATED
+	assert.NoError(t, repo.UpdateOperation(ctx, operation, db))
+
+	found, err := repo.GetOperation(ctx, int(operation.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, "Cashback Reward", found.Description)
+	assert.Equal(t, -1, found.Coefficient)
+	assert.Equal(t, constantPackage.STATUS_DEPRECATED, found.Status)
+}
+
+func TestCreateOperation_DuplicateActiveDescription(t *testing.T) {
+	// The duplicate check of a concurrent request saw no active type: only the unique index catches it.
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to open in-memory DB: %v", err)
+	}
+	assert.NoError(t, db.AutoMigrate(&entityDbV1Package.Operation{}))
+	assert.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_operation_type_active_description ON operation_type (description) WHERE status = 'ACTIVE'").Error)
+	repo := NewOperationRepository(logrus.New())
+	ctx := context.Background()
+
+	deprecated := &entityDbV1Package.Operation{Description: "Cashback", Coefficient: 1, Status: constantPackage.STATUS_DEPRECATED}
+	assert.NoError(t, repo.CreateOperation(ctx, deprecated, db))
+	assert.NoError(t, repo.CreateOperation(ctx, &entityDbV1Package.Operation{Description: "Cashback", Coefficient: 1, Status: constantPackage.STATUS_ACTIVE}, db))
+	err = repo.CreateOperation(ctx, &entityDbV1Package.Operation{Description: "Cashback", Coefficient: -1, Status: constantPackage.STATUS_ACTIVE}, db)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusConflict, appError.Status)
+	assert.Equal(t, errorConstantPackage.DUPLICATE_OPERATION_DESCRIPTION, appError.Code)
+}
+
+func TestGetOperationFees(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	gold := "GOLD"
+	for _, fee := range []*entityDbV1Package.OperationFee{
+		{OperationTypeId: 3, Code: "WITHDRAWAL_FEE", Currency: "USD", FlatAmount: utilMoneyV1.MustParse("2.5")},
+		{OperationTypeId: 3, AccountTier: &gold, Code: "WITHDRAWAL_FEE", Currency: "USD"},
+		{OperationTypeId: 3, Code: "ATM_SURCHARGE", Currency: "USD", FlatAmount: utilMoneyV1.MustParse("1")},
+		{OperationTypeId: 3, Code: "WITHDRAWAL_FEE", Currency: "EUR", FlatAmount: utilMoneyV1.MustParse("2")},
+		{OperationTypeId: 1, Code: "PURCHASE_FEE", Currency: "USD", FlatAmount: utilMoneyV1.MustParse("1")},
+	} {
+		assert.NoError(t, db.Create(fee).Error)
+	}
+
+	fees, err := repo.GetOperationFees(ctx, 3, "USD", db)
+	assert.NoError(t, err)
+	assert.Len(t, fees, 3)
+	assert.Equal(t, "ATM_SURCHARGE", fees[0].Code)
+	assert.Equal(t, "WITHDRAWAL_FEE", fees[1].Code)
+	assert.Nil(t, fees[1].AccountTier)
+	assert.Equal(t, "GOLD", *fees[2].AccountTier)
+}
+
+func TestCountOperationFees(t *testing.T) {
+	repo := NewOperationRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	count, err := repo.CountOperationFees(ctx, db)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	assert.NoError(t, db.Create(&entityDbV1Package.OperationFee{OperationTypeId: 3, Code: "WITHDRAWAL_FEE", Currency: "USD"}).Error)
+	assert.NoError(t, db.Create(&entityDbV1Package.OperationFee{OperationTypeId: 1, Code: "PURCHASE_FEE", Currency: "EUR"}).Error)
+	count, err = repo.CountOperationFees(ctx, db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,37 @@
+package operation_route_v1
+
+import (
+	controllerV1Package "anti-fraud/operation-service/controllers/v1"
+
+	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
+
+	"github.com/gorilla/mux"
+)
+
+type OperationRoutes struct {
+	controller        controllerV1Package.IOperationController
+	muxRouter         *mux.Router
+	middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler
+}
+
+// NewOperationRoutes create and return an instance of OperationRoutes.
+func NewOperationRoutes(controller controllerV1Package.IOperationController, router *mux.Router, middlewareHandler *middlewareHandlerPackageV1.MiddlewareHandler) *OperationRoutes {
+	return &OperationRoutes{controller: controller, muxRouter: router, middlewareHandler: middlewareHandler}
+}
+
+// Init register route for operation-service.
+func (routes *OperationRoutes) Init() {
+	handlerFunc := routes.middlewareHandler.MiddlewareHandlerFunc
+
+	routes.muxRouter.HandleFunc("/operation-types/v1", handlerFunc(routes.controller.CreateOperation)).Methods("POST")
+	routes.muxRouter.HandleFunc("/operation-types/v1", handlerFunc(routes.controller.ListOperations)).Methods("GET")
+	routes.muxRouter.HandleFunc("/operation-types/v1/{operationTypeId}", handlerFunc(routes.controller.GetOperationDetails)).Methods("GET")
+	routes.muxRouter.HandleFunc("/operation-types/v1/{operationTypeId}", handlerFunc(routes.controller.UpdateOperation)).Methods("PATCH")
+	routes.muxRouter.HandleFunc("/operation-types/v1/{operationTypeId}/deprecate", handlerFunc(routes.controller.DeprecateOperation)).Methods("POST")
+
+	// Internal endpoints, called by the remote mediator operation client of the other services, and by operators.
+	routes.muxRouter.HandleFunc("/internal/operation-types/v1/fees", handlerFunc(routes.controller.ComputeFees)).Methods("POST")
+	routes.muxRouter.HandleFunc("/internal/operation-types/v1/cache-stats", handlerFunc(routes.controller.GetCacheStats)).Methods("GET")
+	routes.muxRouter.HandleFunc("/internal/operation-types/v1/{operationTypeId}/coefficient", handlerFunc(routes.controller.GetOperationCoefficient)).Methods("GET")
+	routes.muxRouter.HandleFunc("/internal/operation-types/v1/{operationTypeId}/compute", handlerFunc(routes.controller.ComputeOperation)).Methods("POST")
+}
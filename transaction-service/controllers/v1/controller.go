@@ -1,17 +1,26 @@
 package transaction_controller_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
 	coreV1Package "anti-fraud/transaction-service/core/v1"
 	entityHttpV1Package "anti-fraud/transaction-service/entity/http/v1"
 	mapperV1Package "anti-fraud/transaction-service/mapper/v1"
 	repoV1Package "anti-fraud/transaction-service/repository/v1"
 
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
 	utilV1 "anti-fraud/utils-server/middleware/v1"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,19 +30,32 @@ type ITransactionController interface {
 
 	// CreateTransaction handles an HTTP request to create a new transaction.
 	CreateTransaction(w http.ResponseWriter, r *http.Request)
+
+	// GetTransactionDetails handles an HTTP request to fetch a transaction by its ID.
+	GetTransactionDetails(w http.ResponseWriter, r *http.Request)
+
+	// ListTransactions handles an HTTP request to list transactions with filters and cursor pagination.
+	ListTransactions(w http.ResponseWriter, r *http.Request)
+
+	// ReverseTransaction handles an HTTP request to reverse all or part of a transaction.
+	ReverseTransaction(w http.ResponseWriter, r *http.Request)
+
+	// ListUpcomingInstallments handles an HTTP request to list an account's installments still to be paid.
+	ListUpcomingInstallments(w http.ResponseWriter, r *http.Request)
 }
 
 // TransactionController implements ITransactionController interface.
 type TransactionController struct {
-	repoV1 repoV1Package.ITransactionRepository
-	coreV1 coreV1Package.ITransactionCore
-	db     *gorm.DB
-	logger *logrus.Logger
+	repoV1           repoV1Package.ITransactionRepository
+	coreV1           coreV1Package.ITransactionCore
+	idempotencyStore utilIdempotencyV1.IIdempotencyStore
+	db               *gorm.DB
+	logger           *logrus.Logger
 }
 
 // NewTransactionController creates and returns new TransactionController instance.
-func NewTransactionController(repoV1 repoV1Package.ITransactionRepository, coreV1 coreV1Package.ITransactionCore, db *gorm.DB, logger *logrus.Logger) *TransactionController {
-	return &TransactionController{repoV1: repoV1, coreV1: coreV1, db: db, logger: logger}
+func NewTransactionController(repoV1 repoV1Package.ITransactionRepository, coreV1 coreV1Package.ITransactionCore, idempotencyStore utilIdempotencyV1.IIdempotencyStore, db *gorm.DB, logger *logrus.Logger) *TransactionController {
+	return &TransactionController{repoV1: repoV1, coreV1: coreV1, idempotencyStore: idempotencyStore, db: db, logger: logger}
 }
 
 // CreateTransaction handles the HTTP request for creating a new transaction.
@@ -42,21 +64,26 @@ func NewTransactionController(repoV1 repoV1Package.ITransactionRepository, coreV
 //  1. Parse the JSON request body into a CreateTransactionRequest struct.
 //  2. Validate the request data.
 //  3. Start a new db txn.
+//     If an Idempotency-Key header is sent, claim it; a retry of a completed request replays the stored response.
 //  4. Delegate to the core layer to create the transaction (business logic).
-//  5. Commit db txn.
+//  5. Store the response for the idempotency key and commit db txn.
 //  6. Return http response with the newly created transaction.
 func (controller *TransactionController) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	requestID := utilV1.GetRequestID(ctx)
 	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
 
 	var transactionReq entityHttpV1Package.CreateTransactionRequest
 
 	// 1. Decode HTTP input payload.
-	err := json.NewDecoder(r.Body).Decode(&transactionReq)
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, &transactionReq)
+	}
 	if err != nil {
 		logger.Errorf("Error decoding request body: %v", err)
-		http.Error(w, "Error decoding request body: "+err.Error(), http.StatusBadRequest)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
 		return
 	}
 
@@ -66,31 +93,118 @@ func (controller *TransactionController) CreateTransaction(w http.ResponseWriter
 	err = transactionReq.Validate()
 	if err != nil {
 		logger.Errorf("Error: %v", err)
-		http.Error(w, "Error: "+err.Error(), http.StatusBadRequest)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	idempotencyKey := r.Header.Get(idempotencyConstantPackage.HEADER)
+	if err := utilIdempotencyV1.ValidateKey(idempotencyKey); err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
 	// 3. Begin db txn.
-	tx := controller.db.Begin()
-	defer tx.Rollback()
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	var idempotencyRecord *utilIdempotencyV1.IdempotencyRecord
+	if idempotencyKey != "" {
+		record, replay, err := controller.idempotencyStore.Claim(ctx, idempotencyConstantPackage.SCOPE_CREATE_TRANSACTION, idempotencyKey, utilIdempotencyV1.HashRequest(body), tx)
+		if err != nil {
+			logger.Errorf("Error claiming idempotency key: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+		if replay {
+			logger.Infof("Replaying stored response for idempotency key: %s", idempotencyKey)
+			utilIdempotencyV1.WriteReplay(w, record)
+			return
+		}
+		idempotencyRecord = record
+	}
 
 	// 4. Create a new transaction via the core layer.
-	transaction, err := controller.coreV1.CreateTransaction(logger, mapperV1Package.CreateTransactionPayloadMapper(&transactionReq), tx)
+	transaction, err := controller.coreV1.CreateTransaction(ctx, mapperV1Package.CreateTransactionPayloadMapper(&transactionReq), tx)
 	if err != nil {
 		logger.Errorf("Error creating transaction: %v", err)
-		http.Error(w, "An internal error occurred"+err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
-	// Commit db txn
-	if err := tx.Commit().Error; err != nil {
+	// 5. Build the response, store it for the idempotency key and commit db txn.
+	response, err := json.Marshal(map[string]interface{}{
+		"success":     true,
+		"transaction": mapperV1Package.TransactionDetailsResponseMapper(transaction),
+	})
+	if err != nil {
+		logger.Errorf("Error encoding response: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if idempotencyRecord != nil {
+		if err := controller.idempotencyStore.SaveResponse(ctx, idempotencyRecord, http.StatusOK, response, tx); err != nil {
+			logger.Errorf("Error saving idempotent response: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
 		logger.Errorf("Error committing transaction: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utilErrorsV1.WriteError(w, err)
 		return
 	}
 
-	// 6. Build and send http response.
+	// 6. Send http response.
 	logger.Infof("Transaction created successfully: %v", transaction)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// GetTransactionDetails handles the HTTP request for fetching a transaction by its ID.
+//
+// Workflow:
+//  1. Extract and convert the transactionId path parameter.
+//  2. Start a new db txn.
+//  3. Fetch the transaction via the core layer.
+//  4. Commit db txn.
+//  5. Return http response with the transaction.
+func (controller *TransactionController) GetTransactionDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the transaction id.
+	transactionIdStr := mux.Vars(r)["transactionId"]
+	logger.Infof("GetTransactionDetails endpoint called for transactionId: %v", transactionIdStr)
+	transactionId, err := strconv.Atoi(transactionIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+
+	// 2. Begin db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	// 3. Fetch the transaction via the core layer.
+	transaction, err := controller.coreV1.GetTransaction(ctx, transactionId, tx)
+	if err != nil {
+		logger.Errorf("Error fetching transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Commit db txn.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Build and send http response.
 	response := map[string]interface{}{
 		"success":     true,
 		"transaction": mapperV1Package.TransactionDetailsResponseMapper(transaction),
@@ -98,3 +212,227 @@ func (controller *TransactionController) CreateTransaction(w http.ResponseWriter
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// ListTransactions handles the HTTP request for listing transactions.
+//
+// Workflow:
+//  1. Parse and validate the query parameters (filters, cursor, limit, order).
+//  2. Start a new db txn.
+//  3. Fetch one page of transactions via the core layer.
+//  4. Commit db txn.
+//  5. Return http response with the page and the cursor of the next page, if any.
+func (controller *TransactionController) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+	logger.Infof("ListTransactions endpoint called with query: %s", r.URL.RawQuery)
+
+	// 1. Parse and validate query parameters.
+	listRequest, err := entityHttpV1Package.ParseListTransactionsRequest(r.URL.Query())
+	if err == nil {
+		err = listRequest.Validate()
+	}
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+	filter, err := mapperV1Package.TransactionFilterMapper(listRequest)
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	// 2. Begin db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	// 3. Fetch the page via the core layer.
+	page, err := controller.coreV1.ListTransactions(ctx, filter, tx)
+	if err != nil {
+		logger.Errorf("Error listing transactions: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Commit db txn.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Build and send http response.
+	response := map[string]interface{}{
+		"success":      true,
+		"transactions": mapperV1Package.TransactionListResponseMapper(page.Transactions),
+		"next_cursor":  mapperV1Package.EncodeTransactionCursor(page.Next),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ListUpcomingInstallments handles the HTTP request for listing an account's upcoming installments.
+//
+// Workflow:
+//  1. Parse the query parameters (account_id, from).
+//  2. Start a new db txn.
+//  3. Fetch the pending installments due on or after from via the core layer.
+//  4. Commit db txn.
+//  5. Return http response with the installments, soonest first.
+func (controller *TransactionController) ListUpcomingInstallments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+	logger.Infof("ListUpcomingInstallments endpoint called with query: %s", r.URL.RawQuery)
+
+	// 1. Parse query parameters.
+	listRequest, err := entityHttpV1Package.ParseListUpcomingInstallmentsRequest(r.URL.Query(), time.Now())
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_QUERY_PARAMETER, err.Error()))
+		return
+	}
+
+	// 2. Begin db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	// 3. Fetch the installments via the core layer.
+	installments, err := controller.coreV1.ListUpcomingInstallments(ctx, listRequest.AccountId, listRequest.From, tx)
+	if err != nil {
+		logger.Errorf("Error listing upcoming installments: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 4. Commit db txn.
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Build and send http response.
+	response := map[string]interface{}{
+		"success":      true,
+		"installments": mapperV1Package.InstallmentListResponseMapper(installments),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ReverseTransaction handles the HTTP request for reversing a transaction.
+//
+// Workflow:
+//  1. Extract the transaction id and parse the optional JSON body (an empty body reverses everything left).
+//  2. Validate the request data.
+//  3. Start a new db txn.
+//     If an Idempotency-Key header is sent, claim it; a retry of a completed request replays the stored response.
+//  4. Delegate to the core layer to post the reversal and undo the original's effects.
+//  5. Store the response for the idempotency key and commit db txn.
+//  6. Return http response with the reversal and the updated original.
+func (controller *TransactionController) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := utilV1.GetRequestID(ctx)
+	logger := controller.logger.WithField("request_id", requestID)
+	ctx = utilContextV1.WithLogger(ctx, logger)
+
+	// 1. Extract the transaction id and decode the payload.
+	transactionIdStr := mux.Vars(r)["transactionId"]
+	transactionId, err := strconv.Atoi(transactionIdStr)
+	if err != nil {
+		logger.Errorf("Error converting string to int: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_PATH_PARAMETER, "Error converting string to int: "+err.Error()))
+		return
+	}
+	var reverseReq entityHttpV1Package.ReverseTransactionRequest
+	body, err := io.ReadAll(r.Body)
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, &reverseReq)
+	}
+	if err != nil {
+		logger.Errorf("Error decoding request body: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_REQUEST_BODY, "Error decoding request body: "+err.Error()))
+		return
+	}
+
+	logger.WithField("input payload", reverseReq).Infof("ReverseTransaction endpoint called for transactionId: %d", transactionId)
+
+	// 2. Validate payload.
+	err = reverseReq.Validate()
+	if err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error()))
+		return
+	}
+
+	idempotencyKey := r.Header.Get(idempotencyConstantPackage.HEADER)
+	if err := utilIdempotencyV1.ValidateKey(idempotencyKey); err != nil {
+		logger.Errorf("Error: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 3. Begin db txn.
+	ctx, tx := utilContextV1.Begin(ctx, controller.db)
+	defer utilContextV1.Rollback(ctx, tx)
+
+	var idempotencyRecord *utilIdempotencyV1.IdempotencyRecord
+	if idempotencyKey != "" {
+		// The transaction id is part of the request: the same key on another transaction is a different request.
+		requestHash := utilIdempotencyV1.HashRequest(append([]byte(transactionIdStr+"\n"), body...))
+		record, replay, err := controller.idempotencyStore.Claim(ctx, idempotencyConstantPackage.SCOPE_REVERSE_TRANSACTION, idempotencyKey, requestHash, tx)
+		if err != nil {
+			logger.Errorf("Error claiming idempotency key: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+		if replay {
+			logger.Infof("Replaying stored response for idempotency key: %s", idempotencyKey)
+			utilIdempotencyV1.WriteReplay(w, record)
+			return
+		}
+		idempotencyRecord = record
+	}
+
+	// 4. Reverse the transaction via the core layer.
+	reversal, err := controller.coreV1.ReverseTransaction(ctx, transactionId, mapperV1Package.ReverseTransactionPayloadMapper(&reverseReq), tx)
+	if err != nil {
+		logger.Errorf("Error reversing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 5. Build the response, store it for the idempotency key and commit db txn.
+	response, err := json.Marshal(map[string]interface{}{
+		"success":  true,
+		"reversal": mapperV1Package.TransactionDetailsResponseMapper(reversal.Reversal),
+		"original": mapperV1Package.TransactionDetailsResponseMapper(reversal.Original),
+	})
+	if err != nil {
+		logger.Errorf("Error encoding response: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+	if idempotencyRecord != nil {
+		if err := controller.idempotencyStore.SaveResponse(ctx, idempotencyRecord, http.StatusOK, response, tx); err != nil {
+			logger.Errorf("Error saving idempotent response: %v", err)
+			utilErrorsV1.WriteError(w, err)
+			return
+		}
+	}
+	if err := utilContextV1.Commit(ctx, tx); err != nil {
+		logger.Errorf("Error committing transaction: %v", err)
+		utilErrorsV1.WriteError(w, err)
+		return
+	}
+
+	// 6. Send http response.
+	logger.Infof("Transaction reversed successfully: %v", reversal.Reversal)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
@@ -1,17 +1,27 @@
 package transaction_controller_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	idempotencyConstantPackage "anti-fraud/constants/idempotency"
+	accountClientPackageV1 "anti-fraud/mediator-service/account-service-client"
 	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
 	entityHttpV1Package "anti-fraud/transaction-service/entity/http/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,27 +37,110 @@ type MockTransactionCore struct {
 	mock.Mock
 }
 
-func (m *MockTransactionCore) CreateTransaction(logger *logrus.Entry, payload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+func (m *MockTransactionCore) CreateTransaction(ctx context.Context, payload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
 	args := m.Called(payload, tx)
 	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
 	return transaction, args.Error(1)
 }
 
-func (m *MockTransactionCore) FinalTransactionAmount(logger *logrus.Entry, amount float64, operationTypeID int, tx *gorm.DB) (float64, error) {
-	args := m.Called(amount, operationTypeID, tx)
-	return args.Get(0).(float64), args.Error(1)
+func (m *MockTransactionCore) FinalTransactionAmount(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
 }
 
-func (m *MockTransactionCore) CheckAccountIdExist(logger *logrus.Entry, accountId int, tx *gorm.DB) error {
+func (m *MockTransactionCore) CheckAccountIdExist(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
 	args := m.Called(accountId, tx)
+	account, _ := args.Get(0).(*accountClientPackageV1.Account)
+	return account, args.Error(1)
+}
+
+func (m *MockTransactionCore) ConvertCurrency(ctx context.Context, transaction *entityDbV1Package.Transaction, accountCurrency string, tx *gorm.DB) error {
+	args := m.Called(transaction, accountCurrency, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionCore) EvaluateFraud(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionCore) ApplyCreditLimit(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionCore) DischargeBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionCore) ChargeFees(ctx context.Context, transaction *entityDbV1Package.Transaction, accountTier string, tx *gorm.DB) error {
+	args := m.Called(transaction, accountTier, tx)
 	return args.Error(0)
 }
 
+func (m *MockTransactionCore) GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	args := m.Called(transactionId, tx)
+	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *MockTransactionCore) ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) (*entityCoreV1Package.TransactionPage, error) {
+	args := m.Called(filter, tx)
+	page, _ := args.Get(0).(*entityCoreV1Package.TransactionPage)
+	return page, args.Error(1)
+}
+
+func (m *MockTransactionCore) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionCore) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionCore) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionCore) PostCapturedTransaction(ctx context.Context, capturePayload *entityCoreV1Package.CapturedTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	args := m.Called(capturePayload, tx)
+	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *MockTransactionCore) ScheduleInstallments(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionCore) ListUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	args := m.Called(accountId, from, tx)
+	installments, _ := args.Get(0).([]*entityDbV1Package.Installment)
+	return installments, args.Error(1)
+}
+
+func (m *MockTransactionCore) ReverseTransaction(ctx context.Context, transactionId int, payload *entityCoreV1Package.ReverseTransactionPayload, tx *gorm.DB) (*entityCoreV1Package.TransactionReversal, error) {
+	args := m.Called(transactionId, payload, tx)
+	reversal, _ := args.Get(0).(*entityCoreV1Package.TransactionReversal)
+	return reversal, args.Error(1)
+}
+
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("failed to open in-memory DB: %v", err)
 	}
+	// A single connection keeps every txn on the same in-memory database.
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&utilIdempotencyV1.IdempotencyRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
 	return db
 }
 
@@ -63,6 +156,7 @@ func setupTestController(t *testing.T) (*TransactionController, *MockTransaction
 	controller := NewTransactionController(
 		nil,
 		mockCore,
+		utilIdempotencyV1.NewIdempotencyStore(logger),
 		db,
 		logger,
 	)
@@ -80,7 +174,7 @@ func TestCreateTransaction_Success(t *testing.T) {
 	validPayload := entityHttpV1Package.CreateTransactionRequest{
 		AccountId:       123,
 		OperationTypeId: 1,
-		Amount:          200.0,
+		Amount:          utilMoneyV1.MustParse("200"),
 	}
 	bodyBytes, _ := json.Marshal(validPayload)
 
@@ -96,7 +190,9 @@ func TestCreateTransaction_Success(t *testing.T) {
 		Model:           gorm.Model{ID: 1},
 		AccountId:       123,
 		OperationTypeId: 1,
-		Amount:          200.0,
+		Amount:          utilMoneyV1.MustParse("200"),
+		FraudDecision:   "REVIEW",
+		FraudRules:      "HIGH_AMOUNT",
 	}, nil)
 
 	controller.CreateTransaction(rr, req)
@@ -104,6 +200,8 @@ func TestCreateTransaction_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Contains(t, rr.Body.String(), `"success":true`)
 	assert.Contains(t, rr.Body.String(), `"amount":200`)
+	assert.Contains(t, rr.Body.String(), `"fraud_decision":"REVIEW"`)
+	assert.Contains(t, rr.Body.String(), `"fraud_rules":["HIGH_AMOUNT"]`)
 
 	mockCore.AssertExpectations(t)
 }
@@ -136,7 +234,7 @@ func TestCreateTransaction_ValidationError(t *testing.T) {
 
 	payload := entityHttpV1Package.CreateTransactionRequest{
 		AccountId: 123,
-		Amount:    1000.0,
+		Amount:    utilMoneyV1.MustParse("1000"),
 	}
 	bodyBytes, _ := json.Marshal(payload)
 
@@ -147,7 +245,7 @@ func TestCreateTransaction_ValidationError(t *testing.T) {
 	controller.CreateTransaction(rr, req)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
-	assert.Contains(t, rr.Body.String(), "Error:")
+	assert.Contains(t, rr.Body.String(), "VALIDATION_FAILED")
 
 	mockCore.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
 }
@@ -156,13 +254,45 @@ func TestCreateTransaction_ValidationError(t *testing.T) {
 // 4) TestCreateTransaction_CoreError
 //------------------------------------------------//
 
+func TestCreateTransaction_AmountScale(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	for _, body := range []string{
+		`{"account_id": 123, "operation_type_id": 1, "amount": 10.001, "currency": "USD"}`,
+		`{"account_id": 123, "operation_type_id": 1, "amount": 10.5, "currency": "JPY"}`,
+		`{"account_id": 123, "operation_type_id": 1, "amount": 10.00001}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/v1", bytes.NewReader([]byte(body)))
+		rr := httptest.NewRecorder()
+
+		controller.CreateTransaction(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "decimal places")
+	}
+
+	mockCore.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_UnsupportedCurrency(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/v1", strings.NewReader(`{"account_id": 123, "operation_type_id": 1, "amount": 10, "currency": "XXX"}`))
+	rr := httptest.NewRecorder()
+	controller.CreateTransaction(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "unsupported currency")
+	mockCore.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+}
+
 func TestCreateTransaction_CoreError(t *testing.T) {
 	controller, mockCore, _ := setupTestController(t)
 
 	payload := entityHttpV1Package.CreateTransactionRequest{
 		AccountId:       123,
 		OperationTypeId: 1,
-		Amount:          500,
+		Amount:          utilMoneyV1.MustParse("500"),
 	}
 	bodyBytes, _ := json.Marshal(payload)
 
@@ -181,6 +311,112 @@ func TestCreateTransaction_CoreError(t *testing.T) {
 	mockCore.AssertExpectations(t)
 }
 
+func TestCreateTransaction_UnknownAccount(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	payload := entityHttpV1Package.CreateTransactionRequest{
+		AccountId:       404,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse("500"),
+	}
+	bodyBytes, _ := json.Marshal(payload)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/v1", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account_id: 404 not found in database"))
+
+	controller.CreateTransaction(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.ACCOUNT_NOT_FOUND)
+
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateTransaction_IdempotentReplay(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	bodyBytes, _ := json.Marshal(entityHttpV1Package.CreateTransactionRequest{
+		AccountId:       123,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse("200"),
+	})
+
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, AccountId: 123, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("200")}, nil).
+		Once()
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/v1", bytes.NewReader(bodyBytes))
+		req.Header.Set(idempotencyConstantPackage.HEADER, "retry-key")
+		rr := httptest.NewRecorder()
+
+		controller.CreateTransaction(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		bodies = append(bodies, rr.Body.String())
+		if i == 1 {
+			assert.Equal(t, "true", rr.Header().Get(idempotencyConstantPackage.REPLAYED_HEADER))
+		}
+	}
+
+	assert.Equal(t, bodies[0], bodies[1])
+	mockCore.AssertNumberOfCalls(t, "CreateTransaction", 1)
+}
+
+func TestCreateTransaction_IdempotencyKeyReusedWithDifferentBody(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, AccountId: 123, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("200")}, nil).
+		Once()
+
+	for i, amount := range []utilMoneyV1.Amount{utilMoneyV1.MustParse("200"), utilMoneyV1.MustParse("300")} {
+		bodyBytes, _ := json.Marshal(entityHttpV1Package.CreateTransactionRequest{AccountId: 123, OperationTypeId: 1, Amount: amount})
+		req := httptest.NewRequest(http.MethodPost, "/transactions/v1", bytes.NewReader(bodyBytes))
+		req.Header.Set(idempotencyConstantPackage.HEADER, "reused-key")
+		rr := httptest.NewRecorder()
+
+		controller.CreateTransaction(rr, req)
+
+		if i == 0 {
+			assert.Equal(t, http.StatusOK, rr.Code)
+		} else {
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			assert.Contains(t, rr.Body.String(), errorConstantPackage.IDEMPOTENCY_KEY_REUSED)
+		}
+	}
+
+	mockCore.AssertNumberOfCalls(t, "CreateTransaction", 1)
+}
+
+func TestCreateTransaction_FailedRequestDoesNotKeepIdempotencyKey(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	bodyBytes, _ := json.Marshal(entityHttpV1Package.CreateTransactionRequest{AccountId: 123, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("200")})
+
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(nil, errors.New("some core error")).Once()
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, AccountId: 123, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("200")}, nil).Once()
+
+	for _, expected := range []int{http.StatusInternalServerError, http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/v1", bytes.NewReader(bodyBytes))
+		req.Header.Set(idempotencyConstantPackage.HEADER, "failed-key")
+		rr := httptest.NewRecorder()
+
+		controller.CreateTransaction(rr, req)
+
+		assert.Equal(t, expected, rr.Code)
+	}
+
+	mockCore.AssertExpectations(t)
+}
+
 // ------------------------------------------------//
 // 5) TestCreateTransaction_CommitError
 // ------------------------------------------------//
@@ -190,7 +426,7 @@ func TestCreateTransaction_CommitError(t *testing.T) {
 	payload := entityHttpV1Package.CreateTransactionRequest{
 		AccountId:       123,
 		OperationTypeId: 1,
-		Amount:          500,
+		Amount:          utilMoneyV1.MustParse("500"),
 	}
 	bodyBytes, _ := json.Marshal(payload)
 
@@ -199,7 +435,7 @@ func TestCreateTransaction_CommitError(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).
-		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, Amount: 500}, nil)
+		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, Amount: utilMoneyV1.MustParse("500")}, nil)
 
 	tx := db.Begin()
 	defer tx.Rollback()
@@ -210,7 +446,297 @@ func TestCreateTransaction_CommitError(t *testing.T) {
 	controller.CreateTransaction(rr, req)
 
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
-	assert.Contains(t, rr.Body.String(), "commit failed")
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INTERNAL_ERROR)
+
+	mockCore.AssertExpectations(t)
+}
+
+// ------------------------------------------------//
+// 6) GetTransactionDetails / ListTransactions
+// ------------------------------------------------//
+
+func TestGetTransactionDetails_Success(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("GetTransaction", 7, mock.Anything).
+		Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}, AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("19.99")}, nil)
 
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/transactions/v1/7", nil), map[string]string{"transactionId": "7"})
+	rr := httptest.NewRecorder()
+	controller.GetTransactionDetails(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"transaction_id":7`)
+	assert.Contains(t, rr.Body.String(), `"amount":19.99`)
 	mockCore.AssertExpectations(t)
 }
+
+func TestGetTransactionDetails_NotFound(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("GetTransaction", 7, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.TRANSACTION_NOT_FOUND, "transaction_id: 7 not found in database"))
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/transactions/v1/7", nil), map[string]string{"transactionId": "7"})
+	rr := httptest.NewRecorder()
+	controller.GetTransactionDetails(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.TRANSACTION_NOT_FOUND)
+}
+
+func TestGetTransactionDetails_InvalidPathParam(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/transactions/v1/abc", nil), map[string]string{"transactionId": "abc"})
+	rr := httptest.NewRecorder()
+	controller.GetTransactionDetails(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_PATH_PARAMETER)
+	mockCore.AssertNotCalled(t, "GetTransaction", mock.Anything, mock.Anything)
+}
+
+func TestListTransactions_Success(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	next := &entityCoreV1Package.TransactionCursor{CreatedAt: time.Unix(0, 1700000000123456789), Id: 5}
+	mockCore.On("ListTransactions", mock.MatchedBy(func(filter *entityCoreV1Package.TransactionFilter) bool {
+		return *filter.AccountId == 1 && filter.MinAmount.Cmp(utilMoneyV1.MustParse("-100")) == 0 &&
+			filter.SortOrder == "asc" && filter.Limit == 2 && filter.After == nil
+	}), mock.Anything).Return(&entityCoreV1Package.TransactionPage{
+		Transactions: []*entityDbV1Package.Transaction{{Model: gorm.Model{ID: 4}, AccountId: 1}, {Model: gorm.Model{ID: 5}, AccountId: 1}},
+		Next:         next,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/v1?account_id=1&min_amount=-100&order=asc&limit=2", nil)
+	rr := httptest.NewRecorder()
+	controller.ListTransactions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var body struct {
+		Transactions []entityHttpV1Package.TransactionResponse `json:"transactions"`
+		NextCursor   string                                    `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Len(t, body.Transactions, 2)
+	assert.NotEmpty(t, body.NextCursor)
+
+	// The returned cursor resumes the listing after the last transaction of the page.
+	mockCore.On("ListTransactions", mock.MatchedBy(func(filter *entityCoreV1Package.TransactionFilter) bool {
+		return filter.After != nil && filter.After.Id == 5 && filter.After.CreatedAt.Equal(next.CreatedAt)
+	}), mock.Anything).Return(&entityCoreV1Package.TransactionPage{}, nil).Once()
+
+	req = httptest.NewRequest(http.MethodGet, "/transactions/v1?account_id=1&min_amount=-100&order=asc&limit=2&cursor="+body.NextCursor, nil)
+	rr = httptest.NewRecorder()
+	controller.ListTransactions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"transactions":[]`)
+	assert.Contains(t, rr.Body.String(), `"next_cursor":""`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestListTransactions_InvalidQuery(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	for _, query := range []string{
+		"account_id=abc",
+		"limit=0",
+		"limit=1000",
+		"order=sideways",
+		"min_amount=10&max_amount=5",
+		"from=yesterday",
+		"from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"cursor=not-a-cursor",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/v1?"+query, nil)
+		rr := httptest.NewRecorder()
+		controller.ListTransactions(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER, query)
+	}
+	mockCore.AssertNotCalled(t, "ListTransactions", mock.Anything, mock.Anything)
+}
+
+// ------------------------------------------------//
+// 7) Installments
+// ------------------------------------------------//
+
+func TestCreateTransaction_WithInstallments(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("CreateTransaction", mock.MatchedBy(func(payload *entityCoreV1Package.CreateTransactionPayload) bool {
+		return payload.OperationTypeId == 2 && payload.InstallmentCount == 3
+	}), mock.Anything).Return(&entityDbV1Package.Transaction{
+		Model:            gorm.Model{ID: 7},
+		AccountId:        123,
+		OperationTypeId:  2,
+		Amount:           utilMoneyV1.MustParse("-100"),
+		InstallmentCount: 3,
+		Installments: []*entityDbV1Package.Installment{
+			{Model: gorm.Model{ID: 1}, TransactionId: 7, AccountId: 123, Number: 1, Amount: utilMoneyV1.MustParse("-33.34"), DueDate: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), Status: "PENDING"},
+			{Model: gorm.Model{ID: 2}, TransactionId: 7, AccountId: 123, Number: 2, Amount: utilMoneyV1.MustParse("-33.33"), DueDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), Status: "PENDING"},
+			{Model: gorm.Model{ID: 3}, TransactionId: 7, AccountId: 123, Number: 3, Amount: utilMoneyV1.MustParse("-33.33"), DueDate: time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), Status: "PENDING"},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/v1", strings.NewReader(`{"account_id": 123, "operation_type_id": 2, "amount": 100, "installment_count": 3}`))
+	rr := httptest.NewRecorder()
+	controller.CreateTransaction(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"installment_count":3`)
+	assert.Contains(t, rr.Body.String(), `{"installment_id":1,"transaction_id":7,"account_id":123,"number":1,"amount":-33.34,"due_date":"2026-02-28","status":"PENDING"}`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateTransaction_WithFees(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	parentId := uint(7)
+	mockCore.On("CreateTransaction", mock.Anything, mock.Anything).Return(&entityDbV1Package.Transaction{
+		Model:           gorm.Model{ID: 7},
+		AccountId:       123,
+		OperationTypeId: 3,
+		Amount:          utilMoneyV1.MustParse("-500"),
+		Fees: []*entityDbV1Package.Transaction{
+			{Model: gorm.Model{ID: 8}, AccountId: 123, OperationTypeId: 5, Amount: utilMoneyV1.MustParse("-2.5"), FeeCode: "WITHDRAWAL_FEE", ParentTransactionId: &parentId},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/v1", strings.NewReader(`{"account_id": 123, "operation_type_id": 3, "amount": 500}`))
+	rr := httptest.NewRecorder()
+	controller.CreateTransaction(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"fees":[{"transaction_id":8,"code":"WITHDRAWAL_FEE","amount":-2.5}]`)
+	assert.NotContains(t, rr.Body.String(), `"fee_code"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestCreateTransaction_InvalidInstallmentCount(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	// Whether installment_count is mandatory or accepted is checked by the handler of the operation type.
+	for _, body := range []string{
+		`{"account_id": 123, "operation_type_id": 2, "amount": 100, "installment_count": 1}`,
+		`{"account_id": 123, "operation_type_id": 2, "amount": 100, "installment_count": 49}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/transactions/v1", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		controller.CreateTransaction(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+}
+
+func TestListUpcomingInstallments_Success(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockCore.On("ListUpcomingInstallments", 123, from, mock.Anything).Return([]*entityDbV1Package.Installment{
+		{Model: gorm.Model{ID: 4}, TransactionId: 7, AccountId: 123, Number: 2, Amount: utilMoneyV1.MustParse("-50"), DueDate: time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC), Status: "PENDING"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/v1/installments?account_id=123&from=2026-05-01", nil)
+	rr := httptest.NewRecorder()
+	controller.ListUpcomingInstallments(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"due_date":"2026-05-15"`)
+	assert.Contains(t, rr.Body.String(), `"amount":-50`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestListUpcomingInstallments_InvalidQuery(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	for _, query := range []string{"", "account_id=abc", "account_id=1&from=tomorrow"} {
+		req := httptest.NewRequest(http.MethodGet, "/transactions/v1/installments?"+query, nil)
+		rr := httptest.NewRecorder()
+		controller.ListUpcomingInstallments(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.INVALID_QUERY_PARAMETER, query)
+	}
+	mockCore.AssertNotCalled(t, "ListUpcomingInstallments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ------------------------------------------------//
+// 8) ReverseTransaction
+// ------------------------------------------------//
+
+func TestReverseTransaction_FullReversalWithEmptyBody(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	originalId := uint(7)
+	mockCore.On("ReverseTransaction", 7, mock.MatchedBy(func(payload *entityCoreV1Package.ReverseTransactionPayload) bool {
+		return payload.Amount == nil
+	}), mock.Anything).Return(&entityCoreV1Package.TransactionReversal{
+		Reversal: &entityDbV1Package.Transaction{Model: gorm.Model{ID: 8}, AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("50"), Status: "POSTED", OriginalTransactionId: &originalId},
+		Original: &entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}, AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50"), ReversedAmount: utilMoneyV1.MustParse("50"), Status: "REVERSED"},
+	}, nil)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/transactions/v1/7/reversal", nil), map[string]string{"transactionId": "7"})
+	rr := httptest.NewRecorder()
+	controller.ReverseTransaction(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, `"reversal":{`)
+	assert.Contains(t, body, `"original_transaction_id":7`)
+	assert.Contains(t, body, `"status":"REVERSED"`)
+	assert.Contains(t, body, `"reversed_amount":50`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestReverseTransaction_PartialAmount(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("ReverseTransaction", 7, mock.MatchedBy(func(payload *entityCoreV1Package.ReverseTransactionPayload) bool {
+		return payload.Amount != nil && payload.Amount.Cmp(utilMoneyV1.MustParse("12.5")) == 0
+	}), mock.Anything).Return(&entityCoreV1Package.TransactionReversal{
+		Reversal: &entityDbV1Package.Transaction{Model: gorm.Model{ID: 8}, Amount: utilMoneyV1.MustParse("12.5")},
+		Original: &entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}, Amount: utilMoneyV1.MustParse("-50"), Status: "PARTIALLY_REVERSED"},
+	}, nil)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/transactions/v1/7/reversal", strings.NewReader(`{"amount": "12.50"}`)), map[string]string{"transactionId": "7"})
+	rr := httptest.NewRecorder()
+	controller.ReverseTransaction(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"PARTIALLY_REVERSED"`)
+	mockCore.AssertExpectations(t)
+}
+
+func TestReverseTransaction_InvalidAmount(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	for _, body := range []string{`{"amount": -5}`, `{"amount": 0}`} {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/transactions/v1/7/reversal", strings.NewReader(body)), map[string]string{"transactionId": "7"})
+		rr := httptest.NewRecorder()
+		controller.ReverseTransaction(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), errorConstantPackage.VALIDATION_FAILED, body)
+	}
+	mockCore.AssertNotCalled(t, "ReverseTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReverseTransaction_NotReversible(t *testing.T) {
+	controller, mockCore, _ := setupTestController(t)
+
+	mockCore.On("ReverseTransaction", 7, mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.TRANSACTION_NOT_REVERSIBLE, "transaction_id: 7 is already fully reversed"))
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/transactions/v1/7/reversal", nil), map[string]string{"transactionId": "7"})
+	rr := httptest.NewRecorder()
+	controller.ReverseTransaction(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), errorConstantPackage.TRANSACTION_NOT_REVERSIBLE)
+}
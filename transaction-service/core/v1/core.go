@@ -1,16 +1,28 @@
 package transaction_core_v1
 
 import (
+	accountConstantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	constantPackage "anti-fraud/constants/transaction"
 	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
 	mapperV1Package "anti-fraud/transaction-service/mapper/v1"
 	repoV1Package "anti-fraud/transaction-service/repository/v1"
+	"context"
 	"fmt"
 
 	accountClientPackageV1 "anti-fraud/mediator-service/account-service-client"
+	eventBusPackageV1 "anti-fraud/mediator-service/event-bus"
+	fraudClientPackageV1 "anti-fraud/mediator-service/fraud-service-client"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
 	operationClientPackageV1 "anti-fraud/mediator-service/operation-service-client"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
-	"math"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -20,14 +32,56 @@ import (
 type ITransactionCore interface {
 
 	// CreateTransaction creates a new transaction record in the db
-	CreateTransaction(logger *logrus.Entry, transactionPayload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+	CreateTransaction(ctx context.Context, transactionPayload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
 
-	// FinalTransactionAmount applies business logic to compute the final transaction amount
-	// based on the operationTypeID and coefficient retrieved from the operation service.
-	FinalTransactionAmount(logger *logrus.Entry, amount float64, operationTypeID int, tx *gorm.DB) (float64, error)
+	// FinalTransactionAmount computes the final transaction amount and its side effects with the handler
+	// of the transaction's operation type, resolved through the operation service as of the event time.
+	FinalTransactionAmount(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
 
-	// CheckAccountIdExist verifies whether the provided accountId exists by calling the account service.
-	CheckAccountIdExist(logger *logrus.Entry, accountId int, tx *gorm.DB) error
+	// CheckAccountIdExist verifies whether the provided accountId exists and is active by calling the account service.
+	CheckAccountIdExist(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error)
+
+	// ConvertCurrency converts the transaction's original amount to the account currency at the rate valid at its event time.
+	ConvertCurrency(ctx context.Context, transaction *entityDbV1Package.Transaction, accountCurrency string, tx *gorm.DB) error
+
+	// EvaluateFraud runs the fraud rule engine via the fraud service and records the decision on the transaction.
+	EvaluateFraud(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// ApplyCreditLimit draws down or restores the account's available credit limit by the transaction amount.
+	ApplyCreditLimit(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// ChargeFees posts the fees of the transaction's operation type as debits linked to it.
+	ChargeFees(ctx context.Context, transaction *entityDbV1Package.Transaction, accountTier string, tx *gorm.DB) error
+
+	// DischargeBalance pays down the account's outstanding negative balances with a credit transaction, oldest first.
+	DischargeBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// GetTransaction fetches a transaction by its ID.
+	GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+
+	// ListTransactions returns one page of the transactions matching a filter.
+	ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) (*entityCoreV1Package.TransactionPage, error)
+
+	// GetPostedTransactions returns the account's non-declined transactions created within [from, to), oldest first.
+	GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+
+	// GetPostedBalance returns the sum of the account's non-declined transaction amounts before a time.
+	GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error)
+
+	// CountOperationTypeTransactions returns how many transactions reference an operation type.
+	CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error)
+
+	// PostCapturedTransaction persists the transaction of a captured authorization hold.
+	PostCapturedTransaction(ctx context.Context, capturePayload *entityCoreV1Package.CapturedTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+
+	// ReverseTransaction posts a compensating transaction for all or part of a transaction and undoes its effects.
+	ReverseTransaction(ctx context.Context, transactionId int, payload *entityCoreV1Package.ReverseTransactionPayload, tx *gorm.DB) (*entityCoreV1Package.TransactionReversal, error)
+
+	// ScheduleInstallments splits an installment purchase into monthly installments and persists them.
+	ScheduleInstallments(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// ListUpcomingInstallments returns the account's pending installments due on or after a date.
+	ListUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error)
 }
 
 // TransactionCore implements ITransactionCore interface.
@@ -36,73 +90,328 @@ type TransactionCore struct {
 	logger          *logrus.Logger
 	operationClient operationClientPackageV1.IOperationClient
 	accountClient   accountClientPackageV1.IAccountClient
+	fraudClient     fraudClientPackageV1.IFraudClient
+	fxClient        fxClientPackageV1.IFxClient
+	eventBus        eventBusPackageV1.IEventBus
 }
 
 // NewTransactionCore creates and return new TransactionCore instance.
-func NewTransactionCore(repoV1 repoV1Package.ITransactionRepository, logger *logrus.Logger, operationClient operationClientPackageV1.IOperationClient, accountClient accountClientPackageV1.IAccountClient) *TransactionCore {
-	return &TransactionCore{repoV1: repoV1, logger: logger, operationClient: operationClient, accountClient: accountClient}
+func NewTransactionCore(repoV1 repoV1Package.ITransactionRepository, logger *logrus.Logger, operationClient operationClientPackageV1.IOperationClient, accountClient accountClientPackageV1.IAccountClient, fraudClient fraudClientPackageV1.IFraudClient, fxClient fxClientPackageV1.IFxClient, eventBus eventBusPackageV1.IEventBus) *TransactionCore {
+	return &TransactionCore{repoV1: repoV1, logger: logger, operationClient: operationClient, accountClient: accountClient, fraudClient: fraudClient, fxClient: fxClient, eventBus: eventBus}
 }
 
-// FinalTransactionAmount calculates the final amount for a transaction based on the operation type.
+// FinalTransactionAmount calculates the final amount for a transaction with the handler of its operation type.
 //
 // Steps:
-//  1. Retrieve the coefficient from the operation service using the provided operationTypeID.
-//  2. Convert the original amount to its absolute value and multiply by the coefficient.
-//  3. Return the computed final amount or the original amount and an encountered Error.
+//  1. Submit the input amount, installment count and event time to the operation service, which
+//     validates them and computes the final amount with the handler of the operation type in effect.
+//     An unknown operation type is reported as unprocessable, since it comes from the request body.
+//  2. Set the final signed amount, in the submitted currency, the applied coefficient and the
+//     installment count on the transaction.
 //
 // Parameters:
-//   - amount: The initial transaction amount.
-//   - operationTypeID: The ID representing the type of operation (e.g., debit, credit).
-//   - tx: db txn.
+//   - transaction: transaction db entity, with its event time, input amount and installment count set.
+//   - tx:          db txn.
 //
 // Returns:
-//   - float64: The final computed transaction amount after applying the operation coefficient.
-//   - error:   Encountered Error.
-func (core *TransactionCore) FinalTransactionAmount(logger *logrus.Entry, amount float64, operationTypeID int, tx *gorm.DB) (float64, error) {
-	// Fetch coefficient from the operation service
-	coef, err := core.operationClient.GetOperationCoefficient(logger, operationTypeID, tx)
+//   - error: Encountered Error.
+func (core *TransactionCore) FinalTransactionAmount(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	// Compute the operation with the operation service
+	result, err := core.operationClient.ComputeOperation(ctx, &operationClientPackageV1.OperationInput{
+		AccountId:        transaction.AccountId,
+		OperationTypeId:  transaction.OperationTypeId,
+		Amount:           transaction.InputAmount,
+		InstallmentCount: transaction.InstallmentCount,
+		At:               transaction.CreatedAt,
+	}, tx)
 	if err != nil {
-		logger.Errorf("Error while fetching operation coefficient from operation service: %s", err.Error())
-		return amount, err
+		logger.Errorf("Error while computing operation with operation service: %s", err.Error())
+		if utilErrorsV1.IsNotFound(err) {
+			return utilErrorsV1.NewUnprocessableError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, err.Error())
+		}
+		return err
 	}
 
-	// Compute final amount using absolute value and the retrieved coefficient
-	return (math.Abs(amount) * float64(coef)), nil
+	transaction.OriginalAmount = result.Amount
+	transaction.Coefficient = result.Coefficient
+	transaction.InstallmentCount = result.InstallmentCount
+	return nil
 }
 
-// CheckAccountIdExist verifies that the provided accountId exists in the db.
+// CheckAccountIdExist verifies that the provided accountId exists in the db and accepts transactions.
 // Steps:
 //  1. Calls the account service to retrieve an account by account id.
 //  2. If no account is found (ID == 0) in db, returns an Error.
-//  3. Otherwise, returns nil to indicate the account exists.
+//  3. If the account is not ACTIVE, returns an Error specific to its status.
+//  4. Otherwise, returns the account, whose currency the transaction is booked in.
 //
 // Parameters:
 //   - accountId: id of account.
 //   - tx:        db txn.
 //
 // Returns:
-//   - error: If the account is not found or if there's an error in the account service call.
-func (core *TransactionCore) CheckAccountIdExist(logger *logrus.Entry, accountId int, tx *gorm.DB) error {
+//   - *Account: the active account.
+//   - error:    If the account is not found, not active or if there's an error in the account service call.
+func (core *TransactionCore) CheckAccountIdExist(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
+	logger := utilContextV1.Logger(ctx)
 
-	account, err := core.accountClient.GetAccount(logger, accountId, tx)
+	account, err := core.accountClient.GetAccount(ctx, accountId, tx)
 	if err != nil {
 		logger.Errorf("Error while fetching account data from account service: %s", err.Error())
-		return err
+		if utilErrorsV1.IsNotFound(err) {
+			return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_FOUND, err.Error())
+		}
+		return nil, err
 	}
 	if account.Id == 0 { // account id not found in database
 		logger.Error("Error: account_id not found in database")
-		return fmt.Errorf("account_id: %d not found in database", accountId)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_FOUND, fmt.Sprintf("account_id: %d not found in database", accountId))
 	}
+	switch account.Status {
+	case accountConstantPackage.STATUS_ACTIVE:
+		return account, nil
+	case accountConstantPackage.STATUS_BLOCKED:
+		logger.Error("Error: account is blocked")
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_BLOCKED, fmt.Sprintf("account_id: %d is blocked", accountId))
+	case accountConstantPackage.STATUS_CLOSED:
+		logger.Error("Error: account is closed")
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_CLOSED, fmt.Sprintf("account_id: %d is closed", accountId))
+	default:
+		logger.Errorf("Error: account has unexpected status %s", account.Status)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.ACCOUNT_NOT_ACTIVE, fmt.Sprintf("account_id: %d is not active (status: %s)", accountId, account.Status))
+	}
+}
+
+// ConvertCurrency books the transaction in the account currency.
+//
+// Steps:
+//  1. A transaction submitted without a currency is in the account currency.
+//  2. Reject an original amount with more decimals than its currency's minor unit.
+//  3. In the account currency, the amount is kept as is at a rate of 1.
+//  4. Otherwise, fetch the original to account currency rate valid at the transaction's
+//     event time and convert, rounding half away from zero to the account's minor unit.
+//
+// Parameters:
+//   - transaction:     transaction db entity, with its event time and signed OriginalAmount set.
+//   - accountCurrency: currency of the account.
+//   - tx:              db txn.
+//
+// Returns:
+//   - error: a validation Error, an unprocessable Error when no rate is effective at event time
+//     or the amount converts to zero, or any other encountered Error.
+func (core *TransactionCore) ConvertCurrency(ctx context.Context, transaction *entityDbV1Package.Transaction, accountCurrency string, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	// 1. Default to the account currency.
+	transaction.Currency = accountCurrency
+	if transaction.OriginalCurrency == "" {
+		transaction.OriginalCurrency = accountCurrency
+	}
+
+	// 2. Validate the original amount.
+	if err := utilMoneyV1.ValidateScale(transaction.OriginalAmount, transaction.OriginalCurrency); err != nil {
+		logger.Errorf("Error: %s", err.Error())
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error())
+	}
+
+	// 3. Nothing to convert.
+	if transaction.OriginalCurrency == accountCurrency {
+		transaction.FxRate = utilMoneyV1.IdentityRate
+		transaction.Amount = transaction.OriginalAmount
+		return nil
+	}
+
+	// 4. Convert at the rate valid at event time.
+	rate, err := core.fxClient.GetRate(ctx, transaction.OriginalCurrency, accountCurrency, transaction.CreatedAt, tx)
+	if err != nil {
+		logger.Errorf("Error while fetching fx rate from fx service: %s", err.Error())
+		if utilErrorsV1.IsNotFound(err) {
+			return utilErrorsV1.NewUnprocessableError(errorConstantPackage.FX_RATE_NOT_FOUND, err.Error())
+		}
+		return err
+	}
+	amount, err := utilMoneyV1.Convert(transaction.OriginalAmount, rate, accountCurrency)
+	if err != nil {
+		logger.Errorf("Error while converting amount: %s", err.Error())
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.VALIDATION_FAILED, err.Error())
+	}
+	if amount.IsZero() {
+		logger.Errorf("Error: %s %s converts to zero %s", transaction.OriginalAmount, transaction.OriginalCurrency, accountCurrency)
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.AMOUNT_TOO_SMALL, fmt.Sprintf("%s %s is worth less than the minor unit of %s", transaction.OriginalAmount.Abs(), transaction.OriginalCurrency, accountCurrency))
+	}
+	transaction.FxRate = rate
+	transaction.Amount = amount
+	return nil
+}
+
+// EvaluateFraud runs the fraud rule engine for the transaction.
+//
+// Steps:
+//  1. Calls the fraud service with the transaction's account, operation type, final amount
+//     and the account currency the amount is in.
+//  2. Records the decision and the names of the fired rules on the transaction.
+//
+// Parameters:
+//   - transaction: transaction db entity, with its final amount already computed.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an encountered Error while evaluating rules.
+func (core *TransactionCore) EvaluateFraud(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	decision, err := core.fraudClient.EvaluateTransaction(ctx, &fraudClientPackageV1.TransactionCheck{
+		AccountId:       transaction.AccountId,
+		OperationTypeId: transaction.OperationTypeId,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error while evaluating transaction via fraud service: %s", err.Error())
+		return err
+	}
+	transaction.FraudDecision = decision.Decision
+	transaction.FraudRules = strings.Join(decision.FiredRules, ",")
 	return nil
 }
 
-// CreateTransaction creates a new transaction record in the db after verifying the account and
-// calculating the final amount.
+// ApplyCreditLimit applies the transaction to the account's available credit limit.
+//
+// Steps:
+//  1. Calls the account service with the signed final amount: purchases and withdrawals
+//     (negative amounts) draw the limit down, credit vouchers (positive amounts) restore it.
+//  2. The account service rejects the update if the limit would become negative.
+//
+// Parameters:
+//   - transaction: transaction db entity, with its final amount already computed.
+//   - tx:          db txn, so the limit update commits or rolls back with the transaction insert; a remote
+//     account client compensates its update if the txn of ctx rolls back.
+//
+// Returns:
+//   - error: If the limit is insufficient or the account service call fails.
+func (core *TransactionCore) ApplyCreditLimit(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	err := core.accountClient.UpdateAvailableCreditLimit(ctx, transaction.AccountId, transaction.Amount, tx)
+	if err != nil {
+		logger.Errorf("Error while applying transaction to available credit limit: %s", err.Error())
+	}
+	return err
+}
+
+// DischargeBalance discharges a credit transaction against the account's outstanding debts.
+//
+// Steps:
+//  1. The transaction balance starts equal to its amount; debits stop there.
+//  2. For a credit, fetch the account's negative-balance transactions, oldest first.
+//  3. Reduce each outstanding balance by as much of the credit as remains, persisting it.
+//  4. Store any leftover credit as the balance of the new transaction.
+//
+// Parameters:
+//   - transaction: transaction db entity, with its final amount already computed.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an encountered Error.
+func (core *TransactionCore) DischargeBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	transaction.Balance = transaction.Amount
+	if transaction.Amount.Sign() <= 0 {
+		return nil
+	}
+	return core.dischargeOutstanding(ctx, transaction, tx)
+}
+
+// dischargeOutstanding spends the positive balance of transaction on the account's negative balances, oldest first.
+func (core *TransactionCore) dischargeOutstanding(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	outstandingTransactions, err := core.repoV1.GetOutstandingTransactions(ctx, transaction.AccountId, tx)
+	if err != nil {
+		logger.Errorf("Error while fetching outstanding transactions: %s", err.Error())
+		return err
+	}
+	for _, outstanding := range outstandingTransactions {
+		if transaction.Balance.Sign() <= 0 {
+			break
+		}
+		discharge := utilMoneyV1.Min(transaction.Balance, outstanding.Balance.Neg())
+		outstanding.Balance = outstanding.Balance.Add(discharge)
+		transaction.Balance = transaction.Balance.Sub(discharge)
+		err = core.repoV1.UpdateTransactionBalance(ctx, outstanding, tx)
+		if err != nil {
+			logger.Errorf("Error while discharging balance of transaction %d: %s", outstanding.ID, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// ChargeFees charges the fees of the transaction's operation type on a persisted transaction.
+//
+// Steps:
+//  1. Ask the operation service for the fees of the operation type, for the account tier and
+//     the final amount in the account currency.
+//  2. Post each fee as a debit of the Fee operation type linked to the transaction: it draws the
+//     credit limit down like any debit, and the whole transaction is rejected if the limit cannot
+//     cover it. Fees are not run through the fraud engine and share the transaction's decision.
+//  3. Keep the fee transactions on the transaction, as its fee breakdown.
+//
+// Parameters:
+//   - transaction: persisted transaction db entity, with its final amount in the account currency.
+//   - accountTier: tier of the account, selecting tier-specific fees.
+//   - tx:          db txn, shared with the transaction insert.
+//
+// Returns:
+//   - error: an encountered Error.
+func (core *TransactionCore) ChargeFees(ctx context.Context, transaction *entityDbV1Package.Transaction, accountTier string, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	// 1. Compute the fees.
+	fees, err := core.operationClient.ComputeFees(ctx, &operationClientPackageV1.FeeInput{
+		OperationTypeId: transaction.OperationTypeId,
+		AccountTier:     accountTier,
+		Currency:        transaction.Currency,
+		Amount:          transaction.Amount,
+	}, tx)
+	if err != nil {
+		logger.Errorf("Error while computing fees with operation service: %s", err.Error())
+		return err
+	}
+
+	// 2. Post them.
+	transaction.Fees = make([]*entityDbV1Package.Transaction, 0, len(fees))
+	for _, fee := range fees {
+		feeTransaction := mapperV1Package.FeeTransactionMapper(transaction, fee.OperationTypeId, fee.Code, fee.Amount)
+		err = core.ApplyCreditLimit(ctx, feeTransaction, tx)
+		if err != nil {
+			logger.Errorf("Error while applying fee %s to credit limit: %s", fee.Code, err.Error())
+			return err
+		}
+		err = core.repoV1.CreateTransaction(ctx, feeTransaction, tx)
+		if err != nil {
+			logger.Errorf("Error while persisting fee %s: %s", fee.Code, err.Error())
+			return err
+		}
+
+		// 3. Record the breakdown.
+		transaction.Fees = append(transaction.Fees, feeTransaction)
+	}
+	return nil
+}
+
+// CreateTransaction creates a new transaction record in the db after verifying the account,
+// calculating the final amount, running the fraud rule engine and applying the credit limit.
 //
 // Steps:
 //   1. Ensure the account ID is valid. If invalid, return an error.
-//   2. Calculate the final transaction amount using FinalTransactionAmount.
-//   3. Persist the transaction in the DB
+//   2. Calculate the final transaction amount using FinalTransactionAmount, with the handler of the
+//      operation type in effect at event time; the signed input and the coefficient are kept on the transaction.
+//   3. Convert it to the account currency at the rate valid at event time; everything
+//      after this step, the fraud rules included, works on the converted amount.
+//   4. Evaluate fraud rules and record the decision on the transaction.
+//   5. Unless declined, draw down or restore the available credit limit; reject the
+//      transaction if it would exceed the limit.
+//   6. Unless declined, discharge a credit against outstanding debits (FIFO).
+//   7. Persist the transaction, along with its decision and balance, in the DB
+//   8. Unless declined, schedule the installments of an installment purchase.
+//   9. Unless declined, charge the fees of the operation type as linked debits.
+//  10. Publish TransactionCreated, or TransactionDeclined for a declined transaction.
 //
 // Parameters:
 //   - transactionPayload: Payload containing the data needed to create a transaction (accountId, amount, etc.).
@@ -112,28 +421,519 @@ func (core *TransactionCore) CheckAccountIdExist(logger *logrus.Entry, accountId
 //   - A pointer to the newly created Transaction entity.
 //   - An encountered Error.
 
-func (core *TransactionCore) CreateTransaction(logger *logrus.Entry, transactionPayload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+func (core *TransactionCore) CreateTransaction(ctx context.Context, transactionPayload *entityCoreV1Package.CreateTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("CreateTransaction method called in transaction core layer.")
 
 	// // 1. Validate the account_id exist in db
-	err := core.CheckAccountIdExist(logger, transactionPayload.AccountId, tx)
+	account, err := core.CheckAccountIdExist(ctx, transactionPayload.AccountId, tx)
 	if err != nil {
 		logger.Errorf("Error occured while doing validation on account id: %s", err.Error())
 		return &entityDbV1Package.Transaction{}, err
 	}
 
-	// 2. Map the payload to a DB entity
+	// 2. Map the payload to a DB entity; its event time is fixed now, as the coefficient and fx rate depend on it.
 	transaction := mapperV1Package.TransactionMapper(transactionPayload)
+	transaction.CreatedAt = time.Now()
 
 	// 3. Compute the final transaction amount
-	amount, err := core.FinalTransactionAmount(logger, transaction.Amount, transaction.OperationTypeId, tx)
+	err = core.FinalTransactionAmount(ctx, transaction, tx)
 	if err != nil {
 		logger.Errorf("Error occured while computing final transaction amount by operation type: %s", err.Error())
 		return transaction, err
 	}
-	transaction.Amount = amount
 
-	// 4. Persist the transaction in the DB
-	err = core.repoV1.CreateTransaction(logger, transaction, tx)
+	// 4. Convert it to the account currency.
+	err = core.ConvertCurrency(ctx, transaction, account.Currency, tx)
+	if err != nil {
+		logger.Errorf("Error occured while converting transaction amount: %s", err.Error())
+		return transaction, err
+	}
+
+	// 5. Evaluate fraud rules; the decision is stored with the transaction whatever its outcome.
+	err = core.EvaluateFraud(ctx, transaction, tx)
+	if err != nil {
+		logger.Errorf("Error occured while evaluating fraud rules: %s", err.Error())
+		return transaction, err
+	}
+
+	// 6. Declined transactions are stored for audit but never move the credit limit or balances.
+	transaction.Balance = transaction.Amount
+	if transaction.FraudDecision != fraudConstantPackage.DECISION_DECLINE {
+		err = core.ApplyCreditLimit(ctx, transaction, tx)
+		if err != nil {
+			logger.Errorf("Error occured while applying credit limit: %s", err.Error())
+			return transaction, err
+		}
+
+		// 7. Discharge credits against the oldest outstanding debits.
+		err = core.DischargeBalance(ctx, transaction, tx)
+		if err != nil {
+			logger.Errorf("Error occured while discharging balance: %s", err.Error())
+			return transaction, err
+		}
+	}
+
+	// 8. Persist the transaction in the DB
+	err = core.repoV1.CreateTransaction(ctx, transaction, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting transaction: %s", err.Error())
+		return transaction, err
+	}
+
+	// 9. The purchase carries the full amount against the limit; its installments only schedule the payments.
+	if transaction.InstallmentCount > 0 && transaction.FraudDecision != fraudConstantPackage.DECISION_DECLINE {
+		err = core.ScheduleInstallments(ctx, transaction, tx)
+		if err != nil {
+			logger.Errorf("Error occured while scheduling installments: %s", err.Error())
+			return transaction, err
+		}
+	}
+
+	// 10. Fees are posted once the transaction has an ID to link them to.
+	if transaction.FraudDecision != fraudConstantPackage.DECISION_DECLINE {
+		err = core.ChargeFees(ctx, transaction, account.Tier, tx)
+		if err != nil {
+			logger.Errorf("Error occured while charging fees: %s", err.Error())
+			return transaction, err
+		}
+	}
+
+	// 11. Let the other services know about the transaction.
+	err = core.publishTransaction(ctx, transaction, tx)
 	return transaction, err
 }
+
+// publishTransaction publishes TransactionDeclined for a transaction declined by the fraud rules,
+// TransactionCreated for any other.
+func (core *TransactionCore) publishTransaction(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	if transaction.FraudDecision == fraudConstantPackage.DECISION_DECLINE {
+		firedRules := []string{}
+		if transaction.FraudRules != "" {
+			firedRules = strings.Split(transaction.FraudRules, ",")
+		}
+		return core.eventBus.Publish(ctx, &eventBusPackageV1.TransactionDeclined{
+			TransactionId:   transaction.ID,
+			AccountId:       transaction.AccountId,
+			OperationTypeId: transaction.OperationTypeId,
+			Amount:          transaction.Amount,
+			Currency:        transaction.Currency,
+			FraudRules:      firedRules,
+			OccurredAt:      transaction.CreatedAt,
+		}, tx)
+	}
+	return core.eventBus.Publish(ctx, &eventBusPackageV1.TransactionCreated{
+		TransactionId:   transaction.ID,
+		AccountId:       transaction.AccountId,
+		OperationTypeId: transaction.OperationTypeId,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		FraudDecision:   transaction.FraudDecision,
+		OccurredAt:      transaction.CreatedAt,
+	}, tx)
+}
+
+// PostCapturedTransaction posts the transaction settling a captured authorization hold.
+//
+// Steps:
+//  1. Map the payload to a DB entity, keeping the fraud decision taken at authorization time.
+//  2. Persist the transaction. The hold already drew the credit limit down, so the limit is
+//     left untouched, and authorized amounts are debits, so there is nothing to discharge.
+//  3. Publish TransactionCreated.
+//
+// Parameters:
+//   - capturePayload: account, operation type, final signed amount and fraud decision of the capture.
+//   - tx:             db txn, shared with the authorization update.
+//
+// Returns:
+//   - A pointer to the newly created Transaction entity.
+//   - An encountered Error.
+func (core *TransactionCore) PostCapturedTransaction(ctx context.Context, capturePayload *entityCoreV1Package.CapturedTransactionPayload, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("PostCapturedTransaction method called in transaction core layer.")
+
+	transaction := mapperV1Package.CapturedTransactionMapper(capturePayload)
+	err := core.repoV1.CreateTransaction(ctx, transaction, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting captured transaction: %s", err.Error())
+		return nil, err
+	}
+	err = core.publishTransaction(ctx, transaction, tx)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// GetTransaction fetches a transaction by its ID, along with its installments and fees if it has any.
+//
+// Parameters:
+//   - transactionId: ID of the transaction.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The transaction.
+//   - error: a not found Error if it does not exist, or any other encountered Error.
+func (core *TransactionCore) GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetTransaction method called in transaction core layer.")
+	transaction, err := core.repoV1.GetTransaction(ctx, transactionId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching transaction: %s", err.Error())
+		return transaction, err
+	}
+	if transaction.InstallmentCount > 0 {
+		transaction.Installments, err = core.repoV1.GetInstallments(ctx, transactionId, tx)
+		if err != nil {
+			logger.Errorf("Error occured while fetching installments: %s", err.Error())
+			return transaction, err
+		}
+	}
+	if transaction.ParentTransactionId == nil {
+		transaction.Fees, err = core.repoV1.GetFeeTransactions(ctx, transactionId, tx)
+		if err != nil {
+			logger.Errorf("Error occured while fetching fees: %s", err.Error())
+		}
+	}
+	return transaction, err
+}
+
+// ListTransactions returns one page of the transactions matching a filter.
+//
+// Steps:
+//  1. Fetch one row more than the page size to know whether another page follows.
+//  2. Trim the extra row and point the next cursor at the last returned transaction.
+//
+// Parameters:
+//   - filter: listing filter, sort order, page size and cursor.
+//   - tx:     db txn.
+//
+// Returns:
+//   - The page, with a nil Next cursor on the last page.
+//   - error: an encountered Error.
+func (core *TransactionCore) ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) (*entityCoreV1Package.TransactionPage, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ListTransactions method called in transaction core layer.")
+
+	// 1. Fetch one extra row.
+	pageSize := filter.Limit
+	query := *filter
+	query.Limit = pageSize + 1
+	transactions, err := core.repoV1.ListTransactions(ctx, &query, tx)
+	if err != nil {
+		logger.Errorf("Error occured while listing transactions: %s", err.Error())
+		return nil, err
+	}
+
+	// 2. Build the page.
+	page := &entityCoreV1Package.TransactionPage{Transactions: transactions}
+	if len(transactions) > pageSize {
+		page.Transactions = transactions[:pageSize]
+		last := page.Transactions[pageSize-1]
+		page.Next = &entityCoreV1Package.TransactionCursor{CreatedAt: last.CreatedAt, Id: last.ID}
+	}
+	return page, nil
+}
+
+// GetPostedTransactions returns the transactions that moved the account within [from, to).
+// Declined transactions are left out, as they never affected the account.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - from:      start of the period, inclusive.
+//   - to:        end of the period, exclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The posted transactions, oldest first.
+//   - error: an encountered Error.
+func (core *TransactionCore) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedTransactions method called in transaction core layer.")
+	transactions, err := core.repoV1.GetPostedTransactions(ctx, accountId, from, to, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching posted transactions: %s", err.Error())
+	}
+	return transactions, err
+}
+
+// GetPostedBalance returns the account balance, as the sum of its posted transaction amounts, before a time.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - before:    exclusive upper bound on the transaction creation time.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The balance.
+//   - error: an encountered Error.
+func (core *TransactionCore) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedBalance method called in transaction core layer.")
+	balance, err := core.repoV1.GetPostedBalance(ctx, accountId, before, tx)
+	if err != nil {
+		logger.Errorf("Error occured while computing posted balance: %s", err.Error())
+	}
+	return balance, err
+}
+
+// CountOperationTypeTransactions returns how many transactions, declined ones included, reference an operation type.
+//
+// Parameters:
+//   - operationTypeId: ID of the operation type.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The number of transactions.
+//   - error: an encountered Error.
+func (core *TransactionCore) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CountOperationTypeTransactions method called in transaction core layer.")
+	count, err := core.repoV1.CountOperationTypeTransactions(ctx, operationTypeId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while counting transactions of operation type: %s", err.Error())
+	}
+	return count, err
+}
+
+// ReverseTransaction reverses all or part of a transaction.
+//
+// Steps:
+//  1. Fetch and lock the original, so concurrent reversals cannot both pass the amount check.
+//  2. Reject reversals of declined transactions, of reversals and of fully reversed transactions,
+//     and amounts finer than the account currency's minor unit or above what is left to reverse.
+//     Without an amount, everything left is reversed.
+//  3. Build the compensating transaction, with the opposite sign and linked to the original.
+//  4. Give back the credit limit effect of the reversed amount.
+//  5. Settle the reversal against the original's own outstanding balance first; a reversal of a debit
+//     that was already paid down discharges other debts (FIFO) and keeps any leftover as its balance.
+//  6. Cancel or shrink the pending installments of an installment purchase, latest first.
+//  7. Persist the original's reversed amount, status and balance, and the reversal.
+//
+// Parameters:
+//   - transactionId: ID of the transaction to reverse.
+//   - payload:       amount to reverse, positive; nil for the whole remaining amount.
+//   - tx:            db txn, so every effect commits or rolls back together.
+//
+// Returns:
+//   - The reversal and the updated original.
+//   - An encountered Error.
+func (core *TransactionCore) ReverseTransaction(ctx context.Context, transactionId int, payload *entityCoreV1Package.ReverseTransactionPayload, tx *gorm.DB) (*entityCoreV1Package.TransactionReversal, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ReverseTransaction method called in transaction core layer.")
+
+	// 1. Fetch and lock the original.
+	original, err := core.repoV1.GetTransactionForUpdate(ctx, transactionId, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching transaction: %s", err.Error())
+		return nil, err
+	}
+
+	// 2. Validate the reversal.
+	switch {
+	case original.OriginalTransactionId != nil:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.TRANSACTION_NOT_REVERSIBLE, fmt.Sprintf("transaction_id: %d is a reversal and cannot be reversed", transactionId))
+	case original.FraudDecision == fraudConstantPackage.DECISION_DECLINE:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.TRANSACTION_NOT_REVERSIBLE, fmt.Sprintf("transaction_id: %d was declined and cannot be reversed", transactionId))
+	case original.Status == constantPackage.STATUS_REVERSED:
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.TRANSACTION_NOT_REVERSIBLE, fmt.Sprintf("transaction_id: %d is already fully reversed", transactionId))
+	}
+	remaining := original.Amount.Abs().Sub(original.ReversedAmount)
+	amount := remaining
+	if payload.Amount != nil {
+		amount = *payload.Amount
+		if err := utilMoneyV1.ValidateScale(amount, original.Currency); err != nil {
+			logger.Errorf("Error: %s", err.Error())
+			return nil, utilErrorsV1.NewBadRequestError(errorConstantPackage.VALIDATION_FAILED, err.Error())
+		}
+	}
+	if amount.Cmp(remaining) > 0 {
+		logger.Errorf("Error: reversal of %s exceeds the %s left to reverse", amount, remaining)
+		return nil, utilErrorsV1.NewUnprocessableError(errorConstantPackage.REVERSAL_EXCEEDS_AMOUNT, fmt.Sprintf("reversal of %s exceeds the %s left to reverse on transaction_id: %d", amount, remaining, transactionId))
+	}
+
+	// 3. Build the compensating transaction.
+	signedAmount := amount
+	if original.Amount.Sign() > 0 {
+		signedAmount = amount.Neg()
+	}
+	reversal := mapperV1Package.ReversalTransactionMapper(original, signedAmount)
+
+	// 4. Give back the credit limit effect.
+	err = core.ApplyCreditLimit(ctx, reversal, tx)
+	if err != nil {
+		logger.Errorf("Error occured while applying credit limit: %s", err.Error())
+		return nil, err
+	}
+
+	// 5. Settle against the original's outstanding balance first.
+	settleAgainstOriginal(original, reversal)
+
+	// 6. Shrink the installment schedule.
+	if original.InstallmentCount > 0 {
+		err = core.reduceInstallments(ctx, original, amount, tx)
+		if err != nil {
+			logger.Errorf("Error occured while reducing installments: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	// 7. Persist the original, then discharge any leftover credit and persist the reversal.
+	original.ReversedAmount = original.ReversedAmount.Add(amount)
+	original.Status = constantPackage.STATUS_PARTIALLY_REVERSED
+	if original.ReversedAmount.Cmp(original.Amount.Abs()) == 0 {
+		original.Status = constantPackage.STATUS_REVERSED
+	}
+	err = core.repoV1.UpdateTransactionReversal(ctx, original, tx)
+	if err != nil {
+		logger.Errorf("Error occured while updating reversed transaction: %s", err.Error())
+		return nil, err
+	}
+	if reversal.Balance.Sign() > 0 {
+		err = core.dischargeOutstanding(ctx, reversal, tx)
+		if err != nil {
+			logger.Errorf("Error occured while discharging balance: %s", err.Error())
+			return nil, err
+		}
+	}
+	err = core.repoV1.CreateTransaction(ctx, reversal, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting reversal: %s", err.Error())
+		return nil, err
+	}
+	return &entityCoreV1Package.TransactionReversal{Reversal: reversal, Original: original}, nil
+}
+
+// settleAgainstOriginal nets the reversal balance against what is still outstanding on the original,
+// moving both balances toward zero by the same amount.
+func settleAgainstOriginal(original *entityDbV1Package.Transaction, reversal *entityDbV1Package.Transaction) {
+	if original.Balance.Sign() != original.Amount.Sign() {
+		return
+	}
+	offset := utilMoneyV1.Min(reversal.Balance.Abs(), original.Balance.Abs())
+	if original.Balance.Sign() < 0 {
+		original.Balance = original.Balance.Add(offset)
+		reversal.Balance = reversal.Balance.Sub(offset)
+	} else {
+		original.Balance = original.Balance.Sub(offset)
+		reversal.Balance = reversal.Balance.Add(offset)
+	}
+}
+
+// reduceInstallments removes amount from the pending installments of a purchase, latest first.
+// An installment reduced to nothing is cancelled and keeps its amount for the record.
+func (core *TransactionCore) reduceInstallments(ctx context.Context, original *entityDbV1Package.Transaction, amount utilMoneyV1.Amount, tx *gorm.DB) error {
+	installments, err := core.repoV1.GetInstallments(ctx, int(original.ID), tx)
+	if err != nil {
+		return err
+	}
+	for i := len(installments) - 1; i >= 0 && amount.Sign() > 0; i-- {
+		installment := installments[i]
+		if installment.Status != constantPackage.INSTALLMENT_STATUS_PENDING {
+			continue
+		}
+		reduction := utilMoneyV1.Min(amount, installment.Amount.Abs())
+		amount = amount.Sub(reduction)
+		if reduction.Cmp(installment.Amount.Abs()) == 0 {
+			installment.Status = constantPackage.INSTALLMENT_STATUS_CANCELLED
+		} else if installment.Amount.Sign() < 0 {
+			installment.Amount = installment.Amount.Add(reduction)
+		} else {
+			installment.Amount = installment.Amount.Sub(reduction)
+		}
+		if err := core.repoV1.UpdateInstallment(ctx, installment, tx); err != nil {
+			return err
+		}
+	}
+	original.Installments = installments
+	return nil
+}
+
+// ScheduleInstallments builds and persists the installment schedule of a purchase.
+//
+// Steps:
+//  1. Split the amount in InstallmentCount parts to the minor unit of the account currency; the leftover
+//     minor units go to the first installments, one each, so the same purchase always yields the same schedule.
+//  2. Installment n is due n months after the purchase date, on the same day of the month,
+//     or on the last day of shorter months.
+//  3. Persist the installments, linked to the purchase.
+//
+// Parameters:
+//   - transaction: the persisted purchase, with its ID, creation time and final amount.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an unprocessable Error if the amount cannot be split, or any other encountered Error.
+func (core *TransactionCore) ScheduleInstallments(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ScheduleInstallments method called in transaction core layer.")
+
+	// 1. Split the amount.
+	amounts, err := utilMoneyV1.Split(transaction.Amount, transaction.InstallmentCount, transaction.Currency)
+	if err != nil {
+		logger.Errorf("Error occured while splitting amount in installments: %s", err.Error())
+		return utilErrorsV1.NewUnprocessableError(errorConstantPackage.INVALID_INSTALLMENT_PLAN, err.Error())
+	}
+
+	// 2. Compute the due dates.
+	purchaseDate := transaction.CreatedAt.UTC()
+	installments := make([]*entityDbV1Package.Installment, 0, len(amounts))
+	for i, amount := range amounts {
+		installments = append(installments, &entityDbV1Package.Installment{
+			TransactionId: transaction.ID,
+			AccountId:     transaction.AccountId,
+			Number:        i + 1,
+			Amount:        amount,
+			DueDate:       addMonthsClamped(purchaseDate, i+1),
+			Status:        constantPackage.INSTALLMENT_STATUS_PENDING,
+		})
+	}
+
+	// 3. Persist them.
+	err = core.repoV1.CreateInstallments(ctx, installments, tx)
+	if err != nil {
+		logger.Errorf("Error occured while persisting installments: %s", err.Error())
+		return err
+	}
+	transaction.Installments = installments
+	return nil
+}
+
+// ListUpcomingInstallments returns the installments the account still has to pay.
+//
+// Steps:
+//  1. Verify the account exists via the account service; an unknown account is a not found Error.
+//  2. Fetch the pending installments due on or after from, soonest first.
+//
+// Parameters:
+//   - accountId: ID of the account.
+//   - from:      earliest due date, inclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The upcoming installments.
+//   - error: an encountered Error.
+func (core *TransactionCore) ListUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ListUpcomingInstallments method called in transaction core layer.")
+	if _, err := core.accountClient.GetAccount(ctx, accountId, tx); err != nil {
+		logger.Errorf("Error while fetching account data from account service: %s", err.Error())
+		return nil, err
+	}
+	installments, err := core.repoV1.GetUpcomingInstallments(ctx, accountId, from, tx)
+	if err != nil {
+		logger.Errorf("Error occured while fetching upcoming installments: %s", err.Error())
+	}
+	return installments, err
+}
+
+// addMonthsClamped returns the date months after date, keeping the day of the month
+// unless the target month is shorter, in which case its last day is used.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, time.UTC)
+}
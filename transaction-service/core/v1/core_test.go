@@ -2,13 +2,27 @@ package transaction_core_v1
 
 import (
 	accountCoreV1Package "anti-fraud/account-service/core/v1"
+	accountConstantPackage "anti-fraud/constants/account"
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/fraud"
+	fraudCoreV1Package "anti-fraud/fraud-service/core/v1"
+	fxCoreV1Package "anti-fraud/fx-service/core/v1"
 	accountClientPackageV1 "anti-fraud/mediator-service/account-service-client"
+	eventBusPackageV1 "anti-fraud/mediator-service/event-bus"
+	fraudClientPackageV1 "anti-fraud/mediator-service/fraud-service-client"
+	fxClientPackageV1 "anti-fraud/mediator-service/fx-service-client"
+	operationClientPackageV1 "anti-fraud/mediator-service/operation-service-client"
 	opsCoreV1Package "anti-fraud/operation-service/core/v1"
 	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
+	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -21,20 +35,112 @@ type MockTransactionRepository struct {
 	mock.Mock
 }
 
-func (m *MockTransactionRepository) CreateTransaction(logger *logrus.Entry, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
 	args := m.Called(transaction, tx)
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) GetOutstandingTransactions(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(accountId, tx)
+	transactions, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionRepository) UpdateTransactionBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	args := m.Called(transactionId, tx)
+	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *MockTransactionRepository) ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(filter, tx)
+	transactions, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(accountId, from, to, tx)
+	transactions, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return transactions, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	args := m.Called(accountId, before, tx)
+	return args.Get(0).(utilMoneyV1.Amount), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	args := m.Called(operationTypeId, tx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTransactionRepository) CreateInstallments(ctx context.Context, installments []*entityDbV1Package.Installment, tx *gorm.DB) error {
+	args := m.Called(installments, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetFeeTransactions(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	args := m.Called(transactionId, tx)
+	fees, _ := args.Get(0).([]*entityDbV1Package.Transaction)
+	return fees, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetInstallments(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	args := m.Called(transactionId, tx)
+	installments, _ := args.Get(0).([]*entityDbV1Package.Installment)
+	return installments, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	args := m.Called(accountId, from, tx)
+	installments, _ := args.Get(0).([]*entityDbV1Package.Installment)
+	return installments, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetTransactionForUpdate(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	args := m.Called(transactionId, tx)
+	transaction, _ := args.Get(0).(*entityDbV1Package.Transaction)
+	return transaction, args.Error(1)
+}
+
+func (m *MockTransactionRepository) UpdateTransactionReversal(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	args := m.Called(transaction, tx)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) UpdateInstallment(ctx context.Context, installment *entityDbV1Package.Installment, tx *gorm.DB) error {
+	args := m.Called(installment, tx)
+	return args.Error(0)
+}
+
 type MockOperationClient struct {
 	mock.Mock
 }
 
-func (m *MockOperationClient) GetOperationCoefficient(logger *logrus.Entry, operationTypeID int, tx *gorm.DB) (int, error) {
-	args := m.Called(operationTypeID, tx)
+func (m *MockOperationClient) GetOperationCoefficient(ctx context.Context, operationTypeID int, at time.Time, tx *gorm.DB) (int, error) {
+	args := m.Called(operationTypeID, at, tx)
 	coef, _ := args.Get(0).(int)
 	return coef, args.Error(1)
 }
+func (m *MockOperationClient) ComputeOperation(ctx context.Context, input *operationClientPackageV1.OperationInput, tx *gorm.DB) (*operationClientPackageV1.OperationResult, error) {
+	args := m.Called(input, tx)
+	result, _ := args.Get(0).(*operationClientPackageV1.OperationResult)
+	return result, args.Error(1)
+}
+func (m *MockOperationClient) ComputeFees(ctx context.Context, input *operationClientPackageV1.FeeInput, tx *gorm.DB) ([]*operationClientPackageV1.Fee, error) {
+	args := m.Called(input, tx)
+	fees, _ := args.Get(0).([]*operationClientPackageV1.Fee)
+	return fees, args.Error(1)
+}
+
+func (m *MockOperationClient) CacheStats() operationClientPackageV1.OperationCacheStats {
+	return operationClientPackageV1.OperationCacheStats{}
+}
 func (m *MockOperationClient) SetupCore(core opsCoreV1Package.IOperationCore) {
 	m.Called(core)
 }
@@ -43,16 +149,64 @@ type MockAccountClient struct {
 	mock.Mock
 }
 
-func (m *MockAccountClient) GetAccount(logger *logrus.Entry, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
+func (m *MockAccountClient) GetAccount(ctx context.Context, accountId int, tx *gorm.DB) (*accountClientPackageV1.Account, error) {
 	args := m.Called(accountId, tx)
 	acc, _ := args.Get(0).(*accountClientPackageV1.Account)
 	return acc, args.Error(1)
 }
 
+func (m *MockAccountClient) UpdateAvailableCreditLimit(ctx context.Context, accountId int, delta utilMoneyV1.Amount, tx *gorm.DB) error {
+	args := m.Called(accountId, delta, tx)
+	return args.Error(0)
+}
+
 func (m *MockAccountClient) SetupCore(core accountCoreV1Package.IAccountCore) {
 	m.Called(core)
 }
 
+type MockFraudClient struct {
+	mock.Mock
+}
+
+func (m *MockFraudClient) EvaluateTransaction(ctx context.Context, check *fraudClientPackageV1.TransactionCheck, tx *gorm.DB) (*fraudClientPackageV1.Decision, error) {
+	args := m.Called(check, tx)
+	decision, _ := args.Get(0).(*fraudClientPackageV1.Decision)
+	return decision, args.Error(1)
+}
+
+func (m *MockFraudClient) SetupCore(core fraudCoreV1Package.IFraudCore) {
+	m.Called(core)
+}
+
+type MockFxClient struct {
+	mock.Mock
+}
+
+func (m *MockFxClient) GetRate(ctx context.Context, baseCurrency string, quoteCurrency string, at time.Time, tx *gorm.DB) (utilMoneyV1.Rate, error) {
+	args := m.Called(baseCurrency, quoteCurrency, at, tx)
+	rate, _ := args.Get(0).(utilMoneyV1.Rate)
+	return rate, args.Error(1)
+}
+
+func (m *MockFxClient) SetupCore(core fxCoreV1Package.IFxCore) {
+	m.Called(core)
+}
+
+var _ fxClientPackageV1.IFxClient = (*MockFxClient)(nil)
+
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Subscribe(eventName string, delivery string, subscriber string, handler eventBusPackageV1.Handler) {
+	m.Called(eventName, delivery, subscriber)
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event eventBusPackageV1.Event, tx *gorm.DB) error {
+	args := m.Called(event, tx)
+	return args.Error(0)
+}
+
 //-------------------------------------------//
 // 2. Setup Helpers
 //-------------------------------------------//
@@ -65,17 +219,33 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
-func setupTestCore(t *testing.T) (*TransactionCore, *MockTransactionRepository, *MockOperationClient, *MockAccountClient, *gorm.DB) {
+func setupTestCore(t *testing.T) (*TransactionCore, *MockTransactionRepository, *MockOperationClient, *MockAccountClient, *MockFraudClient, *gorm.DB) {
+	core, repoMock, opMock, accMock, fraudMock, _, db := setupTestCoreWithFx(t)
+	return core, repoMock, opMock, accMock, fraudMock, db
+}
+
+func setupTestCoreWithFx(t *testing.T) (*TransactionCore, *MockTransactionRepository, *MockOperationClient, *MockAccountClient, *MockFraudClient, *MockFxClient, *gorm.DB) {
 	logger := logrus.New()
 	db := setupTestDB(t)
 
 	repoMock := new(MockTransactionRepository)
 	opMock := new(MockOperationClient)
 	accMock := new(MockAccountClient)
+	fraudMock := new(MockFraudClient)
+	fxMock := new(MockFxClient)
+	busMock := new(MockEventBus)
+	busMock.On("Publish", mock.Anything, mock.Anything).Return(nil)
 
-	core := NewTransactionCore(repoMock, logger, opMock, accMock)
+	core := NewTransactionCore(repoMock, logger, opMock, accMock, fraudMock, fxMock, busMock)
 
-	return core, repoMock, opMock, accMock, db
+	return core, repoMock, opMock, accMock, fraudMock, fxMock, db
+}
+
+// operationInput matches the input submitted to the operation service for an operation type and amount.
+func operationInput(operationTypeId int, amount string) interface{} {
+	return mock.MatchedBy(func(input *operationClientPackageV1.OperationInput) bool {
+		return input.OperationTypeId == operationTypeId && input.Amount == utilMoneyV1.MustParse(amount)
+	})
 }
 
 //-------------------------------------------//
@@ -83,28 +253,48 @@ func setupTestCore(t *testing.T) (*TransactionCore, *MockTransactionRepository,
 //-------------------------------------------//
 
 func TestFinalTransactionAmount_Success(t *testing.T) {
-	core, _, opMock, _, db := setupTestCore(t)
+	core, _, opMock, _, _, db := setupTestCore(t)
 
-	opMock.On("GetOperationCoefficient", 1, mock.Anything).
-		Return(3, nil)
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	opMock.On("ComputeOperation", &operationClientPackageV1.OperationInput{AccountId: 1, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("100"), InstallmentCount: 3, At: createdAt}, mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-100"), Coefficient: -1, InstallmentCount: 3}, nil)
 
-	amount, err := core.FinalTransactionAmount(logrus.NewEntry(logrus.New()), 100, 1, db)
+	transaction := &entityDbV1Package.Transaction{Model: gorm.Model{CreatedAt: createdAt}, AccountId: 1, OperationTypeId: 2, InputAmount: utilMoneyV1.MustParse("100"), InstallmentCount: 3}
+	err := core.FinalTransactionAmount(context.Background(), transaction, db)
 	assert.NoError(t, err)
-	assert.Equal(t, 300.0, amount)
+	assert.Equal(t, utilMoneyV1.MustParse("-100"), transaction.OriginalAmount)
+	assert.Equal(t, -1, transaction.Coefficient)
+	assert.Equal(t, 3, transaction.InstallmentCount)
 
 	opMock.AssertExpectations(t)
 }
 
 func TestFinalTransactionAmount_Error(t *testing.T) {
-	core, _, opMock, _, db := setupTestCore(t)
+	core, _, opMock, _, _, db := setupTestCore(t)
 
-	opMock.On("GetOperationCoefficient", 2, mock.Anything).
-		Return(0.0, errors.New("operation client error"))
+	opMock.On("ComputeOperation", operationInput(2, "100"), mock.Anything).
+		Return(nil, errors.New("operation client error"))
 
-	amount, err := core.FinalTransactionAmount(logrus.NewEntry(logrus.New()), 100, 2, db)
+	transaction := &entityDbV1Package.Transaction{OperationTypeId: 2, InputAmount: utilMoneyV1.MustParse("100"), OriginalAmount: utilMoneyV1.MustParse("100")}
+	err := core.FinalTransactionAmount(context.Background(), transaction, db)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "operation client error")
-	assert.Equal(t, 100.0, amount)
+	assert.Equal(t, utilMoneyV1.MustParse("100"), transaction.OriginalAmount)
+
+	opMock.AssertExpectations(t)
+}
+
+func TestFinalTransactionAmount_UnknownOperationType(t *testing.T) {
+	core, _, opMock, _, _, db := setupTestCore(t)
+
+	opMock.On("ComputeOperation", operationInput(99, "100"), mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.OPERATION_TYPE_NOT_FOUND, "operation id: 99 not found in database"))
+
+	err := core.FinalTransactionAmount(context.Background(), &entityDbV1Package.Transaction{OperationTypeId: 99, InputAmount: utilMoneyV1.MustParse("100")}, db)
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.OPERATION_TYPE_NOT_FOUND, appErr.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
 
 	opMock.AssertExpectations(t)
 }
@@ -114,37 +304,68 @@ func TestFinalTransactionAmount_Error(t *testing.T) {
 //-------------------------------------------//
 
 func TestCheckAccountIdExist_Success(t *testing.T) {
-	core, _, _, accMock, db := setupTestCore(t)
+	core, _, _, accMock, _, db := setupTestCore(t)
 
 	accMock.On("GetAccount", 123, mock.Anything).
-		Return(&accountClientPackageV1.Account{Id: 123}, nil)
+		Return(&accountClientPackageV1.Account{Id: 123, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
 
-	err := core.CheckAccountIdExist(logrus.NewEntry(logrus.New()), 123, db)
+	account, err := core.CheckAccountIdExist(context.Background(), 123, db)
 	assert.NoError(t, err)
+	assert.Equal(t, "USD", account.Currency)
 
 	accMock.AssertExpectations(t)
 }
 
 func TestCheckAccountIdExist_NotFound(t *testing.T) {
-	core, _, _, accMock, db := setupTestCore(t)
+	core, _, _, accMock, _, db := setupTestCore(t)
 
 	accMock.On("GetAccount", 456, mock.Anything).
 		Return(&accountClientPackageV1.Account{Id: 0}, nil)
 
-	err := core.CheckAccountIdExist(logrus.NewEntry(logrus.New()), 456, db)
+	_, err := core.CheckAccountIdExist(context.Background(), 456, db)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "account_id: 456 not found")
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.ACCOUNT_NOT_FOUND, appErr.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+
+	accMock.AssertExpectations(t)
+}
+
+func TestCheckAccountIdExist_Blocked(t *testing.T) {
+	core, _, _, accMock, _, db := setupTestCore(t)
+
+	accMock.On("GetAccount", 123, mock.Anything).
+		Return(&accountClientPackageV1.Account{Id: 123, Status: accountConstantPackage.STATUS_BLOCKED}, nil)
+
+	_, err := core.CheckAccountIdExist(context.Background(), 123, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "account_id: 123 is blocked")
+
+	accMock.AssertExpectations(t)
+}
+
+func TestCheckAccountIdExist_Closed(t *testing.T) {
+	core, _, _, accMock, _, db := setupTestCore(t)
+
+	accMock.On("GetAccount", 123, mock.Anything).
+		Return(&accountClientPackageV1.Account{Id: 123, Status: accountConstantPackage.STATUS_CLOSED}, nil)
+
+	_, err := core.CheckAccountIdExist(context.Background(), 123, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "account_id: 123 is closed")
 
 	accMock.AssertExpectations(t)
 }
 
 func TestCheckAccountIdExist_Error(t *testing.T) {
-	core, _, _, accMock, db := setupTestCore(t)
+	core, _, _, accMock, _, db := setupTestCore(t)
 
 	accMock.On("GetAccount", 789, mock.Anything).
 		Return((*accountClientPackageV1.Account)(nil), errors.New("db error"))
 
-	err := core.CheckAccountIdExist(logrus.NewEntry(logrus.New()), 789, db)
+	_, err := core.CheckAccountIdExist(context.Background(), 789, db)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "db error")
 
@@ -156,47 +377,66 @@ func TestCheckAccountIdExist_Error(t *testing.T) {
 //-------------------------------------------//
 
 func TestCreateTransaction_Success(t *testing.T) {
-	core, repoMock, opMock, accMock, db := setupTestCore(t)
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
 
 	payload := &entityCoreV1Package.CreateTransactionPayload{
 		AccountId:       111,
 		OperationTypeId: 2,
-		Amount:          1000.0,
+		Amount:          utilMoneyV1.MustParse("-1000"),
 	}
 
-	accMock.On("GetAccount", 111, mock.Anything).Return(&accountClientPackageV1.Account{Id: 111}, nil)
+	accMock.On("GetAccount", 111, mock.Anything).Return(&accountClientPackageV1.Account{Id: 111, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+
+	opMock.On("ComputeOperation", operationInput(2, "-1000"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("1000"), Coefficient: 1}, nil)
+
+	fraudMock.On("EvaluateTransaction", &fraudClientPackageV1.TransactionCheck{AccountId: 111, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("1000"), Currency: "USD"}, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_APPROVE, FiredRules: []string{}}, nil)
+
+	accMock.On("UpdateAvailableCreditLimit", 111, utilMoneyV1.MustParse("1000"), mock.Anything).Return(nil)
 
-	opMock.On("GetOperationCoefficient", 2, mock.Anything).Return(1, nil)
+	repoMock.On("GetOutstandingTransactions", 111, mock.Anything).Return([]*entityDbV1Package.Transaction{}, nil)
+
+	opMock.On("ComputeFees", &operationClientPackageV1.FeeInput{OperationTypeId: 2, Currency: "USD", Amount: utilMoneyV1.MustParse("1000")}, mock.Anything).
+		Return([]*operationClientPackageV1.Fee{}, nil)
 
 	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).
 		Return(nil).
 		Run(func(args mock.Arguments) {
 			tr := args.Get(0).(*entityDbV1Package.Transaction)
-			assert.Equal(t, 1000.0, tr.Amount, "expected final transaction amount to be 1000")
+			assert.Equal(t, utilMoneyV1.MustParse("1000"), tr.Amount, "expected final transaction amount to be 1000")
 			assert.Equal(t, 2, tr.OperationTypeId)
 			assert.Equal(t, 111, tr.AccountId)
+			assert.Equal(t, constantPackage.DECISION_APPROVE, tr.FraudDecision)
+			assert.Equal(t, utilMoneyV1.MustParse("1000"), tr.Balance, "expected undischarged credit to stay on the new transaction")
+			assert.Equal(t, utilMoneyV1.MustParse("-1000"), tr.InputAmount, "expected the input to be stored as submitted")
+			assert.Equal(t, 1, tr.Coefficient)
 		})
 
 	tx := db.Begin()
 	defer tx.Rollback()
 
-	transaction, err := core.CreateTransaction(logrus.NewEntry(logrus.New()), payload, tx)
+	transaction, err := core.CreateTransaction(context.Background(), payload, tx)
 	assert.NoError(t, err)
 	assert.NotNil(t, transaction)
-	assert.Equal(t, 1000.0, transaction.Amount)
+	assert.Equal(t, utilMoneyV1.MustParse("1000"), transaction.Amount)
+	core.eventBus.(*MockEventBus).AssertCalled(t, "Publish", mock.MatchedBy(func(event *eventBusPackageV1.TransactionCreated) bool {
+		return event.AccountId == 111 && event.Amount.Cmp(utilMoneyV1.MustParse("1000")) == 0 && event.FraudDecision == constantPackage.DECISION_APPROVE
+	}), tx)
 
 	repoMock.AssertExpectations(t)
 	opMock.AssertExpectations(t)
 	accMock.AssertExpectations(t)
+	fraudMock.AssertExpectations(t)
 }
 
 func TestCreateTransaction_AccountNotFound(t *testing.T) {
-	core, repoMock, _, accMock, db := setupTestCore(t)
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
 
 	payload := &entityCoreV1Package.CreateTransactionPayload{
 		AccountId:       999,
 		OperationTypeId: 2,
-		Amount:          100.0,
+		Amount:          utilMoneyV1.MustParse("100"),
 	}
 
 	accMock.On("GetAccount", 999, mock.Anything).
@@ -205,10 +445,10 @@ func TestCreateTransaction_AccountNotFound(t *testing.T) {
 	tx := db.Begin()
 	defer tx.Rollback()
 
-	transaction, err := core.CreateTransaction(logrus.NewEntry(logrus.New()), payload, tx)
+	transaction, err := core.CreateTransaction(context.Background(), payload, tx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "account_id: 999 not found")
-	assert.Equal(t, 0.0, transaction.Amount, "expect zero transaction returned or partial data")
+	assert.Equal(t, utilMoneyV1.MustParse("0"), transaction.Amount, "expect zero transaction returned or partial data")
 
 	repoMock.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
 
@@ -216,24 +456,24 @@ func TestCreateTransaction_AccountNotFound(t *testing.T) {
 }
 
 func TestCreateTransaction_FinalAmountError(t *testing.T) {
-	core, repoMock, opMock, accMock, db := setupTestCore(t)
+	core, repoMock, opMock, accMock, _, db := setupTestCore(t)
 
 	payload := &entityCoreV1Package.CreateTransactionPayload{
 		AccountId:       222,
 		OperationTypeId: 3,
-		Amount:          50.0,
+		Amount:          utilMoneyV1.MustParse("50"),
 	}
 
 	accMock.On("GetAccount", 222, mock.Anything).
-		Return(&accountClientPackageV1.Account{Id: 222}, nil)
+		Return(&accountClientPackageV1.Account{Id: 222, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
 
-	opMock.On("GetOperationCoefficient", 3, mock.Anything).
-		Return(0.0, errors.New("coef error"))
+	opMock.On("ComputeOperation", operationInput(3, "50"), mock.Anything).
+		Return(nil, errors.New("coef error"))
 
 	tx := db.Begin()
 	defer tx.Rollback()
 
-	_, err := core.CreateTransaction(logrus.NewEntry(logrus.New()), payload, tx)
+	_, err := core.CreateTransaction(context.Background(), payload, tx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "coef error")
 
@@ -245,16 +485,20 @@ func TestCreateTransaction_FinalAmountError(t *testing.T) {
 }
 
 func TestCreateTransaction_RepoError(t *testing.T) {
-	core, repoMock, opMock, accMock, db := setupTestCore(t)
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
 
 	payload := &entityCoreV1Package.CreateTransactionPayload{
 		AccountId:       333,
 		OperationTypeId: 4,
-		Amount:          250.0,
+		Amount:          utilMoneyV1.MustParse("250"),
 	}
 
-	accMock.On("GetAccount", 333, mock.Anything).Return(&accountClientPackageV1.Account{Id: 333}, nil)
-	opMock.On("GetOperationCoefficient", 4, mock.Anything).Return(1.0, nil)
+	accMock.On("GetAccount", 333, mock.Anything).Return(&accountClientPackageV1.Account{Id: 333, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(4, "250"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-250"), Coefficient: -1}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_APPROVE}, nil)
+	accMock.On("UpdateAvailableCreditLimit", 333, mock.Anything, mock.Anything).Return(nil)
 
 	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).
 		Return(errors.New("repo create error"))
@@ -262,11 +506,666 @@ func TestCreateTransaction_RepoError(t *testing.T) {
 	tx := db.Begin()
 	defer tx.Rollback()
 
-	_, err := core.CreateTransaction(logrus.NewEntry(logrus.New()), payload, tx)
+	_, err := core.CreateTransaction(context.Background(), payload, tx)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "repo create error")
+	core.eventBus.(*MockEventBus).AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
 
 	accMock.AssertExpectations(t)
 	opMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
 }
+
+func TestCreateTransaction_DeclinedIsStoredWithDecision(t *testing.T) {
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
+
+	payload := &entityCoreV1Package.CreateTransactionPayload{
+		AccountId:       444,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse("90000"),
+	}
+
+	accMock.On("GetAccount", 444, mock.Anything).Return(&accountClientPackageV1.Account{Id: 444, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(1, "90000"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-90000"), Coefficient: -1}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_DECLINE, FiredRules: []string{"HIGH_AMOUNT", "OTHER"}}, nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	transaction, err := core.CreateTransaction(context.Background(), payload, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.DECISION_DECLINE, transaction.FraudDecision)
+	assert.Equal(t, "HIGH_AMOUNT,OTHER", transaction.FraudRules)
+	assert.Equal(t, utilMoneyV1.MustParse("-90000"), transaction.Amount)
+	assert.Equal(t, utilMoneyV1.MustParse("-90000"), transaction.Balance)
+	core.eventBus.(*MockEventBus).AssertCalled(t, "Publish", mock.MatchedBy(func(event *eventBusPackageV1.TransactionDeclined) bool {
+		return event.AccountId == 444 && assert.ObjectsAreEqual([]string{"HIGH_AMOUNT", "OTHER"}, event.FraudRules)
+	}), tx)
+
+	accMock.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+	opMock.AssertNotCalled(t, "ComputeFees", mock.Anything, mock.Anything)
+	repoMock.AssertExpectations(t)
+	fraudMock.AssertExpectations(t)
+}
+
+func TestCreateTransaction_FraudEngineError(t *testing.T) {
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
+
+	payload := &entityCoreV1Package.CreateTransactionPayload{
+		AccountId:       555,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse("10"),
+	}
+
+	accMock.On("GetAccount", 555, mock.Anything).Return(&accountClientPackageV1.Account{Id: 555, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(1, "10"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-10"), Coefficient: -1}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return((*fraudClientPackageV1.Decision)(nil), errors.New("engine error"))
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	_, err := core.CreateTransaction(context.Background(), payload, tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "engine error")
+
+	repoMock.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+	fraudMock.AssertExpectations(t)
+}
+
+func TestCreateTransaction_InsufficientCreditLimit(t *testing.T) {
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
+
+	payload := &entityCoreV1Package.CreateTransactionPayload{
+		AccountId:       666,
+		OperationTypeId: 1,
+		Amount:          utilMoneyV1.MustParse("700"),
+	}
+
+	accMock.On("GetAccount", 666, mock.Anything).Return(&accountClientPackageV1.Account{Id: 666, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(1, "700"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-700"), Coefficient: -1}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_APPROVE}, nil)
+	accMock.On("UpdateAvailableCreditLimit", 666, utilMoneyV1.MustParse("-700"), mock.Anything).
+		Return(errors.New("insufficient available credit limit for account_id: 666"))
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	_, err := core.CreateTransaction(context.Background(), payload, tx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient available credit limit")
+
+	repoMock.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+	accMock.AssertExpectations(t)
+}
+
+//-------------------------------------------//
+// 6. Test: ApplyCreditLimit
+//-------------------------------------------//
+
+func TestApplyCreditLimit_CreditVoucherRestoresLimit(t *testing.T) {
+	core, _, _, accMock, _, db := setupTestCore(t)
+
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("60"), mock.Anything).Return(nil)
+
+	err := core.ApplyCreditLimit(context.Background(), &entityDbV1Package.Transaction{AccountId: 1, Amount: utilMoneyV1.MustParse("60")}, db)
+	assert.NoError(t, err)
+
+	accMock.AssertExpectations(t)
+}
+
+//-------------------------------------------//
+// 7. Test: DischargeBalance
+//-------------------------------------------//
+
+func TestDischargeBalance_DebitKeepsAmountAsBalance(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	transaction := &entityDbV1Package.Transaction{AccountId: 1, Amount: utilMoneyV1.MustParse("-50")}
+	err := core.DischargeBalance(context.Background(), transaction, db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParse("-50"), transaction.Balance)
+
+	repoMock.AssertNotCalled(t, "GetOutstandingTransactions", mock.Anything, mock.Anything)
+}
+
+func TestDischargeBalance_FIFO(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	oldest := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, AccountId: 1, Amount: utilMoneyV1.MustParse("-50"), Balance: utilMoneyV1.MustParse("-50")}
+	middle := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 2}, AccountId: 1, Amount: utilMoneyV1.MustParse("-23.5"), Balance: utilMoneyV1.MustParse("-23.5")}
+	newest := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 3}, AccountId: 1, Amount: utilMoneyV1.MustParse("-18.7"), Balance: utilMoneyV1.MustParse("-18.7")}
+	repoMock.On("GetOutstandingTransactions", 1, mock.Anything).
+		Return([]*entityDbV1Package.Transaction{oldest, middle, newest}, nil)
+	repoMock.On("UpdateTransactionBalance", mock.Anything, mock.Anything).Return(nil)
+
+	credit := &entityDbV1Package.Transaction{AccountId: 1, Amount: utilMoneyV1.MustParse("60")}
+	err := core.DischargeBalance(context.Background(), credit, db)
+	assert.NoError(t, err)
+
+	assert.Equal(t, utilMoneyV1.MustParse("0"), oldest.Balance)
+	assert.Equal(t, utilMoneyV1.MustParse("-13.5"), middle.Balance)
+	assert.Equal(t, utilMoneyV1.MustParse("-18.7"), newest.Balance)
+	assert.Equal(t, utilMoneyV1.MustParse("0"), credit.Balance)
+	repoMock.AssertNumberOfCalls(t, "UpdateTransactionBalance", 2)
+}
+
+func TestDischargeBalance_LeftoverStaysOnCredit(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	outstanding := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, AccountId: 1, Amount: utilMoneyV1.MustParse("-50"), Balance: utilMoneyV1.MustParse("-50")}
+	repoMock.On("GetOutstandingTransactions", 1, mock.Anything).
+		Return([]*entityDbV1Package.Transaction{outstanding}, nil)
+	repoMock.On("UpdateTransactionBalance", outstanding, mock.Anything).Return(nil)
+
+	credit := &entityDbV1Package.Transaction{AccountId: 1, Amount: utilMoneyV1.MustParse("100")}
+	err := core.DischargeBalance(context.Background(), credit, db)
+	assert.NoError(t, err)
+
+	assert.Equal(t, utilMoneyV1.MustParse("0"), outstanding.Balance)
+	assert.Equal(t, utilMoneyV1.MustParse("50"), credit.Balance)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDischargeBalance_RepoError(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	repoMock.On("GetOutstandingTransactions", 1, mock.Anything).
+		Return(([]*entityDbV1Package.Transaction)(nil), errors.New("db error"))
+
+	err := core.DischargeBalance(context.Background(), &entityDbV1Package.Transaction{AccountId: 1, Amount: utilMoneyV1.MustParse("10")}, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+	repoMock.AssertExpectations(t)
+}
+
+//-------------------------------------------//
+// 8. Test: GetTransaction / ListTransactions
+//-------------------------------------------//
+
+func TestGetTransaction_NotFound(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	repoMock.On("GetTransaction", 42, mock.Anything).
+		Return(&entityDbV1Package.Transaction{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.TRANSACTION_NOT_FOUND, "transaction_id: 42 not found in database"))
+
+	_, err := core.GetTransaction(context.Background(), 42, db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	repoMock.AssertExpectations(t)
+}
+
+func TestListTransactions_NextCursor(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	rows := []*entityDbV1Package.Transaction{
+		{Model: gorm.Model{ID: 3, CreatedAt: createdAt.Add(2 * time.Minute)}},
+		{Model: gorm.Model{ID: 2, CreatedAt: createdAt.Add(time.Minute)}},
+		{Model: gorm.Model{ID: 1, CreatedAt: createdAt}},
+	}
+	repoMock.On("ListTransactions", mock.MatchedBy(func(filter *entityCoreV1Package.TransactionFilter) bool {
+		return filter.Limit == 3
+	}), mock.Anything).Return(rows, nil)
+
+	filter := &entityCoreV1Package.TransactionFilter{Limit: 2}
+	page, err := core.ListTransactions(context.Background(), filter, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, &entityCoreV1Package.TransactionCursor{CreatedAt: rows[1].CreatedAt, Id: 2}, page.Next)
+	assert.Equal(t, 2, filter.Limit, "caller filter must not be modified")
+	repoMock.AssertExpectations(t)
+}
+
+func TestListTransactions_LastPage(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	repoMock.On("ListTransactions", mock.Anything, mock.Anything).
+		Return([]*entityDbV1Package.Transaction{{Model: gorm.Model{ID: 1}}}, nil)
+
+	page, err := core.ListTransactions(context.Background(), &entityCoreV1Package.TransactionFilter{Limit: 2}, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Transactions, 1)
+	assert.Nil(t, page.Next)
+}
+
+//-------------------------------------------//
+// Test: Installments
+//-------------------------------------------//
+
+func TestCreateTransaction_InstallmentPurchaseSchedulesInstallments(t *testing.T) {
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
+
+	payload := &entityCoreV1Package.CreateTransactionPayload{
+		AccountId:        111,
+		OperationTypeId:  2,
+		Amount:           utilMoneyV1.MustParse("100"),
+		InstallmentCount: 3,
+	}
+	purchasedAt := time.Date(2026, 1, 31, 15, 0, 0, 0, time.UTC)
+
+	accMock.On("GetAccount", 111, mock.Anything).Return(&accountClientPackageV1.Account{Id: 111, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(2, "100"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-100"), Coefficient: -1, InstallmentCount: 3}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_APPROVE, FiredRules: []string{}}, nil)
+	// The whole purchase amount is drawn from the limit at once.
+	accMock.On("UpdateAvailableCreditLimit", 111, utilMoneyV1.MustParse("-100"), mock.Anything).Return(nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		transaction := args.Get(0).(*entityDbV1Package.Transaction)
+		transaction.ID = 9
+		transaction.CreatedAt = purchasedAt
+	})
+	repoMock.On("CreateInstallments", mock.Anything, mock.Anything).Return(nil)
+	opMock.On("ComputeFees", mock.Anything, mock.Anything).Return([]*operationClientPackageV1.Fee{}, nil)
+
+	transaction, err := core.CreateTransaction(context.Background(), payload, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, transaction.InstallmentCount)
+	assert.Len(t, transaction.Installments, 3)
+	expected := []struct {
+		amount  string
+		dueDate time.Time
+	}{
+		{"-33.34", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"-33.33", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"-33.33", time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	for i, installment := range transaction.Installments {
+		assert.Equal(t, uint(9), installment.TransactionId)
+		assert.Equal(t, 111, installment.AccountId)
+		assert.Equal(t, i+1, installment.Number)
+		assert.Equal(t, expected[i].amount, installment.Amount.String())
+		assert.Equal(t, expected[i].dueDate, installment.DueDate)
+		assert.Equal(t, "PENDING", installment.Status)
+	}
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateTransaction_DeclinedInstallmentPurchaseHasNoSchedule(t *testing.T) {
+	core, repoMock, opMock, accMock, fraudMock, db := setupTestCore(t)
+
+	payload := &entityCoreV1Package.CreateTransactionPayload{AccountId: 111, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("90000"), InstallmentCount: 3}
+
+	accMock.On("GetAccount", 111, mock.Anything).Return(&accountClientPackageV1.Account{Id: 111, Status: accountConstantPackage.STATUS_ACTIVE, Currency: "USD"}, nil)
+	opMock.On("ComputeOperation", operationInput(2, "90000"), mock.Anything).
+		Return(&operationClientPackageV1.OperationResult{Amount: utilMoneyV1.MustParse("-90000"), Coefficient: -1, InstallmentCount: 3}, nil)
+	fraudMock.On("EvaluateTransaction", mock.Anything, mock.Anything).
+		Return(&fraudClientPackageV1.Decision{Decision: constantPackage.DECISION_DECLINE, FiredRules: []string{"HIGH_AMOUNT"}}, nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	transaction, err := core.CreateTransaction(context.Background(), payload, db)
+
+	assert.NoError(t, err)
+	assert.Empty(t, transaction.Installments)
+	repoMock.AssertNotCalled(t, "CreateInstallments", mock.Anything, mock.Anything)
+}
+
+func TestScheduleInstallments_AmountTooSmall(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	transaction := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 1}, Amount: utilMoneyV1.MustParse("-0.02"), InstallmentCount: 3, Currency: "USD"}
+	err := core.ScheduleInstallments(context.Background(), transaction, db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	assert.Equal(t, errorConstantPackage.INVALID_INSTALLMENT_PLAN, appErr.Code)
+	repoMock.AssertNotCalled(t, "CreateInstallments", mock.Anything, mock.Anything)
+}
+
+func TestGetTransaction_LoadsInstallments(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	repoMock.On("GetTransaction", 9, mock.Anything).Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 9}, InstallmentCount: 2}, nil)
+	repoMock.On("GetInstallments", 9, mock.Anything).Return([]*entityDbV1Package.Installment{{Number: 1}, {Number: 2}}, nil)
+	repoMock.On("GetFeeTransactions", 9, mock.Anything).Return([]*entityDbV1Package.Transaction{}, nil)
+
+	transaction, err := core.GetTransaction(context.Background(), 9, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, transaction.Installments, 2)
+	repoMock.AssertExpectations(t)
+}
+
+func TestListUpcomingInstallments_AccountNotFound(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	accMock.On("GetAccount", 5, mock.Anything).
+		Return(&accountClientPackageV1.Account{}, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account not found"))
+
+	installments, err := core.ListUpcomingInstallments(context.Background(), 5, time.Now(), db)
+
+	assert.Nil(t, installments)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+	repoMock.AssertNotCalled(t, "GetUpcomingInstallments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+//-------------------------------------------//
+// Test: Fees
+//-------------------------------------------//
+
+func TestChargeFees_PostsLinkedFees(t *testing.T) {
+	core, repoMock, opMock, accMock, _, db := setupTestCore(t)
+
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	transaction := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 7, CreatedAt: createdAt}, AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-500"), Currency: "USD", FraudDecision: constantPackage.DECISION_REVIEW}
+	opMock.On("ComputeFees", &operationClientPackageV1.FeeInput{OperationTypeId: 3, AccountTier: "GOLD", Currency: "USD", Amount: utilMoneyV1.MustParse("-500")}, mock.Anything).
+		Return([]*operationClientPackageV1.Fee{{Code: "ATM_SURCHARGE", Amount: utilMoneyV1.MustParse("1"), OperationTypeId: 9}, {Code: "WITHDRAWAL_FEE", Amount: utilMoneyV1.MustParse("2.5"), OperationTypeId: 9}}, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-1"), mock.Anything).Return(nil).Once()
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-2.5"), mock.Anything).Return(nil).Once()
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	err := core.ChargeFees(context.Background(), transaction, "GOLD", db)
+
+	assert.NoError(t, err)
+	assert.Len(t, transaction.Fees, 2)
+	for i, expected := range []struct {
+		code   string
+		amount string
+	}{{"ATM_SURCHARGE", "-1"}, {"WITHDRAWAL_FEE", "-2.5"}} {
+		fee := transaction.Fees[i]
+		assert.Equal(t, expected.code, fee.FeeCode)
+		assert.Equal(t, expected.amount, fee.Amount.String())
+		assert.Equal(t, fee.Amount, fee.Balance)
+		assert.Equal(t, uint(7), *fee.ParentTransactionId)
+		assert.Equal(t, 9, fee.OperationTypeId)
+		assert.Equal(t, "USD", fee.Currency)
+		assert.Equal(t, createdAt, fee.CreatedAt)
+		assert.Equal(t, constantPackage.DECISION_REVIEW, fee.FraudDecision)
+	}
+	accMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestChargeFees_InsufficientCreditLimit(t *testing.T) {
+	core, repoMock, opMock, accMock, _, db := setupTestCore(t)
+
+	transaction := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}, AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-500"), Currency: "USD"}
+	opMock.On("ComputeFees", mock.Anything, mock.Anything).
+		Return([]*operationClientPackageV1.Fee{{Code: "WITHDRAWAL_FEE", Amount: utilMoneyV1.MustParse("2.5")}}, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-2.5"), mock.Anything).
+		Return(utilErrorsV1.NewUnprocessableError(errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, "insufficient credit limit"))
+
+	err := core.ChargeFees(context.Background(), transaction, "STANDARD", db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.INSUFFICIENT_CREDIT_LIMIT, appErr.Code)
+	repoMock.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+}
+
+func TestGetTransaction_LoadsFees(t *testing.T) {
+	core, repoMock, _, _, _, db := setupTestCore(t)
+
+	repoMock.On("GetTransaction", 7, mock.Anything).Return(&entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}}, nil)
+	repoMock.On("GetFeeTransactions", 7, mock.Anything).Return([]*entityDbV1Package.Transaction{{Model: gorm.Model{ID: 8}, FeeCode: "WITHDRAWAL_FEE"}}, nil)
+
+	transaction, err := core.GetTransaction(context.Background(), 7, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, transaction.Fees, 1)
+	repoMock.AssertNotCalled(t, "GetInstallments", mock.Anything, mock.Anything)
+	repoMock.AssertExpectations(t)
+}
+
+//-------------------------------------------//
+// Test: ReverseTransaction
+//-------------------------------------------//
+
+func TestReverseTransaction_FullReversalOfOutstandingDebit(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 5}, AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-100"), Balance: utilMoneyV1.MustParse("-100"), FraudDecision: constantPackage.DECISION_APPROVE, Currency: "USD", Status: "POSTED", Coefficient: -1}
+	repoMock.On("GetTransactionForUpdate", 5, mock.Anything).Return(original, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("100"), mock.Anything).Return(nil)
+	repoMock.On("UpdateTransactionReversal", original, mock.Anything).Return(nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := core.ReverseTransaction(context.Background(), 5, &entityCoreV1Package.ReverseTransactionPayload{}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "100", result.Reversal.Amount.String())
+	assert.Equal(t, "100", result.Reversal.InputAmount.String())
+	assert.Equal(t, 1, result.Reversal.Coefficient)
+	assert.True(t, result.Reversal.Balance.IsZero())
+	assert.Equal(t, uint(5), *result.Reversal.OriginalTransactionId)
+	assert.Equal(t, 1, result.Reversal.OperationTypeId)
+	assert.Equal(t, "REVERSED", result.Original.Status)
+	assert.Equal(t, "100", result.Original.ReversedAmount.String())
+	assert.True(t, result.Original.Balance.IsZero())
+	repoMock.AssertNotCalled(t, "GetOutstandingTransactions", mock.Anything, mock.Anything)
+	repoMock.AssertExpectations(t)
+	accMock.AssertExpectations(t)
+}
+
+func TestReverseTransaction_PartialReversalOfPaidDebitDischargesOtherDebts(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 5}, AccountId: 1, Amount: utilMoneyV1.MustParse("-100"), Balance: utilMoneyV1.Zero, FraudDecision: constantPackage.DECISION_APPROVE, Currency: "USD", Status: "POSTED"}
+	other := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 6}, AccountId: 1, Amount: utilMoneyV1.MustParse("-30"), Balance: utilMoneyV1.MustParse("-30")}
+	repoMock.On("GetTransactionForUpdate", 5, mock.Anything).Return(original, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("40"), mock.Anything).Return(nil)
+	repoMock.On("UpdateTransactionReversal", original, mock.Anything).Return(nil)
+	repoMock.On("GetOutstandingTransactions", 1, mock.Anything).Return([]*entityDbV1Package.Transaction{other}, nil)
+	repoMock.On("UpdateTransactionBalance", other, mock.Anything).Return(nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	amount := utilMoneyV1.MustParse("40")
+	result, err := core.ReverseTransaction(context.Background(), 5, &entityCoreV1Package.ReverseTransactionPayload{Amount: &amount}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PARTIALLY_REVERSED", result.Original.Status)
+	assert.Equal(t, "10", result.Reversal.Balance.String())
+	assert.True(t, other.Balance.IsZero())
+	repoMock.AssertExpectations(t)
+}
+
+func TestReverseTransaction_CreditVoucherLeavesNewDebt(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 7}, AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("50"), Balance: utilMoneyV1.MustParse("20"), FraudDecision: constantPackage.DECISION_APPROVE, Currency: "USD", Status: "POSTED"}
+	repoMock.On("GetTransactionForUpdate", 7, mock.Anything).Return(original, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("-50"), mock.Anything).Return(nil)
+	repoMock.On("UpdateTransactionReversal", original, mock.Anything).Return(nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := core.ReverseTransaction(context.Background(), 7, &entityCoreV1Package.ReverseTransactionPayload{}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "-50", result.Reversal.Amount.String())
+	assert.Equal(t, "-30", result.Reversal.Balance.String())
+	assert.True(t, result.Original.Balance.IsZero())
+}
+
+func TestReverseTransaction_ShrinksInstallmentsLatestFirst(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 8}, AccountId: 1, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("-100"), Balance: utilMoneyV1.MustParse("-100"), FraudDecision: constantPackage.DECISION_APPROVE, Currency: "USD", Status: "POSTED", InstallmentCount: 3}
+	installments := []*entityDbV1Package.Installment{
+		{Model: gorm.Model{ID: 1}, Number: 1, Amount: utilMoneyV1.MustParse("-33.34"), Status: "PENDING"},
+		{Model: gorm.Model{ID: 2}, Number: 2, Amount: utilMoneyV1.MustParse("-33.33"), Status: "PENDING"},
+		{Model: gorm.Model{ID: 3}, Number: 3, Amount: utilMoneyV1.MustParse("-33.33"), Status: "PENDING"},
+	}
+	repoMock.On("GetTransactionForUpdate", 8, mock.Anything).Return(original, nil)
+	accMock.On("UpdateAvailableCreditLimit", 1, utilMoneyV1.MustParse("50"), mock.Anything).Return(nil)
+	repoMock.On("GetInstallments", 8, mock.Anything).Return(installments, nil)
+	repoMock.On("UpdateInstallment", mock.Anything, mock.Anything).Return(nil)
+	repoMock.On("UpdateTransactionReversal", original, mock.Anything).Return(nil)
+	repoMock.On("CreateTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	amount := utilMoneyV1.MustParse("50")
+	_, err := core.ReverseTransaction(context.Background(), 8, &entityCoreV1Package.ReverseTransactionPayload{Amount: &amount}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PENDING", installments[0].Status)
+	assert.Equal(t, "-33.34", installments[0].Amount.String())
+	assert.Equal(t, "PENDING", installments[1].Status)
+	assert.Equal(t, "-16.66", installments[1].Amount.String())
+	assert.Equal(t, "CANCELLED", installments[2].Status)
+	repoMock.AssertNumberOfCalls(t, "UpdateInstallment", 2)
+}
+
+func TestReverseTransaction_ExceedsRemainingAmount(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 5}, AccountId: 1, Amount: utilMoneyV1.MustParse("-100"), ReversedAmount: utilMoneyV1.MustParse("80"), FraudDecision: constantPackage.DECISION_APPROVE, Currency: "USD", Status: "PARTIALLY_REVERSED"}
+	repoMock.On("GetTransactionForUpdate", 5, mock.Anything).Return(original, nil)
+
+	amount := utilMoneyV1.MustParse("20.01")
+	result, err := core.ReverseTransaction(context.Background(), 5, &entityCoreV1Package.ReverseTransactionPayload{Amount: &amount}, db)
+
+	assert.Nil(t, result)
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.REVERSAL_EXCEEDS_AMOUNT, appErr.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	accMock.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReverseTransaction_NotReversible(t *testing.T) {
+	reversalOf := uint(1)
+	for name, original := range map[string]*entityDbV1Package.Transaction{
+		"declined":       {Amount: utilMoneyV1.MustParse("-10"), FraudDecision: constantPackage.DECISION_DECLINE, Status: "POSTED"},
+		"reversal":       {Amount: utilMoneyV1.MustParse("10"), FraudDecision: constantPackage.DECISION_APPROVE, Status: "POSTED", OriginalTransactionId: &reversalOf},
+		"fully reversed": {Amount: utilMoneyV1.MustParse("-10"), ReversedAmount: utilMoneyV1.MustParse("10"), FraudDecision: constantPackage.DECISION_APPROVE, Status: "REVERSED"},
+	} {
+		core, repoMock, _, _, _, db := setupTestCore(t)
+		repoMock.On("GetTransactionForUpdate", 2, mock.Anything).Return(original, nil)
+
+		_, err := core.ReverseTransaction(context.Background(), 2, &entityCoreV1Package.ReverseTransactionPayload{}, db)
+
+		appErr, ok := utilErrorsV1.AsAppError(err)
+		assert.True(t, ok, name)
+		assert.Equal(t, errorConstantPackage.TRANSACTION_NOT_REVERSIBLE, appErr.Code, name)
+		repoMock.AssertNotCalled(t, "CreateTransaction", mock.Anything, mock.Anything)
+	}
+}
+
+//-------------------------------------------//
+// Test: PostCapturedTransaction
+//-------------------------------------------//
+
+func TestPostCapturedTransaction_PersistsWithoutTouchingTheLimit(t *testing.T) {
+	core, repoMock, _, accMock, fraudMock, db := setupTestCore(t)
+
+	repoMock.On("CreateTransaction", mock.MatchedBy(func(transaction *entityDbV1Package.Transaction) bool {
+		return transaction.AccountId == 1 && transaction.Amount.Cmp(utilMoneyV1.MustParse("-40")) == 0 &&
+			transaction.Balance.Cmp(transaction.Amount) == 0 && transaction.FraudDecision == constantPackage.DECISION_REVIEW &&
+			transaction.FraudRules == "high_amount" && transaction.Status == "POSTED"
+	}), mock.Anything).Return(nil)
+
+	transaction, err := core.PostCapturedTransaction(context.Background(), &entityCoreV1Package.CapturedTransactionPayload{
+		AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-40"), FraudDecision: constantPackage.DECISION_REVIEW, FraudRules: "high_amount",
+	}, db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transaction.OperationTypeId)
+	core.eventBus.(*MockEventBus).AssertCalled(t, "Publish", mock.MatchedBy(func(event *eventBusPackageV1.TransactionCreated) bool {
+		return event.AccountId == 1 && event.FraudDecision == constantPackage.DECISION_REVIEW
+	}), db)
+	repoMock.AssertExpectations(t)
+	accMock.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+	fraudMock.AssertNotCalled(t, "EvaluateTransaction", mock.Anything, mock.Anything)
+}
+
+//-------------------------------------------//
+// Test: ConvertCurrency
+//-------------------------------------------//
+
+func TestConvertCurrency_SameCurrencyKeepsAmount(t *testing.T) {
+	core, _, _, _, _, fxMock, db := setupTestCoreWithFx(t)
+
+	transaction := &entityDbV1Package.Transaction{OriginalAmount: utilMoneyV1.MustParse("-12.34")}
+	err := core.ConvertCurrency(context.Background(), transaction, "USD", db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", transaction.Currency)
+	assert.Equal(t, "USD", transaction.OriginalCurrency)
+	assert.Equal(t, utilMoneyV1.MustParse("-12.34"), transaction.Amount)
+	assert.Equal(t, utilMoneyV1.IdentityRate, transaction.FxRate)
+	fxMock.AssertNotCalled(t, "GetRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConvertCurrency_ConvertsAtEventTime(t *testing.T) {
+	core, _, _, _, _, fxMock, db := setupTestCoreWithFx(t)
+
+	eventTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	transaction := &entityDbV1Package.Transaction{Model: gorm.Model{CreatedAt: eventTime}, OriginalAmount: utilMoneyV1.MustParse("-10.01"), OriginalCurrency: "EUR"}
+	fxMock.On("GetRate", "EUR", "USD", eventTime, mock.Anything).Return(utilMoneyV1.MustParseRate("1.085"), nil)
+
+	err := core.ConvertCurrency(context.Background(), transaction, "USD", db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", transaction.Currency)
+	assert.Equal(t, utilMoneyV1.MustParse("-10.86"), transaction.Amount, "expected -10.86085 to round half away from zero")
+	assert.Equal(t, utilMoneyV1.MustParse("-10.01"), transaction.OriginalAmount)
+	assert.Equal(t, "1.085", transaction.FxRate.String())
+	fxMock.AssertExpectations(t)
+}
+
+func TestConvertCurrency_RateNotFound(t *testing.T) {
+	core, _, _, _, _, fxMock, db := setupTestCoreWithFx(t)
+
+	transaction := &entityDbV1Package.Transaction{OriginalAmount: utilMoneyV1.MustParse("-10"), OriginalCurrency: "GBP"}
+	fxMock.On("GetRate", "GBP", "USD", mock.Anything, mock.Anything).
+		Return(nil, utilErrorsV1.NewNotFoundError(errorConstantPackage.FX_RATE_NOT_FOUND, "no GBP/USD rate"))
+
+	err := core.ConvertCurrency(context.Background(), transaction, "USD", db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	assert.Equal(t, errorConstantPackage.FX_RATE_NOT_FOUND, appErr.Code)
+}
+
+func TestConvertCurrency_ScaleExceedsOriginalCurrency(t *testing.T) {
+	core, _, _, _, _, fxMock, db := setupTestCoreWithFx(t)
+
+	transaction := &entityDbV1Package.Transaction{OriginalAmount: utilMoneyV1.MustParse("-100.5"), OriginalCurrency: "JPY"}
+	err := core.ConvertCurrency(context.Background(), transaction, "USD", db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+	assert.Equal(t, errorConstantPackage.VALIDATION_FAILED, appErr.Code)
+	fxMock.AssertNotCalled(t, "GetRate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConvertCurrency_ConvertsToZero(t *testing.T) {
+	core, _, _, _, _, fxMock, db := setupTestCoreWithFx(t)
+
+	transaction := &entityDbV1Package.Transaction{OriginalAmount: utilMoneyV1.MustParse("-1"), OriginalCurrency: "JPY"}
+	fxMock.On("GetRate", "JPY", "USD", mock.Anything, mock.Anything).Return(utilMoneyV1.MustParseRate("0.0045"), nil)
+
+	err := core.ConvertCurrency(context.Background(), transaction, "USD", db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	assert.Equal(t, errorConstantPackage.AMOUNT_TOO_SMALL, appErr.Code)
+}
+
+func TestReverseTransaction_AmountScaleFollowsOriginalCurrency(t *testing.T) {
+	core, repoMock, _, accMock, _, db := setupTestCore(t)
+
+	original := &entityDbV1Package.Transaction{Model: gorm.Model{ID: 5}, AccountId: 1, Amount: utilMoneyV1.MustParse("-1000"), FraudDecision: constantPackage.DECISION_APPROVE, Currency: "JPY", Status: "POSTED"}
+	repoMock.On("GetTransactionForUpdate", 5, mock.Anything).Return(original, nil)
+
+	amount := utilMoneyV1.MustParse("10.5")
+	_, err := core.ReverseTransaction(context.Background(), 5, &entityCoreV1Package.ReverseTransactionPayload{Amount: &amount}, db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+	assert.Equal(t, errorConstantPackage.VALIDATION_FAILED, appErr.Code)
+	accMock.AssertNotCalled(t, "UpdateAvailableCreditLimit", mock.Anything, mock.Anything, mock.Anything)
+}
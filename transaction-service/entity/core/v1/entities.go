@@ -1,7 +1,65 @@
 package transaction_entity_core_v1
 
+import (
+	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
 type CreateTransactionPayload struct {
-	AccountId       int     `json:"account_id"`
-	OperationTypeId int     `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
+	AccountId        int                `json:"account_id"`
+	OperationTypeId  int                `json:"operation_type_id"`
+	Amount           utilMoneyV1.Amount `json:"amount"`
+	Currency         string             `json:"currency"`          // currency of Amount, empty for the account currency
+	InstallmentCount int                `json:"installment_count"` // 0 unless the purchase is paid in installments
+}
+
+// CapturedTransactionPayload holds the transaction posted when an authorization hold is captured.
+// Amount is already final and signed; the fraud decision is the one taken when the hold was authorized.
+type CapturedTransactionPayload struct {
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	Currency        string             `json:"currency"` // the account currency the hold was placed in
+	FraudDecision   string             `json:"fraud_decision"`
+	FraudRules      string             `json:"fraud_rules"`
+	InputAmount     utilMoneyV1.Amount `json:"input_amount"` // captured amount as requested
+	Coefficient     int                `json:"coefficient"`  // coefficient applied when the hold was authorized
+}
+
+// ReverseTransactionPayload holds the amount to reverse, in the account currency; nil reverses everything not reversed yet.
+type ReverseTransactionPayload struct {
+	Amount *utilMoneyV1.Amount `json:"amount"`
+}
+
+// TransactionReversal is the outcome of a reversal: the compensating transaction and the updated original.
+type TransactionReversal struct {
+	Reversal *entityDbV1Package.Transaction
+	Original *entityDbV1Package.Transaction
+}
+
+// TransactionCursor is the position of the last transaction of a page in (created_at, id) order.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	Id        uint
+}
+
+// TransactionFilter selects and orders the transactions returned by a listing.
+// Nil fields do not filter.
+type TransactionFilter struct {
+	AccountId       *int
+	OperationTypeId *int
+	MinAmount       *utilMoneyV1.Amount
+	MaxAmount       *utilMoneyV1.Amount
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	SortOrder       string             // SORT_ORDER_ASC or SORT_ORDER_DESC, by (created_at, id)
+	Limit           int                // page size
+	After           *TransactionCursor // resume after this position
+}
+
+// TransactionPage is one page of a transaction listing. Next is nil on the last page.
+type TransactionPage struct {
+	Transactions []*entityDbV1Package.Transaction
+	Next         *TransactionCursor
 }
@@ -2,17 +2,55 @@ package transaction_entity_db_v1
 
 import (
 	constantPackage "anti-fraud/constants/transaction"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type Transaction struct {
 	gorm.Model
-	AccountId       int     `json:"account_id"`
-	OperationTypeId int     `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
+	AccountId        int                `json:"account_id"`
+	OperationTypeId  int                `json:"operation_type_id"`
+	Amount           utilMoneyV1.Amount `json:"amount"`   // in the account currency
+	Balance          utilMoneyV1.Amount `json:"balance"`  // amount not yet discharged, starts equal to Amount
+	Currency         string             `json:"currency"` // the account currency
+	FraudDecision    string             `json:"fraud_decision"`
+	FraudRules       string             `json:"fraud_rules"`       // comma separated names of the fraud rules that fired
+	InstallmentCount int                `json:"installment_count"` // 0 unless the purchase is paid in installments
+	Installments     []*Installment     `json:"-" gorm:"-"`        // schedule of an installment purchase, loaded on demand
+
+	Status                string             `json:"status"`                  // POSTED, PARTIALLY_REVERSED or REVERSED
+	ReversedAmount        utilMoneyV1.Amount `json:"reversed_amount"`         // sum of the reversals, always positive
+	OriginalTransactionId *uint              `json:"original_transaction_id"` // set on a reversal, to the transaction it compensates
+
+	OriginalAmount   utilMoneyV1.Amount `json:"original_amount"`   // signed like Amount, in OriginalCurrency
+	OriginalCurrency string             `json:"original_currency"` // currency the transaction was submitted in
+	FxRate           utilMoneyV1.Rate   `json:"fx_rate"`           // OriginalCurrency to Currency rate valid at event time, 1 when they match
+
+	InputAmount utilMoneyV1.Amount `json:"input_amount"` // amount as submitted, sign included, in OriginalCurrency
+	Coefficient int                `json:"coefficient"`  // operation type coefficient applied to InputAmount, as of the event time
+
+	FeeCode             string         `json:"fee_code"`              // set on a fee, to the code of the fee charged
+	ParentTransactionId *uint          `json:"parent_transaction_id"` // set on a fee, to the transaction it is charged on
+	Fees                []*Transaction `json:"-" gorm:"-"`            // fees charged on the transaction, loaded on demand
 }
 
 func (Transaction) TableName() string {
 	return constantPackage.TABLE_NAME
 }
+
+// Installment is one scheduled payment of an installment purchase.
+type Installment struct {
+	gorm.Model
+	TransactionId uint               `json:"transaction_id"` // parent purchase
+	AccountId     int                `json:"account_id"`
+	Number        int                `json:"number"` // 1-based position in the schedule
+	Amount        utilMoneyV1.Amount `json:"amount"` // signed like the parent purchase
+	DueDate       time.Time          `json:"due_date"`
+	Status        string             `json:"status"`
+}
+
+func (Installment) TableName() string {
+	return constantPackage.INSTALLMENT_TABLE_NAME
+}
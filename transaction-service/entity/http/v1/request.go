@@ -1,11 +1,21 @@
 package transaction_entity_http_v1
 
-import "errors"
+import (
+	constantPackage "anti-fraud/constants/transaction"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
 
 type CreateTransactionRequest struct {
-	AccountId       int     `json:"account_id"`
-	OperationTypeId int     `json:"operation_type_id"`
-	Amount          float64 `json:"amount"`
+	AccountId        int                `json:"account_id"`
+	OperationTypeId  int                `json:"operation_type_id"`
+	Amount           utilMoneyV1.Amount `json:"amount"`
+	Currency         string             `json:"currency"`          // optional, defaults to the account currency
+	InstallmentCount *int               `json:"installment_count"` // mandatory for, and only accepted with, operation types paid in installments
 }
 
 func (createAccountRequest *CreateTransactionRequest) Validate() error {
@@ -15,8 +25,162 @@ func (createAccountRequest *CreateTransactionRequest) Validate() error {
 	if createAccountRequest.OperationTypeId == 0 {
 		return errors.New("operation_type_id is mandatory")
 	}
-	if createAccountRequest.Amount == 0.0 {
+	if createAccountRequest.Amount.IsZero() {
 		return errors.New("amount should be non-zero")
 	}
+	// Whether the operation type takes installments is up to its handler, in the operation service.
+	if createAccountRequest.InstallmentCount != nil {
+		if *createAccountRequest.InstallmentCount < constantPackage.MIN_INSTALLMENT_COUNT || *createAccountRequest.InstallmentCount > constantPackage.MAX_INSTALLMENT_COUNT {
+			return fmt.Errorf("installment_count should be between %d and %d", constantPackage.MIN_INSTALLMENT_COUNT, constantPackage.MAX_INSTALLMENT_COUNT)
+		}
+	}
+	// Without a currency, the scale is checked by the core once the account currency is known.
+	if createAccountRequest.Currency == "" {
+		return nil
+	}
+	return utilMoneyV1.ValidateScale(createAccountRequest.Amount, createAccountRequest.Currency)
+}
+
+// ReverseTransactionRequest holds the amount to reverse, in the account currency; without it the whole
+// remaining amount is reversed. Its scale is checked by the core against the account currency.
+type ReverseTransactionRequest struct {
+	Amount *utilMoneyV1.Amount `json:"amount"`
+}
+
+func (reverseRequest *ReverseTransactionRequest) Validate() error {
+	if reverseRequest.Amount == nil {
+		return nil
+	}
+	if reverseRequest.Amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
 	return nil
 }
+
+// ListTransactionsRequest holds the query parameters of the transaction listing.
+type ListTransactionsRequest struct {
+	AccountId       *int
+	OperationTypeId *int
+	MinAmount       *utilMoneyV1.Amount
+	MaxAmount       *utilMoneyV1.Amount
+	From            *time.Time // created_at >= from, RFC 3339
+	To              *time.Time // created_at < to, RFC 3339
+	Cursor          string     // opaque next_cursor of the previous page
+	Limit           int
+	Order           string
+}
+
+// ParseListTransactionsRequest reads the listing query parameters, applying the default page size and order.
+func ParseListTransactionsRequest(query url.Values) (*ListTransactionsRequest, error) {
+	listRequest := &ListTransactionsRequest{
+		Cursor: query.Get("cursor"),
+		Limit:  constantPackage.DEFAULT_PAGE_LIMIT,
+		Order:  constantPackage.SORT_ORDER_DESC,
+	}
+	var err error
+	if listRequest.AccountId, err = parseIntParam(query, "account_id"); err != nil {
+		return nil, err
+	}
+	if listRequest.OperationTypeId, err = parseIntParam(query, "operation_type_id"); err != nil {
+		return nil, err
+	}
+	if listRequest.MinAmount, err = parseAmountParam(query, "min_amount"); err != nil {
+		return nil, err
+	}
+	if listRequest.MaxAmount, err = parseAmountParam(query, "max_amount"); err != nil {
+		return nil, err
+	}
+	if listRequest.From, err = parseTimeParam(query, "from"); err != nil {
+		return nil, err
+	}
+	if listRequest.To, err = parseTimeParam(query, "to"); err != nil {
+		return nil, err
+	}
+	if limit, err := parseIntParam(query, "limit"); err != nil {
+		return nil, err
+	} else if limit != nil {
+		listRequest.Limit = *limit
+	}
+	if order := query.Get("order"); order != "" {
+		listRequest.Order = order
+	}
+	return listRequest, nil
+}
+
+func (listRequest *ListTransactionsRequest) Validate() error {
+	if listRequest.Limit < 1 || listRequest.Limit > constantPackage.MAX_PAGE_LIMIT {
+		return fmt.Errorf("limit should be between 1 and %d", constantPackage.MAX_PAGE_LIMIT)
+	}
+	if listRequest.Order != constantPackage.SORT_ORDER_ASC && listRequest.Order != constantPackage.SORT_ORDER_DESC {
+		return fmt.Errorf("order should be %s or %s", constantPackage.SORT_ORDER_ASC, constantPackage.SORT_ORDER_DESC)
+	}
+	if listRequest.MinAmount != nil && listRequest.MaxAmount != nil && listRequest.MinAmount.Cmp(*listRequest.MaxAmount) > 0 {
+		return errors.New("min_amount should not be greater than max_amount")
+	}
+	if listRequest.From != nil && listRequest.To != nil && !listRequest.From.Before(*listRequest.To) {
+		return errors.New("from should be before to")
+	}
+	return nil
+}
+
+// ListUpcomingInstallmentsRequest holds the query parameters of the upcoming installments listing.
+type ListUpcomingInstallmentsRequest struct {
+	AccountId int
+	From      time.Time // earliest due date, YYYY-MM-DD
+}
+
+// ParseListUpcomingInstallmentsRequest reads the upcoming installments query parameters; from defaults to today.
+func ParseListUpcomingInstallmentsRequest(query url.Values, now time.Time) (*ListUpcomingInstallmentsRequest, error) {
+	accountId, err := parseIntParam(query, "account_id")
+	if err != nil {
+		return nil, err
+	}
+	if accountId == nil {
+		return nil, errors.New("account_id is mandatory")
+	}
+	year, month, day := now.UTC().Date()
+	listRequest := &ListUpcomingInstallmentsRequest{AccountId: *accountId, From: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	if raw := query.Get("from"); raw != "" {
+		listRequest.From, err = time.Parse(constantPackage.INSTALLMENT_DATE_LAYOUT, raw)
+		if err != nil {
+			return nil, errors.New("from should be a YYYY-MM-DD date")
+		}
+	}
+	return listRequest, nil
+}
+
+func parseIntParam(query url.Values, name string) (*int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s should be an integer", name)
+	}
+	return &value, nil
+}
+
+func parseAmountParam(query url.Values, name string) (*utilMoneyV1.Amount, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := utilMoneyV1.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err.Error())
+	}
+	return &value, nil
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s should be an RFC 3339 timestamp", name)
+	}
+	return &value, nil
+}
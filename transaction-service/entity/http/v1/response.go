@@ -1,11 +1,55 @@
 package transaction_entity_http_v1
 
-import "time"
-
-type CreateTransactionResponse struct {
-	TransactionID   int       `json:"transaction_id"`
-	AccountId       int       `json:"account_id"`
-	OperationTypeId int       `json:"operation_type_id"`
-	Amount          float64   `json:"amount"`
-	EventDate       time.Time `json:"event_date"`
+import (
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"time"
+)
+
+// TransactionResponse is the read model of a transaction, shared by the create, get and list endpoints.
+type TransactionResponse struct {
+	TransactionID   int                `json:"transaction_id"`
+	AccountId       int                `json:"account_id"`
+	OperationTypeId int                `json:"operation_type_id"`
+	Amount          utilMoneyV1.Amount `json:"amount"`
+	Balance         utilMoneyV1.Amount `json:"balance"`
+	Currency        string             `json:"currency"`
+	FraudDecision   string             `json:"fraud_decision"`
+	FraudRules      []string           `json:"fraud_rules"`
+	EventDate       time.Time          `json:"event_date"`
+
+	InstallmentCount int                   `json:"installment_count,omitempty"`
+	Installments     []InstallmentResponse `json:"installments,omitempty"`
+
+	Status                string             `json:"status"`
+	ReversedAmount        utilMoneyV1.Amount `json:"reversed_amount"`
+	OriginalTransactionID *int               `json:"original_transaction_id,omitempty"` // set on a reversal
+
+	OriginalAmount   utilMoneyV1.Amount `json:"original_amount"`
+	OriginalCurrency string             `json:"original_currency"`
+	FxRate           utilMoneyV1.Rate   `json:"fx_rate"`
+
+	InputAmount utilMoneyV1.Amount `json:"input_amount"`
+	Coefficient int                `json:"coefficient"`
+
+	FeeCode             string        `json:"fee_code,omitempty"`              // set on a fee
+	ParentTransactionID *int          `json:"parent_transaction_id,omitempty"` // set on a fee
+	Fees                []FeeResponse `json:"fees,omitempty"`                  // breakdown of the fees charged on the transaction
+}
+
+// FeeResponse is the read model of one fee charged on a transaction.
+type FeeResponse struct {
+	TransactionID int                `json:"transaction_id"`
+	Code          string             `json:"code"`
+	Amount        utilMoneyV1.Amount `json:"amount"` // signed like the fee transaction
+}
+
+// InstallmentResponse is the read model of one installment of a purchase.
+type InstallmentResponse struct {
+	InstallmentID int                `json:"installment_id"`
+	TransactionID int                `json:"transaction_id"`
+	AccountId     int                `json:"account_id"`
+	Number        int                `json:"number"`
+	Amount        utilMoneyV1.Amount `json:"amount"`
+	DueDate       string             `json:"due_date"` // YYYY-MM-DD
+	Status        string             `json:"status"`
 }
@@ -7,7 +7,12 @@ import (
 	routerV1Package "anti-fraud/transaction-service/routes/v1"
 
 	accountClientV1Package "anti-fraud/mediator-service/account-service-client"
+	eventBusV1Package "anti-fraud/mediator-service/event-bus"
+	fraudClientV1Package "anti-fraud/mediator-service/fraud-service-client"
+	fxClientV1Package "anti-fraud/mediator-service/fx-service-client"
 	operationClientV1Package "anti-fraud/mediator-service/operation-service-client"
+	transactionClientV1Package "anti-fraud/mediator-service/transaction-service-client"
+	utilIdempotencyV1 "anti-fraud/utils-server/idempotency/v1"
 	middlewareHandlerPackageV1 "anti-fraud/utils-server/middleware/v1"
 
 	"github.com/gorilla/mux"
@@ -22,12 +27,16 @@ type TransactionManager struct {
 	logger          *logrus.Logger
 	operationClient operationClientV1Package.IOperationClient
 	accountClient   accountClientV1Package.IAccountClient
+	fraudClient     fraudClientV1Package.IFraudClient
+	fxClient        fxClientV1Package.IFxClient
+	eventBus        eventBusV1Package.IEventBus
+	coreV1          coreV1Package.ITransactionCore
 }
 
 // NewTransactionManager create and return new instance of TransactionManager.
-func NewTransactionManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, operationClient operationClientV1Package.IOperationClient, accountClient accountClientV1Package.IAccountClient) *TransactionManager {
+func NewTransactionManager(db *gorm.DB, router *mux.Router, logger *logrus.Logger, operationClient operationClientV1Package.IOperationClient, accountClient accountClientV1Package.IAccountClient, fraudClient fraudClientV1Package.IFraudClient, fxClient fxClientV1Package.IFxClient, eventBus eventBusV1Package.IEventBus) *TransactionManager {
 
-	return &TransactionManager{db: db, router: router, logger: logger, operationClient: operationClient, accountClient: accountClient}
+	return &TransactionManager{db: db, router: router, logger: logger, operationClient: operationClient, accountClient: accountClient, fraudClient: fraudClient, fxClient: fxClient, eventBus: eventBus}
 }
 
 // Init instantiate and wire all components, register routes for transaction-service.
@@ -35,8 +44,14 @@ func (mw *TransactionManager) Init() {
 
 	middlewareHandler := middlewareHandlerPackageV1.NewMiddlewareHandler(mw.logger)
 	repoV1 := repoV1Package.NewTransactionRepository(mw.logger)
-	coreV1 := coreV1Package.NewTransactionCore(repoV1, mw.logger, mw.operationClient, mw.accountClient)
-	controllerV1 := controllerV1Package.NewTransactionController(repoV1, coreV1, mw.db, mw.logger)
+	mw.coreV1 = coreV1Package.NewTransactionCore(repoV1, mw.logger, mw.operationClient, mw.accountClient, mw.fraudClient, mw.fxClient, mw.eventBus)
+	idempotencyStore := utilIdempotencyV1.NewIdempotencyStore(mw.logger)
+	controllerV1 := controllerV1Package.NewTransactionController(repoV1, mw.coreV1, idempotencyStore, mw.db, mw.logger)
 	router := routerV1Package.NewTransactionRoutes(controllerV1, mw.router, middlewareHandler)
 	router.Init()
 }
+
+// ConfigureClient configure core instance of transaction service in transaction-client.
+func (mw *TransactionManager) ConfigureClient(client transactionClientV1Package.ITransactionClient) {
+	client.SetupCore(mw.coreV1)
+}
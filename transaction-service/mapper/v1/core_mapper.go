@@ -3,12 +3,68 @@ package transaction_mapper_v1
 import (
 	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityHttpV1Package "anti-fraud/transaction-service/entity/http/v1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
 )
 
 func CreateTransactionPayloadMapper(transactionCreationRequest *entityHttpV1Package.CreateTransactionRequest) *entityCoreV1Package.CreateTransactionPayload {
-	return &entityCoreV1Package.CreateTransactionPayload{
+	payload := &entityCoreV1Package.CreateTransactionPayload{
 		AccountId:       transactionCreationRequest.AccountId,
 		OperationTypeId: transactionCreationRequest.OperationTypeId,
 		Amount:          transactionCreationRequest.Amount,
+		Currency:        transactionCreationRequest.Currency,
 	}
+	if transactionCreationRequest.InstallmentCount != nil {
+		payload.InstallmentCount = *transactionCreationRequest.InstallmentCount
+	}
+	return payload
+}
+
+func ReverseTransactionPayloadMapper(reverseRequest *entityHttpV1Package.ReverseTransactionRequest) *entityCoreV1Package.ReverseTransactionPayload {
+	return &entityCoreV1Package.ReverseTransactionPayload{Amount: reverseRequest.Amount}
+}
+
+func TransactionFilterMapper(listRequest *entityHttpV1Package.ListTransactionsRequest) (*entityCoreV1Package.TransactionFilter, error) {
+	filter := &entityCoreV1Package.TransactionFilter{
+		AccountId:       listRequest.AccountId,
+		OperationTypeId: listRequest.OperationTypeId,
+		MinAmount:       listRequest.MinAmount,
+		MaxAmount:       listRequest.MaxAmount,
+		CreatedFrom:     listRequest.From,
+		CreatedTo:       listRequest.To,
+		SortOrder:       listRequest.Order,
+		Limit:           listRequest.Limit,
+	}
+	if listRequest.Cursor != "" {
+		cursor, err := DecodeTransactionCursor(listRequest.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+	return filter, nil
+}
+
+// EncodeTransactionCursor renders a cursor as an opaque URL-safe token.
+func EncodeTransactionCursor(cursor *entityCoreV1Package.TransactionCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.Id)))
+}
+
+// DecodeTransactionCursor parses a token produced by EncodeTransactionCursor.
+func DecodeTransactionCursor(token string) (*entityCoreV1Package.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	var createdAt int64
+	var id uint
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &createdAt, &id); err != nil {
+		return nil, errors.New("cursor is invalid")
+	}
+	return &entityCoreV1Package.TransactionCursor{CreatedAt: time.Unix(0, createdAt), Id: id}, nil
 }
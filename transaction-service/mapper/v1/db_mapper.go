@@ -1,14 +1,90 @@
 package transaction_mapper_v1
 
 import (
+	fraudConstantPackage "anti-fraud/constants/fraud"
+	operationConstantPackage "anti-fraud/constants/operation"
+	constantPackage "anti-fraud/constants/transaction"
 	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 )
 
 func TransactionMapper(transactionPayload *entityCoreV1Package.CreateTransactionPayload) *entityDbV1Package.Transaction {
 	return &entityDbV1Package.Transaction{
-		AccountId:       transactionPayload.AccountId,
-		OperationTypeId: transactionPayload.OperationTypeId,
-		Amount:          transactionPayload.Amount,
+		AccountId:        transactionPayload.AccountId,
+		OperationTypeId:  transactionPayload.OperationTypeId,
+		Amount:           transactionPayload.Amount,
+		InstallmentCount: transactionPayload.InstallmentCount,
+		Status:           constantPackage.STATUS_POSTED,
+		OriginalAmount:   transactionPayload.Amount,
+		OriginalCurrency: transactionPayload.Currency,
+		InputAmount:      transactionPayload.Amount,
 	}
 }
+
+// CapturedTransactionMapper builds the transaction settling a captured authorization hold.
+func CapturedTransactionMapper(capturePayload *entityCoreV1Package.CapturedTransactionPayload) *entityDbV1Package.Transaction {
+	return &entityDbV1Package.Transaction{
+		AccountId:        capturePayload.AccountId,
+		OperationTypeId:  capturePayload.OperationTypeId,
+		Amount:           capturePayload.Amount,
+		Balance:          capturePayload.Amount,
+		Currency:         capturePayload.Currency,
+		FraudDecision:    capturePayload.FraudDecision,
+		FraudRules:       capturePayload.FraudRules,
+		Status:           constantPackage.STATUS_POSTED,
+		OriginalAmount:   capturePayload.Amount,
+		OriginalCurrency: capturePayload.Currency,
+		FxRate:           utilMoneyV1.IdentityRate,
+		InputAmount:      capturePayload.InputAmount,
+		Coefficient:      capturePayload.Coefficient,
+	}
+}
+
+// ReversalTransactionMapper builds the compensating transaction of original for a signed amount.
+// Reversals are not run through the fraud engine: they only give back what original took.
+// A reversal is expressed in the account currency, whatever currency original was submitted in,
+// and applies the opposite of the coefficient original was computed with.
+func ReversalTransactionMapper(original *entityDbV1Package.Transaction, amount utilMoneyV1.Amount) *entityDbV1Package.Transaction {
+	originalId := original.ID
+	return &entityDbV1Package.Transaction{
+		AccountId:             original.AccountId,
+		OperationTypeId:       original.OperationTypeId,
+		Amount:                amount,
+		Balance:               amount,
+		Currency:              original.Currency,
+		FraudDecision:         fraudConstantPackage.DECISION_APPROVE,
+		Status:                constantPackage.STATUS_POSTED,
+		OriginalTransactionId: &originalId,
+		OriginalAmount:        amount,
+		OriginalCurrency:      original.Currency,
+		FxRate:                utilMoneyV1.IdentityRate,
+		InputAmount:           amount.Abs(),
+		Coefficient:           -original.Coefficient,
+	}
+}
+
+// FeeTransactionMapper builds the debit charging a fee on parent, for a positive fee amount, with the
+// operation type of kind FEE the operation service resolved. A fee is booked in the account currency
+// at the event time of parent, shares its fraud decision and is linked to it.
+func FeeTransactionMapper(parent *entityDbV1Package.Transaction, operationTypeId int, code string, amount utilMoneyV1.Amount) *entityDbV1Package.Transaction {
+	parentId := parent.ID
+	feeTransaction := &entityDbV1Package.Transaction{
+		AccountId:           parent.AccountId,
+		OperationTypeId:     operationTypeId,
+		Amount:              amount.Neg(),
+		Balance:             amount.Neg(),
+		Currency:            parent.Currency,
+		FraudDecision:       parent.FraudDecision,
+		Status:              constantPackage.STATUS_POSTED,
+		OriginalAmount:      amount.Neg(),
+		OriginalCurrency:    parent.Currency,
+		FxRate:              utilMoneyV1.IdentityRate,
+		InputAmount:         amount,
+		Coefficient:         operationConstantPackage.COEFFICIENT_DEBIT,
+		FeeCode:             code,
+		ParentTransactionId: &parentId,
+	}
+	feeTransaction.CreatedAt = parent.CreatedAt
+	return feeTransaction
+}
@@ -1,16 +1,86 @@
 package transaction_mapper_v1
 
 import (
+	constantPackage "anti-fraud/constants/transaction"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
 	entityHttpV1Package "anti-fraud/transaction-service/entity/http/v1"
+	"strings"
 )
 
-func TransactionDetailsResponseMapper(transaction *entityDbV1Package.Transaction) *entityHttpV1Package.CreateTransactionResponse {
-	return &entityHttpV1Package.CreateTransactionResponse{
-		TransactionID:   int(transaction.ID),
-		AccountId:       transaction.AccountId,
-		OperationTypeId: transaction.OperationTypeId,
-		Amount:          transaction.Amount,
-		EventDate:       transaction.CreatedAt,
+func TransactionDetailsResponseMapper(transaction *entityDbV1Package.Transaction) *entityHttpV1Package.TransactionResponse {
+	fraudRules := []string{}
+	if transaction.FraudRules != "" {
+		fraudRules = strings.Split(transaction.FraudRules, ",")
 	}
+	var originalTransactionId *int
+	if transaction.OriginalTransactionId != nil {
+		id := int(*transaction.OriginalTransactionId)
+		originalTransactionId = &id
+	}
+	var parentTransactionId *int
+	if transaction.ParentTransactionId != nil {
+		id := int(*transaction.ParentTransactionId)
+		parentTransactionId = &id
+	}
+	return &entityHttpV1Package.TransactionResponse{
+		TransactionID:    int(transaction.ID),
+		AccountId:        transaction.AccountId,
+		OperationTypeId:  transaction.OperationTypeId,
+		Amount:           transaction.Amount,
+		Balance:          transaction.Balance,
+		Currency:         transaction.Currency,
+		FraudDecision:    transaction.FraudDecision,
+		FraudRules:       fraudRules,
+		EventDate:        transaction.CreatedAt,
+		InstallmentCount: transaction.InstallmentCount,
+		Installments:     InstallmentListResponseMapper(transaction.Installments),
+
+		Status:                transaction.Status,
+		ReversedAmount:        transaction.ReversedAmount,
+		OriginalTransactionID: originalTransactionId,
+		OriginalAmount:        transaction.OriginalAmount,
+		OriginalCurrency:      transaction.OriginalCurrency,
+		FxRate:                transaction.FxRate,
+		InputAmount:           transaction.InputAmount,
+		Coefficient:           transaction.Coefficient,
+		FeeCode:               transaction.FeeCode,
+		ParentTransactionID:   parentTransactionId,
+		Fees:                  FeeListResponseMapper(transaction.Fees),
+	}
+}
+
+func FeeListResponseMapper(fees []*entityDbV1Package.Transaction) []entityHttpV1Package.FeeResponse {
+	response := make([]entityHttpV1Package.FeeResponse, 0, len(fees))
+	for _, fee := range fees {
+		response = append(response, entityHttpV1Package.FeeResponse{
+			TransactionID: int(fee.ID),
+			Code:          fee.FeeCode,
+			Amount:        fee.Amount,
+		})
+	}
+	return response
+}
+
+func TransactionListResponseMapper(transactions []*entityDbV1Package.Transaction) []*entityHttpV1Package.TransactionResponse {
+	response := make([]*entityHttpV1Package.TransactionResponse, 0, len(transactions))
+	for _, transaction := range transactions {
+		response = append(response, TransactionDetailsResponseMapper(transaction))
+	}
+	return response
+}
+
+func InstallmentListResponseMapper(installments []*entityDbV1Package.Installment) []entityHttpV1Package.InstallmentResponse {
+	response := make([]entityHttpV1Package.InstallmentResponse, 0, len(installments))
+	for _, installment := range installments {
+		response = append(response, entityHttpV1Package.InstallmentResponse{
+			InstallmentID: int(installment.ID),
+			TransactionID: int(installment.TransactionId),
+			AccountId:     installment.AccountId,
+			Number:        installment.Number,
+			Amount:        installment.Amount,
+			DueDate:       installment.DueDate.Format(constantPackage.INSTALLMENT_DATE_LAYOUT),
+			Status:        installment.Status,
+		})
+	}
+	return response
 }
@@ -1,18 +1,71 @@
 package transaction_repo_v1
 
 import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	fraudConstantPackage "anti-fraud/constants/fraud"
 	constantPackage "anti-fraud/constants/transaction"
+	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
+	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ITransactionRepository defines methods interface for performing operations in the db.
 type ITransactionRepository interface {
 
 	// CreateTransaction persists a Transaction entity to the db.
-	CreateTransaction(logger *logrus.Entry, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+	CreateTransaction(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// GetOutstandingTransactions locks and returns the account's transactions with a negative balance, oldest first.
+	GetOutstandingTransactions(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+
+	// UpdateTransactionBalance persists the balance of a Transaction entity.
+	UpdateTransactionBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// GetTransaction fetches a Transaction entity by its ID.
+	GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+
+	// GetFeeTransactions fetches the fee transactions charged on a transaction, by ID.
+	GetFeeTransactions(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+
+	// GetTransactionForUpdate fetches and locks a Transaction entity by its ID.
+	GetTransactionForUpdate(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error)
+
+	// UpdateTransactionReversal persists the status, reversed amount and balance of a reversed Transaction entity.
+	UpdateTransactionReversal(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error
+
+	// ListTransactions fetches the Transaction entities matching a filter, in (created_at, id) order.
+	ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+
+	// GetPostedTransactions fetches the account's non-declined transactions created within [from, to), oldest first.
+	GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error)
+
+	// GetPostedBalance sums the amounts of the account's non-declined transactions created before a time.
+	GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error)
+
+	// CountOperationTypeTransactions counts the transactions, declined ones included, of an operation type.
+	CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error)
+
+	// CreateInstallments persists the installment schedule of a purchase.
+	CreateInstallments(ctx context.Context, installments []*entityDbV1Package.Installment, tx *gorm.DB) error
+
+	// GetInstallments fetches the installment schedule of a purchase, in installment order.
+	GetInstallments(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Installment, error)
+
+	// UpdateInstallment persists the amount and status of an Installment entity.
+	UpdateInstallment(ctx context.Context, installment *entityDbV1Package.Installment, tx *gorm.DB) error
+
+	// GetUpcomingInstallments fetches the account's pending installments due on or after a date, by due date.
+	GetUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error)
 }
 
 // TransactionRepository implements the ITransactionRepository interface.
@@ -38,11 +91,383 @@ func NewTransactionRepository(logger *logrus.Logger) *TransactionRepository {
 //
 // Returns:
 //   - error: an encountered Error. else return nil.
-func (repo *TransactionRepository) CreateTransaction(logger *logrus.Entry, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+func (repo *TransactionRepository) CreateTransaction(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
 	logger.Info("CreateTransaction method called in transaction repo layer.")
-	result := tx.Table(constantPackage.TABLE_NAME).Create(transaction)
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Create(transaction)
 	if result.Error != nil {
 		logger.Errorf("Failed to create account: %v", result.Error)
 	}
 	return result.Error
 }
+
+// GetOutstandingTransactions fetches the account's transactions that still have a negative balance.
+//
+// Steps:
+//  1. Filter by account, negative balance and non-declined fraud decision.
+//  2. Order by creation (oldest first) so callers can discharge them FIFO.
+//  3. Lock the rows (SELECT ... FOR UPDATE) so concurrent credits cannot discharge the same balance twice.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - tx:        db txn.
+//
+// Returns:
+//   - Outstanding transactions, oldest first.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetOutstandingTransactions(ctx context.Context, accountId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetOutstandingTransactions method called in transaction repo layer.")
+	transactions := []*entityDbV1Package.Transaction{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("account_id = ? AND balance < 0 AND fraud_decision <> ?", accountId, fraudConstantPackage.DECISION_DECLINE).
+		Order("created_at ASC, id ASC").
+		Find(&transactions)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching outstanding transactions: %s", result.Error.Error())
+	}
+	return transactions, result.Error
+}
+
+// UpdateTransactionBalance updates the balance column of a transaction.
+//
+// Parameters:
+//   - transaction: entity db transaction carrying the new balance.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an encountered Error. else return nil.
+func (repo *TransactionRepository) UpdateTransactionBalance(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateTransactionBalance method called in transaction repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("id = ?", transaction.ID).
+		Update("balance", transaction.Balance)
+	if result.Error != nil {
+		logger.Errorf("Failed to update transaction balance: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetTransaction fetches a transaction by its ID.
+//
+// Steps:
+//  1. Query the table with the given transaction ID.
+//  2. If the record is not found, return a not found Error.
+//
+// Parameters:
+//   - transactionId: ID of the transaction.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The transaction.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetTransaction(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetTransaction method called in transaction repo layer.")
+	transaction := &entityDbV1Package.Transaction{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Where("id = ?", transactionId).First(transaction)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		logger.Errorf("Failed to find transaction with transactionId: %d", transactionId)
+		return transaction, utilErrorsV1.NewNotFoundError(errorConstantPackage.TRANSACTION_NOT_FOUND, fmt.Sprintf("transaction_id: %d not found in database", transactionId))
+	}
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching transaction: %s", result.Error.Error())
+	}
+	return transaction, result.Error
+}
+
+// GetFeeTransactions fetches the fees charged on a transaction.
+//
+// Parameters:
+//   - transactionId: ID of the transaction the fees are charged on.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The fee transactions, by ID; empty when none was charged.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetFeeTransactions(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetFeeTransactions method called in transaction repo layer.")
+	fees := []*entityDbV1Package.Transaction{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("parent_transaction_id = ? AND deleted_at IS NULL", transactionId).
+		Order("id ASC").
+		Find(&fees)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching fee transactions: %s", result.Error.Error())
+	}
+	return fees, result.Error
+}
+
+// ListTransactions fetches the transactions matching a filter.
+//
+// Steps:
+//  1. Apply every non-nil filter (account, operation type, amount range, created_at range).
+//  2. Resume after the filter cursor: keyset pagination on (created_at, id), so pages stay
+//     stable while new transactions are inserted.
+//  3. Order by (created_at, id) in the filter sort order and fetch at most filter.Limit rows.
+//
+// Parameters:
+//   - filter: listing filter, sort order, page size and cursor.
+//   - tx:     db txn.
+//
+// Returns:
+//   - The matching transactions.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) ListTransactions(ctx context.Context, filter *entityCoreV1Package.TransactionFilter, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("ListTransactions method called in transaction repo layer.")
+	query := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Where("deleted_at IS NULL")
+
+	// 1. Filters.
+	if filter.AccountId != nil {
+		query = query.Where("account_id = ?", *filter.AccountId)
+	}
+	if filter.OperationTypeId != nil {
+		query = query.Where("operation_type_id = ?", *filter.OperationTypeId)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	// 2. Cursor and 3. order.
+	direction, comparator := "ASC", ">"
+	if filter.SortOrder == constantPackage.SORT_ORDER_DESC {
+		direction, comparator = "DESC", "<"
+	}
+	if filter.After != nil {
+		query = query.Where(
+			fmt.Sprintf("created_at %s ? OR (created_at = ? AND id %s ?)", comparator, comparator),
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.Id,
+		)
+	}
+
+	transactions := []*entityDbV1Package.Transaction{}
+	result := query.
+		Order(fmt.Sprintf("created_at %s, id %s", direction, direction)).
+		Limit(filter.Limit).
+		Find(&transactions)
+	if result.Error != nil {
+		logger.Errorf("Error occured while listing transactions: %s", result.Error.Error())
+	}
+	return transactions, result.Error
+}
+
+// GetPostedTransactions fetches the transactions that moved the account within a period.
+//
+// Steps:
+//  1. Filter by account, created_at in [from, to) and non-declined fraud decision.
+//  2. Order by creation (oldest first), so callers can compute a running balance.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - from:      start of the period, inclusive.
+//   - to:        end of the period, exclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The posted transactions, oldest first.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetPostedTransactions(ctx context.Context, accountId int, from time.Time, to time.Time, tx *gorm.DB) ([]*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedTransactions method called in transaction repo layer.")
+	transactions := []*entityDbV1Package.Transaction{}
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("account_id = ? AND created_at >= ? AND created_at < ? AND fraud_decision <> ? AND deleted_at IS NULL",
+			accountId, from, to, fraudConstantPackage.DECISION_DECLINE).
+		Order("created_at ASC, id ASC").
+		Find(&transactions)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching posted transactions: %s", result.Error.Error())
+	}
+	return transactions, result.Error
+}
+
+// GetPostedBalance sums the signed amounts of the account's non-declined transactions created before a time.
+//
+// Parameters:
+//   - accountId: account owning the transactions.
+//   - before:    exclusive upper bound on created_at.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The balance, zero when the account has no transaction.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetPostedBalance(ctx context.Context, accountId int, before time.Time, tx *gorm.DB) (utilMoneyV1.Amount, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetPostedBalance method called in transaction repo layer.")
+	var balance utilMoneyV1.Amount
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_id = ? AND created_at < ? AND fraud_decision <> ? AND deleted_at IS NULL",
+			accountId, before, fraudConstantPackage.DECISION_DECLINE).
+		Row().Scan(&balance)
+	if result != nil {
+		logger.Errorf("Error occured while computing posted balance: %s", result.Error())
+	}
+	return balance, result
+}
+
+// CountOperationTypeTransactions counts the transactions referencing an operation type, whatever their decision or status.
+//
+// Parameters:
+//   - operationTypeId: ID of the operation type.
+//   - tx:              db txn.
+//
+// Returns:
+//   - The number of transactions.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) CountOperationTypeTransactions(ctx context.Context, operationTypeId int, tx *gorm.DB) (int64, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CountOperationTypeTransactions method called in transaction repo layer.")
+	var count int64
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).Where("operation_type_id = ? AND deleted_at IS NULL", operationTypeId).Count(&count)
+	if result.Error != nil {
+		logger.Errorf("Error occured while counting transactions of operation type: %s", result.Error.Error())
+	}
+	return count, result.Error
+}
+
+// CreateInstallments inserts the installment records of a purchase in one statement.
+//
+// Parameters:
+//   - installments: entity db installments, already linked to their parent transaction.
+//   - tx:           db txn, the same one that persists the parent transaction.
+//
+// Returns:
+//   - error: an encountered Error. else return nil.
+func (repo *TransactionRepository) CreateInstallments(ctx context.Context, installments []*entityDbV1Package.Installment, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("CreateInstallments method called in transaction repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.INSTALLMENT_TABLE_NAME).Create(installments)
+	if result.Error != nil {
+		logger.Errorf("Failed to create installments: %v", result.Error)
+	}
+	return result.Error
+}
+
+// GetInstallments fetches the installment schedule of a purchase.
+//
+// Parameters:
+//   - transactionId: ID of the parent purchase.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The installments, in installment order; empty for a purchase without installments.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetInstallments(ctx context.Context, transactionId int, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetInstallments method called in transaction repo layer.")
+	installments := []*entityDbV1Package.Installment{}
+	result := tx.WithContext(ctx).Table(constantPackage.INSTALLMENT_TABLE_NAME).
+		Where("transaction_id = ? AND deleted_at IS NULL", transactionId).
+		Order("number ASC").
+		Find(&installments)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching installments: %s", result.Error.Error())
+	}
+	return installments, result.Error
+}
+
+// GetUpcomingInstallments fetches the account's installments still to be paid.
+//
+// Steps:
+//  1. Filter by account, pending status and due date on or after from.
+//  2. Order by due date, then by purchase and installment number, so the order is stable.
+//
+// Parameters:
+//   - accountId: account owning the installments.
+//   - from:      earliest due date, inclusive.
+//   - tx:        db txn.
+//
+// Returns:
+//   - The upcoming installments, soonest first.
+//   - error: an encountered Error.
+func (repo *TransactionRepository) GetUpcomingInstallments(ctx context.Context, accountId int, from time.Time, tx *gorm.DB) ([]*entityDbV1Package.Installment, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetUpcomingInstallments method called in transaction repo layer.")
+	installments := []*entityDbV1Package.Installment{}
+	result := tx.WithContext(ctx).Table(constantPackage.INSTALLMENT_TABLE_NAME).
+		Where("account_id = ? AND status = ? AND due_date >= ? AND deleted_at IS NULL",
+			accountId, constantPackage.INSTALLMENT_STATUS_PENDING, from).
+		Order("due_date ASC, transaction_id ASC, number ASC").
+		Find(&installments)
+	if result.Error != nil {
+		logger.Errorf("Error occured while fetching upcoming installments: %s", result.Error.Error())
+	}
+	return installments, result.Error
+}
+
+// GetTransactionForUpdate fetches a transaction by its ID and locks it (SELECT ... FOR UPDATE),
+// so concurrent reversals of the same transaction run one after the other.
+//
+// Parameters:
+//   - transactionId: ID of the transaction.
+//   - tx:            db txn.
+//
+// Returns:
+//   - The transaction.
+//   - error: a not found Error if it does not exist, or any other encountered Error.
+func (repo *TransactionRepository) GetTransactionForUpdate(ctx context.Context, transactionId int, tx *gorm.DB) (*entityDbV1Package.Transaction, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("GetTransactionForUpdate method called in transaction repo layer.")
+	return repo.GetTransaction(ctx, transactionId, tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
+// UpdateTransactionReversal updates the reversal state of a transaction.
+//
+// Parameters:
+//   - transaction: entity db transaction carrying the new status, reversed amount and balance.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an encountered Error. else return nil.
+func (repo *TransactionRepository) UpdateTransactionReversal(ctx context.Context, transaction *entityDbV1Package.Transaction, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateTransactionReversal method called in transaction repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.TABLE_NAME).
+		Where("id = ?", transaction.ID).
+		Updates(map[string]interface{}{
+			"status":          transaction.Status,
+			"reversed_amount": transaction.ReversedAmount,
+			"balance":         transaction.Balance,
+		})
+	if result.Error != nil {
+		logger.Errorf("Failed to update transaction reversal: %v", result.Error)
+	}
+	return result.Error
+}
+
+// UpdateInstallment updates the amount and status of an installment.
+//
+// Parameters:
+//   - installment: entity db installment carrying the new amount and status.
+//   - tx:          db txn.
+//
+// Returns:
+//   - error: an encountered Error. else return nil.
+func (repo *TransactionRepository) UpdateInstallment(ctx context.Context, installment *entityDbV1Package.Installment, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("UpdateInstallment method called in transaction repo layer.")
+	result := tx.WithContext(ctx).Table(constantPackage.INSTALLMENT_TABLE_NAME).
+		Where("id = ?", installment.ID).
+		Updates(map[string]interface{}{
+			"amount": installment.Amount,
+			"status": installment.Status,
+		})
+	if result.Error != nil {
+		logger.Errorf("Failed to update installment: %v", result.Error)
+	}
+	return result.Error
+}
@@ -1,10 +1,16 @@
 package transaction_repo_v1
 
 import (
+	fraudConstantPackage "anti-fraud/constants/fraud"
 	constantPackage "anti-fraud/constants/transaction"
+	entityCoreV1Package "anti-fraud/transaction-service/entity/core/v1"
 	entityDbV1Package "anti-fraud/transaction-service/entity/db/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	utilMoneyV1 "anti-fraud/utils-server/money/v1"
 
+	"context"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +23,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("failed to open in-memory DB: %v", err)
 	}
-	err = db.AutoMigrate(&entityDbV1Package.Transaction{})
+	err = db.AutoMigrate(&entityDbV1Package.Transaction{}, &entityDbV1Package.Installment{})
 	if err != nil {
 		t.Fatalf("failed to migrate schema: %v", err)
 	}
@@ -33,10 +39,10 @@ func TestCreateTransaction_Success(t *testing.T) {
 	txModel := &entityDbV1Package.Transaction{
 		AccountId:       123,
 		OperationTypeId: 1,
-		Amount:          100.50,
+		Amount:          utilMoneyV1.MustParse("100.5"),
 	}
 
-	err := repo.CreateTransaction(logrus.NewEntry(logrus.New()), txModel, db)
+	err := repo.CreateTransaction(context.Background(), txModel, db)
 	assert.NoError(t, err, "expected no error inserting transaction")
 	assert.NotZero(t, txModel.ID, "expected transaction to have a generated ID after insert")
 
@@ -60,10 +66,286 @@ func TestCreateTransaction_DBError(t *testing.T) {
 	txModel := &entityDbV1Package.Transaction{
 		AccountId:       999,
 		OperationTypeId: 2,
-		Amount:          500,
+		Amount:          utilMoneyV1.MustParse("500"),
 	}
 
-	err = repo.CreateTransaction(logrus.NewEntry(logrus.New()), txModel, db)
+	err = repo.CreateTransaction(context.Background(), txModel, db)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "database is closed")
 }
+
+func TestGetOutstandingTransactions_OldestFirst(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+
+	first := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50"), Balance: utilMoneyV1.MustParse("-50"), FraudDecision: fraudConstantPackage.DECISION_APPROVE}
+	settled := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-20"), Balance: utilMoneyV1.MustParse("0"), FraudDecision: fraudConstantPackage.DECISION_APPROVE}
+	declined := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-30"), Balance: utilMoneyV1.MustParse("-30"), FraudDecision: fraudConstantPackage.DECISION_DECLINE}
+	second := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-10"), Balance: utilMoneyV1.MustParse("-10"), FraudDecision: fraudConstantPackage.DECISION_REVIEW}
+	otherAccount := &entityDbV1Package.Transaction{AccountId: 2, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-10"), Balance: utilMoneyV1.MustParse("-10"), FraudDecision: fraudConstantPackage.DECISION_APPROVE}
+	for _, transaction := range []*entityDbV1Package.Transaction{first, settled, declined, second, otherAccount} {
+		db.Create(transaction)
+	}
+
+	found, err := repo.GetOutstandingTransactions(context.Background(), 1, db)
+	assert.NoError(t, err)
+	assert.Len(t, found, 2)
+	assert.Equal(t, first.ID, found[0].ID)
+	assert.Equal(t, second.ID, found[1].ID)
+}
+
+func TestUpdateTransactionBalance_Success(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+
+	transaction := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50"), Balance: utilMoneyV1.MustParse("-50")}
+	db.Create(transaction)
+
+	transaction.Balance = utilMoneyV1.MustParse("-20")
+	err := repo.UpdateTransactionBalance(context.Background(), transaction, db)
+	assert.NoError(t, err)
+
+	var found entityDbV1Package.Transaction
+	db.Table(constantPackage.TABLE_NAME).First(&found, transaction.ID)
+	assert.Equal(t, utilMoneyV1.MustParse("-20"), found.Balance)
+	assert.Equal(t, utilMoneyV1.MustParse("-50"), found.Amount)
+}
+
+func TestGetTransaction_Found(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+
+	transaction := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-12.34")}
+	db.Create(transaction)
+
+	found, err := repo.GetTransaction(context.Background(), int(transaction.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, transaction.ID, found.ID)
+	assert.Equal(t, utilMoneyV1.MustParse("-12.34"), found.Amount)
+}
+
+func TestGetTransaction_NotFound(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+
+	_, err := repo.GetTransaction(context.Background(), 9999, db)
+	assert.True(t, utilErrorsV1.IsNotFound(err))
+}
+
+// seedListing inserts five transactions one minute apart; the last two share the same created_at.
+func seedListing(db *gorm.DB) []*entityDbV1Package.Transaction {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	transactions := []*entityDbV1Package.Transaction{
+		{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-10")},
+		{AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("25.5")},
+		{AccountId: 2, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-30")},
+		{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-40")},
+		{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-50")},
+	}
+	for i, transaction := range transactions {
+		minute := i
+		if i == len(transactions)-1 {
+			minute = i - 1
+		}
+		transaction.CreatedAt = base.Add(time.Duration(minute) * time.Minute)
+		db.Create(transaction)
+	}
+	return transactions
+}
+
+func TestListTransactions_Filters(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seeded := seedListing(db)
+
+	accountId, operationTypeId := 1, 1
+	minAmount, maxAmount := utilMoneyV1.MustParse("-45"), utilMoneyV1.MustParse("-10")
+	from := seeded[0].CreatedAt.Add(time.Second)
+	found, err := repo.ListTransactions(context.Background(), &entityCoreV1Package.TransactionFilter{
+		AccountId:       &accountId,
+		OperationTypeId: &operationTypeId,
+		MinAmount:       &minAmount,
+		MaxAmount:       &maxAmount,
+		CreatedFrom:     &from,
+		SortOrder:       constantPackage.SORT_ORDER_ASC,
+		Limit:           10,
+	}, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, seeded[3].ID, found[0].ID)
+}
+
+func TestListTransactions_CreatedToIsExclusive(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seeded := seedListing(db)
+
+	to := seeded[1].CreatedAt
+	found, err := repo.ListTransactions(context.Background(), &entityCoreV1Package.TransactionFilter{
+		CreatedTo: &to,
+		SortOrder: constantPackage.SORT_ORDER_ASC,
+		Limit:     10,
+	}, db)
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, seeded[0].ID, found[0].ID)
+}
+
+func TestListTransactions_KeysetPagination(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seeded := seedListing(db)
+
+	for _, order := range []string{constantPackage.SORT_ORDER_ASC, constantPackage.SORT_ORDER_DESC} {
+		var ids []uint
+		filter := &entityCoreV1Package.TransactionFilter{SortOrder: order, Limit: 2}
+		for {
+			page, err := repo.ListTransactions(context.Background(), filter, db)
+			assert.NoError(t, err)
+			if len(page) == 0 {
+				break
+			}
+			for _, transaction := range page {
+				ids = append(ids, transaction.ID)
+			}
+			last := page[len(page)-1]
+			filter.After = &entityCoreV1Package.TransactionCursor{CreatedAt: last.CreatedAt, Id: last.ID}
+		}
+
+		expected := []uint{seeded[0].ID, seeded[1].ID, seeded[2].ID, seeded[3].ID, seeded[4].ID}
+		if order == constantPackage.SORT_ORDER_DESC {
+			expected = []uint{seeded[4].ID, seeded[3].ID, seeded[2].ID, seeded[1].ID, seeded[0].ID}
+		}
+		assert.Equal(t, expected, ids, order)
+	}
+}
+
+func TestGetPostedTransactions_PeriodAndDeclined(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seeded := seedListing(db)
+	declined := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-999"), FraudDecision: fraudConstantPackage.DECISION_DECLINE}
+	declined.CreatedAt = seeded[1].CreatedAt.Add(time.Second)
+	db.Create(declined)
+
+	found, err := repo.GetPostedTransactions(context.Background(), 1, seeded[1].CreatedAt, seeded[4].CreatedAt.Add(time.Second), db)
+
+	assert.NoError(t, err)
+	assert.Len(t, found, 3)
+	assert.Equal(t, []uint{seeded[1].ID, seeded[3].ID, seeded[4].ID}, []uint{found[0].ID, found[1].ID, found[2].ID})
+}
+
+func TestGetPostedBalance(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seeded := seedListing(db)
+	declined := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 1, Amount: utilMoneyV1.MustParse("-999"), FraudDecision: fraudConstantPackage.DECISION_DECLINE}
+	declined.CreatedAt = seeded[0].CreatedAt
+	db.Create(declined)
+
+	balance, err := repo.GetPostedBalance(context.Background(), 1, seeded[3].CreatedAt, db)
+	assert.NoError(t, err)
+	assert.Equal(t, "15.5", balance.String())
+
+	balance, err = repo.GetPostedBalance(context.Background(), 3, seeded[3].CreatedAt, db)
+	assert.NoError(t, err)
+	assert.True(t, balance.IsZero())
+}
+
+func TestCountOperationTypeTransactions(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	seedListing(db)
+	db.Create(&entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 4, Amount: utilMoneyV1.MustParse("5"), FraudDecision: fraudConstantPackage.DECISION_DECLINE})
+
+	count, err := repo.CountOperationTypeTransactions(context.Background(), 4, db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	count, err = repo.CountOperationTypeTransactions(context.Background(), 3, db)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestInstallments_CreateAndFetch(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+	dueDate := func(month time.Month) time.Time { return time.Date(2026, month, 10, 0, 0, 0, 0, time.UTC) }
+
+	err := repo.CreateInstallments(ctx, []*entityDbV1Package.Installment{
+		{TransactionId: 1, AccountId: 1, Number: 1, Amount: utilMoneyV1.MustParse("-50"), DueDate: dueDate(2), Status: constantPackage.INSTALLMENT_STATUS_PENDING},
+		{TransactionId: 1, AccountId: 1, Number: 2, Amount: utilMoneyV1.MustParse("-50"), DueDate: dueDate(4), Status: constantPackage.INSTALLMENT_STATUS_PENDING},
+		{TransactionId: 2, AccountId: 1, Number: 1, Amount: utilMoneyV1.MustParse("-20"), DueDate: dueDate(3), Status: constantPackage.INSTALLMENT_STATUS_PENDING},
+		{TransactionId: 3, AccountId: 2, Number: 1, Amount: utilMoneyV1.MustParse("-10"), DueDate: dueDate(3), Status: constantPackage.INSTALLMENT_STATUS_PENDING},
+		{TransactionId: 4, AccountId: 1, Number: 1, Amount: utilMoneyV1.MustParse("-10"), DueDate: dueDate(3), Status: "CANCELLED"},
+	}, db)
+	assert.NoError(t, err)
+
+	schedule, err := repo.GetInstallments(ctx, 1, db)
+	assert.NoError(t, err)
+	assert.Len(t, schedule, 2)
+	assert.Equal(t, 1, schedule[0].Number)
+
+	upcoming, err := repo.GetUpcomingInstallments(ctx, 1, dueDate(3), db)
+	assert.NoError(t, err)
+	assert.Len(t, upcoming, 2)
+	assert.Equal(t, uint(2), upcoming[0].TransactionId)
+	assert.Equal(t, uint(1), upcoming[1].TransactionId)
+	assert.Equal(t, 2, upcoming[1].Number)
+}
+
+func TestGetFeeTransactions(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	parent := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-500")}
+	assert.NoError(t, repo.CreateTransaction(ctx, parent, db))
+	other := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 3, Amount: utilMoneyV1.MustParse("-100")}
+	assert.NoError(t, repo.CreateTransaction(ctx, other, db))
+	for _, fee := range []*entityDbV1Package.Transaction{
+		{AccountId: 1, OperationTypeId: 5, Amount: utilMoneyV1.MustParse("-2.5"), FeeCode: "WITHDRAWAL_FEE", ParentTransactionId: &parent.ID},
+		{AccountId: 1, OperationTypeId: 5, Amount: utilMoneyV1.MustParse("-1"), FeeCode: "ATM_SURCHARGE", ParentTransactionId: &parent.ID},
+		{AccountId: 1, OperationTypeId: 5, Amount: utilMoneyV1.MustParse("-1"), FeeCode: "ATM_SURCHARGE", ParentTransactionId: &other.ID},
+	} {
+		assert.NoError(t, repo.CreateTransaction(ctx, fee, db))
+	}
+
+	fees, err := repo.GetFeeTransactions(ctx, int(parent.ID), db)
+	assert.NoError(t, err)
+	assert.Len(t, fees, 2)
+	assert.Equal(t, "WITHDRAWAL_FEE", fees[0].FeeCode)
+	assert.Equal(t, "ATM_SURCHARGE", fees[1].FeeCode)
+}
+
+func TestReversalUpdates(t *testing.T) {
+	repo := NewTransactionRepository(logrus.New())
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	transaction := &entityDbV1Package.Transaction{AccountId: 1, OperationTypeId: 2, Amount: utilMoneyV1.MustParse("-90"), Balance: utilMoneyV1.MustParse("-90"), Status: constantPackage.STATUS_POSTED}
+	db.Create(transaction)
+	installment := &entityDbV1Package.Installment{TransactionId: transaction.ID, AccountId: 1, Number: 1, Amount: utilMoneyV1.MustParse("-90"), Status: constantPackage.INSTALLMENT_STATUS_PENDING}
+	db.Create(installment)
+
+	locked, err := repo.GetTransactionForUpdate(ctx, int(transaction.ID), db)
+	assert.NoError(t, err)
+	locked.Status = constantPackage.STATUS_PARTIALLY_REVERSED
+	locked.ReversedAmount = utilMoneyV1.MustParse("30")
+	locked.Balance = utilMoneyV1.MustParse("-60")
+	assert.NoError(t, repo.UpdateTransactionReversal(ctx, locked, db))
+	installment.Amount = utilMoneyV1.MustParse("-60")
+	assert.NoError(t, repo.UpdateInstallment(ctx, installment, db))
+
+	found, err := repo.GetTransaction(ctx, int(transaction.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, constantPackage.STATUS_PARTIALLY_REVERSED, found.Status)
+	assert.Equal(t, utilMoneyV1.MustParse("30"), found.ReversedAmount)
+	assert.Equal(t, utilMoneyV1.MustParse("-60"), found.Balance)
+	schedule, err := repo.GetInstallments(ctx, int(transaction.ID), db)
+	assert.NoError(t, err)
+	assert.Equal(t, utilMoneyV1.MustParse("-60"), schedule[0].Amount)
+}
@@ -23,4 +23,9 @@ func (routes *TransactionRoutes) Init() {
 	handlerFunc := routes.middlewareHandler.MiddlewareHandlerFunc
 
 	routes.muxRouter.HandleFunc("/transactions/v1", handlerFunc(routes.controller.CreateTransaction)).Methods("POST")
+	routes.muxRouter.HandleFunc("/transactions/v1", handlerFunc(routes.controller.ListTransactions)).Methods("GET")
+	// Registered before /{transactionId}, which would otherwise match "installments".
+	routes.muxRouter.HandleFunc("/transactions/v1/installments", handlerFunc(routes.controller.ListUpcomingInstallments)).Methods("GET")
+	routes.muxRouter.HandleFunc("/transactions/v1/{transactionId}", handlerFunc(routes.controller.GetTransactionDetails)).Methods("GET")
+	routes.muxRouter.HandleFunc("/transactions/v1/{transactionId}/reversal", handlerFunc(routes.controller.ReverseTransaction)).Methods("POST")
 }
@@ -1,43 +1,249 @@
 package util_config
 
 import (
+	authorizationConstantPackage "anti-fraud/constants/authorization"
+	configConstantPackage "anti-fraud/constants/config"
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	operationConstantPackage "anti-fraud/constants/operation"
+	outboxConstantPackage "anti-fraud/constants/outbox"
+	"errors"
 	"fmt"
-	"os"
+	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/sirupsen/logrus"
 )
 
 type DatabaseConfig struct {
-	Host     string `yaml:"host"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	DBName   string `yaml:"dbname"`
-	Port     int    `yaml:"port"`
-	SSLMode  string `yaml:"sslmode"`
-	TimeZone string `yaml:"timezone"`
-	Uri      string `yaml:"uri"`
+	Host     string     `yaml:"host"`
+	User     string     `yaml:"user"`
+	Password string     `yaml:"password"`
+	DBName   string     `yaml:"dbname"`
+	Port     int        `yaml:"port"`
+	SSLMode  string     `yaml:"sslmode"`
+	TimeZone string     `yaml:"timezone"`
+	Uri      string     `yaml:"uri"` // optional postgres DSN or URL, used instead of the fields above
+	Pool     PoolConfig `yaml:"pool"`
+}
+
+// PoolConfig holds the settings of the db connection pool; a zero value means no limit.
+type PoolConfig struct {
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`  // a connection is closed this long after it was opened
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"` // a connection is closed after being idle this long
+}
+
+// ServerConfig holds the settings of the HTTP server.
+type ServerConfig struct {
+	Addr            string        `yaml:"addr"` // e.g. :8080
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // how long in-flight requests may run on SIGINT or SIGTERM
+}
+
+// LoggingConfig holds the settings of the application logger.
+type LoggingConfig struct {
+	Level  string `yaml:"level"`  // a logrus level: trace, debug, info, warn, error, fatal or panic
+	Format string `yaml:"format"` // json or text
+}
+
+// FeaturesConfig toggles the background jobs, so a deployment can run them on some instances only.
+type FeaturesConfig struct {
+	AuthorizationSweeper bool `yaml:"authorization_sweeper"` // release expired authorization holds
+	OutboxRelay          bool `yaml:"outbox_relay"`          // deliver outbox events to the sinks
+}
+
+// AuthorizationConfig holds the settings of authorization holds; durations are written like "168h" or "1m".
+type AuthorizationConfig struct {
+	HoldTTL       time.Duration `yaml:"hold_ttl"`       // an uncaptured hold expires this long after it is authorized
+	SweepInterval time.Duration `yaml:"sweep_interval"` // how often the sweeper releases expired holds
+}
+
+// FxConfig holds the settings of the exchange rate table.
+type FxConfig struct {
+	RatesFile string `yaml:"rates_file"` // optional CSV of exchange rates loaded at startup
+}
+
+// OperationCacheConfig holds the settings of the operation type cache of the mediator operation client.
+type OperationCacheConfig struct {
+	TTL         time.Duration `yaml:"ttl"`          // how long an operation type is served from the cache; negative disables the cache
+	NegativeTTL time.Duration `yaml:"negative_ttl"` // how long an unknown operation type id is remembered as not found
+}
+
+// RemoteServiceConfig holds the settings of the HTTP calls a remote mediator client makes to its owning service.
+type RemoteServiceConfig struct {
+	BaseURL          string        `yaml:"base_url"`          // e.g. http://account-service:8080
+	Timeout          time.Duration `yaml:"timeout"`           // per attempt
+	MaxRetries       int           `yaml:"max_retries"`       // retries after the first attempt; negative disables retries
+	RetryBackoff     time.Duration `yaml:"retry_backoff"`     // wait before the first retry, doubled for each following one
+	BreakerThreshold int           `yaml:"breaker_threshold"` // consecutive failed calls that open the circuit breaker
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown"`  // how long an open circuit breaker rejects calls
+}
+
+// MediatorConfig selects how the mediator account and operation clients reach their owning service.
+type MediatorConfig struct {
+	Mode      string              `yaml:"mode"` // local (default) or remote
+	Account   RemoteServiceConfig `yaml:"account"`
+	Operation RemoteServiceConfig `yaml:"operation"`
+}
+
+// OutboxSinkConfig configures one destination of the outbox relay.
+type OutboxSinkConfig struct {
+	Type    string            `yaml:"type"`    // log, file or webhook
+	Path    string            `yaml:"path"`    // file: the JSON lines file events are appended to
+	URL     string            `yaml:"url"`     // webhook: the endpoint events are POSTed to
+	Headers map[string]string `yaml:"headers"` // webhook: optional extra headers, e.g. an Authorization token
+	Timeout time.Duration     `yaml:"timeout"` // webhook: per delivery
+}
+
+// OutboxConfig holds the settings of the relay delivering outbox events to the sinks.
+type OutboxConfig struct {
+	PollInterval time.Duration      `yaml:"poll_interval"` // how often the relay polls for pending events
+	BatchSize    int                `yaml:"batch_size"`    // events delivered in one relay db txn
+	MaxAttempts  int                `yaml:"max_attempts"`  // failed deliveries after which an event is marked dead
+	BackoffBase  time.Duration      `yaml:"backoff_base"`  // wait before retrying an event after its first failure, doubled on each failure
+	BackoffMax   time.Duration      `yaml:"backoff_max"`   // upper bound of the wait between two deliveries of an event
+	Sinks        []OutboxSinkConfig `yaml:"sinks"`         // every event is delivered to each; a log sink when empty
 }
 
 type Config struct {
-	Database DatabaseConfig `yaml:"database"` // Use a map for dynamic service names
+	Server         ServerConfig         `yaml:"server"`
+	Logging        LoggingConfig        `yaml:"logging"`
+	Database       DatabaseConfig       `yaml:"database"` // Use a map for dynamic service names
+	Authorization  AuthorizationConfig  `yaml:"authorization"`
+	Fx             FxConfig             `yaml:"fx"`
+	OperationCache OperationCacheConfig `yaml:"operation_cache"`
+	Mediator       MediatorConfig       `yaml:"mediator"`
+	Outbox         OutboxConfig         `yaml:"outbox"`
+	Features       FeaturesConfig       `yaml:"features"`
 }
 
-var (
-	configFile = "config.yml"
-)
+// setDefaults fills the settings that are still unset once every layer has been applied.
+func (config *Config) setDefaults() {
+	if config.Authorization.HoldTTL <= 0 {
+		config.Authorization.HoldTTL = authorizationConstantPackage.DEFAULT_HOLD_TTL
+	}
+	if config.Authorization.SweepInterval <= 0 {
+		config.Authorization.SweepInterval = authorizationConstantPackage.DEFAULT_SWEEP_INTERVAL
+	}
+	if config.OperationCache.TTL == 0 {
+		config.OperationCache.TTL = operationConstantPackage.DEFAULT_CACHE_TTL
+	}
+	if config.OperationCache.NegativeTTL == 0 {
+		config.OperationCache.NegativeTTL = operationConstantPackage.DEFAULT_CACHE_NEGATIVE_TTL
+	}
+	if config.Mediator.Mode == "" {
+		config.Mediator.Mode = mediatorConstantPackage.MODE_LOCAL
+	}
+	setRemoteServiceDefaults(&config.Mediator.Account)
+	setRemoteServiceDefaults(&config.Mediator.Operation)
+	setOutboxDefaults(&config.Outbox)
+}
 
-// Load config from config.yml file.
-func LoadConfig() (*Config, error) {
-	file, err := os.Open(configFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %v", err)
+// Validate checks the loaded config and returns every problem found, joined, or nil.
+func (config *Config) Validate() error {
+	errs := []error{}
+	if config.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr is required"))
+	}
+	if config.Server.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("server.shutdown_timeout should not be negative, got %s", config.Server.ShutdownTimeout))
+	}
+	if _, err := logrus.ParseLevel(config.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging.level: %v", err))
+	}
+	if config.Logging.Format != configConstantPackage.LOG_FORMAT_JSON && config.Logging.Format != configConstantPackage.LOG_FORMAT_TEXT {
+		errs = append(errs, fmt.Errorf("logging.format should be %s or %s, got %q", configConstantPackage.LOG_FORMAT_JSON, configConstantPackage.LOG_FORMAT_TEXT, config.Logging.Format))
+	}
+	if config.Database.Uri == "" {
+		if config.Database.Host == "" || config.Database.DBName == "" {
+			errs = append(errs, errors.New("database.host and database.dbname are required unless database.uri is set"))
+		}
+		if config.Database.Port <= 0 || config.Database.Port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port should be between 1 and 65535, got %d", config.Database.Port))
+		}
+	}
+	pool := config.Database.Pool
+	if pool.MaxOpenConns < 0 || pool.MaxIdleConns < 0 || pool.ConnMaxLifetime < 0 || pool.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("database.pool settings should not be negative"))
 	}
-	defer file.Close()
+	if pool.MaxOpenConns > 0 && pool.MaxIdleConns > pool.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.pool.max_idle_conns (%d) should not exceed max_open_conns (%d)", pool.MaxIdleConns, pool.MaxOpenConns))
+	}
+	if config.Mediator.Mode != mediatorConstantPackage.MODE_LOCAL && config.Mediator.Mode != mediatorConstantPackage.MODE_REMOTE {
+		errs = append(errs, fmt.Errorf("mediator.mode should be %s or %s, got %q", mediatorConstantPackage.MODE_LOCAL, mediatorConstantPackage.MODE_REMOTE, config.Mediator.Mode))
+	}
+	if config.Mediator.Mode == mediatorConstantPackage.MODE_REMOTE {
+		if config.Mediator.Account.BaseURL == "" || config.Mediator.Operation.BaseURL == "" {
+			errs = append(errs, fmt.Errorf("mediator.account.base_url and mediator.operation.base_url are required in %s mode", mediatorConstantPackage.MODE_REMOTE))
+		}
+	}
+	errs = append(errs, validateOutbox(&config.Outbox)...)
+	return errors.Join(errs...)
+}
 
-	var config Config
-	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&config); err != nil {
-		return nil, fmt.Errorf("failed to decode config file: %v", err)
+// setOutboxDefaults fills the settings the outbox section leaves unset.
+func setOutboxDefaults(outbox *OutboxConfig) {
+	if outbox.PollInterval <= 0 {
+		outbox.PollInterval = outboxConstantPackage.DEFAULT_POLL_INTERVAL
+	}
+	if outbox.BatchSize <= 0 {
+		outbox.BatchSize = outboxConstantPackage.DEFAULT_BATCH_SIZE
+	}
+	if outbox.MaxAttempts <= 0 {
+		outbox.MaxAttempts = outboxConstantPackage.DEFAULT_MAX_ATTEMPTS
+	}
+	if outbox.BackoffBase <= 0 {
+		outbox.BackoffBase = outboxConstantPackage.DEFAULT_BACKOFF_BASE
+	}
+	if outbox.BackoffMax <= 0 {
+		outbox.BackoffMax = outboxConstantPackage.DEFAULT_BACKOFF_MAX
+	}
+	if len(outbox.Sinks) == 0 {
+		outbox.Sinks = []OutboxSinkConfig{{Type: outboxConstantPackage.SINK_LOG}}
+	}
+}
+
+// validateOutbox checks the backoff and the sinks of the outbox section.
+func validateOutbox(outbox *OutboxConfig) []error {
+	errs := []error{}
+	if outbox.BackoffMax < outbox.BackoffBase {
+		errs = append(errs, fmt.Errorf("outbox.backoff_max should not be less than outbox.backoff_base (%s), got %s", outbox.BackoffBase, outbox.BackoffMax))
+	}
+	for i, sink := range outbox.Sinks {
+		switch sink.Type {
+		case outboxConstantPackage.SINK_LOG:
+		case outboxConstantPackage.SINK_FILE:
+			if sink.Path == "" {
+				errs = append(errs, fmt.Errorf("outbox.sinks[%d].path is required for a %s sink", i, sink.Type))
+			}
+		case outboxConstantPackage.SINK_WEBHOOK:
+			if sink.URL == "" {
+				errs = append(errs, fmt.Errorf("outbox.sinks[%d].url is required for a %s sink", i, sink.Type))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("outbox.sinks[%d].type should be %s, %s or %s, got %q", i, outboxConstantPackage.SINK_LOG, outboxConstantPackage.SINK_FILE, outboxConstantPackage.SINK_WEBHOOK, sink.Type))
+		}
+	}
+	return errs
+}
+
+// setRemoteServiceDefaults fills the settings a remote service section leaves unset.
+func setRemoteServiceDefaults(remote *RemoteServiceConfig) {
+	if remote.Timeout <= 0 {
+		remote.Timeout = mediatorConstantPackage.DEFAULT_TIMEOUT
+	}
+	if remote.MaxRetries == 0 {
+		remote.MaxRetries = mediatorConstantPackage.DEFAULT_MAX_RETRIES
+	}
+	if remote.RetryBackoff <= 0 {
+		remote.RetryBackoff = mediatorConstantPackage.DEFAULT_RETRY_BACKOFF
+	}
+	if remote.BreakerThreshold <= 0 {
+		remote.BreakerThreshold = mediatorConstantPackage.DEFAULT_BREAKER_THRESHOLD
+	}
+	if remote.BreakerCooldown <= 0 {
+		remote.BreakerCooldown = mediatorConstantPackage.DEFAULT_BREAKER_COOLDOWN
 	}
-	return &config, nil
 }
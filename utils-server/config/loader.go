@@ -0,0 +1,255 @@
+package util_config
+
+import (
+	configConstantPackage "anti-fraud/constants/config"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfig builds the config from four layers, each overriding the previous one.
+//
+// Layers:
+//  1. Built-in defaults.
+//  2. The YAML file named by the -config flag, else by CONFIG_FILE, else config.yml. A missing config.yml
+//     is skipped; a missing file named explicitly is an error.
+//  3. Environment variables, see envOverrides.
+//  4. Command line flags, see newFlagSet; only the flags present in args apply.
+//
+// The settings still unset are then defaulted, and the result is validated.
+//
+// Parameters:
+//   - args: command line arguments without the program name, e.g. os.Args[1:].
+//
+// Returns:
+//   - The loaded config.
+//   - error: flag.ErrHelp if args ask for usage, otherwise an Error naming the faulty layer or settings.
+func LoadConfig(args []string) (*Config, error) {
+	flags, overrides := newFlagSet()
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	setFlags := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	config := defaultConfig()
+
+	path, explicit := configConstantPackage.DEFAULT_CONFIG_FILE, false
+	if value, ok := os.LookupEnv(configConstantPackage.CONFIG_FILE_ENV); ok && value != "" {
+		path, explicit = value, true
+	}
+	if setFlags["config"] {
+		path, explicit = overrides.configFile, true
+	}
+	if err := decodeFile(config, path, explicit); err != nil {
+		return nil, err
+	}
+
+	if err := applyEnv(config); err != nil {
+		return nil, err
+	}
+	overrides.apply(config, setFlags)
+
+	config.setDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return config, nil
+}
+
+// defaultConfig returns the settings used when no layer sets them.
+func defaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:            configConstantPackage.DEFAULT_HTTP_ADDR,
+			ReadTimeout:     configConstantPackage.DEFAULT_READ_TIMEOUT,
+			WriteTimeout:    configConstantPackage.DEFAULT_WRITE_TIMEOUT,
+			IdleTimeout:     configConstantPackage.DEFAULT_IDLE_TIMEOUT,
+			ShutdownTimeout: configConstantPackage.DEFAULT_SHUTDOWN_TIMEOUT,
+		},
+		Logging: LoggingConfig{
+			Level:  configConstantPackage.DEFAULT_LOG_LEVEL,
+			Format: configConstantPackage.LOG_FORMAT_JSON,
+		},
+		Database: DatabaseConfig{
+			Host:     configConstantPackage.DEFAULT_DB_HOST,
+			User:     configConstantPackage.DEFAULT_DB_USER,
+			DBName:   configConstantPackage.DEFAULT_DB_NAME,
+			Port:     configConstantPackage.DEFAULT_DB_PORT,
+			SSLMode:  configConstantPackage.DEFAULT_DB_SSLMODE,
+			TimeZone: configConstantPackage.DEFAULT_DB_TIMEZONE,
+			Pool: PoolConfig{
+				MaxOpenConns:    configConstantPackage.DEFAULT_MAX_OPEN_CONNS,
+				MaxIdleConns:    configConstantPackage.DEFAULT_MAX_IDLE_CONNS,
+				ConnMaxLifetime: configConstantPackage.DEFAULT_CONN_MAX_LIFETIME,
+				ConnMaxIdleTime: configConstantPackage.DEFAULT_CONN_MAX_IDLE_TIME,
+			},
+		},
+		Features: FeaturesConfig{
+			AuthorizationSweeper: true,
+			OutboxRelay:          true,
+		},
+	}
+}
+
+// decodeFile decodes the YAML file at path onto config, keeping the settings the file leaves out.
+func decodeFile(config *Config, path string, explicit bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil
+		}
+		return fmt.Errorf("failed to open config file: %v", err)
+	}
+	defer file.Close()
+
+	if err := yaml.NewDecoder(file).Decode(config); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to decode config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// envOverride sets one setting from the value of an environment variable.
+type envOverride struct {
+	name string
+	set  func(config *Config, value string) error
+}
+
+// envOverrides lists the environment variables read by LoadConfig.
+var envOverrides = []envOverride{
+	{"SERVER_ADDR", func(config *Config, value string) error { config.Server.Addr = value; return nil }},
+	{"SERVER_SHUTDOWN_TIMEOUT", durationSetter(func(config *Config) *time.Duration { return &config.Server.ShutdownTimeout })},
+	{"LOG_LEVEL", func(config *Config, value string) error { config.Logging.Level = value; return nil }},
+	{"LOG_FORMAT", func(config *Config, value string) error { config.Logging.Format = value; return nil }},
+	{"DATABASE_URL", func(config *Config, value string) error { config.Database.Uri = value; return nil }},
+	{"DB_HOST", func(config *Config, value string) error { config.Database.Host = value; return nil }},
+	{"DB_PORT", intSetter(func(config *Config) *int { return &config.Database.Port })},
+	{"DB_USER", func(config *Config, value string) error { config.Database.User = value; return nil }},
+	{"DB_PASSWORD", func(config *Config, value string) error { config.Database.Password = value; return nil }},
+	{"DB_NAME", func(config *Config, value string) error { config.Database.DBName = value; return nil }},
+	{"DB_SSLMODE", func(config *Config, value string) error { config.Database.SSLMode = value; return nil }},
+	{"DB_TIMEZONE", func(config *Config, value string) error { config.Database.TimeZone = value; return nil }},
+	{"DB_MAX_OPEN_CONNS", intSetter(func(config *Config) *int { return &config.Database.Pool.MaxOpenConns })},
+	{"DB_MAX_IDLE_CONNS", intSetter(func(config *Config) *int { return &config.Database.Pool.MaxIdleConns })},
+	{"DB_CONN_MAX_LIFETIME", durationSetter(func(config *Config) *time.Duration { return &config.Database.Pool.ConnMaxLifetime })},
+	{"DB_CONN_MAX_IDLE_TIME", durationSetter(func(config *Config) *time.Duration { return &config.Database.Pool.ConnMaxIdleTime })},
+	{"MEDIATOR_MODE", func(config *Config, value string) error { config.Mediator.Mode = value; return nil }},
+	{"FEATURE_AUTHORIZATION_SWEEPER", boolSetter(func(config *Config) *bool { return &config.Features.AuthorizationSweeper })},
+	{"FEATURE_OUTBOX_RELAY", boolSetter(func(config *Config) *bool { return &config.Features.OutboxRelay })},
+}
+
+// applyEnv applies the environment variables of envOverrides that are set and not empty.
+func applyEnv(config *Config) error {
+	errs := []error{}
+	for _, override := range envOverrides {
+		value, ok := os.LookupEnv(override.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := override.set(config, value); err != nil {
+			errs = append(errs, fmt.Errorf("env %s: %v", override.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func intSetter(field func(config *Config) *int) func(config *Config, value string) error {
+	return func(config *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(config) = parsed
+		return nil
+	}
+}
+
+func durationSetter(field func(config *Config) *time.Duration) func(config *Config, value string) error {
+	return func(config *Config, value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(config) = parsed
+		return nil
+	}
+}
+
+func boolSetter(field func(config *Config) *bool) func(config *Config, value string) error {
+	return func(config *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(config) = parsed
+		return nil
+	}
+}
+
+// flagOverrides holds the values of the command line flags until LoadConfig applies the ones present.
+type flagOverrides struct {
+	configFile   string
+	httpAddr     string
+	logLevel     string
+	logFormat    string
+	dbHost       string
+	dbPort       int
+	dbUser       string
+	dbPassword   string
+	dbName       string
+	mediatorMode string
+}
+
+// newFlagSet declares the command line flags read by LoadConfig.
+func newFlagSet() (*flag.FlagSet, *flagOverrides) {
+	overrides := &flagOverrides{}
+	flags := flag.NewFlagSet("anti-fraud", flag.ContinueOnError)
+	flags.StringVar(&overrides.configFile, "config", configConstantPackage.DEFAULT_CONFIG_FILE, "path of the YAML config file (env "+configConstantPackage.CONFIG_FILE_ENV+")")
+	flags.StringVar(&overrides.httpAddr, "http-addr", configConstantPackage.DEFAULT_HTTP_ADDR, "address the HTTP server listens on (env SERVER_ADDR)")
+	flags.StringVar(&overrides.logLevel, "log-level", configConstantPackage.DEFAULT_LOG_LEVEL, "log level (env LOG_LEVEL)")
+	flags.StringVar(&overrides.logFormat, "log-format", configConstantPackage.LOG_FORMAT_JSON, "log format, json or text (env LOG_FORMAT)")
+	flags.StringVar(&overrides.dbHost, "db-host", configConstantPackage.DEFAULT_DB_HOST, "db host (env DB_HOST)")
+	flags.IntVar(&overrides.dbPort, "db-port", configConstantPackage.DEFAULT_DB_PORT, "db port (env DB_PORT)")
+	flags.StringVar(&overrides.dbUser, "db-user", configConstantPackage.DEFAULT_DB_USER, "db user (env DB_USER)")
+	flags.StringVar(&overrides.dbPassword, "db-password", "", "db password (env DB_PASSWORD)")
+	flags.StringVar(&overrides.dbName, "db-name", configConstantPackage.DEFAULT_DB_NAME, "db name (env DB_NAME)")
+	flags.StringVar(&overrides.mediatorMode, "mediator-mode", "", "local or remote (env MEDIATOR_MODE)")
+	return flags, overrides
+}
+
+// apply copies the flags named in setFlags onto config.
+func (overrides *flagOverrides) apply(config *Config, setFlags map[string]bool) {
+	if setFlags["http-addr"] {
+		config.Server.Addr = overrides.httpAddr
+	}
+	if setFlags["log-level"] {
+		config.Logging.Level = overrides.logLevel
+	}
+	if setFlags["log-format"] {
+		config.Logging.Format = overrides.logFormat
+	}
+	if setFlags["db-host"] {
+		config.Database.Host = overrides.dbHost
+	}
+	if setFlags["db-port"] {
+		config.Database.Port = overrides.dbPort
+	}
+	if setFlags["db-user"] {
+		config.Database.User = overrides.dbUser
+	}
+	if setFlags["db-password"] {
+		config.Database.Password = overrides.dbPassword
+	}
+	if setFlags["db-name"] {
+		config.Database.DBName = overrides.dbName
+	}
+	if setFlags["mediator-mode"] {
+		config.Mediator.Mode = overrides.mediatorMode
+	}
+}
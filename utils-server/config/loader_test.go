@@ -0,0 +1,163 @@
+package util_config
+
+import (
+	configConstantPackage "anti-fraud/constants/config"
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	outboxConstantPackage "anti-fraud/constants/outbox"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfigFile writes content to a YAML file in a temp dir and returns its path.
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+// chdirTemp runs the test from an empty temp dir, so no config.yml is found by default.
+func chdirTemp(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working dir: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("failed to change working dir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(dir) })
+}
+
+func TestLoadConfig_DefaultsWithoutFile(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, "")
+
+	config, err := LoadConfig(nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, configConstantPackage.DEFAULT_HTTP_ADDR, config.Server.Addr)
+	assert.Equal(t, configConstantPackage.DEFAULT_LOG_LEVEL, config.Logging.Level)
+	assert.Equal(t, configConstantPackage.DEFAULT_DB_PORT, config.Database.Port)
+	assert.Equal(t, configConstantPackage.DEFAULT_MAX_OPEN_CONNS, config.Database.Pool.MaxOpenConns)
+	assert.Equal(t, mediatorConstantPackage.MODE_LOCAL, config.Mediator.Mode)
+	assert.Equal(t, []OutboxSinkConfig{{Type: outboxConstantPackage.SINK_LOG}}, config.Outbox.Sinks)
+	assert.Equal(t, outboxConstantPackage.DEFAULT_MAX_ATTEMPTS, config.Outbox.MaxAttempts)
+	assert.Equal(t, outboxConstantPackage.DEFAULT_BACKOFF_MAX, config.Outbox.BackoffMax)
+	assert.True(t, config.Features.AuthorizationSweeper)
+	assert.True(t, config.Features.OutboxRelay)
+}
+
+func TestLoadConfig_LayersOverrideInOrder(t *testing.T) {
+	chdirTemp(t)
+	path := writeConfigFile(t, `
+server:
+  addr: ":9000"
+logging:
+  level: debug
+database:
+  host: file-host
+  user: file-user
+  port: 5433
+  pool:
+    max_open_conns: 50
+features:
+  outbox_relay: false
+`)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, path)
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("DB_PORT", "6432")
+	t.Setenv("DB_MAX_IDLE_CONNS", "10")
+	t.Setenv("LOG_LEVEL", "")
+
+	config, err := LoadConfig([]string{"-db-port", "7432", "-log-format", "text"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":9000", config.Server.Addr, "file over defaults")
+	assert.Equal(t, "debug", config.Logging.Level, "an empty env var is ignored")
+	assert.Equal(t, "file-user", config.Database.User)
+	assert.Equal(t, "env-host", config.Database.Host, "env over file")
+	assert.Equal(t, 7432, config.Database.Port, "flag over env")
+	assert.Equal(t, configConstantPackage.LOG_FORMAT_TEXT, config.Logging.Format)
+	assert.Equal(t, 50, config.Database.Pool.MaxOpenConns)
+	assert.Equal(t, 10, config.Database.Pool.MaxIdleConns)
+	assert.Equal(t, configConstantPackage.DEFAULT_CONN_MAX_LIFETIME, config.Database.Pool.ConnMaxLifetime, "defaults kept for settings the file leaves out")
+	assert.True(t, config.Features.AuthorizationSweeper)
+	assert.False(t, config.Features.OutboxRelay)
+}
+
+func TestLoadConfig_ConfigFlagOverridesEnv(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, filepath.Join(t.TempDir(), "missing.yml"))
+	path := writeConfigFile(t, "authorization:\n  hold_ttl: 1h\n")
+
+	config, err := LoadConfig([]string{"-config", path})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, config.Authorization.HoldTTL)
+}
+
+func TestLoadConfig_MissingExplicitFile(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, filepath.Join(t.TempDir(), "missing.yml"))
+
+	_, err := LoadConfig(nil)
+
+	assert.ErrorContains(t, err, "failed to open config file")
+}
+
+func TestLoadConfig_InvalidEnv(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, "")
+	t.Setenv("DB_PORT", "five")
+	t.Setenv("FEATURE_OUTBOX_RELAY", "maybe")
+
+	_, err := LoadConfig(nil)
+
+	assert.ErrorContains(t, err, "env DB_PORT")
+	assert.ErrorContains(t, err, "env FEATURE_OUTBOX_RELAY")
+}
+
+func TestLoadConfig_ValidationReportsEveryError(t *testing.T) {
+	chdirTemp(t)
+	path := writeConfigFile(t, `
+outbox:
+  backoff_base: 1m
+  backoff_max: 30s
+  sinks:
+    - type: file
+`)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, path)
+
+	_, err := LoadConfig([]string{"-log-level", "loud", "-mediator-mode", "carrier-pigeon", "-db-port", "0"})
+
+	assert.ErrorContains(t, err, "invalid config")
+	assert.ErrorContains(t, err, "logging.level")
+	assert.ErrorContains(t, err, "mediator.mode")
+	assert.ErrorContains(t, err, "database.port")
+	assert.ErrorContains(t, err, "outbox.backoff_max")
+	assert.ErrorContains(t, err, "outbox.sinks[0].path")
+}
+
+func TestLoadConfig_RemoteModeRequiresBaseURLs(t *testing.T) {
+	chdirTemp(t)
+	t.Setenv(configConstantPackage.CONFIG_FILE_ENV, "")
+	t.Setenv("MEDIATOR_MODE", mediatorConstantPackage.MODE_REMOTE)
+
+	_, err := LoadConfig(nil)
+
+	assert.ErrorContains(t, err, "base_url")
+}
+
+func TestLoadConfig_Help(t *testing.T) {
+	chdirTemp(t)
+
+	_, err := LoadConfig([]string{"-h"})
+
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
@@ -0,0 +1,200 @@
+package util_context_v1
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type contextKey string
+
+const (
+	requestIDKey contextKey = "requestID"
+	loggerKey    contextKey = "logger"
+	txKey        contextKey = "tx"
+	hooksKey     contextKey = "txHooks"
+)
+
+// txHooks collects the functions to run once the db txn begun with the context commits, or rolls back.
+// The hooks of a savepoint have the hooks of its txn, or enclosing savepoint, as parent.
+type txHooks struct {
+	mu          sync.Mutex
+	afterCommit []func()
+	onRollback  []func()
+	done        bool
+	parent      *txHooks
+}
+
+// WithRequestID returns a copy of ctx carrying the request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the request id carried by ctx, or "" when there is none.
+func RequestID(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// WithLogger returns a copy of ctx carrying the request-scoped logger.
+func WithLogger(ctx context.Context, logger *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// Logger returns the logger carried by ctx. Without one, it falls back to the standard logger,
+// tagged with the request id of ctx if any, so callers never have to check for nil.
+func Logger(ctx context.Context) *logrus.Entry {
+	if logger, ok := ctx.Value(loggerKey).(*logrus.Entry); ok && logger != nil {
+		return logger
+	}
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	if requestID := RequestID(ctx); requestID != "" {
+		logger = logger.WithField("request_id", requestID)
+	}
+	return logger
+}
+
+// WithTx returns a copy of ctx carrying the db txn of the request.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey, tx)
+}
+
+// Tx returns the db txn carried by ctx, or nil when there is none.
+func Tx(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey).(*gorm.DB); ok {
+		return tx
+	}
+	return nil
+}
+
+// Begin starts a db txn bound to ctx, so its queries stop once ctx is cancelled or times out,
+// and returns a copy of ctx carrying it. The txn should be committed with Commit and rolled back
+// with Rollback, so the functions registered with AfterCommit and OnRollback run.
+func Begin(ctx context.Context, db *gorm.DB) (context.Context, *gorm.DB) {
+	tx := db.WithContext(ctx).Begin()
+	ctx = context.WithValue(ctx, hooksKey, &txHooks{})
+	return WithTx(ctx, tx), tx
+}
+
+// AfterCommit registers fn to run once the db txn begun with ctx commits; fn is dropped if the txn
+// rolls back. Without a txn begun by Begin, there is nothing to wait for and fn runs right away.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.afterCommit = append(hooks.afterCommit, fn)
+}
+
+// OnRollback registers fn to compensate for a side effect the db txn begun with ctx cannot undo, e.g. a
+// call committed by another service; fn runs if the txn rolls back, or fails to commit, and is dropped
+// once it commits. Without a txn begun by Begin, nothing rolls back and fn is dropped.
+func OnRollback(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok {
+		return
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.onRollback = append(hooks.onRollback, fn)
+}
+
+// Savepoint returns a copy of ctx whose AfterCommit and OnRollback functions are kept apart from those of
+// its txn until the savepoint ends, so a savepoint rolled back compensates for its own side effects while
+// the txn carries on. The caller creates the db savepoint itself, then ends it with ReleaseSavepoint or
+// RollbackSavepoint. Without a txn begun by Begin, ctx is returned as is.
+func Savepoint(ctx context.Context) context.Context {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, hooksKey, &txHooks{parent: hooks})
+}
+
+// ReleaseSavepoint ends the savepoint of ctx, handing its functions over to the enclosing txn, or savepoint.
+func ReleaseSavepoint(ctx context.Context) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok || hooks.parent == nil {
+		return
+	}
+	afterCommit, onRollback := finish(ctx)
+	hooks.parent.mu.Lock()
+	defer hooks.parent.mu.Unlock()
+	hooks.parent.afterCommit = append(hooks.parent.afterCommit, afterCommit...)
+	hooks.parent.onRollback = append(hooks.parent.onRollback, onRollback...)
+}
+
+// RollbackSavepoint ends the savepoint of ctx, whose writes the caller rolled back: its functions
+// registered with OnRollback run, newest first, and those registered with AfterCommit are dropped.
+func RollbackSavepoint(ctx context.Context) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok || hooks.parent == nil {
+		return
+	}
+	_, onRollback := finish(ctx)
+	runReversed(onRollback)
+}
+
+// Commit commits tx, the txn begun with ctx, then runs the functions registered with AfterCommit in order.
+// If the commit fails, the functions registered with OnRollback run instead.
+func Commit(ctx context.Context, tx *gorm.DB) error {
+	err := tx.Commit().Error
+	afterCommit, onRollback := finish(ctx)
+	if err != nil {
+		runReversed(onRollback)
+		return err
+	}
+	for _, fn := range afterCommit {
+		fn()
+	}
+	return nil
+}
+
+// Rollback rolls tx, the txn begun with ctx, back, then runs the functions registered with OnRollback,
+// newest first. It does nothing once the txn was committed or rolled back, so it can be deferred
+// right after Begin.
+func Rollback(ctx context.Context, tx *gorm.DB) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if ok {
+		hooks.mu.Lock()
+		done := hooks.done
+		hooks.mu.Unlock()
+		if done {
+			return
+		}
+	}
+	tx.Rollback()
+	_, onRollback := finish(ctx)
+	runReversed(onRollback)
+}
+
+// finish marks the txn of ctx as ended and hands its hooks over, once.
+func finish(ctx context.Context) ([]func(), []func()) {
+	hooks, ok := ctx.Value(hooksKey).(*txHooks)
+	if !ok {
+		return nil, nil
+	}
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	if hooks.done {
+		return nil, nil
+	}
+	hooks.done = true
+	afterCommit, onRollback := hooks.afterCommit, hooks.onRollback
+	hooks.afterCommit, hooks.onRollback = nil, nil
+	return afterCommit, onRollback
+}
+
+// runReversed runs fns newest first, so compensations undo side effects in the opposite order.
+func runReversed(fns []func()) {
+	for i := len(fns) - 1; i >= 0; i-- {
+		fns[i]()
+	}
+}
@@ -0,0 +1,107 @@
+package util_errors_v1
+
+import (
+	constantPackage "anti-fraud/constants/errors"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// AppError is the shared domain error returned by repositories and cores. It carries a
+// machine-readable code and the HTTP status controllers must answer with.
+type AppError struct {
+	Code    string
+	Message string
+	Status  int
+	Err     error // optional underlying cause
+}
+
+// Error returns the human-readable message, followed by the cause if any.
+func (appError *AppError) Error() string {
+	if appError.Err != nil {
+		return appError.Message + ": " + appError.Err.Error()
+	}
+	return appError.Message
+}
+
+// Unwrap exposes the underlying cause to errors.Is / errors.As.
+func (appError *AppError) Unwrap() error {
+	return appError.Err
+}
+
+// ErrorBody is the JSON body written for every failed request.
+type ErrorBody struct {
+	Success bool         `json:"success"`
+	Error   ErrorDetails `json:"error"`
+}
+
+// ErrorDetails holds the machine-readable code and the human-readable message of an error.
+type ErrorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewBadRequestError creates an AppError answered with 400 Bad Request.
+func NewBadRequestError(code string, message string) *AppError {
+	return &AppError{Code: code, Message: message, Status: http.StatusBadRequest}
+}
+
+// NewNotFoundError creates an AppError answered with 404 Not Found.
+func NewNotFoundError(code string, message string) *AppError {
+	return &AppError{Code: code, Message: message, Status: http.StatusNotFound}
+}
+
+// NewConflictError creates an AppError answered with 409 Conflict.
+func NewConflictError(code string, message string) *AppError {
+	return &AppError{Code: code, Message: message, Status: http.StatusConflict}
+}
+
+// NewUnprocessableError creates an AppError answered with 422 Unprocessable Entity.
+func NewUnprocessableError(code string, message string) *AppError {
+	return &AppError{Code: code, Message: message, Status: http.StatusUnprocessableEntity}
+}
+
+// NewInternalError wraps an unexpected error into an AppError answered with 500 Internal Server Error.
+func NewInternalError(err error) *AppError {
+	return &AppError{Code: constantPackage.INTERNAL_ERROR, Message: "An internal error occurred", Status: http.StatusInternalServerError, Err: err}
+}
+
+// NewServiceUnavailableError wraps the failure to reach another service into an AppError answered with 503 Service Unavailable.
+func NewServiceUnavailableError(message string, err error) *AppError {
+	return &AppError{Code: constantPackage.SERVICE_UNAVAILABLE, Message: message, Status: http.StatusServiceUnavailable, Err: err}
+}
+
+// AsAppError returns the AppError found in err's chain, if any.
+func AsAppError(err error) (*AppError, bool) {
+	var appError *AppError
+	if errors.As(err, &appError) {
+		return appError, true
+	}
+	return nil, false
+}
+
+// IsNotFound reports whether err is an AppError answered with 404 Not Found.
+func IsNotFound(err error) bool {
+	appError, ok := AsAppError(err)
+	return ok && appError.Status == http.StatusNotFound
+}
+
+// WriteError translates err into its HTTP status and writes the JSON error body.
+// Errors outside the taxonomy are reported as INTERNAL_ERROR, and the cause of an
+// internal or unavailable service error is never exposed to the client.
+func WriteError(w http.ResponseWriter, err error) {
+	appError, ok := AsAppError(err)
+	if !ok {
+		appError = NewInternalError(err)
+	}
+	message := appError.Error()
+	if appError.Status >= http.StatusInternalServerError {
+		message = appError.Message
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appError.Status)
+	json.NewEncoder(w).Encode(ErrorBody{
+		Success: false,
+		Error:   ErrorDetails{Code: appError.Code, Message: message},
+	})
+}
@@ -0,0 +1,22 @@
+package util_idempotency_v1
+
+import (
+	constantPackage "anti-fraud/constants/idempotency"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyRecord stores the outcome of a create request issued with an Idempotency-Key,
+// so that a retry of the same request can be answered without executing it again.
+type IdempotencyRecord struct {
+	gorm.Model
+	Scope          string `json:"scope" gorm:"uniqueIndex:idx_idempotency_key_scope_key"`
+	IdempotencyKey string `json:"idempotency_key" gorm:"uniqueIndex:idx_idempotency_key_scope_key"`
+	RequestHash    string `json:"request_hash"`
+	StatusCode     int    `json:"status_code"`
+	ResponseBody   string `json:"response_body"`
+}
+
+func (IdempotencyRecord) TableName() string {
+	return constantPackage.TABLE_NAME
+}
@@ -0,0 +1,140 @@
+package util_idempotency_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	constantPackage "anti-fraud/constants/idempotency"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IIdempotencyStore persists Idempotency-Key records for create endpoints.
+type IIdempotencyStore interface {
+
+	// Claim reserves a key for the current db txn, or returns the stored record to replay.
+	Claim(ctx context.Context, scope string, key string, requestHash string, tx *gorm.DB) (*IdempotencyRecord, bool, error)
+
+	// SaveResponse stores the response of a claimed key in the same db txn as the created resource.
+	SaveResponse(ctx context.Context, record *IdempotencyRecord, statusCode int, body []byte, tx *gorm.DB) error
+}
+
+// IdempotencyStore implements IIdempotencyStore interface.
+type IdempotencyStore struct {
+	logger *logrus.Logger
+}
+
+// NewIdempotencyStore creates and returns new IdempotencyStore instance.
+func NewIdempotencyStore(logger *logrus.Logger) *IdempotencyStore {
+	return &IdempotencyStore{logger: logger}
+}
+
+// Claim reserves an idempotency key inside the caller's db txn.
+//
+// Steps:
+//  1. Insert a record for (scope, key), ignoring the unique index conflict.
+//     A concurrent request inserting the same key waits on the index until the owning txn ends.
+//  2. If the insert succeeded, the caller owns the key and must execute the request.
+//  3. Otherwise, load the stored record:
+//     - a different request hash means the key was reused for another request;
+//     - a record without a response is still being processed by another request;
+//     - otherwise the stored response must be replayed.
+//
+// Parameters:
+//   - ctx:         Carries the logger; cancels the queries.
+//   - scope:       The endpoint the key belongs to.
+//   - key:         The client-chosen idempotency key.
+//   - requestHash: The hash of the request body.
+//   - tx:          The db txn the created resource is written in.
+//
+// Returns:
+//   - The claimed or stored record.
+//   - true if the stored response must be replayed.
+//   - An encountered Error.
+func (store *IdempotencyStore) Claim(ctx context.Context, scope string, key string, requestHash string, tx *gorm.DB) (*IdempotencyRecord, bool, error) {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("Claim method called in idempotency store layer.")
+
+	// 1. Try to insert the key.
+	record := &IdempotencyRecord{Scope: scope, IdempotencyKey: key, RequestHash: requestHash}
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		logger.Errorf("Error occured while claiming idempotency key: %s", result.Error.Error())
+		return nil, false, result.Error
+	}
+
+	// 2. Key claimed by this request.
+	if result.RowsAffected == 1 {
+		return record, false, nil
+	}
+
+	// 3. Key already used: load the stored record.
+	var existing IdempotencyRecord
+	if err := tx.WithContext(ctx).Where("scope = ? AND idempotency_key = ?", scope, key).First(&existing).Error; err != nil {
+		logger.Errorf("Error occured while fetching idempotency key: %s", err.Error())
+		return nil, false, err
+	}
+	if existing.RequestHash != requestHash {
+		logger.Errorf("Error: idempotency key %s reused with a different request body", key)
+		return nil, false, utilErrorsV1.NewUnprocessableError(errorConstantPackage.IDEMPOTENCY_KEY_REUSED, fmt.Sprintf("idempotency key %s was already used with a different request body", key))
+	}
+	if existing.StatusCode == 0 {
+		logger.Errorf("Error: idempotency key %s is still being processed", key)
+		return nil, false, utilErrorsV1.NewConflictError(errorConstantPackage.IDEMPOTENCY_KEY_IN_PROGRESS, fmt.Sprintf("a request with idempotency key %s is still being processed", key))
+	}
+	return &existing, true, nil
+}
+
+// SaveResponse stores the response of a claimed key.
+//
+// Parameters:
+//   - ctx:        Carries the logger; cancels the queries.
+//   - record:     The record returned by Claim.
+//   - statusCode: The HTTP status of the response.
+//   - body:       The JSON body of the response.
+//   - tx:         The db txn the record was claimed in.
+//
+// Returns:
+//   - An encountered Error.
+func (store *IdempotencyStore) SaveResponse(ctx context.Context, record *IdempotencyRecord, statusCode int, body []byte, tx *gorm.DB) error {
+	logger := utilContextV1.Logger(ctx)
+	logger.Info("SaveResponse method called in idempotency store layer.")
+
+	record.StatusCode = statusCode
+	record.ResponseBody = string(body)
+	err := tx.WithContext(ctx).Model(record).Updates(map[string]interface{}{"status_code": statusCode, "response_body": record.ResponseBody}).Error
+	if err != nil {
+		logger.Errorf("Error occured while saving idempotent response: %s", err.Error())
+	}
+	return err
+}
+
+// HashRequest returns the hex encoded SHA-256 of a request body.
+func HashRequest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateKey rejects idempotency keys the table cannot store.
+func ValidateKey(key string) error {
+	if len(key) > constantPackage.MAX_KEY_LENGTH {
+		return utilErrorsV1.NewBadRequestError(errorConstantPackage.INVALID_IDEMPOTENCY_KEY, fmt.Sprintf("%s must not exceed %d characters", constantPackage.HEADER, constantPackage.MAX_KEY_LENGTH))
+	}
+	return nil
+}
+
+// WriteReplay writes a stored response back to the client.
+func WriteReplay(w http.ResponseWriter, record *IdempotencyRecord) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(constantPackage.REPLAYED_HEADER, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write([]byte(record.ResponseBody))
+}
@@ -0,0 +1,96 @@
+package util_idempotency_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to in-memory database: %v", err)
+	}
+	if err := db.AutoMigrate(&IdempotencyRecord{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestClaim_NewKey(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewIdempotencyStore(logrus.New())
+
+	record, replay, err := store.Claim(context.Background(), "scope", "key-1", HashRequest([]byte("body")), db)
+
+	assert.NoError(t, err)
+	assert.False(t, replay)
+	assert.NotZero(t, record.ID)
+}
+
+func TestClaim_ReplaysStoredResponse(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewIdempotencyStore(logrus.New())
+	ctx := context.Background()
+	hash := HashRequest([]byte("body"))
+
+	record, _, err := store.Claim(ctx, "scope", "key-1", hash, db)
+	assert.NoError(t, err)
+	assert.NoError(t, store.SaveResponse(ctx, record, http.StatusOK, []byte(`{"success":true}`), db))
+
+	stored, replay, err := store.Claim(ctx, "scope", "key-1", hash, db)
+
+	assert.NoError(t, err)
+	assert.True(t, replay)
+	assert.Equal(t, http.StatusOK, stored.StatusCode)
+	assert.Equal(t, `{"success":true}`, stored.ResponseBody)
+}
+
+func TestClaim_DifferentBody(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewIdempotencyStore(logrus.New())
+	ctx := context.Background()
+
+	record, _, _ := store.Claim(ctx, "scope", "key-1", HashRequest([]byte("body")), db)
+	store.SaveResponse(ctx, record, http.StatusOK, []byte(`{}`), db)
+
+	_, _, err := store.Claim(ctx, "scope", "key-1", HashRequest([]byte("other body")), db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.IDEMPOTENCY_KEY_REUSED, appErr.Code)
+}
+
+func TestClaim_InProgress(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewIdempotencyStore(logrus.New())
+	ctx := context.Background()
+	hash := HashRequest([]byte("body"))
+
+	store.Claim(ctx, "scope", "key-1", hash, db)
+	_, _, err := store.Claim(ctx, "scope", "key-1", hash, db)
+
+	appErr, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.IDEMPOTENCY_KEY_IN_PROGRESS, appErr.Code)
+}
+
+func TestClaim_KeysAreScoped(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewIdempotencyStore(logrus.New())
+	ctx := context.Background()
+
+	store.Claim(ctx, "POST /accounts/v1", "key-1", HashRequest([]byte("a")), db)
+	_, replay, err := store.Claim(ctx, "POST /transactions/v1", "key-1", HashRequest([]byte("b")), db)
+
+	assert.NoError(t, err)
+	assert.False(t, replay)
+}
@@ -1,17 +1,17 @@
 package util_middleware_v1
 
 import (
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
-type contextKey string
-
-const requestIDKey contextKey = "requestID"
-
 type MiddlewareHandler struct {
 	logger *logrus.Logger
 }
@@ -26,12 +26,17 @@ func (middlewareHandler *MiddlewareHandler) MiddlewareHandlerFunc(handler http.H
 		defer func() {
 			if rec := recover(); rec != nil {
 				middlewareHandler.logger.Errorf("Recovered from panic: %v", rec)
-				http.Error(w, "An internal server error occurred", http.StatusInternalServerError)
+				utilErrorsV1.WriteError(w, utilErrorsV1.NewInternalError(fmt.Errorf("panic: %v", rec)))
 			}
 
 		}()
-		reqID := uuid.New().String()
-		ctx := context.WithValue(r.Context(), requestIDKey, reqID)
+		// Keep the request id of a remote mediator client, so its logs and ours share it.
+		reqID := r.Header.Get(mediatorConstantPackage.REQUEST_ID_HEADER)
+		if reqID == "" || len(reqID) > 64 {
+			reqID = uuid.New().String()
+		}
+		ctx := utilContextV1.WithRequestID(r.Context(), reqID)
+		ctx = utilContextV1.WithLogger(ctx, middlewareHandler.logger.WithField("request_id", reqID))
 
 		middlewareHandler.logger.WithFields(logrus.Fields{
 			"method":     r.Method,
@@ -45,8 +50,5 @@ func (middlewareHandler *MiddlewareHandler) MiddlewareHandlerFunc(handler http.H
 
 // Helper to retrieve the request ID from context in controllers or anywhere else
 func GetRequestID(ctx context.Context) string {
-	if val, ok := ctx.Value(requestIDKey).(string); ok {
-		return val
-	}
-	return ""
+	return utilContextV1.RequestID(ctx)
 }
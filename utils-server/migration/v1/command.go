@@ -0,0 +1,96 @@
+package util_migration_v1
+
+import (
+	constantPackage "anti-fraud/constants/migration"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Usage describes the migration subcommands.
+const Usage = `usage: migrate <command> [flags]
+  up          apply every pending migration
+  down [N]    revert the last N applied migrations, 1 by default
+  status      list the migrations and whether each is applied
+  version     print the highest applied version`
+
+// SplitArgs separates the arguments following the migrate subcommand into the command with its own
+// arguments, e.g. "down 2", and the config flags that follow them.
+func SplitArgs(args []string) (commandArgs []string, flagArgs []string) {
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
+}
+
+// Run executes one migration command and prints its outcome to out.
+//
+// Parameters:
+//   - args: the command and its arguments, e.g. []string{"down", "2"}.
+//   - out:  where the outcome is printed.
+//
+// Returns:
+//   - An Error for an unknown command or bad arguments, with Usage, or the Error of the migrator.
+func Run(ctx context.Context, migrator IMigrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", Usage)
+	}
+	command, rest := args[0], args[1:]
+	if command != constantPackage.COMMAND_DOWN && len(rest) > 0 {
+		return fmt.Errorf("%s takes no arguments\n%s", command, Usage)
+	}
+
+	switch command {
+	case constantPackage.COMMAND_UP:
+		applied, err := migrator.Up(ctx)
+		fmt.Fprintf(out, "%d migrations applied\n", applied)
+		return err
+	case constantPackage.COMMAND_DOWN:
+		steps := 1
+		if len(rest) > 1 {
+			return fmt.Errorf("down takes at most one argument\n%s", Usage)
+		}
+		if len(rest) == 1 {
+			parsed, err := strconv.Atoi(rest[0])
+			if err != nil || parsed <= 0 {
+				return fmt.Errorf("down takes a positive number of migrations, got %q\n%s", rest[0], Usage)
+			}
+			steps = parsed
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		fmt.Fprintf(out, "%d migrations reverted\n", reverted)
+		return err
+	case constantPackage.COMMAND_STATUS:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			state, appliedAt := "pending", ""
+			if status.Applied {
+				state, appliedAt = "applied", status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.Missing {
+				state = "applied, unknown to this binary"
+			}
+			fmt.Fprintf(writer, "%06d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		return writer.Flush()
+	case constantPackage.COMMAND_VERSION:
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, version)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", command, Usage)
+	}
+}
@@ -0,0 +1,35 @@
+package util_migration_v1
+
+import (
+	constantPackage "anti-fraud/constants/migration"
+	"time"
+)
+
+// Migration is one version of the schema, read from a pair of up and down SQL files.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaVersion is a row of the schema-version table, recording one applied migration.
+type SchemaVersion struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName returns the table of SchemaVersion.
+func (SchemaVersion) TableName() string {
+	return constantPackage.TABLE_NAME
+}
+
+// MigrationStatus tells whether a migration is applied.
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	Missing   bool // applied to the db, but unknown to this binary
+}
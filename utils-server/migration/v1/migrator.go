@@ -0,0 +1,364 @@
+package util_migration_v1
+
+import (
+	constantPackage "anti-fraud/constants/migration"
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IMigrator defines methods interface for bringing the db schema to the version of the code.
+type IMigrator interface {
+
+	// Up applies every migration not applied yet, oldest first, and returns how many were applied.
+	Up(ctx context.Context) (int, error)
+
+	// Down reverts the last steps applied migrations, newest first, and returns how many were reverted.
+	Down(ctx context.Context, steps int) (int, error)
+
+	// Status lists the known migrations, and the applied ones unknown to this binary, by version.
+	Status(ctx context.Context) ([]*MigrationStatus, error)
+
+	// Version returns the highest applied version, 0 for an empty schema.
+	Version(ctx context.Context) (uint, error)
+
+	// CheckSchema returns an error if a migration of this binary is not applied.
+	CheckSchema(ctx context.Context) error
+}
+
+// migrationFile matches <version>_<name>.<up|down>.sql.
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migrator implements IMigrator interface.
+//
+// Each migration runs in a db txn of its own, together with the insert, or delete, of its row in the
+// schema-version table, so a failed migration leaves nothing behind. On postgres, Up and Down hold an
+// advisory lock for their whole run, so instances started together apply each migration once.
+type Migrator struct {
+	db         *gorm.DB
+	logger     *logrus.Logger
+	migrations []*Migration
+}
+
+// NewMigrator creates and returns new Migrator instance, reading the migrations of source.
+//
+// Returns:
+//   - An Error if a file name does not match <version>_<name>.<up|down>.sql, a version is used twice,
+//     or a migration misses its up or down file.
+func NewMigrator(db *gorm.DB, logger *logrus.Logger, source fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, logger: logger, migrations: migrations}, nil
+}
+
+// loadMigrations reads the SQL files at the root of source, sorted by version.
+func loadMigrations(source fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %v", err)
+	}
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			if strings.HasSuffix(entry.Name(), ".sql") {
+				return nil, fmt.Errorf("migration file %s should be named <version>_<name>.<up|down>.sql", entry.Name())
+			}
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s: %v", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %v", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == constantPackage.COMMAND_UP {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s should have a non-empty up and down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies the pending migrations.
+//
+// Workflow:
+//  1. Take the advisory lock and create the schema-version table if needed.
+//  2. Adopt the version reached by the golang-migrate CLI, if the schema-version table is empty.
+//  3. Apply each migration not applied yet, oldest first, each in its own db txn; stop at the first failure.
+//
+// Returns:
+//   - The number of migrations applied.
+//   - An encountered Error.
+func (migrator *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := migrator.withLock(ctx, func(conn *gorm.DB) error {
+		versions, err := migrator.appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrator.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			migrator.logger.Infof("Applying migration %06d_%s.", migration.Version, migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&SchemaVersion{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %06d_%s failed: %v", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations; steps should be positive.
+//
+// Returns:
+//   - The number of migrations reverted.
+//   - An Error if a migration fails or an applied version is unknown to this binary.
+func (migrator *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps should be positive, got %d", steps)
+	}
+	reverted := 0
+	err := migrator.withLock(ctx, func(conn *gorm.DB) error {
+		if _, err := migrator.appliedVersions(conn); err != nil {
+			return err
+		}
+		rows := []*SchemaVersion{}
+		if err := conn.Order("version DESC").Limit(steps).Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			migration := migrator.find(row.Version)
+			if migration == nil {
+				return fmt.Errorf("migration %06d_%s is applied but unknown to this binary, cannot revert it", row.Version, row.Name)
+			}
+			migrator.logger.Infof("Reverting migration %06d_%s.", migration.Version, migration.Name)
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaVersion{}, migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %06d_%s failed: %v", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns the state of every known migration, plus the applied ones this binary does not know.
+func (migrator *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	rows, err := migrator.appliedRows(migrator.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	applied := map[uint]*SchemaVersion{}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	statuses := []*MigrationStatus{}
+	for _, migration := range migrator.migrations {
+		status := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &row.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range applied {
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, &MigrationStatus{Version: row.Version, Name: row.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Version returns the highest applied version.
+func (migrator *Migrator) Version(ctx context.Context) (uint, error) {
+	rows, err := migrator.appliedRows(migrator.db.WithContext(ctx))
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return rows[len(rows)-1].Version, nil
+}
+
+// CheckSchema compares the applied migrations with the ones of this binary.
+//
+// Returns:
+//   - An Error naming the pending migrations if the schema is behind the code, nil otherwise. A schema
+//     ahead of the code, e.g. while an older instance runs during a rollout, is only logged.
+func (migrator *Migrator) CheckSchema(ctx context.Context) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	pending := []string{}
+	for _, status := range statuses {
+		if status.Missing {
+			migrator.logger.Warnf("Migration %06d_%s is applied but unknown to this binary; the schema is ahead of the code.", status.Version, status.Name)
+		}
+		if !status.Applied {
+			pending = append(pending, fmt.Sprintf("%06d_%s", status.Version, status.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("the db schema is behind the code, %d pending migrations %v: run %q", len(pending), pending, constantPackage.SUBCOMMAND+" "+constantPackage.COMMAND_UP)
+	}
+	return nil
+}
+
+// find returns the migration of version, or nil.
+func (migrator *Migrator) find(version uint) *Migration {
+	for _, migration := range migrator.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+// appliedRows returns the rows of the schema-version table by version, none if the table does not exist yet.
+func (migrator *Migrator) appliedRows(db *gorm.DB) ([]*SchemaVersion, error) {
+	rows := []*SchemaVersion{}
+	if !db.Migrator().HasTable(constantPackage.TABLE_NAME) {
+		return rows, nil
+	}
+	err := db.Order("version ASC").Find(&rows).Error
+	return rows, err
+}
+
+// appliedVersions prepares the schema-version table and returns the applied versions.
+//
+// Steps:
+//  1. Create the schema-version table if needed.
+//  2. If it is empty and the golang-migrate table reached a clean version, record every known migration up to
+//     it as applied, so a db migrated by the CLI is not migrated twice.
+func (migrator *Migrator) appliedVersions(conn *gorm.DB) (map[uint]struct{}, error) {
+	err := conn.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`, constantPackage.TABLE_NAME)).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s table: %v", constantPackage.TABLE_NAME, err)
+	}
+	rows, err := migrator.appliedRows(conn)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 && conn.Migrator().HasTable(constantPackage.LEGACY_TABLE_NAME) {
+		if rows, err = migrator.adoptLegacyVersion(conn); err != nil {
+			return nil, err
+		}
+	}
+	versions := map[uint]struct{}{}
+	for _, row := range rows {
+		versions[row.Version] = struct{}{}
+	}
+	return versions, nil
+}
+
+// adoptLegacyVersion records the migrations applied by the golang-migrate CLI in the schema-version table.
+func (migrator *Migrator) adoptLegacyVersion(conn *gorm.DB) ([]*SchemaVersion, error) {
+	var legacy struct {
+		Version int64
+		Dirty   bool
+	}
+	result := conn.Table(constantPackage.LEGACY_TABLE_NAME).Select("version, dirty").Limit(1).Scan(&legacy)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to read %s table: %v", constantPackage.LEGACY_TABLE_NAME, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	if legacy.Dirty {
+		return nil, fmt.Errorf("%s is dirty at version %d: repair the schema and the table by hand before migrating", constantPackage.LEGACY_TABLE_NAME, legacy.Version)
+	}
+
+	rows := []*SchemaVersion{}
+	now := time.Now().UTC()
+	for _, migration := range migrator.migrations {
+		if int64(migration.Version) <= legacy.Version {
+			rows = append(rows, &SchemaVersion{Version: migration.Version, Name: migration.Name, AppliedAt: now})
+		}
+	}
+	if len(rows) == 0 {
+		return rows, nil
+	}
+	if err := conn.Create(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to adopt %s version %d: %v", constantPackage.LEGACY_TABLE_NAME, legacy.Version, err)
+	}
+	migrator.logger.Infof("Adopted version %d of %s, %d migrations recorded as applied.", legacy.Version, constantPackage.LEGACY_TABLE_NAME, len(rows))
+	return rows, nil
+}
+
+// withLock runs fn on one pinned db connection, holding the migration advisory lock on postgres.
+// Other dialects, e.g. sqlite in tests, run fn without a lock.
+func (migrator *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return migrator.db.WithContext(ctx).Connection(func(pinned *gorm.DB) error {
+		// A new session, so the clauses of one query do not leak into the next on the pinned connection.
+		conn := pinned.Session(&gorm.Session{NewDB: true})
+		if conn.Dialector.Name() != "postgres" {
+			return fn(conn)
+		}
+		migrator.logger.Infof("Waiting for the migration lock %d.", constantPackage.ADVISORY_LOCK_KEY)
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", constantPackage.ADVISORY_LOCK_KEY).Error; err != nil {
+			return fmt.Errorf("failed to take the migration lock: %v", err)
+		}
+		defer func() {
+			// Unlock even if ctx is cancelled, or the pooled connection would keep the lock.
+			if err := conn.WithContext(context.WithoutCancel(ctx)).Exec("SELECT pg_advisory_unlock(?)", constantPackage.ADVISORY_LOCK_KEY).Error; err != nil {
+				migrator.logger.Errorf("Error occured while releasing the migration lock: %v", err)
+			}
+		}()
+		return fn(conn)
+	})
+}
@@ -0,0 +1,225 @@
+package util_migration_v1
+
+import (
+	constantPackage "anti-fraud/constants/migration"
+	databaseMigration "anti-fraud/database/migration"
+	"bytes"
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migration.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	return db
+}
+
+// testMigrations is a three-version schema in sqlite SQL.
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"000001_account.up.sql":     {Data: []byte("CREATE TABLE account (id INTEGER PRIMARY KEY);\nCREATE INDEX idx_account_id ON account (id);")},
+		"000001_account.down.sql":   {Data: []byte("DROP TABLE account;")},
+		"000002_note.up.sql":        {Data: []byte("CREATE TABLE note (id INTEGER PRIMARY KEY);")},
+		"000002_note.down.sql":      {Data: []byte("DROP TABLE note;")},
+		"000003_note_text.up.sql":   {Data: []byte("ALTER TABLE note ADD COLUMN text TEXT;")},
+		"000003_note_text.down.sql": {Data: []byte("ALTER TABLE note DROP COLUMN text;")},
+		"README.md":                 {Data: []byte("not a migration")},
+	}
+}
+
+func newTestMigrator(t *testing.T, db *gorm.DB, source fstest.MapFS) *Migrator {
+	migrator, err := NewMigrator(db, logrus.New(), source)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	return migrator
+}
+
+func TestNewMigrator_InvalidSources(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"should be named":  {"001-account.up.sql": {Data: []byte("SELECT 1;")}},
+		"up and down file": {"000001_account.up.sql": {Data: []byte("SELECT 1;")}},
+		"is used by":       {"000001_account.up.sql": {Data: []byte("SELECT 1;")}, "000001_note.down.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for expected, source := range tests {
+		_, err := NewMigrator(setupTestDB(t), logrus.New(), source)
+		assert.ErrorContains(t, err, expected)
+	}
+}
+
+func TestUp_AppliesPendingMigrationsOnce(t *testing.T) {
+	db := setupTestDB(t)
+	migrator := newTestMigrator(t, db, testMigrations())
+	ctx := context.Background()
+
+	assert.ErrorContains(t, migrator.CheckSchema(ctx), "3 pending migrations")
+
+	applied, err := migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, applied)
+	assert.True(t, db.Migrator().HasColumn("note", "text"))
+
+	applied, err = migrator.Up(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, applied)
+
+	version, err := migrator.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), version)
+	assert.NoError(t, migrator.CheckSchema(ctx))
+}
+
+func TestUp_FailedMigrationLeavesNothingBehind(t *testing.T) {
+	db := setupTestDB(t)
+	source := testMigrations()
+	source["000002_note.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE note (id INTEGER PRIMARY KEY);\nCREATE TABLE account (id INTEGER);")}
+	migrator := newTestMigrator(t, db, source)
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "migration 000002_note failed")
+	assert.Equal(t, 1, applied)
+	assert.False(t, db.Migrator().HasTable("note"), "the migration is rolled back as a whole")
+	version, _ := migrator.Version(context.Background())
+	assert.Equal(t, uint(1), version)
+}
+
+func TestDown_RevertsNewestFirst(t *testing.T) {
+	db := setupTestDB(t)
+	migrator := newTestMigrator(t, db, testMigrations())
+	ctx := context.Background()
+	migrator.Up(ctx)
+
+	reverted, err := migrator.Down(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+	assert.False(t, db.Migrator().HasTable("note"))
+	assert.True(t, db.Migrator().HasTable("account"))
+	version, _ := migrator.Version(ctx)
+	assert.Equal(t, uint(1), version)
+
+	reverted, err = migrator.Down(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+
+	_, err = migrator.Down(ctx, 0)
+	assert.ErrorContains(t, err, "steps should be positive")
+}
+
+func TestStatus_ReportsPendingAndUnknownMigrations(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	newTestMigrator(t, db, testMigrations()).Up(ctx)
+
+	// An older binary knows only the first migration.
+	older := fstest.MapFS{}
+	for _, name := range []string{"000001_account.up.sql", "000001_account.down.sql"} {
+		older[name] = testMigrations()[name]
+	}
+	older["000004_tag.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE tag (id INTEGER PRIMARY KEY);")}
+	older["000004_tag.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE tag;")}
+	migrator := newTestMigrator(t, db, older)
+
+	statuses, err := migrator.Status(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 4)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].Missing)
+	assert.True(t, statuses[1].Missing)
+	assert.True(t, statuses[2].Missing)
+	assert.Equal(t, uint(4), statuses[3].Version)
+	assert.False(t, statuses[3].Applied)
+	assert.ErrorContains(t, migrator.CheckSchema(ctx), "000004_tag")
+
+	_, err = migrator.Down(ctx, 1)
+	assert.ErrorContains(t, err, "unknown to this binary")
+}
+
+func TestUp_AdoptsLegacyVersion(t *testing.T) {
+	db := setupTestDB(t)
+	db.Exec("CREATE TABLE account (id INTEGER PRIMARY KEY)")
+	db.Exec("CREATE TABLE note (id INTEGER PRIMARY KEY)")
+	db.Exec("CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (2, false)")
+	migrator := newTestMigrator(t, db, testMigrations())
+
+	applied, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, applied, "only the migration after the legacy version runs")
+	assert.True(t, db.Migrator().HasColumn("note", "text"))
+}
+
+func TestUp_RefusesDirtyLegacyVersion(t *testing.T) {
+	db := setupTestDB(t)
+	db.Exec("CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (2, true)")
+	migrator := newTestMigrator(t, db, testMigrations())
+
+	_, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "dirty at version 2")
+	assert.False(t, db.Migrator().HasTable("account"))
+}
+
+func TestRun_Commands(t *testing.T) {
+	db := setupTestDB(t)
+	migrator := newTestMigrator(t, db, testMigrations())
+	ctx := context.Background()
+	out := &bytes.Buffer{}
+
+	assert.NoError(t, Run(ctx, migrator, []string{constantPackage.COMMAND_UP}, out))
+	assert.NoError(t, Run(ctx, migrator, []string{constantPackage.COMMAND_DOWN, "2"}, out))
+	assert.NoError(t, Run(ctx, migrator, []string{constantPackage.COMMAND_VERSION}, out))
+	assert.NoError(t, Run(ctx, migrator, []string{constantPackage.COMMAND_STATUS}, out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, []string{"3 migrations applied", "2 migrations reverted", "1"}, lines[:3])
+	assert.Regexp(t, `^000001\s+account\s+applied`, lines[4])
+	assert.Regexp(t, `^000002\s+note\s+pending`, lines[5])
+
+	assert.ErrorContains(t, Run(ctx, migrator, nil, out), "missing command")
+	assert.ErrorContains(t, Run(ctx, migrator, []string{"sideways"}, out), "unknown command")
+	assert.ErrorContains(t, Run(ctx, migrator, []string{constantPackage.COMMAND_DOWN, "all"}, out), "positive number")
+	assert.ErrorContains(t, Run(ctx, migrator, []string{constantPackage.COMMAND_UP, "2"}, out), "takes no arguments")
+}
+
+func TestSplitArgs(t *testing.T) {
+	commandArgs, flagArgs := SplitArgs([]string{"down", "2", "-db-host", "localhost"})
+
+	assert.Equal(t, []string{"down", "2"}, commandArgs)
+	assert.Equal(t, []string{"-db-host", "localhost"}, flagArgs)
+}
+
+// TestEmbeddedMigrations loads the migrations of the binary and checks that every table a down file
+// drops was created by an up file of the same or an earlier version.
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(databaseMigration.FS)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	createTable := regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+	dropTable := regexp.MustCompile(`(?i)DROP TABLE (?:IF EXISTS )?(\w+)`)
+	created := map[string]bool{}
+	for _, migration := range migrations {
+		for _, match := range createTable.FindAllStringSubmatch(migration.Up, -1) {
+			created[match[1]] = true
+		}
+		for _, match := range dropTable.FindAllStringSubmatch(migration.Down, -1) {
+			assert.True(t, created[match[1]], "%06d_%s drops table %s, which no up file creates", migration.Version, migration.Name, match[1])
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package util_money_v1
+
+import (
+	constantPackage "anti-fraud/constants/money"
+
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Amount is an exact monetary amount, held as an integer number of 10^-SCALE units.
+// It is written to JSON as a plain decimal number and stored as NUMERIC.
+type Amount struct {
+	units int64
+}
+
+// unitsPerWhole is the number of units in one whole currency unit.
+var unitsPerWhole = int64(math.Pow10(constantPackage.SCALE))
+
+// currencyScales holds the number of minor-unit decimal places of each supported currency.
+var currencyScales = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"BRL": 2,
+	"JPY": 0,
+	"KWD": 3,
+}
+
+// Zero is the zero amount.
+var Zero = Amount{}
+
+// FromInt returns the amount of a whole number of currency units.
+func FromInt(whole int64) Amount {
+	return Amount{units: whole * unitsPerWhole}
+}
+
+// Parse reads an exact decimal string such as "-12.34" or "1.5e2".
+// It fails when the value has more than SCALE decimal places or does not fit.
+func Parse(value string) (Amount, error) {
+	return parse(value, false)
+}
+
+// MustParse is like Parse but panics on error. It is meant for constants and tests.
+func MustParse(value string) Amount {
+	amount, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+// parse converts a decimal string into an Amount, rounding half away from zero when round is set.
+func parse(value string, round bool) (Amount, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Zero, fmt.Errorf("invalid amount: %q", value)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt64(unitsPerWhole))
+	if !rat.IsInt() && !round {
+		return Zero, fmt.Errorf("amount %s has more than %d decimal places", value, constantPackage.SCALE)
+	}
+	units, ok := roundRat(rat)
+	if !ok {
+		return Zero, fmt.Errorf("amount %s is out of range", value)
+	}
+	return Amount{units: units}, nil
+}
+
+// Add returns a + other.
+func (a Amount) Add(other Amount) Amount {
+	return Amount{units: a.units + other.units}
+}
+
+// Sub returns a - other.
+func (a Amount) Sub(other Amount) Amount {
+	return Amount{units: a.units - other.units}
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{units: -a.units}
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	if a.units < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// MulInt returns a multiplied by an integer factor, such as an operation coefficient.
+func (a Amount) MulInt(factor int) Amount {
+	return Amount{units: a.units * int64(factor)}
+}
+
+// Cmp compares a and other, returning -1, 0 or +1.
+func (a Amount) Cmp(other Amount) int {
+	switch {
+	case a.units < other.units:
+		return -1
+	case a.units > other.units:
+		return 1
+	}
+	return 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of a.
+func (a Amount) Sign() int {
+	return a.Cmp(Zero)
+}
+
+// IsZero reports whether a is zero.
+func (a Amount) IsZero() bool {
+	return a.units == 0
+}
+
+// Min returns the smaller of a and b.
+func Min(a Amount, b Amount) Amount {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Decimals returns the number of significant decimal places of a.
+func (a Amount) Decimals() int {
+	fraction := a.units % unitsPerWhole
+	if fraction == 0 {
+		return 0
+	}
+	decimals := constantPackage.SCALE
+	for fraction%10 == 0 {
+		fraction /= 10
+		decimals--
+	}
+	return decimals
+}
+
+// String returns the shortest exact decimal form of a, e.g. "-12.5".
+func (a Amount) String() string {
+	sign := ""
+	units := a.units
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	whole := strconv.FormatUint(uint64(units)/uint64(unitsPerWhole), 10)
+	fraction := uint64(units) % uint64(unitsPerWhole)
+	if fraction == 0 {
+		return sign + whole
+	}
+	digits := fmt.Sprintf("%0*d", constantPackage.SCALE, fraction)
+	return sign + whole + "." + strings.TrimRight(digits, "0")
+}
+
+// ValidateScale rejects amounts with more decimal places than the currency's minor unit allows.
+func ValidateScale(amount Amount, currency string) error {
+	scale, ok := currencyScales[currency]
+	if !ok {
+		return fmt.Errorf("unsupported currency: %s", currency)
+	}
+	if amount.Decimals() > scale {
+		return fmt.Errorf("amount %s has more than %d decimal places allowed for %s", amount, scale, currency)
+	}
+	return nil
+}
+
+// Split divides a into parts amounts of the currency's minor unit that add up exactly to a.
+// The remainder is spread one minor unit at a time over the first parts, so the result is
+// deterministic: splitting 100 USD in 3 gives 33.34, 33.33, 33.33. Every part has the sign of a.
+func Split(a Amount, parts int, currency string) ([]Amount, error) {
+	scale, ok := currencyScales[currency]
+	if !ok {
+		return nil, fmt.Errorf("unsupported currency: %s", currency)
+	}
+	if parts < 1 {
+		return nil, errors.New("parts should be positive")
+	}
+	minorUnit := int64(math.Pow10(constantPackage.SCALE - scale))
+	abs := a.Abs().units
+	minorUnits := abs / minorUnit
+	if minorUnits < int64(parts) {
+		return nil, fmt.Errorf("amount %s cannot be split in %d parts of at least one minor unit", a, parts)
+	}
+	share := minorUnits / int64(parts)
+	remainder := minorUnits % int64(parts)
+	sign := int64(a.Sign())
+	amounts := make([]Amount, parts)
+	for i := range amounts {
+		units := share * minorUnit
+		if int64(i) < remainder {
+			units += minorUnit
+		}
+		amounts[i] = Amount{units: sign * units}
+	}
+	// Sub-minor-unit digits, if any, stay with the first part so the sum is exact.
+	amounts[0].units += sign * (abs % minorUnit)
+	return amounts, nil
+}
+
+// MarshalJSON writes a as an exact JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a numeric string, without going through float64.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	value = strings.Trim(value, `"`)
+	amount, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// Scan reads a NUMERIC column. Drivers without a decimal type may hand back a float,
+// which is rounded to SCALE decimal places.
+func (a *Amount) Scan(value interface{}) error {
+	var amount Amount
+	var err error
+	switch v := value.(type) {
+	case nil:
+		amount = Zero
+	case int64:
+		amount = FromInt(v)
+	case float64:
+		amount, err = parse(strconv.FormatFloat(v, 'f', -1, 64), true)
+	case []byte:
+		amount, err = parse(string(v), true)
+	case string:
+		amount, err = parse(v, true)
+	default:
+		err = errors.New("unsupported amount column type")
+	}
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// Value writes a as an exact decimal string.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// GormDataType declares amounts as a numeric column.
+func (Amount) GormDataType() string {
+	return "numeric"
+}
+
+// GormDBDataType declares amounts as NUMERIC with the fixed SCALE.
+func (Amount) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return constantPackage.DB_TYPE
+}
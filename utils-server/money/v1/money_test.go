@@ -0,0 +1,118 @@
+package util_money_v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Exact(t *testing.T) {
+	amount, err := Parse("0.1")
+	assert.NoError(t, err)
+	sum := amount.Add(MustParse("0.2"))
+	assert.Equal(t, MustParse("0.3"), sum)
+	assert.Equal(t, "0.3", sum.String())
+}
+
+func TestParse_TooManyDecimals(t *testing.T) {
+	_, err := Parse("1.00001")
+	assert.Error(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse("abc")
+	assert.Error(t, err)
+}
+
+func TestParse_Exponent(t *testing.T) {
+	assert.Equal(t, FromInt(150), MustParse("1.5e2"))
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "-12.5", MustParse("-12.50").String())
+	assert.Equal(t, "200", FromInt(200).String())
+	assert.Equal(t, "-0.0001", MustParse("-0.0001").String())
+}
+
+func TestArithmetic(t *testing.T) {
+	amount := MustParse("-19.99")
+	assert.Equal(t, MustParse("19.99"), amount.Abs())
+	assert.Equal(t, MustParse("-19.99"), amount.Abs().MulInt(-1))
+	assert.Equal(t, -1, amount.Sign())
+	assert.Equal(t, MustParse("-19.99"), Min(amount, Zero))
+	assert.True(t, amount.Sub(amount).IsZero())
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	var payload struct {
+		Amount Amount `json:"amount"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 123.45}`), &payload))
+	assert.Equal(t, MustParse("123.45"), payload.Amount)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"amount":123.45}`, string(data))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "7.5"}`), &payload))
+	assert.Equal(t, MustParse("7.5"), payload.Amount)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 1.123456}`), &payload))
+}
+
+func TestScan(t *testing.T) {
+	var amount Amount
+	assert.NoError(t, amount.Scan([]byte("12.3400")))
+	assert.Equal(t, MustParse("12.34"), amount)
+
+	assert.NoError(t, amount.Scan(int64(5)))
+	assert.Equal(t, FromInt(5), amount)
+
+	assert.NoError(t, amount.Scan(0.30000000000000004))
+	assert.Equal(t, MustParse("0.3"), amount)
+
+	assert.NoError(t, amount.Scan(nil))
+	assert.True(t, amount.IsZero())
+}
+
+func TestValue(t *testing.T) {
+	value, err := MustParse("-10.01").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-10.01", value)
+}
+
+func TestValidateScale(t *testing.T) {
+	assert.NoError(t, ValidateScale(MustParse("10.25"), "USD"))
+	assert.Error(t, ValidateScale(MustParse("10.255"), "USD"))
+	assert.NoError(t, ValidateScale(MustParse("10.255"), "KWD"))
+	assert.Error(t, ValidateScale(MustParse("10.5"), "JPY"))
+	assert.Error(t, ValidateScale(FromInt(1), "XXX"))
+}
+
+func TestSplit(t *testing.T) {
+	parts, err := Split(MustParse("-100"), 3, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, []Amount{MustParse("-33.34"), MustParse("-33.33"), MustParse("-33.33")}, parts)
+
+	parts, err = Split(MustParse("10.05"), 4, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, []Amount{MustParse("2.52"), MustParse("2.51"), MustParse("2.51"), MustParse("2.51")}, parts)
+
+	parts, err = Split(MustParse("1000"), 3, "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, []Amount{MustParse("334"), MustParse("333"), MustParse("333")}, parts)
+
+	parts, err = Split(MustParse("1.0051"), 2, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("1.0051"), parts[0].Add(parts[1]))
+}
+
+func TestSplit_Invalid(t *testing.T) {
+	_, err := Split(MustParse("0.02"), 3, "USD")
+	assert.Error(t, err)
+	_, err = Split(MustParse("10"), 0, "USD")
+	assert.Error(t, err)
+	_, err = Split(MustParse("10"), 2, "XXX")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package util_money_v1
+
+import (
+	constantPackage "anti-fraud/constants/money"
+
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Rate is an exact exchange rate, held as an integer number of 10^-RATE_SCALE units.
+// A rate r from base to quote currency means that one base unit is worth r quote units.
+type Rate struct {
+	units int64
+}
+
+// unitsPerRate is the number of units in a rate of one.
+var unitsPerRate = int64(math.Pow10(constantPackage.RATE_SCALE))
+
+// IdentityRate is the rate between a currency and itself.
+var IdentityRate = Rate{units: unitsPerRate}
+
+// ParseRate reads an exact decimal string such as "5.4321".
+// It fails when the value has more than RATE_SCALE decimal places or does not fit.
+func ParseRate(value string) (Rate, error) {
+	return parseRate(value, false)
+}
+
+// MustParseRate is like ParseRate but panics on error. It is meant for constants and tests.
+func MustParseRate(value string) Rate {
+	rate, err := ParseRate(value)
+	if err != nil {
+		panic(err)
+	}
+	return rate
+}
+
+// parseRate converts a decimal string into a Rate, rounding half away from zero when round is set.
+func parseRate(value string, round bool) (Rate, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Rate{}, fmt.Errorf("invalid rate: %q", value)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt64(unitsPerRate))
+	if !rat.IsInt() && !round {
+		return Rate{}, fmt.Errorf("rate %s has more than %d decimal places", value, constantPackage.RATE_SCALE)
+	}
+	units, ok := roundRat(rat)
+	if !ok {
+		return Rate{}, fmt.Errorf("rate %s is out of range", value)
+	}
+	return Rate{units: units}, nil
+}
+
+// roundRat rounds rat to an integer, half away from zero, reporting whether it fits in an int64.
+func roundRat(rat *big.Rat) (int64, bool) {
+	if !rat.IsInt() {
+		half := big.NewRat(1, 2)
+		if rat.Sign() < 0 {
+			half.Neg(half)
+		}
+		rat = new(big.Rat).Add(rat, half)
+	}
+	units := new(big.Int).Quo(rat.Num(), rat.Denom())
+	return units.Int64(), units.IsInt64()
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of r.
+func (r Rate) Sign() int {
+	switch {
+	case r.units < 0:
+		return -1
+	case r.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// String returns the shortest exact decimal form of r, e.g. "5.4321".
+func (r Rate) String() string {
+	sign := ""
+	units := r.units
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	whole := strconv.FormatUint(uint64(units)/uint64(unitsPerRate), 10)
+	fraction := uint64(units) % uint64(unitsPerRate)
+	if fraction == 0 {
+		return sign + whole
+	}
+	digits := fmt.Sprintf("%0*d", constantPackage.RATE_SCALE, fraction)
+	return sign + whole + "." + strings.TrimRight(digits, "0")
+}
+
+// ValidateCurrency rejects currency codes without a known minor unit.
+func ValidateCurrency(currency string) error {
+	if _, ok := currencyScales[currency]; !ok {
+		return fmt.Errorf("unsupported currency: %s", currency)
+	}
+	return nil
+}
+
+// Convert multiplies a by rate and rounds the result, half away from zero, to the minor unit of
+// the target currency, so a converted amount always passes ValidateScale for that currency.
+func Convert(a Amount, rate Rate, currency string) (Amount, error) {
+	scale, ok := currencyScales[currency]
+	if !ok {
+		return Zero, fmt.Errorf("unsupported currency: %s", currency)
+	}
+	if rate.Sign() <= 0 {
+		return Zero, errors.New("rate should be positive")
+	}
+	minorUnit := int64(math.Pow10(constantPackage.SCALE - scale))
+	rat := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(a.units), big.NewInt(rate.units)),
+		new(big.Int).Mul(big.NewInt(unitsPerRate), big.NewInt(minorUnit)),
+	)
+	minorUnits, ok := roundRat(rat)
+	if !ok || minorUnits > math.MaxInt64/minorUnit || minorUnits < math.MinInt64/minorUnit {
+		return Zero, fmt.Errorf("amount %s converted at %s is out of range", a, rate)
+	}
+	return Amount{units: minorUnits * minorUnit}, nil
+}
+
+// MarshalJSON writes r as an exact JSON number.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a numeric string, without going through float64.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+	rate, err := ParseRate(strings.Trim(value, `"`))
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// Scan reads a NUMERIC column. Drivers without a decimal type may hand back a float,
+// which is rounded to RATE_SCALE decimal places.
+func (r *Rate) Scan(value interface{}) error {
+	var rate Rate
+	var err error
+	switch v := value.(type) {
+	case nil:
+		rate = Rate{}
+	case int64:
+		rate, err = parseRate(strconv.FormatInt(v, 10), false)
+	case float64:
+		rate, err = parseRate(strconv.FormatFloat(v, 'f', -1, 64), true)
+	case []byte:
+		rate, err = parseRate(string(v), true)
+	case string:
+		rate, err = parseRate(v, true)
+	default:
+		err = errors.New("unsupported rate column type")
+	}
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// Value writes r as an exact decimal string.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// GormDataType declares rates as a numeric column.
+func (Rate) GormDataType() string {
+	return "numeric"
+}
+
+// GormDBDataType declares rates as NUMERIC with the fixed RATE_SCALE.
+func (Rate) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return constantPackage.RATE_DB_TYPE
+}
@@ -0,0 +1,78 @@
+package util_money_v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	rate, err := ParseRate("5.4321")
+	assert.NoError(t, err)
+	assert.Equal(t, "5.4321", rate.String())
+	assert.Equal(t, "1", IdentityRate.String())
+
+	_, err = ParseRate("0.00000000001")
+	assert.Error(t, err)
+	_, err = ParseRate("abc")
+	assert.Error(t, err)
+}
+
+func TestRateJSON_RoundTrip(t *testing.T) {
+	var payload struct {
+		Rate Rate `json:"rate"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rate": 0.0066}`), &payload))
+	assert.Equal(t, MustParseRate("0.0066"), payload.Rate)
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"rate":0.0066}`, string(data))
+}
+
+func TestRateScan(t *testing.T) {
+	var rate Rate
+	assert.NoError(t, rate.Scan([]byte("1.0850000000")))
+	assert.Equal(t, MustParseRate("1.085"), rate)
+
+	assert.NoError(t, rate.Scan(int64(2)))
+	assert.Equal(t, MustParseRate("2"), rate)
+
+	value, err := rate.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", value)
+}
+
+func TestValidateCurrency(t *testing.T) {
+	assert.NoError(t, ValidateCurrency("EUR"))
+	assert.Error(t, ValidateCurrency("XXX"))
+	assert.Error(t, ValidateCurrency("usd"))
+}
+
+func TestConvert(t *testing.T) {
+	// 100 EUR at 1.0850 USD per EUR.
+	converted, err := Convert(MustParse("100"), MustParseRate("1.085"), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("108.5"), converted)
+
+	// Rounded half away from zero to the target minor unit, keeping the sign.
+	converted, err = Convert(MustParse("-10.01"), MustParseRate("0.5"), "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("-5.01"), converted)
+
+	converted, err = Convert(MustParse("12.34"), MustParseRate("151.37"), "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("1868"), converted)
+
+	converted, err = Convert(MustParse("1000"), MustParseRate("0.00203"), "KWD")
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("2.03"), converted)
+}
+
+func TestConvert_Invalid(t *testing.T) {
+	_, err := Convert(MustParse("10"), MustParseRate("1.1"), "XXX")
+	assert.Error(t, err)
+	_, err = Convert(MustParse("10"), Rate{}, "USD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package util_remote_v1
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calling a service that keeps failing.
+//
+// It opens after threshold consecutive failed calls and rejects calls until cooldown has passed.
+// It then lets a single trial call through: a success closes it, a failure opens it again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int       // consecutive failed calls
+	openUntil time.Time // zero while closed
+	trial     bool      // a trial call is in flight after the cooldown
+	now       func() time.Time
+}
+
+// newCircuitBreaker creates a closed circuit breaker.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may be made now.
+func (breaker *circuitBreaker) allow() bool {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if breaker.openUntil.IsZero() {
+		return true
+	}
+	if breaker.trial || breaker.now().Before(breaker.openUntil) {
+		return false
+	}
+	breaker.trial = true
+	return true
+}
+
+// success closes the circuit breaker.
+func (breaker *circuitBreaker) success() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	breaker.failures = 0
+	breaker.openUntil = time.Time{}
+	breaker.trial = false
+}
+
+// abandon ends a call its caller gave up on without counting it, so a trial call can be made again.
+func (breaker *circuitBreaker) abandon() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	breaker.trial = false
+}
+
+// failure counts a failed call, and opens the circuit breaker once threshold is reached or a trial call failed.
+func (breaker *circuitBreaker) failure() {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	breaker.failures++
+	if breaker.trial || breaker.failures >= breaker.threshold {
+		breaker.openUntil = breaker.now().Add(breaker.cooldown)
+		breaker.trial = false
+	}
+}
@@ -0,0 +1,54 @@
+package util_remote_v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*circuitBreaker, *time.Time) {
+	breaker := newCircuitBreaker(threshold, cooldown)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(3, time.Minute)
+
+	breaker.failure()
+	breaker.failure()
+	assert.True(t, breaker.allow())
+
+	breaker.failure()
+	assert.False(t, breaker.allow())
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(2, time.Minute)
+
+	breaker.failure()
+	breaker.success()
+	breaker.failure()
+
+	assert.True(t, breaker.allow())
+}
+
+func TestCircuitBreaker_TrialAfterCooldown(t *testing.T) {
+	breaker, now := newTestBreaker(1, time.Minute)
+	breaker.failure()
+
+	*now = now.Add(time.Minute)
+	assert.True(t, breaker.allow(), "one trial call is let through after the cooldown")
+	assert.False(t, breaker.allow(), "other calls wait for the trial call")
+
+	breaker.failure()
+	assert.False(t, breaker.allow(), "a failed trial call opens the breaker again")
+
+	*now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	breaker.success()
+	assert.True(t, breaker.allow())
+	assert.True(t, breaker.allow())
+}
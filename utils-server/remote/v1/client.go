@@ -0,0 +1,224 @@
+package util_remote_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Request is one HTTP/JSON call to the internal endpoints of a service.
+type Request struct {
+	Method  string
+	Path    string            // appended to the base URL, e.g. /internal/accounts/v1/1
+	Query   url.Values        // optional
+	Body    interface{}       // encoded as JSON, nil for none
+	Headers map[string]string // optional, e.g. an Idempotency-Key
+}
+
+// Client calls the internal endpoints of one service over HTTP/JSON, with a timeout per attempt,
+// retries with exponential backoff and a circuit breaker.
+//
+// Every request is retried on a transport error or a 502, 503 or 504 answer, so callers must only
+// send requests that are safe to repeat: reads, pure computations, or writes carrying an Idempotency-Key.
+type Client struct {
+	name         string
+	baseURL      string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	breaker      *circuitBreaker
+	wait         func(ctx context.Context, backoff time.Duration) error
+}
+
+// NewClient creates a Client for the named service as configured.
+func NewClient(name string, config utilConfig.RemoteServiceConfig) *Client {
+	maxRetries := config.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	return &Client{
+		name:         name,
+		baseURL:      strings.TrimRight(config.BaseURL, "/"),
+		httpClient:   &http.Client{Timeout: config.Timeout},
+		maxRetries:   maxRetries,
+		retryBackoff: config.RetryBackoff,
+		breaker:      newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		wait:         waitBackoff,
+	}
+}
+
+// Do sends a request and decodes the JSON answer into out.
+//
+// Steps:
+//  1. Reject the call while the circuit breaker is open.
+//  2. Send the request, retrying transport errors and 502, 503 or 504 answers after a growing backoff.
+//     Once ctx is done, the call is given up: neither retried nor waited on.
+//  3. Count the outcome in the circuit breaker: an answer below 500 means the service is healthy. A call
+//     given up by its caller says nothing of the service, and is not counted.
+//  4. Decode a 2xx answer into out, or the JSON error body of any other answer into an AppError
+//     carrying the service's code and status, so callers handle remote and local errors alike.
+//
+// Parameters:
+//   - ctx:     cancels the call; its request id, if any, is forwarded in the X-Request-ID header.
+//   - request: the call to make.
+//   - out:     the struct the success body is decoded in, nil to discard it.
+//
+// Returns:
+//   - error: the AppError answered by the service, a 503 SERVICE_UNAVAILABLE one when it cannot be reached,
+//     or the Error of ctx once it is done.
+func (client *Client) Do(ctx context.Context, request *Request, out interface{}) error {
+	logger := utilContextV1.Logger(ctx)
+
+	// 1. Fail fast while the service is known to be down.
+	if !client.breaker.allow() {
+		logger.Errorf("Circuit breaker of %s is open, not calling %s %s", client.name, request.Method, request.Path)
+		return utilErrorsV1.NewServiceUnavailableError(fmt.Sprintf("%s is unavailable", client.name), errors.New("circuit breaker open"))
+	}
+
+	var body []byte
+	if request.Body != nil {
+		encoded, err := json.Marshal(request.Body)
+		if err != nil {
+			return utilErrorsV1.NewInternalError(err)
+		}
+		body = encoded
+	}
+
+	// 2. Send, retrying the failures a later attempt may not hit.
+	var statusCode int
+	var responseBody []byte
+	var err error
+	backoff := client.retryBackoff
+	abandoned := false
+	for attempt := 0; ; attempt++ {
+		statusCode, responseBody, err = client.send(ctx, request, body)
+		if err != nil && ctx.Err() != nil {
+			abandoned = true
+			break
+		}
+		if !isRetryable(statusCode, err) || attempt >= client.maxRetries {
+			break
+		}
+		logger.Warnf("Call to %s %s %s failed (attempt %d, status %d, error %v), retrying in %s", client.name, request.Method, request.Path, attempt+1, statusCode, err, backoff)
+		if client.wait(ctx, backoff) != nil {
+			abandoned = true
+			break
+		}
+		backoff *= 2
+	}
+
+	// 3. Record the outcome.
+	if abandoned {
+		client.breaker.abandon()
+		logger.Warnf("Call to %s %s %s given up by its caller: %v", client.name, request.Method, request.Path, ctx.Err())
+		return ctx.Err()
+	}
+	if err != nil || statusCode >= http.StatusInternalServerError {
+		client.breaker.failure()
+	} else {
+		client.breaker.success()
+	}
+	if err != nil {
+		logger.Errorf("Error calling %s %s %s: %v", client.name, request.Method, request.Path, err)
+		return utilErrorsV1.NewServiceUnavailableError(fmt.Sprintf("%s is unavailable", client.name), err)
+	}
+
+	// 4. Decode the answer.
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return decodeError(statusCode, responseBody)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		logger.Errorf("Error decoding answer of %s %s %s: %v", client.name, request.Method, request.Path, err)
+		return utilErrorsV1.NewInternalError(err)
+	}
+	return nil
+}
+
+// send makes a single attempt and reads the whole answer.
+func (client *Client) send(ctx context.Context, request *Request, body []byte) (int, []byte, error) {
+	target := client.baseURL + request.Path
+	if len(request.Query) > 0 {
+		target += "?" + request.Query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, request.Method, target, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	if requestID := utilContextV1.RequestID(ctx); requestID != "" {
+		httpRequest.Header.Set(mediatorConstantPackage.REQUEST_ID_HEADER, requestID)
+	}
+	for name, value := range request.Headers {
+		httpRequest.Header.Set(name, value)
+	}
+
+	response, err := client.httpClient.Do(httpRequest)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return response.StatusCode, responseBody, nil
+}
+
+// waitBackoff waits for backoff to pass, or for ctx to be done, and returns the Error of ctx in the latter case.
+func waitBackoff(ctx context.Context, backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryable reports whether a failed attempt may succeed when repeated.
+func isRetryable(statusCode int, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decodeError rebuilds the AppError of a JSON error body; a body in another format is kept as the cause.
+func decodeError(statusCode int, responseBody []byte) error {
+	var errorBody utilErrorsV1.ErrorBody
+	if err := json.Unmarshal(responseBody, &errorBody); err != nil || errorBody.Error.Code == "" {
+		code := errorConstantPackage.INTERNAL_ERROR
+		if statusCode == http.StatusServiceUnavailable {
+			code = errorConstantPackage.SERVICE_UNAVAILABLE
+		}
+		return &utilErrorsV1.AppError{Code: code, Message: http.StatusText(statusCode), Status: statusCode, Err: errors.New(string(responseBody))}
+	}
+	return &utilErrorsV1.AppError{Code: errorBody.Error.Code, Message: errorBody.Error.Message, Status: statusCode}
+}
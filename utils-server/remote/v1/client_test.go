@@ -0,0 +1,183 @@
+package util_remote_v1
+
+import (
+	errorConstantPackage "anti-fraud/constants/errors"
+	mediatorConstantPackage "anti-fraud/constants/mediator"
+	utilConfig "anti-fraud/utils-server/config"
+	utilContextV1 "anti-fraud/utils-server/context/v1"
+	utilErrorsV1 "anti-fraud/utils-server/errors/v1"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(baseURL string, maxRetries int, breakerThreshold int) (*Client, *[]time.Duration) {
+	client := NewClient("test-service", utilConfig.RemoteServiceConfig{
+		BaseURL:          baseURL,
+		Timeout:          time.Second,
+		MaxRetries:       maxRetries,
+		RetryBackoff:     10 * time.Millisecond,
+		BreakerThreshold: breakerThreshold,
+		BreakerCooldown:  time.Minute,
+	})
+	var waits []time.Duration
+	client.wait = func(ctx context.Context, wait time.Duration) error {
+		waits = append(waits, wait)
+		return ctx.Err()
+	}
+	return client, &waits
+}
+
+func TestClient_Do_DecodesSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/internal/things/v1/7", r.URL.Path)
+		assert.Equal(t, "req-1", r.Header.Get(mediatorConstantPackage.REQUEST_ID_HEADER))
+		assert.Equal(t, "key-1", r.Header.Get("Idempotency-Key"))
+		w.Write([]byte(`{"success":true,"value":42}`))
+	}))
+	defer server.Close()
+	client, _ := newTestClient(server.URL+"/", 2, 5)
+
+	var out struct {
+		Value int `json:"value"`
+	}
+	ctx := utilContextV1.WithRequestID(context.Background(), "req-1")
+	err := client.Do(ctx, &Request{Method: http.MethodPost, Path: "/internal/things/v1/7", Body: map[string]int{"a": 1}, Headers: map[string]string{"Idempotency-Key": "key-1"}}, &out)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, out.Value)
+}
+
+func TestClient_Do_MapsErrorBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		utilErrorsV1.WriteError(w, utilErrorsV1.NewNotFoundError(errorConstantPackage.ACCOUNT_NOT_FOUND, "account not found"))
+	}))
+	defer server.Close()
+	client, _ := newTestClient(server.URL, 2, 5)
+
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appError.Status)
+	assert.Equal(t, errorConstantPackage.ACCOUNT_NOT_FOUND, appError.Code)
+	assert.Equal(t, int32(1), calls.Load(), "a 4xx answer is not retried")
+}
+
+func TestClient_Do_RetriesWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+	client, waits := newTestClient(server.URL, 2, 5)
+
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, *waits)
+}
+
+func TestClient_Do_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	client, _ := newTestClient(server.URL, 1, 5)
+
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusBadGateway, appError.Status)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_Do_UnreachableService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	client, waits := newTestClient(server.URL, 2, 5)
+
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, appError.Status)
+	assert.Equal(t, errorConstantPackage.SERVICE_UNAVAILABLE, appError.Code)
+	assert.Len(t, *waits, 2)
+}
+
+func TestClient_Do_CircuitBreakerOpens(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, _ := newTestClient(server.URL, 0, 2)
+	ctx := context.Background()
+
+	client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+	client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+	err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	appError, ok := utilErrorsV1.AsAppError(err)
+	assert.True(t, ok)
+	assert.Equal(t, errorConstantPackage.SERVICE_UNAVAILABLE, appError.Code)
+	assert.Equal(t, int32(2), calls.Load(), "the open circuit breaker rejects the third call")
+}
+
+func TestClient_Do_CancelledCallerIsNotRetriedNorCounted(t *testing.T) {
+	var calls atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client, waits := newTestClient(server.URL, 3, 1)
+
+	err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), calls.Load(), "a cancelled call is not retried")
+	assert.Empty(t, *waits)
+	assert.True(t, client.breaker.allow(), "a cancelled call does not open the circuit breaker")
+}
+
+func TestClient_Do_CancelDuringBackoffStopsRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, _ := newTestClient(server.URL, 3, 1)
+	client.wait = waitBackoff
+	client.retryBackoff = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/x"}, nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 10*time.Second, "the backoff is not waited out once the caller is gone")
+	assert.Equal(t, int32(1), calls.Load())
+	assert.True(t, client.breaker.allow())
+}
@@ -10,26 +10,36 @@ import (
 	"gorm.io/gorm/schema"
 )
 
-// Establish connection with db.
-func EstablishDBConnection() (*gorm.DB, error) {
-
-	// Load config
-	config, err := configPackage.LoadConfig()
-	if err != nil {
-		return nil, err
+// Establish connection with db, using the uri of config if set, and size its connection pool.
+func EstablishDBConnection(config configPackage.DatabaseConfig) (*gorm.DB, error) {
+
+	dsn := config.Uri
+	if dsn == "" {
+		dsn = fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+			config.Host, config.User, config.Password, config.DBName, config.Port, config.SSLMode, config.TimeZone)
 	}
-	dbConfig := config.Database
-
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
-		dbConfig.Host, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.Port, dbConfig.SSLMode, dbConfig.TimeZone)
 
 	// Connect to DB
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true, // Use singular table names
 		},
+		TranslateError: true, // Report unique violations as gorm.ErrDuplicatedKey
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Size the pool
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(config.Pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.Pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(config.Pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(config.Pool.ConnMaxIdleTime)
 
-	return db, err
+	return db, nil
 }